@@ -2,7 +2,17 @@
 
 CREATE TYPE template_engine AS ENUM ('HANDLEBARS', 'MUSTACHE', 'HTML');
 
-CREATE TYPE output_format AS ENUM ('PDF', 'HTML', 'DOCX');
+CREATE TYPE schema_compat_policy AS ENUM ('NONE', 'WARN', 'BLOCK');
+
+CREATE TYPE output_format AS ENUM ('PDF', 'HTML', 'DOCX', 'XML');
+
+CREATE TYPE template_version_status AS ENUM ('DRAFT', 'PUBLISHED', 'DEPRECATED', 'ARCHIVED');
+
+CREATE TYPE template_version_review_status AS ENUM ('NONE', 'PENDING', 'APPROVED', 'REJECTED');
+
+CREATE TYPE template_version_review_action AS ENUM ('SUBMITTED', 'APPROVED', 'REJECTED');
+
+CREATE TYPE template_partial_kind AS ENUM ('PARTIAL', 'LAYOUT');
 
 CREATE TYPE document_status AS ENUM (
     'PENDING',
@@ -13,8 +23,26 @@ CREATE TYPE document_status AS ENUM (
     'CANCELLED'
 );
 
+CREATE TYPE document_priority AS ENUM ('HIGH', 'NORMAL', 'LOW');
+
 CREATE TYPE dms_status AS ENUM ('NOT_SENT', 'QUEUED', 'SENT', 'FAILED');
 
 CREATE TYPE callback_status AS ENUM ('PENDING', 'SUCCESS', 'FAILED', 'RETRYING');
 
 CREATE TYPE storage_provider AS ENUM ('LOCAL', 'S3', 'MINIO', 'GCS', 'AZURE');
+
+CREATE TYPE schedule_status AS ENUM ('ACTIVE', 'PAUSED', 'COMPLETED');
+
+CREATE TYPE schedule_misfire_policy AS ENUM ('FIRE_ONCE', 'FIRE_ALL', 'SKIP');
+
+CREATE TYPE schedule_run_status AS ENUM ('SUCCEEDED', 'FAILED', 'SKIPPED');
+
+CREATE TYPE batch_status AS ENUM ('RUNNING', 'CANCELLING', 'COMPLETED', 'CANCELLED');
+
+CREATE TYPE batch_item_status AS ENUM ('PENDING', 'SUBMITTED', 'REJECTED', 'CANCELLED');
+
+CREATE TYPE batch_post_action AS ENUM ('NONE', 'ZIP', 'MERGE');
+
+CREATE TYPE archive_job_kind AS ENUM ('ZIP', 'MERGE');
+
+CREATE TYPE archive_job_status AS ENUM ('QUEUED', 'PROCESSING', 'COMPLETED', 'FAILED');
@@ -0,0 +1,42 @@
+CREATE TABLE document_archive_jobs (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    tenant_id             UUID,
+
+    kind                  archive_job_kind NOT NULL,
+    status                archive_job_status NOT NULL DEFAULT 'QUEUED',
+    label                 VARCHAR(150),
+
+    -- Explicit ids, or ids resolved from filter when processing starts
+    document_ids          JSONB,
+    filter                JSONB,
+    -- ZIP options: format (zip | tar.gz), folder layout, manifest, compression level, password
+    -- (password is cleared when the job finishes)
+    options               JSONB NOT NULL DEFAULT '{}',
+
+    -- Progress: documents collected out of total
+    total                 INTEGER NOT NULL DEFAULT 0,
+    processed             INTEGER NOT NULL DEFAULT 0,
+    attempts              INTEGER NOT NULL DEFAULT 0,
+
+    output_path           TEXT,
+    output_format         VARCHAR(20),
+    error_message         TEXT,
+
+    created_by            VARCHAR(100),
+    started_at            TIMESTAMP,
+    -- Refreshed while processing; a stale heartbeat lets another worker take the job over
+    heartbeat_at          TIMESTAMP,
+    completed_at          TIMESTAMP,
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_document_archive_jobs_source
+        CHECK (document_ids IS NOT NULL OR filter IS NOT NULL)
+);
+
+CREATE INDEX idx_document_archive_jobs_status
+    ON document_archive_jobs (status, created_at);
+
+CREATE INDEX idx_document_archive_jobs_tenant_id
+    ON document_archive_jobs (tenant_id);
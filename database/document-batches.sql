@@ -0,0 +1,65 @@
+CREATE TABLE document_batches (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    tenant_id             UUID,
+
+    name                  VARCHAR(150),
+    status                batch_status NOT NULL DEFAULT 'RUNNING',
+    total_items           INTEGER NOT NULL,
+
+    -- Post-processing once every item is terminal
+    post_action           batch_post_action NOT NULL DEFAULT 'NONE',
+    output_path           TEXT,
+    output_error          TEXT,
+
+    metadata              JSONB,
+
+    -- Single callback when the batch is terminal
+    has_callback          BOOLEAN NOT NULL DEFAULT FALSE,
+    callback_url          TEXT,
+    callback_status       callback_status NOT NULL DEFAULT 'PENDING',
+    callback_attempts     INTEGER NOT NULL DEFAULT 0,
+    next_callback_at      TIMESTAMP,
+
+    -- Lease runner: batch is being cancelled / finalized (ZIP / merge) until this time
+    claimed_until         TIMESTAMP,
+
+    created_by            VARCHAR(100),
+    completed_at          TIMESTAMP,
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_document_batches_status
+    ON document_batches (status);
+
+CREATE INDEX idx_document_batches_tenant_id
+    ON document_batches (tenant_id);
+
+CREATE TABLE document_batch_items (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    batch_id              BIGINT NOT NULL REFERENCES document_batches (id) ON DELETE CASCADE,
+    seq                   INTEGER NOT NULL,
+
+    request_id            VARCHAR(100) NOT NULL,
+    input                 JSONB NOT NULL,
+
+    status                batch_item_status NOT NULL DEFAULT 'PENDING',
+    document_id           BIGINT REFERENCES documents (id) ON DELETE SET NULL,
+    error_message         TEXT,
+
+    -- Lease runner: document is being created outside the claim transaction until this time
+    claimed_until         TIMESTAMP,
+
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_document_batch_items_seq
+    ON document_batch_items (batch_id, seq);
+
+-- Submission queue: only items not yet handed to the documents pipeline
+CREATE INDEX idx_document_batch_items_pending
+    ON document_batch_items (batch_id, seq)
+    WHERE status = 'PENDING';
@@ -0,0 +1,25 @@
+CREATE TABLE document_import_mappings (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    tenant_id             UUID,
+
+    name                  VARCHAR(150) NOT NULL,
+    description           TEXT,
+
+    -- Default template when an import does not pass template_code
+    template_code         VARCHAR(100),
+
+    -- [{column, path, type, format, required}]; empty maps every column to the same-named path
+    fields                JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    group_by              VARCHAR(150),
+    request_id_column     VARCHAR(150),
+    request_id_prefix     VARCHAR(50),
+
+    created_by            VARCHAR(100),
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_document_import_mappings_tenant_id
+    ON document_import_mappings (tenant_id, template_code);
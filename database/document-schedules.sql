@@ -0,0 +1,71 @@
+CREATE TABLE document_schedules (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    tenant_id             UUID,
+
+    name                  VARCHAR(150) NOT NULL,
+    description           TEXT,
+
+    template_code         VARCHAR(100) NOT NULL,
+    template_version      INTEGER,
+
+    output_format         output_format,
+    locale                VARCHAR(20),
+    priority              document_priority,
+
+    -- Recurring (cron) or one-off (run_at), exactly one of them
+    cron_expression       VARCHAR(100),
+    run_at                TIMESTAMP,
+    timezone              VARCHAR(64) NOT NULL DEFAULT 'UTC',
+
+    payload               JSONB NOT NULL DEFAULT '{}'::jsonb,
+    payload_source        JSONB,
+    metadata              JSONB,
+
+    store_to_dms          BOOLEAN NOT NULL DEFAULT FALSE,
+    has_callback          BOOLEAN NOT NULL DEFAULT FALSE,
+    callback_url          TEXT,
+
+    misfire_policy        schedule_misfire_policy NOT NULL DEFAULT 'FIRE_ONCE',
+    status                schedule_status NOT NULL DEFAULT 'ACTIVE',
+
+    next_run_at           TIMESTAMP,
+    last_run_at           TIMESTAMP,
+    -- Lease runner: schedule is being fired (payload fetch, document create) until this time
+    claimed_until         TIMESTAMP,
+
+    created_by            VARCHAR(100),
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_document_schedules_trigger
+        CHECK ((cron_expression IS NULL) <> (run_at IS NULL))
+);
+
+CREATE INDEX idx_document_schedules_due
+    ON document_schedules (status, next_run_at);
+
+CREATE INDEX idx_document_schedules_tenant_id
+    ON document_schedules (tenant_id);
+
+CREATE TABLE document_schedule_runs (
+    id                    BIGSERIAL PRIMARY KEY,
+
+    tenant_id             UUID,
+
+    schedule_id           BIGINT NOT NULL REFERENCES document_schedules (id) ON DELETE CASCADE,
+
+    scheduled_for         TIMESTAMP NOT NULL,
+    status                schedule_run_status NOT NULL,
+
+    request_id            VARCHAR(100),
+    document_id           BIGINT REFERENCES documents (id) ON DELETE SET NULL,
+
+    error_message         TEXT,
+
+    created_at            TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One run per fire time; also guards against double firing across replicas
+CREATE UNIQUE INDEX uq_document_schedule_runs_fire
+    ON document_schedule_runs (schedule_id, scheduled_for);
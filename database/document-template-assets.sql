@@ -0,0 +1,27 @@
+CREATE TABLE document_template_assets (
+    id               BIGSERIAL PRIMARY KEY,
+
+    tenant_id        UUID,
+
+    template_id      BIGINT REFERENCES document_templates (id) ON DELETE CASCADE,
+
+    name             VARCHAR(200) NOT NULL,
+    content_type     VARCHAR(100) NOT NULL,
+    size             BIGINT NOT NULL,
+    checksum         VARCHAR(64) NOT NULL,
+
+    storage_path     TEXT NOT NULL,
+    storage_provider storage_provider NOT NULL,
+
+    created_by       VARCHAR(100),
+    created_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Satu nama per scope; template_id NULL = asset tenant-wide, tenant_id NULL = global.
+CREATE UNIQUE INDEX uq_template_assets_name
+    ON document_template_assets (
+        COALESCE(tenant_id, '00000000-0000-0000-0000-000000000000'::uuid),
+        COALESCE(template_id, 0),
+        name
+    );
@@ -0,0 +1,56 @@
+CREATE TABLE document_template_partials (
+    id              BIGSERIAL PRIMARY KEY,
+
+    tenant_id       UUID,
+
+    name            VARCHAR(100) NOT NULL,
+    kind            template_partial_kind NOT NULL DEFAULT 'PARTIAL',
+    description     TEXT,
+
+    current_version INT,
+
+    created_by      VARCHAR(100),
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Partial global (tenant_id NULL) juga unik per nama.
+CREATE UNIQUE INDEX uq_template_partials_name
+    ON document_template_partials (COALESCE(tenant_id, '00000000-0000-0000-0000-000000000000'::uuid), name);
+
+CREATE TABLE document_template_partial_versions (
+    id              BIGSERIAL PRIMARY KEY,
+
+    partial_id      BIGINT NOT NULL REFERENCES document_template_partials (id) ON DELETE CASCADE,
+    tenant_id       UUID,
+
+    version         INT NOT NULL,
+    content         TEXT NOT NULL,
+    checksum        VARCHAR(64),
+    published_at    TIMESTAMP,
+
+    created_by      VARCHAR(100),
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_template_partial_versions_version
+    ON document_template_partial_versions (partial_id, version);
+
+CREATE TABLE document_template_partial_dependencies (
+    id                  BIGSERIAL PRIMARY KEY,
+
+    tenant_id           UUID,
+
+    template_id         BIGINT NOT NULL REFERENCES document_templates (id) ON DELETE CASCADE,
+    template_version_id BIGINT NOT NULL REFERENCES document_template_versions (id) ON DELETE CASCADE,
+    partial_id          BIGINT NOT NULL REFERENCES document_template_partials (id) ON DELETE CASCADE,
+    partial_version     INT NOT NULL,
+
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_template_partial_dependencies_partial
+    ON document_template_partial_dependencies (partial_id);
+
+CREATE INDEX idx_template_partial_dependencies_version
+    ON document_template_partial_dependencies (template_version_id);
@@ -0,0 +1,46 @@
+CREATE TABLE document_template_test_cases (
+    id              BIGSERIAL PRIMARY KEY,
+
+    tenant_id       UUID,
+
+    template_id     BIGINT NOT NULL REFERENCES document_templates (id) ON DELETE CASCADE,
+
+    name            VARCHAR(150) NOT NULL,
+    description     TEXT,
+
+    payload         JSONB NOT NULL DEFAULT '{}'::jsonb,
+    output_format   output_format NOT NULL,
+    expected_output TEXT NOT NULL,
+    expected_pages  INT,
+
+    created_by      VARCHAR(100),
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_template_test_cases_name
+    ON document_template_test_cases (template_id, name);
+
+CREATE TABLE document_template_test_results (
+    id              BIGSERIAL PRIMARY KEY,
+
+    tenant_id       UUID,
+
+    template_id     BIGINT NOT NULL REFERENCES document_templates (id) ON DELETE CASCADE,
+    version_id      BIGINT NOT NULL REFERENCES document_template_versions (id) ON DELETE CASCADE,
+    test_case_id    BIGINT NOT NULL REFERENCES document_template_test_cases (id) ON DELETE CASCADE,
+
+    case_name       VARCHAR(150) NOT NULL,
+    passed          BOOLEAN NOT NULL,
+    checksum        VARCHAR(64),
+
+    actual_output   TEXT NOT NULL DEFAULT '',
+    actual_pages    INT,
+    diff            TEXT,
+    error           TEXT,
+
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_template_test_results_latest
+    ON document_template_test_results (version_id, test_case_id, created_at DESC);
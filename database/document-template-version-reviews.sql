@@ -0,0 +1,20 @@
+CREATE TABLE document_template_version_reviews (
+    id              BIGSERIAL PRIMARY KEY,
+
+    tenant_id       UUID,
+
+    template_id     BIGINT NOT NULL REFERENCES document_templates (id) ON DELETE CASCADE,
+    version_id      BIGINT NOT NULL REFERENCES document_template_versions (id) ON DELETE CASCADE,
+
+    action          template_version_review_action NOT NULL,
+    actor           VARCHAR(100) NOT NULL,
+    comment         TEXT,
+
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_template_version_reviews_version
+    ON document_template_version_reviews (version_id, created_at);
+
+CREATE INDEX idx_template_version_reviews_template
+    ON document_template_version_reviews (template_id);
@@ -13,15 +13,31 @@ CREATE TABLE document_template_versions (
     sample_payload  JSONB,
 
     output_format   output_format NOT NULL,
+    layout          VARCHAR(100),
+    translations    JSONB,         -- locale -> key -> message
+    default_locale  VARCHAR(20),
+    pdfa_conformance VARCHAR(20),  -- e.g. 'PDF/A-2b'; NULL: plain PDF
+    e_invoice       JSONB,         -- syntax (UBL | CII); PDF output: CII content + Factur-X profile
 
     checksum        VARCHAR(64),
 
+    status          template_version_status NOT NULL DEFAULT 'DRAFT',
+
     is_published    BOOLEAN NOT NULL DEFAULT FALSE,
     published_at    TIMESTAMP,
 
+    scheduled_publish_at TIMESTAMP,
+    deprecated_at   TIMESTAMP,
+    archived_at     TIMESTAMP,
+
+    review_status   template_version_review_status NOT NULL DEFAULT 'NONE',
+    submitted_by    VARCHAR(100),
+    submitted_at    TIMESTAMP,
+
     created_by      VARCHAR(100),
 
     created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 
     CONSTRAINT document_template_versions_template_version_uq
         UNIQUE (template_id, version)
@@ -36,5 +52,16 @@ CREATE INDEX idx_template_versions_version
 CREATE INDEX idx_template_versions_published
     ON document_template_versions (is_published);
 
+CREATE INDEX idx_template_versions_status
+    ON document_template_versions (template_id, status);
+
+CREATE INDEX idx_template_versions_scheduled
+    ON document_template_versions (scheduled_publish_at)
+    WHERE scheduled_publish_at IS NOT NULL;
+
+CREATE INDEX idx_template_versions_review_status
+    ON document_template_versions (review_status)
+    WHERE review_status = 'PENDING';
+
 CREATE INDEX idx_template_versions_created_at
     ON document_template_versions (created_at);
@@ -14,6 +14,18 @@ CREATE TABLE document_templates (
 
     is_active       BOOLEAN NOT NULL DEFAULT TRUE,
 
+    -- jumlah approval reviewer sebelum versi DRAFT boleh dipublish (0 = tanpa review)
+    required_approvals INT NOT NULL DEFAULT 0,
+    -- penurunan required_approvals yang menunggu persetujuan actor lain
+    pending_approvals    INT,
+    pending_approvals_by VARCHAR(100),
+
+    -- perlakuan perubahan schema breaking saat publish: NONE | WARN | BLOCK
+    schema_compat_policy schema_compat_policy NOT NULL DEFAULT 'WARN',
+
+    -- watermark default dokumen PDF/HTML: {text, image, opacity, rotation, font_size, color, pages}
+    watermark       JSONB,
+
     created_by      VARCHAR(100),
     updated_by      VARCHAR(100),
 
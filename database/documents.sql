@@ -15,10 +15,12 @@ CREATE TABLE documents (
     metadata              JSONB,
 
     status                document_status NOT NULL DEFAULT 'PENDING',
+    priority              document_priority NOT NULL DEFAULT 'NORMAL',
 
     error_message         TEXT,
 
     output_format         output_format NOT NULL,
+    locale                VARCHAR(20),
 
     -- Generated file
     file_name             VARCHAR(255),
@@ -30,6 +32,18 @@ CREATE TABLE documents (
     checksum              VARCHAR(64),
     content_type          VARCHAR(100),
 
+    -- watermark diminta (source REQUEST) / yang diterapkan setelah GENERATED
+    watermark             JSONB,
+    -- enkripsi PDF: spec (tanpa password), password request tersegel (dihapus setelah GENERATED)
+    encryption            JSONB,
+    encryption_secret     TEXT,
+    is_encrypted          BOOLEAN NOT NULL DEFAULT FALSE,
+    -- PDF/A: level yang lolos validasi dan laporan validasi terakhir
+    pdfa_conformance      VARCHAR(20),
+    pdfa_report           JSONB,
+    -- File pendamping (mis. XML Factur-X yang juga di-embed di PDF/A-3)
+    attachments           JSONB,
+
     -- Digital signature
     is_signed             BOOLEAN NOT NULL DEFAULT FALSE,
     signature_provider    VARCHAR(100),
@@ -70,6 +84,7 @@ CREATE UNIQUE INDEX uq_documents_request_id_global
 CREATE INDEX idx_documents_request_id ON documents (request_id);
 CREATE INDEX idx_documents_template_code ON documents (template_code);
 CREATE INDEX idx_documents_status ON documents (status);
+CREATE INDEX idx_documents_priority_status ON documents (priority, status);
 CREATE INDEX idx_documents_dms_status ON documents (dms_status);
 CREATE INDEX idx_documents_callback_status ON documents (callback_status);
 CREATE INDEX idx_documents_created_at ON documents (created_at);
@@ -1,6 +1,10 @@
 package bootstrap
 
-import "go-document-generator/internal/transport/apis"
+import (
+	"context"
+
+	"go-document-generator/internal/transport/apis"
+)
 
 // RunApp memuat config (global), wiring terisolasi (DB, Redis, Kafka, routes), lalu jalankan HTTP server sampai signal.
 func RunApp() error {
@@ -39,11 +43,9 @@ func RunApp() error {
 		return err
 	}
 	cleanups = append(cleanups, closeDoc)
-	services.Templates = docServices.Templates
-	services.TemplateVersions = docServices.TemplateVersions
-	services.Documents = docServices.Documents
-	services.RenderLogs = docServices.RenderLogs
-	services.Callbacks = docServices.Callbacks
+	// Semua service dokumen diambil dari wiring; hanya Users yang di-wire terpisah.
+	docServices.Users = services.Users
+	services = docServices
 
 	defer func() {
 		for _, fn := range cleanups {
@@ -51,6 +53,13 @@ func RunApp() error {
 		}
 	}()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startVersionPublishScheduler(ctx, services.TemplateVersions)
+	startDocumentScheduleRunner(ctx, services.Schedules)
+	startDocumentBatchRunner(ctx, services.Batches)
+	startDocumentArchiveRunner(ctx, services.Archives)
+
 	e := newEcho(services)
 	return runHTTP(e)
 }
@@ -0,0 +1,144 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"time"
+
+	ucArchive "go-document-generator/internal/usecase/documentarchives"
+	ucBatch "go-document-generator/internal/usecase/documentbatches"
+	ucSched "go-document-generator/internal/usecase/documentschedules"
+	ucVer "go-document-generator/internal/usecase/documenttemplateversions"
+)
+
+const (
+	defaultVersionPublishInterval   = 30 * time.Second
+	defaultDocumentScheduleInterval = 15 * time.Second
+	defaultDocumentBatchInterval    = 5 * time.Second
+	defaultDocumentArchiveInterval  = 5 * time.Second
+)
+
+// startVersionPublishScheduler menjalankan loop publish terjadwal sampai ctx dibatalkan.
+// Aman dijalankan di banyak replika: transisi status di DB bersifat kondisional.
+func startVersionPublishScheduler(ctx context.Context, svc ucVer.Service) {
+	interval := defaultVersionPublishInterval
+	if s := Config().Scheduler.VersionPublishIntervalSeconds; s < 0 {
+		log.Println("scheduler: version publish scheduler disabled")
+		return
+	} else if s > 0 {
+		interval = time.Duration(s) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				n, err := svc.PublishDue(ctx, now)
+				if err != nil {
+					log.Printf("scheduler: PublishDue: %v", err)
+					continue
+				}
+				if n > 0 {
+					log.Printf("scheduler: published %d scheduled template version(s)", n)
+				}
+			}
+		}
+	}()
+}
+
+// startDocumentScheduleRunner menjalankan jadwal generation dokumen yang jatuh tempo sampai ctx dibatalkan.
+// Aman dijalankan di banyak replika: jadwal diklaim dengan row lock SKIP LOCKED.
+func startDocumentScheduleRunner(ctx context.Context, svc ucSched.Service) {
+	interval := defaultDocumentScheduleInterval
+	if s := Config().Scheduler.DocumentScheduleIntervalSeconds; s < 0 {
+		log.Println("scheduler: document schedule runner disabled")
+		return
+	} else if s > 0 {
+		interval = time.Duration(s) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				n, err := svc.RunDue(ctx, now)
+				if err != nil {
+					log.Printf("scheduler: RunDue: %v", err)
+				}
+				if n > 0 {
+					log.Printf("scheduler: created %d scheduled document(s)", n)
+				}
+			}
+		}
+	}()
+}
+
+// startDocumentBatchRunner memproses batch dokumen (submit item, finalisasi, callback) sampai ctx dibatalkan.
+// Aman dijalankan di banyak replika: item dan batch diklaim dengan row lock SKIP LOCKED.
+func startDocumentBatchRunner(ctx context.Context, svc ucBatch.Service) {
+	interval := defaultDocumentBatchInterval
+	if s := Config().Scheduler.DocumentBatchIntervalSeconds; s < 0 {
+		log.Println("scheduler: document batch runner disabled")
+		return
+	} else if s > 0 {
+		interval = time.Duration(s) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				n, err := svc.RunDue(ctx, now)
+				if err != nil {
+					log.Printf("scheduler: batch RunDue: %v", err)
+				}
+				if n > 0 {
+					log.Printf("scheduler: submitted %d batch item(s)", n)
+				}
+			}
+		}
+	}()
+}
+
+// startDocumentArchiveRunner memproses job ZIP / merge yang antre sampai ctx dibatalkan.
+// Aman dijalankan di banyak replika: job diklaim dengan row lock SKIP LOCKED.
+func startDocumentArchiveRunner(ctx context.Context, svc ucArchive.Service) {
+	interval := defaultDocumentArchiveInterval
+	if s := Config().Scheduler.DocumentArchiveIntervalSeconds; s < 0 {
+		log.Println("scheduler: document archive runner disabled")
+		return
+	} else if s > 0 {
+		interval = time.Duration(s) * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				n, err := svc.RunDue(ctx, now)
+				if err != nil {
+					log.Printf("scheduler: archive RunDue: %v", err)
+				}
+				if n > 0 {
+					log.Printf("scheduler: finished %d archive job(s)", n)
+				}
+			}
+		}
+	}()
+}
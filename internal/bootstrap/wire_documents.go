@@ -4,25 +4,47 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	documentsinfra "go-document-generator/internal/infrastructure/documents"
+	pdfinfra "go-document-generator/internal/infrastructure/documents/pdf"
+	xmlinfra "go-document-generator/internal/infrastructure/documents/xml"
 	kafkainfra "go-document-generator/internal/infrastructure/broker/kafka"
+	"go-document-generator/internal/infrastructure/broker/redisstream"
 	miniostg "go-document-generator/internal/infrastructure/storage/minio"
 	ossstg  "go-document-generator/internal/infrastructure/storage/oss"
 	s3stg   "go-document-generator/internal/infrastructure/storage/s3"
 	beginpg "go-document-generator/internal/repository/begin/postgres"
+	archivepg "go-document-generator/internal/repository/documentarchives/postgres"
+	batchpg "go-document-generator/internal/repository/documentbatches/postgres"
 	cbpg "go-document-generator/internal/repository/documentcallbackattempts/postgres"
+	importpg "go-document-generator/internal/repository/documentimports/postgres"
 	logpg "go-document-generator/internal/repository/documentrenderlogs/postgres"
 	tplpg "go-document-generator/internal/repository/documenttemplates/postgres"
+	assetpg "go-document-generator/internal/repository/documenttemplateassets/postgres"
+	partialpg "go-document-generator/internal/repository/documenttemplatepartials/postgres"
+	testpg "go-document-generator/internal/repository/documenttemplatetests/postgres"
+	reviewpg "go-document-generator/internal/repository/documenttemplateversionreviews/postgres"
 	verpg "go-document-generator/internal/repository/documenttemplateversions/postgres"
 	docpg "go-document-generator/internal/repository/documents/postgres"
+	schedpg "go-document-generator/internal/repository/documentschedules/postgres"
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/pdfsecurity"
 	sharedStorage "go-document-generator/internal/shared/storage"
 	"go-document-generator/internal/transport/apis"
 	"go-document-generator/internal/transport/event/events"
+	ucArchive "go-document-generator/internal/usecase/documentarchives"
+	ucBatch "go-document-generator/internal/usecase/documentbatches"
 	ucCb "go-document-generator/internal/usecase/documentcallbackattempts"
+	ucImport "go-document-generator/internal/usecase/documentimports"
 	ucDoc "go-document-generator/internal/usecase/documents"
+	"go-document-generator/internal/usecase/documents/postrender"
 	ucLog "go-document-generator/internal/usecase/documentrenderlogs"
+	ucSched "go-document-generator/internal/usecase/documentschedules"
+	ucAsset "go-document-generator/internal/usecase/documenttemplateassets"
+	ucPartial "go-document-generator/internal/usecase/documenttemplatepartials"
 	ucTpl "go-document-generator/internal/usecase/documenttemplates"
+	ucTest "go-document-generator/internal/usecase/documenttemplatetests"
 	ucVer "go-document-generator/internal/usecase/documenttemplateversions"
 
 	cachetpl "go-document-generator/internal/infrastructure/cache/template"
@@ -46,10 +68,7 @@ func wireDocumentServices(db *gorm.DB, redis *goredis.Client) (apis.Services, fu
 	if topicDoc == "" {
 		topicDoc = "document-events"
 	}
-	topicDocProcess := c.Kafka.TopicDocumentProcess
-	if topicDocProcess == "" {
-		topicDocProcess = "document-process"
-	}
+	topicProcessHigh, topicProcessNormal, topicProcessLow := c.Kafka.DocumentProcessTopics()
 
 	tx := beginpg.NewBeginRepository(db)
 
@@ -58,9 +77,17 @@ func wireDocumentServices(db *gorm.DB, redis *goredis.Client) (apis.Services, fu
 
 	tplRepo := cachetpl.NewCachedTemplateRepo(rawTplRepo, redis)
 	verRepo := cachetpl.NewCachedVersionRepo(rawVerRepo, redis)
+	reviewRepo := reviewpg.NewDocumentTemplateVersionReviewsRepository(db)
+	testRepo := testpg.NewDocumentTemplateTestsRepository(db)
+	partialRepo := partialpg.NewDocumentTemplatePartialsRepository(db)
+	assetRepo := assetpg.NewDocumentTemplateAssetsRepository(db)
 	docRepo := docpg.NewDocumentsRepository(db)
 	logRepo := logpg.NewDocumentRenderLogsRepository(db)
 	cbRepo := cbpg.NewDocumentCallbackAttemptsRepository(db)
+	schedRepo := schedpg.NewDocumentSchedulesRepository(db)
+	batchRepo := batchpg.NewDocumentBatchesRepository(db)
+	importRepo := importpg.NewDocumentImportsRepository(db)
+	archiveRepo := archivepg.NewDocumentArchivesRepository(db)
 
 	tplProducer, err := libkafka.NewProducer[events.TemplateCreatedEvent](
 		c.KafkaBrokersList(), topicTpl,
@@ -103,22 +130,47 @@ func wireDocumentServices(db *gorm.DB, redis *goredis.Client) (apis.Services, fu
 		return apis.Services{}, nil, err
 	}
 
-	// document-process: generation trigger — partition by request_id.
-	docProcessProducer, err := libkafka.NewProducer[events.DocumentEvent](
-		c.KafkaBrokersList(), topicDocProcess,
-		libkafka.WithKeyFunc(func(e events.DocumentEvent) []byte { return []byte(e.ResourceID) }),
-	)
+	// document-process[-high|-low]: generation trigger per lane prioritas — partition by request_id.
+	newProcessProducer := func(topic string) (*libkafka.Producer[events.DocumentEvent], error) {
+		return libkafka.NewProducer[events.DocumentEvent](
+			c.KafkaBrokersList(), topic,
+			libkafka.WithKeyFunc(func(e events.DocumentEvent) []byte { return []byte(e.ResourceID) }),
+		)
+	}
+	docProcessProducer, err := newProcessProducer(topicProcessNormal)
+	if err != nil {
+		_ = tplProducer.Close()
+		_ = verProducer.Close()
+		_ = docEventProducer.Close()
+		_ = docBulkProducer.Close()
+		return apis.Services{}, nil, err
+	}
+	docProcessHighProducer, err := newProcessProducer(topicProcessHigh)
 	if err != nil {
 		_ = tplProducer.Close()
 		_ = verProducer.Close()
 		_ = docEventProducer.Close()
 		_ = docBulkProducer.Close()
+		_ = docProcessProducer.Close()
+		return apis.Services{}, nil, err
+	}
+	docProcessLowProducer, err := newProcessProducer(topicProcessLow)
+	if err != nil {
+		_ = tplProducer.Close()
+		_ = verProducer.Close()
+		_ = docEventProducer.Close()
+		_ = docBulkProducer.Close()
+		_ = docProcessProducer.Close()
+		_ = docProcessHighProducer.Close()
 		return apis.Services{}, nil, err
 	}
 
 	tplPublisher := kafkainfra.NewTemplateEventPublisherKafka(tplProducer)
 	verPublisher := kafkainfra.NewVersionEventPublisherKafka(verProducer)
-	docPublisher := kafkainfra.NewDocumentEventPublisherKafka(docEventProducer, docBulkProducer, docProcessProducer)
+	// Transisi status juga ditulis ke Redis stream document-status untuk SSE lintas replica.
+	statusStream := redisstream.NewDocumentStatusStream(redis)
+	docPublisher := statusStream.Publisher(kafkainfra.NewDocumentEventPublisherKafka(docEventProducer, docBulkProducer,
+		kafkainfra.ProcessProducers{High: docProcessHighProducer, Normal: docProcessProducer, Low: docProcessLowProducer}))
 	selector := documentsinfra.NewSelector()
 
 	// Storage provider dipilih berdasarkan config storage.provider.
@@ -155,12 +207,44 @@ func wireDocumentServices(db *gorm.DB, redis *goredis.Client) (apis.Services, fu
 		storageProvider = sharedStorage.NewLocalProvider(c.Storage.BaseDir)
 	}
 
+	// Library partial dan asset store dipakai generator dokumen dan lint versi; golden test memakai
+	// Preview service dokumen, jadi semuanya dibuat lebih dulu.
+	assetSvc := ucAsset.NewService(assetRepo, tplRepo, storageProvider)
+	partialSvc := ucPartial.NewService(partialRepo, tplRepo, verRepo, selector, assetSvc, tx)
+	// Tahap pasca-render (watermark) memakai qpdf untuk PDF dan asset store untuk logo; e-invoice
+	// divalidasi xmllint terhadap XSD dari konfigurasi.
+	sealer := pdfsecurity.NewSealer(c.Generation.PDFPasswordSecret)
+	postRender := &postrender.Pipeline{
+		PDF: pdfinfra.NewQPDFProcessor(), Assets: assetSvc,
+		OwnerPassword: c.Generation.PDFOwnerPassword, Sealer: sealer,
+		PDFA: pdfinfra.NewGhostscriptPDFA(c.Generation.PDFAICCProfile), PDFAFlagOnly: c.Generation.PDFAFlagNonConformant,
+	}
+	postRender.XML = xmlinfra.NewXSDValidator(map[string]string{
+		einvoice.SyntaxUBL: c.Generation.EInvoiceSchemaUBL,
+		einvoice.SyntaxCII: c.Generation.EInvoiceSchemaCII,
+	})
+	if c.Generation.PDFAValidator != "" {
+		postRender.PDFAValidator = pdfinfra.NewVeraPDFValidator(c.Generation.PDFAValidator)
+	}
+	docSvc := ucDoc.NewService(docRepo, tplRepo, verRepo, tx, docPublisher, selector, storageProvider, partialSvc, assetSvc, postRender, c.Localization, c.Generation,
+		ucDoc.SyncOptions{Timeout: c.Generation.SyncTimeout(), MaxInFlight: c.Generation.SyncMaxInFlight}, statusStream)
+	testSvc := ucTest.NewService(testRepo, tplRepo, verRepo, docSvc, pdfinfra.NewPDFToTextExtractor())
+	batchSvc := ucBatch.NewService(batchRepo, tx, docSvc, storageProvider, c.Callback.HMACSecret, c.Callback.AllowedHosts)
+
 	svc := apis.Services{
 		Templates:        ucTpl.NewService(tplRepo, tx, tplPublisher),
-		TemplateVersions: ucVer.NewService(verRepo, tplRepo, tx, verPublisher),
-		Documents:        ucDoc.NewService(docRepo, tplRepo, verRepo, tx, docPublisher, selector, storageProvider),
+		TemplateVersions: ucVer.NewService(verRepo, tplRepo, reviewRepo, docRepo, testSvc, partialSvc, assetSvc, tx, verPublisher),
+		TemplateTests:    testSvc,
+		Partials:         partialSvc,
+		Assets:           assetSvc,
+		Documents:        docSvc,
 		RenderLogs:       ucLog.NewService(logRepo, docRepo),
 		Callbacks:        ucCb.NewService(cbRepo, docRepo, c.Callback.HMACSecret),
+		Schedules: ucSched.NewService(schedRepo, tplRepo, tx, docSvc,
+			time.Duration(c.Scheduler.DocumentScheduleMisfireGraceSeconds)*time.Second, c.Scheduler.DocumentSchedulePayloadHosts),
+		Batches:          batchSvc,
+		Imports:          ucImport.NewService(importRepo, tplRepo, verRepo, batchSvc),
+		Archives:         ucArchive.NewService(archiveRepo, docRepo, tx, docSvc, storageProvider, sealer),
 	}
 
 	cleanup := func() {
@@ -169,6 +253,8 @@ func wireDocumentServices(db *gorm.DB, redis *goredis.Client) (apis.Services, fu
 		_ = docEventProducer.Close()
 		_ = docBulkProducer.Close()
 		_ = docProcessProducer.Close()
+		_ = docProcessHighProducer.Close()
+		_ = docProcessLowProducer.Close()
 	}
 	return svc, cleanup, nil
 }
@@ -16,6 +16,9 @@ type Configuration struct {
 	Auth          Auth              `json:"auth"`
 	Dms           Dms               `json:"dms"`
 	Callback      CallbackConfig    `json:"callback"`
+	Scheduler     Scheduler         `json:"scheduler"`
+	Localization  Localization      `json:"localization"`
+	Generation    Generation        `json:"generation"`
 	// Consumers     Consumers         `json:"consumers"`
 }
 
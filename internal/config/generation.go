@@ -0,0 +1,50 @@
+package config
+
+import "time"
+
+// Generation konfigurasi mode generation sinkron (POST /documents?mode=sync) dan lane prioritas worker.
+type Generation struct {
+	// SyncTimeoutSeconds batas tunggu render sinkron sebelum fallback async (202). 0 = default 10 detik.
+	SyncTimeoutSeconds int `json:"sync_timeout_seconds"`
+	// SyncMaxInFlight jumlah render sinkron paralel per proses; sisanya langsung async. 0 = default 8.
+	SyncMaxInFlight int `json:"sync_max_in_flight"`
+	// DefaultPriority prioritas dokumen bila request tidak menyebut priority (HIGH | NORMAL | LOW). Kosong = NORMAL.
+	DefaultPriority string `json:"default_priority"`
+	// TenantPriorities override per tenant: tenant_id -> priority.
+	TenantPriorities map[string]string `json:"tenant_priorities"`
+	// WorkerSlots render paralel per worker yang dibagi ketiga lane. 0 = default 4.
+	WorkerSlots int `json:"worker_slots"`
+	// PriorityWeightHigh / Normal / Low bobot pembagian slot saat lane berebut. 0 = default 6 / 3 / 1.
+	PriorityWeightHigh   int `json:"priority_weight_high"`
+	PriorityWeightNormal int `json:"priority_weight_normal"`
+	PriorityWeightLow    int `json:"priority_weight_low"`
+	// PDFOwnerPassword owner password PDF terenkripsi. Kosong = acak per dokumen.
+	PDFOwnerPassword string `json:"pdf_owner_password"`
+	// PDFPasswordSecret kunci penyegel password dari request (encryption.password, password ZIP arsip).
+	// Kosong = hanya password_path; password ZIP ditolak.
+	PDFPasswordSecret string `json:"pdf_password_secret"`
+	// PDFAICCProfile path profil ICC RGB untuk output intent PDF/A (mis. srgb.icc bawaan Ghostscript).
+	PDFAICCProfile string `json:"pdfa_icc_profile"`
+	// PDFAValidator path binary veraPDF. Kosong = preflight bawaan (pdfa_conformance dokumen tidak diisi).
+	PDFAValidator string `json:"pdfa_validator"`
+	// PDFAFlagNonConformant true: output PDF/A yang tidak konform tetap disimpan dan ditandai; false: dokumen FAILED.
+	PDFAFlagNonConformant bool `json:"pdfa_flag_non_conformant"`
+	// EInvoiceSchemaUBL / EInvoiceSchemaCII path XSD untuk validasi e-invoice. Kosong = syntax ditolak.
+	EInvoiceSchemaUBL string `json:"einvoice_schema_ubl"`
+	EInvoiceSchemaCII string `json:"einvoice_schema_cii"`
+}
+
+// SyncTimeout batas tunggu render sinkron; 0 berarti default usecase.
+func (g Generation) SyncTimeout() time.Duration {
+	return time.Duration(g.SyncTimeoutSeconds) * time.Second
+}
+
+// PriorityFor mengembalikan prioritas default tenant; tanpa override memakai DefaultPriority global.
+func (g Generation) PriorityFor(tenantID *string) string {
+	if tenantID != nil {
+		if p, ok := g.TenantPriorities[*tenantID]; ok && p != "" {
+			return p
+		}
+	}
+	return g.DefaultPriority
+}
@@ -12,8 +12,27 @@ type Kafka struct {
 	// Consumer subscribe ke topic ini, bukan document-events (observability).
 	// Default: "document-process"
 	TopicDocumentProcess  string `json:"topic_document_process"`
+	// TopicDocumentProcessHigh / TopicDocumentProcessLow lane prioritas HIGH dan LOW;
+	// TopicDocumentProcess dipakai lane NORMAL. Default: "<topic_document_process>-high" / "-low"
+	TopicDocumentProcessHigh string `json:"topic_document_process_high"`
+	TopicDocumentProcessLow  string `json:"topic_document_process_low"`
 	GroupIDDocumentWorker string `json:"group_id_document_worker"`
 	// Consumer kedua (contoh: order)
 	TopicOrders   string `json:"topic_orders"`
 	GroupIDOrders string `json:"group_id_orders"`
-}
\ No newline at end of file
+}
+// DocumentProcessTopics topic process per lane prioritas (high, normal, low) beserta default-nya.
+func (k Kafka) DocumentProcessTopics() (high, normal, low string) {
+	normal = k.TopicDocumentProcess
+	if normal == "" {
+		normal = "document-process"
+	}
+	high, low = k.TopicDocumentProcessHigh, k.TopicDocumentProcessLow
+	if high == "" {
+		high = normal + "-high"
+	}
+	if low == "" {
+		low = normal + "-low"
+	}
+	return high, normal, low
+}
@@ -0,0 +1,19 @@
+package config
+
+// Localization pemilihan locale dokumen bila request tidak menyebut locale.
+type Localization struct {
+	// DefaultLocale locale default semua tenant, mis. "id".
+	DefaultLocale string `json:"default_locale"`
+	// TenantLocales override per tenant: tenant_id -> locale.
+	TenantLocales map[string]string `json:"tenant_locales"`
+}
+
+// LocaleFor mengembalikan locale default tenant; tanpa override memakai DefaultLocale global.
+func (l Localization) LocaleFor(tenantID *string) string {
+	if tenantID != nil {
+		if loc, ok := l.TenantLocales[*tenantID]; ok && loc != "" {
+			return loc
+		}
+	}
+	return l.DefaultLocale
+}
@@ -0,0 +1,23 @@
+package config
+
+// Scheduler konfigurasi loop background di proses app.
+type Scheduler struct {
+	// VersionPublishIntervalSeconds interval cek versi template yang dijadwalkan publish.
+	// 0 = default 30 detik; negatif = scheduler dinonaktifkan.
+	VersionPublishIntervalSeconds int `json:"version_publish_interval_seconds"`
+	// DocumentScheduleIntervalSeconds interval cek jadwal generation dokumen yang jatuh tempo.
+	// 0 = default 15 detik; negatif = scheduler dinonaktifkan.
+	DocumentScheduleIntervalSeconds int `json:"document_schedule_interval_seconds"`
+	// DocumentScheduleMisfireGraceSeconds keterlambatan maksimum yang masih dianggap tepat waktu
+	// (di atasnya misfire policy berlaku). 0 = default 300 detik.
+	DocumentScheduleMisfireGraceSeconds int `json:"document_schedule_misfire_grace_seconds"`
+	// DocumentSchedulePayloadHosts host payload source yang boleh di jaringan internal;
+	// host lain hanya boleh resolve ke IP publik (mencegah SSRF).
+	DocumentSchedulePayloadHosts []string `json:"document_schedule_payload_hosts"`
+	// DocumentBatchIntervalSeconds interval pemrosesan batch dokumen (submit item, finalisasi, callback).
+	// 0 = default 5 detik; negatif = runner dinonaktifkan.
+	DocumentBatchIntervalSeconds int `json:"document_batch_interval_seconds"`
+	// DocumentArchiveIntervalSeconds interval cek job ZIP / merge yang antre.
+	// 0 = default 5 detik; negatif = runner dinonaktifkan.
+	DocumentArchiveIntervalSeconds int `json:"document_archive_interval_seconds"`
+}
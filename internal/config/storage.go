@@ -15,7 +15,8 @@ type Storage struct {
 
 // Auth konfigurasi autentikasi API.
 type Auth struct {
-	// APIKeys daftar API key yang valid. Kosong = auth dinonaktifkan (dev mode).
+	// APIKeys daftar API key yang valid, "nama=key" untuk memberi nama actor (audit, review).
+	// Kosong = auth dinonaktifkan (dev mode).
 	APIKeys []string `json:"api_keys"`
 }
 
@@ -30,4 +31,6 @@ type CallbackConfig struct {
 	// HMACSecret digunakan untuk sign callback payload. Kosong = tanpa signature.
 	HMACSecret string `json:"hmac_secret"`
 	MaxRetries int    `json:"max_retries"`
+	// AllowedHosts host callback batch yang boleh di jaringan internal; host lain wajib resolve ke IP publik.
+	AllowedHosts []string `json:"allowed_hosts"`
 }
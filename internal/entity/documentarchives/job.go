@@ -0,0 +1,47 @@
+package documentarchives
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/archive"
+)
+
+// Job pembuatan ZIP / merge async. Dokumen dipilih dari DocumentIDs atau Filter; Filter di-resolve
+// menjadi DocumentIDs saat job mulai diproses. Options hanya untuk ZIP; password-nya dihapus saat
+// job selesai.
+type Job struct {
+	ID           int64
+	TenantID     *string
+	Kind         enums.ArchiveJobKind
+	Status       enums.ArchiveJobStatus
+	Label        *string
+	DocumentIDs  []int64
+	Filter       *Filter
+	Options      archive.Options
+	Total        int
+	Processed    int
+	Attempts     int
+	OutputPath   *string
+	OutputFormat *string
+	ErrorMessage *string
+	CreatedBy    *string
+	StartedAt    *time.Time
+	HeartbeatAt  *time.Time
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Terminal true bila job sudah selesai (berhasil atau gagal).
+func (j Job) Terminal() bool {
+	return j.Status == enums.ArchiveJobStatusCompleted || j.Status == enums.ArchiveJobStatusFailed
+}
+
+// Filter pemilihan dokumen GENERATED, mis. semua INVOICE bulan Maret.
+type Filter struct {
+	TemplateCode string                 `json:"template_code,omitempty"`
+	Priority     enums.DocumentPriority `json:"priority,omitempty"`
+	CreatedFrom  *time.Time             `json:"created_from,omitempty"`
+	CreatedTo    *time.Time             `json:"created_to,omitempty"`
+}
@@ -0,0 +1,84 @@
+package documentbatches
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// Batch pembuatan dokumen massal yang dilacak: item dibuat async dalam potongan, progres
+// dihitung dari status item dan dokumennya. Setelah semua item final, output opsional
+// di-ZIP / merge dan satu callback dikirim.
+type Batch struct {
+	ID               int64
+	TenantID         *string
+	Name             *string
+	Status           enums.BatchStatus
+	TotalItems       int
+	PostAction       enums.BatchPostAction
+	OutputPath       *string
+	OutputError      *string
+	Metadata         map[string]any
+	HasCallback      bool
+	CallbackURL      *string
+	CallbackStatus   enums.CallbackStatus
+	CallbackAttempts int
+	NextCallbackAt   *time.Time
+	CreatedBy        *string
+	CompletedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	// Progress diisi service saat dibaca; tidak disimpan.
+	Progress Progress
+}
+
+// Terminal true bila batch tidak akan berubah lagi selain pengiriman callback.
+func (b Batch) Terminal() bool {
+	return b.Status == enums.BatchStatusCompleted || b.Status == enums.BatchStatusCancelled
+}
+
+// Progress agregat status item batch. Item SUBMITTED dihitung menurut status dokumennya;
+// item REJECTED dihitung Failed.
+type Progress struct {
+	Total      int64 `json:"total"`
+	Pending    int64 `json:"pending"` // belum dibuat dokumennya
+	Queued     int64 `json:"queued"`
+	Processing int64 `json:"processing"`
+	Generated  int64 `json:"generated"`
+	Failed     int64 `json:"failed"`
+	Cancelled  int64 `json:"cancelled"`
+}
+
+// Open jumlah item yang belum final.
+func (p Progress) Open() int64 { return p.Pending + p.Queued + p.Processing }
+
+// ItemInput spesifikasi pembuatan satu dokumen; default batch sudah diterapkan.
+type ItemInput struct {
+	TemplateCode    string                 `json:"template_code"`
+	TemplateVersion *int                   `json:"template_version,omitempty"`
+	OutputFormat    enums.OutputFormat     `json:"output_format,omitempty"`
+	Locale          *string                `json:"locale,omitempty"`
+	Priority        enums.DocumentPriority `json:"priority,omitempty"`
+	Payload         map[string]any         `json:"payload"`
+	Metadata        map[string]any         `json:"metadata,omitempty"`
+	StoreToDms      bool                   `json:"store_to_dms,omitempty"`
+	ExpiredAt       *time.Time             `json:"expired_at,omitempty"`
+}
+
+// Item satu dokumen dalam batch.
+type Item struct {
+	ID           int64
+	BatchID      int64
+	Seq          int
+	RequestID    string
+	Input        ItemInput
+	Status       enums.BatchItemStatus
+	DocumentID   *int64
+	ErrorMessage *string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// DocumentStatus status dokumen item SUBMITTED (join saat list).
+	DocumentStatus enums.DocumentStatus
+}
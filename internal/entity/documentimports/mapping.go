@@ -0,0 +1,39 @@
+package documentimports
+
+import (
+	"time"
+
+	"go-document-generator/internal/shared/mailmerge"
+)
+
+// Mapping pemetaan kolom file import (CSV / JSONL) ke payload yang disimpan untuk dipakai ulang.
+// TemplateCode bila diisi menjadi template default saat import memakai mapping ini.
+type Mapping struct {
+	ID              int64
+	TenantID        *string
+	Name            string
+	Description     *string
+	TemplateCode    *string
+	Fields          []mailmerge.Field
+	GroupBy         *string
+	RequestIDColumn *string
+	RequestIDPrefix *string
+	CreatedBy       *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Rules aturan mailmerge dari mapping.
+func (m Mapping) Rules() mailmerge.Mapping {
+	r := mailmerge.Mapping{Fields: m.Fields}
+	if m.GroupBy != nil {
+		r.GroupBy = *m.GroupBy
+	}
+	if m.RequestIDColumn != nil {
+		r.RequestIDColumn = *m.RequestIDColumn
+	}
+	if m.RequestIDPrefix != nil {
+		r.RequestIDPrefix = *m.RequestIDPrefix
+	}
+	return r
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pdfa"
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type Document struct {
@@ -17,14 +20,23 @@ type Document struct {
 	Payload            map[string]any
 	Metadata           map[string]any
 	Status             enums.DocumentStatus
+	Priority           enums.DocumentPriority // lane topic process
 	ErrorMessage       *string
 	OutputFormat       enums.OutputFormat
+	Locale             *string // locale terjemahan {{t}} yang dipilih saat dokumen dibuat
 	FileName           *string
 	FilePath           *string
 	StorageProvider    *enums.StorageProvider
 	FileSize           *int64
 	Checksum           *string
 	ContentType        *string
+	Watermark          *watermark.Spec // diminta saat create; setelah GENERATED: watermark yang diterapkan
+	Encryption         *pdfsecurity.Spec
+	EncryptionSecret   *string // password request tersegel; dihapus setelah PDF terenkripsi
+	IsEncrypted        bool
+	PDFAConformance    *string      // level PDF/A yang lolos validasi, mis. "PDF/A-2b"
+	PDFAReport         *pdfa.Report // hasil validasi PDF/A terakhir (audit arsip)
+	Attachments        []Attachment // file pendamping output utama, mis. XML e-invoice Factur-X
 	IsSigned           bool
 	SignatureProvider  *string
 	SignedAt           *time.Time
@@ -44,3 +56,19 @@ type Document struct {
 	UpdatedAt          time.Time
 	DeletedAt          *time.Time
 }
+
+// AttachmentKindEInvoiceXML XML e-invoice yang juga di-embed di PDF hybrid.
+const AttachmentKindEInvoiceXML = "EINVOICE_XML"
+
+// Attachment file pendamping yang disimpan terpisah dari file utama dokumen.
+type Attachment struct {
+	Name            string                `json:"name"`
+	Kind            string                `json:"kind"`
+	FileName        string                `json:"file_name"`
+	FilePath        string                `json:"file_path"`
+	StorageProvider enums.StorageProvider `json:"storage_provider"`
+	ContentType     string                `json:"content_type"`
+	FileSize        int64                 `json:"file_size"`
+	Checksum        string                `json:"checksum"`
+	Syntax          string                `json:"syntax,omitempty"`
+}
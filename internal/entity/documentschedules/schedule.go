@@ -0,0 +1,57 @@
+package documentschedules
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// Schedule generation dokumen terjadwal: berulang (CronExpression) atau sekali (RunAt).
+// Setiap waktu jadwal membuat satu dokumen dengan request_id deterministik.
+type Schedule struct {
+	ID              int64
+	TenantID        *string
+	Name            string
+	Description     *string
+	TemplateCode    string
+	TemplateVersion *int // nil: versi published terbaru saat jadwal jalan
+	OutputFormat    enums.OutputFormat
+	Locale          *string
+	Priority        enums.DocumentPriority
+	CronExpression  *string
+	RunAt           *time.Time
+	Timezone        string // zona waktu IANA untuk CronExpression, mis. "Asia/Jakarta"
+	Payload         map[string]any
+	PayloadSource   *PayloadSource
+	Metadata        map[string]any
+	StoreToDms      bool
+	HasCallback     bool
+	CallbackURL     *string
+	MisfirePolicy   enums.ScheduleMisfirePolicy
+	Status          enums.ScheduleStatus
+	NextRunAt       *time.Time
+	LastRunAt       *time.Time
+	CreatedBy       *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// PayloadSource payload yang diambil saat jadwal jalan dan di-merge di atas Payload statis.
+type PayloadSource struct {
+	Type    string            `json:"type"` // HTTP
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Run riwayat satu waktu jadwal.
+type Run struct {
+	ID           int64
+	TenantID     *string
+	ScheduleID   int64
+	ScheduledFor time.Time
+	Status       enums.ScheduleRunStatus
+	RequestID    *string
+	DocumentID   *int64
+	ErrorMessage *string
+	CreatedAt    time.Time
+}
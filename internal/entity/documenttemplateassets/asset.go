@@ -0,0 +1,24 @@
+package documenttemplateassets
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// Asset file (gambar, font, stylesheet) yang direferensikan template lewat {{asset "nama"}}.
+// TemplateID nil berarti asset berlaku untuk semua template tenant; TenantID nil berarti global.
+type Asset struct {
+	ID              int64
+	TenantID        *string
+	TemplateID      *int64
+	Name            string
+	ContentType     string
+	Size            int64
+	Checksum        string // sha256 hex isi file; dipakai sebagai key storage (content-addressed)
+	StoragePath     string
+	StorageProvider enums.StorageProvider
+	CreatedBy       *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,45 @@
+package documenttemplatepartials
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// Partial fragmen template yang bisa dipakai ulang (header, footer, layout) lintas template.
+// TenantID nil berarti partial global yang tersedia untuk semua tenant.
+type Partial struct {
+	ID             int64
+	TenantID       *string
+	Name           string
+	Kind           enums.TemplatePartialKind
+	Description    *string
+	CurrentVersion *int // versi yang dipakai saat render; nil bila belum ada versi yang dipublish
+	CreatedBy      *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PartialVersion isi partial pada satu versi; content tidak berubah setelah dibuat.
+type PartialVersion struct {
+	ID          int64
+	PartialID   int64
+	TenantID    *string
+	Version     int
+	Content     string
+	Checksum    *string
+	PublishedAt *time.Time
+	CreatedBy   *string
+	CreatedAt   time.Time
+}
+
+// Dependency versi partial yang dipakai sebuah versi template saat dipublish.
+type Dependency struct {
+	ID                int64
+	TenantID          *string
+	TemplateID        int64
+	TemplateVersionID int64
+	PartialID         int64
+	PartialVersion    int
+	CreatedAt         time.Time
+}
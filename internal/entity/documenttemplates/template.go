@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type Template struct {
@@ -16,8 +17,18 @@ type Template struct {
 	DefaultFormat enums.OutputFormat
 	Category      *string
 	IsActive      bool
-	CreatedBy     *string
-	UpdatedBy     *string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// RequiredApprovals jumlah approval reviewer sebelum versi DRAFT boleh dipublish; 0 = tanpa review.
+	RequiredApprovals int
+	// PendingApprovals penurunan required_approvals yang menunggu persetujuan actor lain
+	// (PendingApprovalsBy = actor yang meminta); nil bila tidak ada.
+	PendingApprovals   *int
+	PendingApprovalsBy *string
+	// SchemaCompatPolicy menentukan apakah perubahan schema breaking memblokir publish.
+	SchemaCompatPolicy enums.SchemaCompatPolicy
+	// Watermark default untuk dokumen PDF/HTML template ini bila request tidak membawa watermark.
+	Watermark *watermark.Spec
+	CreatedBy *string
+	UpdatedBy *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
@@ -0,0 +1,41 @@
+package documenttemplatetests
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// TestCase golden test sebuah template: payload dan snapshot output yang diharapkan.
+// ExpectedOutput disimpan dalam bentuk ternormalisasi (teks hasil ekstraksi untuk PDF).
+type TestCase struct {
+	ID             int64
+	TenantID       *string
+	TemplateID     int64
+	Name           string
+	Description    *string
+	Payload        map[string]any
+	OutputFormat   enums.OutputFormat
+	ExpectedOutput string
+	ExpectedPages  *int // khusus PDF
+	CreatedBy      *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TestResult hasil menjalankan satu test case terhadap satu versi template.
+type TestResult struct {
+	ID           int64
+	TenantID     *string
+	TemplateID   int64
+	VersionID    int64
+	TestCaseID   int64
+	CaseName     string
+	Passed       bool
+	Checksum     *string // checksum content versi saat test dijalankan
+	ActualOutput string
+	ActualPages  *int
+	Diff         *string
+	Error        *string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,19 @@
+package documenttemplateversionreviews
+
+import (
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+// Review satu entri audit review versi template (submit/approve/reject).
+type Review struct {
+	ID         int64
+	TenantID   *string
+	TemplateID int64
+	VersionID  int64
+	Action     enums.TemplateVersionReviewAction
+	Actor      string
+	Comment    *string
+	CreatedAt  time.Time
+}
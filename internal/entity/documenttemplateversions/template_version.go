@@ -4,21 +4,64 @@ import (
 	"time"
 
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/i18n"
 )
 
+// GeneratedSchemaComment penanda ($comment) schema skeleton hasil derive dari content.
+const GeneratedSchemaComment = "generated from template content"
+
 type TemplateVersion struct {
-	ID            int64
-	TenantID      *string
-	TemplateID    int64
-	Version       int
-	Content       string
-	Schema        map[string]any
-	Variables     []any
-	SamplePayload map[string]any
-	OutputFormat  enums.OutputFormat
-	Checksum      *string
-	IsPublished   bool
-	PublishedAt   *time.Time
-	CreatedBy     *string
-	CreatedAt     time.Time
+	ID                 int64
+	TenantID           *string
+	TemplateID         int64
+	Version            int
+	Content            string
+	Schema             map[string]any
+	Variables          []any
+	SamplePayload      map[string]any
+	OutputFormat       enums.OutputFormat
+	Layout             *string                      // nama partial layout; nil bila content dirender langsung
+	Translations       map[string]map[string]string // locale -> key -> pesan {{t "key"}}; terkunci bersama versi saat publish
+	DefaultLocale      *string                      // fallback terakhir setelah locale dokumen
+	PDFAConformance    *string                      // level arsip output PDF, mis. "PDF/A-2b"; nil: PDF biasa
+	EInvoice           *einvoice.Spec               // syntax XSD output XML, atau XML Factur-X untuk output PDF
+	Checksum           *string
+	Status             enums.TemplateVersionStatus
+	IsPublished        bool
+	PublishedAt        *time.Time
+	ScheduledPublishAt *time.Time
+	DeprecatedAt       *time.Time
+	ArchivedAt         *time.Time
+	ReviewStatus       enums.TemplateVersionReviewStatus
+	SubmittedBy        *string
+	SubmittedAt        *time.Time
+	CreatedBy          *string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsRenderable true bila versi boleh dipakai untuk membuat dokumen baru.
+// DRAFT hanya untuk preview; ARCHIVED tidak boleh dipakai sama sekali.
+func (v TemplateVersion) IsRenderable() bool {
+	return v.Status == enums.TemplateVersionStatusPublished || v.Status == enums.TemplateVersionStatusDeprecated
+}
+
+// EnforcedSchema schema yang divalidasi terhadap payload dokumen. Schema skeleton hasil
+// derive hanya tebakan dari content, jadi tidak ditegakkan (nil).
+func (v TemplateVersion) EnforcedSchema() map[string]any {
+	if len(v.Schema) == 0 || v.Schema["$comment"] == GeneratedSchemaComment {
+		return nil
+	}
+	return v.Schema
+}
+
+// Translator bundle versi dengan rantai fallback locale (beserta induknya) lalu default_locale;
+// locale kosong berarti render dengan bahasa default.
+func (v TemplateVersion) Translator(locale string) i18n.Translator {
+	def := ""
+	if v.DefaultLocale != nil {
+		def = *v.DefaultLocale
+	}
+	return i18n.New(v.Translations, locale, def)
 }
@@ -8,12 +8,59 @@ const (
 	TemplateEngineHTML       TemplateEngine = "HTML"
 )
 
+// SchemaCompatPolicy perlakuan perubahan schema yang breaking saat versi dipublish.
+type SchemaCompatPolicy string
+
+const (
+	SchemaCompatPolicyNone  SchemaCompatPolicy = "NONE"
+	SchemaCompatPolicyWarn  SchemaCompatPolicy = "WARN"
+	SchemaCompatPolicyBlock SchemaCompatPolicy = "BLOCK"
+)
+
 type OutputFormat string
 
 const (
 	OutputFormatPDF  OutputFormat = "PDF"
 	OutputFormatHTML OutputFormat = "HTML"
 	OutputFormatDOCX OutputFormat = "DOCX"
+	OutputFormatXML  OutputFormat = "XML"
+)
+
+type TemplateVersionStatus string
+
+const (
+	TemplateVersionStatusDraft      TemplateVersionStatus = "DRAFT"
+	TemplateVersionStatusPublished  TemplateVersionStatus = "PUBLISHED"
+	TemplateVersionStatusDeprecated TemplateVersionStatus = "DEPRECATED"
+	TemplateVersionStatusArchived   TemplateVersionStatus = "ARCHIVED"
+)
+
+// TemplateVersionReviewStatus status review four-eyes untuk versi DRAFT.
+type TemplateVersionReviewStatus string
+
+const (
+	TemplateVersionReviewStatusNone     TemplateVersionReviewStatus = "NONE"
+	TemplateVersionReviewStatusPending  TemplateVersionReviewStatus = "PENDING"
+	TemplateVersionReviewStatusApproved TemplateVersionReviewStatus = "APPROVED"
+	TemplateVersionReviewStatusRejected TemplateVersionReviewStatus = "REJECTED"
+)
+
+// TemplateVersionReviewAction jenis entri audit review.
+type TemplateVersionReviewAction string
+
+const (
+	TemplateVersionReviewActionSubmitted TemplateVersionReviewAction = "SUBMITTED"
+	TemplateVersionReviewActionApproved  TemplateVersionReviewAction = "APPROVED"
+	TemplateVersionReviewActionRejected  TemplateVersionReviewAction = "REJECTED"
+)
+
+// TemplatePartialKind jenis fragmen di library partial: PARTIAL dipanggil dari content,
+// LAYOUT membungkus content lewat block "content".
+type TemplatePartialKind string
+
+const (
+	TemplatePartialKindPartial TemplatePartialKind = "PARTIAL"
+	TemplatePartialKindLayout  TemplatePartialKind = "LAYOUT"
 )
 
 type DocumentStatus string
@@ -27,6 +74,15 @@ const (
 	DocumentStatusCancelled  DocumentStatus = "CANCELLED"
 )
 
+// DocumentPriority lane antrean generation; tiap lane punya topic process sendiri.
+type DocumentPriority string
+
+const (
+	DocumentPriorityHigh   DocumentPriority = "HIGH"
+	DocumentPriorityNormal DocumentPriority = "NORMAL"
+	DocumentPriorityLow    DocumentPriority = "LOW"
+)
+
 type DmsStatus string
 
 const (
@@ -54,3 +110,71 @@ const (
 	StorageProviderGCS   StorageProvider = "GCS"
 	StorageProviderAzure StorageProvider = "AZURE"
 )
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "ACTIVE"
+	ScheduleStatusPaused    ScheduleStatus = "PAUSED"
+	ScheduleStatusCompleted ScheduleStatus = "COMPLETED" // jadwal one-off yang sudah dijalankan
+)
+
+// ScheduleMisfirePolicy perlakuan waktu jadwal yang terlewat (scheduler mati / tertinggal).
+type ScheduleMisfirePolicy string
+
+const (
+	ScheduleMisfireFireOnce ScheduleMisfirePolicy = "FIRE_ONCE" // jalankan sekali untuk waktu terlewat terakhir
+	ScheduleMisfireFireAll  ScheduleMisfirePolicy = "FIRE_ALL"  // jalankan setiap waktu yang terlewat
+	ScheduleMisfireSkip     ScheduleMisfirePolicy = "SKIP"      // lewati, tunggu jadwal berikutnya
+)
+
+type ScheduleRunStatus string
+
+const (
+	ScheduleRunStatusSucceeded ScheduleRunStatus = "SUCCEEDED"
+	ScheduleRunStatusFailed    ScheduleRunStatus = "FAILED"
+	ScheduleRunStatusSkipped   ScheduleRunStatus = "SKIPPED"
+)
+
+type BatchStatus string
+
+const (
+	BatchStatusRunning    BatchStatus = "RUNNING"
+	BatchStatusCancelling BatchStatus = "CANCELLING" // menunggu dokumen QUEUED dibatalkan
+	BatchStatusCompleted  BatchStatus = "COMPLETED"
+	BatchStatusCancelled  BatchStatus = "CANCELLED"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemStatusPending   BatchItemStatus = "PENDING"   // belum dibuat dokumennya
+	BatchItemStatusSubmitted BatchItemStatus = "SUBMITTED" // dokumen dibuat; status lanjut di documents
+	BatchItemStatusRejected  BatchItemStatus = "REJECTED"  // Create gagal (validasi, template tidak ada, ...)
+	BatchItemStatusCancelled BatchItemStatus = "CANCELLED"
+)
+
+// BatchPostAction pengolahan output setelah semua item batch selesai.
+type BatchPostAction string
+
+const (
+	BatchPostActionNone  BatchPostAction = "NONE"
+	BatchPostActionZip   BatchPostAction = "ZIP"
+	BatchPostActionMerge BatchPostAction = "MERGE"
+)
+
+type ArchiveJobKind string
+
+const (
+	ArchiveJobKindZip   ArchiveJobKind = "ZIP"
+	ArchiveJobKindMerge ArchiveJobKind = "MERGE"
+)
+
+type ArchiveJobStatus string
+
+const (
+	ArchiveJobStatusQueued     ArchiveJobStatus = "QUEUED"
+	ArchiveJobStatusProcessing ArchiveJobStatus = "PROCESSING"
+	ArchiveJobStatusCompleted  ArchiveJobStatus = "COMPLETED"
+	ArchiveJobStatusFailed     ArchiveJobStatus = "FAILED"
+)
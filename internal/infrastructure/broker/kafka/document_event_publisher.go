@@ -7,6 +7,7 @@ import (
 	"time"
 
 	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/entity/enums"
 	"go-document-generator/internal/transport/event/events"
 	ucDoc "go-document-generator/internal/usecase/documents"
 
@@ -16,18 +17,35 @@ import (
 // DocumentEventPublisherKafka mempublikasikan event dokumen ke Kafka.
 // doc    → topic document-events  (lifecycle events: CREATE / UPDATE)
 // bulk   → topic document-events  (zip / merge completion events)
-// process → topic document-process (generation trigger)
+// process → topic document-process[-high|-low] (generation trigger, per lane prioritas)
 type DocumentEventPublisherKafka struct {
 	doc     *libkafka.Producer[events.DocumentEvent]     // document-events
 	bulk    *libkafka.Producer[events.DocumentBulkEvent] // document-events (bulk ops)
-	process *libkafka.Producer[events.DocumentEvent]     // document-process
+	process ProcessProducers                             // document-process per lane
 }
 
-// NewDocumentEventPublisherKafka membuat publisher dengan 3 producer.
+// ProcessProducers producer generation trigger per lane prioritas; High / Low nil memakai Normal.
+type ProcessProducers struct {
+	High   *libkafka.Producer[events.DocumentEvent] // document-process-high
+	Normal *libkafka.Producer[events.DocumentEvent] // document-process
+	Low    *libkafka.Producer[events.DocumentEvent] // document-process-low
+}
+
+func (p ProcessProducers) lane(priority enums.DocumentPriority) *libkafka.Producer[events.DocumentEvent] {
+	switch {
+	case priority == enums.DocumentPriorityHigh && p.High != nil:
+		return p.High
+	case priority == enums.DocumentPriorityLow && p.Low != nil:
+		return p.Low
+	}
+	return p.Normal
+}
+
+// NewDocumentEventPublisherKafka membuat publisher dengan producer lifecycle, bulk, dan process per lane.
 func NewDocumentEventPublisherKafka(
 	doc *libkafka.Producer[events.DocumentEvent],
 	bulk *libkafka.Producer[events.DocumentBulkEvent],
-	process *libkafka.Producer[events.DocumentEvent],
+	process ProcessProducers,
 ) ucDoc.DocumentEventPublisher {
 	return &DocumentEventPublisherKafka{doc: doc, bulk: bulk, process: process}
 }
@@ -93,7 +111,7 @@ func (p *DocumentEventPublisherKafka) PublishDocumentProcess(ctx context.Context
 		Before: nil,
 		After:  toDocumentState(&d),
 	}
-	return p.process.Publish(ctx, evt)
+	return p.process.lane(d.Priority).Publish(ctx, evt)
 }
 
 // toDocumentState mengkonversi entity dokumen ke snapshot untuk event envelope.
@@ -114,6 +132,7 @@ func toDocumentState(d *docEntity.Document) *events.DocumentState {
 		TemplateVersion: d.TemplateVersion,
 		OutputFormat:    string(d.OutputFormat),
 		Status:          string(d.Status),
+		Priority:        string(d.Priority),
 		ErrorMessage:    d.ErrorMessage,
 		RetryCount:      d.RetryCount,
 		FilePath:        d.FilePath,
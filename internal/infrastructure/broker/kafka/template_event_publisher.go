@@ -4,6 +4,7 @@ import (
 	"context"
 
 	tplEntity "go-document-generator/internal/entity/documenttemplates"
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
 	"go-document-generator/internal/transport/event/events"
 	ucTpl "go-document-generator/internal/usecase/documenttemplates"
@@ -37,13 +38,40 @@ func NewVersionEventPublisherKafka(producer *libkafka.Producer[events.TemplateVe
 }
 
 func (p *VersionEventPublisherKafka) PublishVersionCreated(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "CREATED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionUpdated(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "UPDATED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionPublished(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "PUBLISHED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionDeprecated(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "DEPRECATED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionArchived(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "ARCHIVED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionScheduled(ctx context.Context, v verEntity.TemplateVersion) error {
+	return p.publish(ctx, "SCHEDULED", v)
+}
+
+func (p *VersionEventPublisherKafka) PublishVersionReviewed(ctx context.Context, v verEntity.TemplateVersion, r reviewEntity.Review) error {
 	return p.producer.Publish(ctx, events.TemplateVersionCreatedEvent{
 		ID: v.ID, TemplateID: v.TemplateID, Version: v.Version,
+		Action: "REVIEW_" + string(r.Action), Status: string(v.Status),
+		ReviewStatus: string(v.ReviewStatus), Actor: r.Actor, Comment: r.Comment,
 	})
 }
 
-func (p *VersionEventPublisherKafka) PublishVersionPublished(ctx context.Context, v verEntity.TemplateVersion) error {
+func (p *VersionEventPublisherKafka) publish(ctx context.Context, action string, v verEntity.TemplateVersion) error {
 	return p.producer.Publish(ctx, events.TemplateVersionCreatedEvent{
 		ID: v.ID, TemplateID: v.TemplateID, Version: v.Version,
+		Action: action, Status: string(v.Status), ScheduledPublishAt: v.ScheduledPublishAt,
 	})
 }
@@ -0,0 +1,305 @@
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	ucDoc "go-document-generator/internal/usecase/documents"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// StatusStreamKey Redis stream berisi transisi status dokumen semua tenant.
+	StatusStreamKey = "document-status"
+
+	statusStreamMaxLen = 100000
+	readBlock          = 5 * time.Second
+	subscriberBuffer   = 64
+)
+
+// statusMessage isi field "data" entry stream.
+type statusMessage struct {
+	DocumentID     int64     `json:"document_id"`
+	RequestID      string    `json:"request_id"`
+	TenantID       *string   `json:"tenant_id,omitempty"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	ErrorMessage   *string   `json:"error_message,omitempty"`
+	At             time.Time `json:"at"`
+}
+
+// DocumentStatusStream menulis transisi status ke Redis stream dan membagikannya ke subscriber SSE.
+// Satu goroutine per proses membaca stream (XREAD) lalu fan-out di memori, sehingga jumlah koneksi
+// Redis tidak bertambah per client.
+type DocumentStatusStream struct {
+	redis *goredis.Client
+
+	mu      sync.Mutex
+	subs    map[*subscriber]struct{}
+	started bool
+}
+
+type subscriber struct {
+	tenant string
+	ch     chan ucDoc.StatusEvent
+}
+
+func NewDocumentStatusStream(redis *goredis.Client) *DocumentStatusStream {
+	return &DocumentStatusStream{redis: redis, subs: map[*subscriber]struct{}{}}
+}
+
+// Publisher membungkus publisher event dokumen: setiap perubahan status juga ditulis ke stream.
+func (s *DocumentStatusStream) Publisher(inner ucDoc.DocumentEventPublisher) ucDoc.DocumentEventPublisher {
+	return &statusPublisher{DocumentEventPublisher: inner, stream: s}
+}
+
+type statusPublisher struct {
+	ucDoc.DocumentEventPublisher
+	stream *DocumentStatusStream
+}
+
+// PublishDocumentEvent menulis ke stream lebih dulu agar transisi (termasuk QUEUED → PROCESSING)
+// tetap sampai ke SSE walau produce Kafka lambat atau gagal.
+func (p *statusPublisher) PublishDocumentEvent(ctx context.Context, action string, before, after *docEntity.Document) error {
+	var streamErr error
+	if after != nil && (before == nil || before.Status != after.Status) {
+		streamErr = p.stream.append(ctx, before, after)
+	}
+	return errors.Join(streamErr, p.DocumentEventPublisher.PublishDocumentEvent(ctx, action, before, after))
+}
+
+func (s *DocumentStatusStream) append(ctx context.Context, before, after *docEntity.Document) error {
+	msg := statusMessage{
+		DocumentID:   after.ID,
+		RequestID:    after.RequestID,
+		TenantID:     after.TenantID,
+		Status:       string(after.Status),
+		ErrorMessage: after.ErrorMessage,
+		At:           time.Now().UTC(),
+	}
+	if before != nil {
+		msg.PreviousStatus = string(before.Status)
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.redis.XAdd(ctx, &goredis.XAddArgs{
+		Stream: StatusStreamKey,
+		MaxLen: statusStreamMaxLen,
+		Approx: true,
+		Values: map[string]any{"tenant": tenantKey(after.TenantID), "data": b},
+	}).Err()
+}
+
+// Subscribe mendaftarkan subscriber ke fan-out lalu (bila resume) mengirim backlog setelah lastEventID.
+// Event live yang sudah terkirim lewat backlog dilewati berdasarkan urutan id stream.
+func (s *DocumentStatusStream) Subscribe(ctx context.Context, tenantID *string, lastEventID string) (<-chan ucDoc.StatusEvent, error) {
+	if lastEventID != "" && !validID(lastEventID) {
+		return nil, fmt.Errorf("%w: invalid Last-Event-ID %q", apperror.ErrInvalidInput, lastEventID)
+	}
+	if err := s.start(); err != nil {
+		return nil, err
+	}
+	sub := &subscriber{tenant: tenantKey(tenantID), ch: make(chan ucDoc.StatusEvent, subscriberBuffer)}
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	var backlog []goredis.XMessage
+	if lastEventID != "" {
+		var err error
+		backlog, err = s.redis.XRange(ctx, StatusStreamKey, "("+lastEventID, "+").Result()
+		if err != nil {
+			s.unsubscribe(sub)
+			return nil, err
+		}
+	}
+
+	out := make(chan ucDoc.StatusEvent, subscriberBuffer)
+	go func() {
+		defer close(out)
+		defer s.unsubscribe(sub)
+		last := lastEventID
+		send := func(evt ucDoc.StatusEvent) bool {
+			select {
+			case out <- evt:
+				last = evt.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for _, m := range backlog {
+			if evt, ok := decode(m); ok && evt.tenant == sub.tenant && !send(evt.StatusEvent) {
+				return
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-sub.ch:
+				if !ok {
+					return
+				}
+				if last != "" && !idAfter(evt.ID, last) {
+					continue
+				}
+				if !send(evt) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *DocumentStatusStream) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[sub]; ok {
+		delete(s.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// start menjalankan reader stream sekali per proses, mulai dari entry terakhir saat ini.
+func (s *DocumentStatusStream) start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return nil
+	}
+	ctx := context.Background()
+	from := "0-0"
+	last, err := s.redis.XRevRangeN(ctx, StatusStreamKey, "+", "-", 1).Result()
+	if err != nil {
+		return err
+	}
+	if len(last) > 0 {
+		from = last[0].ID
+	}
+	s.started = true
+	go s.read(ctx, from)
+	return nil
+}
+
+func (s *DocumentStatusStream) read(ctx context.Context, from string) {
+	for {
+		res, err := s.redis.XRead(ctx, &goredis.XReadArgs{
+			Streams: []string{StatusStreamKey, from},
+			Count:   100,
+			Block:   readBlock,
+		}).Result()
+		if errors.Is(err, goredis.Nil) {
+			continue
+		}
+		if err != nil {
+			log.Printf("redisstream: read %s: %v", StatusStreamKey, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		for _, st := range res {
+			for _, m := range st.Messages {
+				from = m.ID
+				if evt, ok := decode(m); ok {
+					s.dispatch(evt)
+				}
+			}
+		}
+	}
+}
+
+// dispatch tidak pernah menunggu subscriber; yang buffer-nya penuh diputus dan harus
+// reconnect dengan Last-Event-ID.
+func (s *DocumentStatusStream) dispatch(evt tenantEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		if sub.tenant != evt.tenant {
+			continue
+		}
+		select {
+		case sub.ch <- evt.StatusEvent:
+		default:
+			delete(s.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+type tenantEvent struct {
+	ucDoc.StatusEvent
+	tenant string
+}
+
+func decode(m goredis.XMessage) (tenantEvent, bool) {
+	data, _ := m.Values["data"].(string)
+	var msg statusMessage
+	if err := json.Unmarshal([]byte(data), &msg); err != nil {
+		log.Printf("redisstream: decode %s: %v", m.ID, err)
+		return tenantEvent{}, false
+	}
+	tenant, _ := m.Values["tenant"].(string)
+	return tenantEvent{
+		tenant: tenant,
+		StatusEvent: ucDoc.StatusEvent{
+			ID:             m.ID,
+			DocumentID:     msg.DocumentID,
+			RequestID:      msg.RequestID,
+			TenantID:       msg.TenantID,
+			Status:         enums.DocumentStatus(msg.Status),
+			PreviousStatus: enums.DocumentStatus(msg.PreviousStatus),
+			ErrorMessage:   msg.ErrorMessage,
+			At:             msg.At,
+		},
+	}, true
+}
+
+// tenantKey key fan-out per tenant pemilik dokumen; dokumen tanpa tenant memakai key kosong.
+// Berbeda dengan GetByID (tenant nil membaca semua tenant), subscriber key kosong hanya menerima
+// dokumen tanpa tenant; watch satu dokumen karena itu subscribe dengan tenant pemilik dokumen.
+func tenantKey(tenantID *string) string {
+	if tenantID == nil {
+		return ""
+	}
+	return *tenantID
+}
+
+// idAfter membandingkan id stream "<ms>-<seq>".
+func idAfter(a, b string) bool {
+	am, as := splitID(a)
+	bm, bs := splitID(b)
+	return am > bm || (am == bm && as > bs)
+}
+
+func splitID(id string) (uint64, uint64) {
+	ms, seq, _ := strings.Cut(id, "-")
+	m, _ := strconv.ParseUint(ms, 10, 64)
+	q, _ := strconv.ParseUint(seq, 10, 64)
+	return m, q
+}
+
+func validID(id string) bool {
+	ms, seq, ok := strings.Cut(id, "-")
+	if _, err := strconv.ParseUint(ms, 10, 64); err != nil {
+		return false
+	}
+	if !ok {
+		return true
+	}
+	_, err := strconv.ParseUint(seq, 10, 64)
+	return err == nil
+}
@@ -8,6 +8,7 @@ import (
 
 	tplEntity "go-document-generator/internal/entity/documenttemplates"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
 	tplrepo "go-document-generator/internal/repository/documenttemplates"
 	verrepo "go-document-generator/internal/repository/documenttemplateversions"
 
@@ -128,30 +129,92 @@ func (r *CachedVersionRepo) GetByID(ctx context.Context, tx *gorm.DB, templateID
 	return ver, nil
 }
 
+// GetByIDForUpdate selalu ke DB: lock baris tidak boleh dilayani cache.
+func (r *CachedVersionRepo) GetByIDForUpdate(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+	return r.inner.GetByIDForUpdate(ctx, tx, templateID, versionID, tenantID)
+}
+
 func (r *CachedVersionRepo) Create(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
 	return r.inner.Create(ctx, tx, v)
 }
 
-func (r *CachedVersionRepo) ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string, isPublished *bool) ([]verEntity.TemplateVersion, error) {
-	return r.inner.ListByTemplateID(ctx, tx, templateID, tenantID, isPublished)
+func (r *CachedVersionRepo) Update(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
+	result, err := r.inner.Update(ctx, tx, v)
+	if err == nil {
+		r.evict(ctx, result)
+	}
+	return result, err
+}
+
+func (r *CachedVersionRepo) ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, f verrepo.ListFilter) ([]verEntity.TemplateVersion, error) {
+	return r.inner.ListByTemplateID(ctx, tx, templateID, f)
 }
 
 func (r *CachedVersionRepo) NextVersionNumber(ctx context.Context, tx *gorm.DB, templateID int64) (int, error) {
 	return r.inner.NextVersionNumber(ctx, tx, templateID)
 }
 
-func (r *CachedVersionRepo) UnpublishOthers(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) error {
-	return r.inner.UnpublishOthers(ctx, tx, templateID, exceptVersionID)
+func (r *CachedVersionRepo) UpdateStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	templateID, versionID int64,
+	tenantID *string,
+	from []enums.TemplateVersionStatus,
+	to enums.TemplateVersionStatus,
+) (verEntity.TemplateVersion, error) {
+	result, err := r.inner.UpdateStatus(ctx, tx, templateID, versionID, tenantID, from, to)
+	if err == nil {
+		r.evict(ctx, result)
+	}
+	return result, err
 }
 
-func (r *CachedVersionRepo) Publish(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
-	result, err := r.inner.Publish(ctx, tx, templateID, versionID, tenantID)
+func (r *CachedVersionRepo) SetReviewStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	templateID, versionID int64,
+	tenantID *string,
+	from []enums.TemplateVersionReviewStatus,
+	to enums.TemplateVersionReviewStatus,
+	submittedBy *string,
+) (verEntity.TemplateVersion, error) {
+	result, err := r.inner.SetReviewStatus(ctx, tx, templateID, versionID, tenantID, from, to, submittedBy)
 	if err == nil {
-		_ = r.redis.Del(ctx, versionLatestKey(templateID, tenantID))
+		r.evict(ctx, result)
 	}
 	return result, err
 }
 
+func (r *CachedVersionRepo) DeprecatePublished(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) ([]verEntity.TemplateVersion, error) {
+	result, err := r.inner.DeprecatePublished(ctx, tx, templateID, exceptVersionID)
+	if err == nil {
+		for _, v := range result {
+			r.evict(ctx, v)
+		}
+	}
+	return result, err
+}
+
+func (r *CachedVersionRepo) SetSchedule(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string, at *time.Time) (verEntity.TemplateVersion, error) {
+	result, err := r.inner.SetSchedule(ctx, tx, templateID, versionID, tenantID, at)
+	if err == nil {
+		r.evict(ctx, result)
+	}
+	return result, err
+}
+
+func (r *CachedVersionRepo) ListDueScheduled(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]verEntity.TemplateVersion, error) {
+	return r.inner.ListDueScheduled(ctx, tx, now, limit)
+}
+
+// evict menghapus semua key cache yang bisa berisi versi ini.
+func (r *CachedVersionRepo) evict(ctx context.Context, v verEntity.TemplateVersion) {
+	_ = r.redis.Del(ctx,
+		versionLatestKey(v.TemplateID, v.TenantID),
+		versionByIDKey(v.TemplateID, v.ID, v.TenantID),
+		versionByNumKey(v.TemplateID, v.Version, v.TenantID),
+	)
+}
 
 // helpers
 
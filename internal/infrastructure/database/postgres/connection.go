@@ -5,7 +5,11 @@ import (
 
 	cbmodel "go-document-generator/internal/repository/documentcallbackattempts/model"
 	logmodel "go-document-generator/internal/repository/documentrenderlogs/model"
+	assetmodel "go-document-generator/internal/repository/documenttemplateassets/model"
+	partialmodel "go-document-generator/internal/repository/documenttemplatepartials/model"
 	tplmodel "go-document-generator/internal/repository/documenttemplates/model"
+	testmodel "go-document-generator/internal/repository/documenttemplatetests/model"
+	reviewmodel "go-document-generator/internal/repository/documenttemplateversionreviews/model"
 	vermodel "go-document-generator/internal/repository/documenttemplateversions/model"
 	docmodel "go-document-generator/internal/repository/documents/model"
 	"go-document-generator/internal/repository/user/model"
@@ -39,6 +43,13 @@ func Migrate(db *gorm.DB) error {
 		&model.User{},
 		&tplmodel.DocumentTemplate{},
 		&vermodel.DocumentTemplateVersion{},
+		&reviewmodel.DocumentTemplateVersionReview{},
+		&testmodel.DocumentTemplateTestCase{},
+		&testmodel.DocumentTemplateTestResult{},
+		&partialmodel.DocumentTemplatePartial{},
+		&partialmodel.DocumentTemplatePartialVersion{},
+		&partialmodel.DocumentTemplatePartialDependency{},
+		&assetmodel.DocumentTemplateAsset{},
 		&docmodel.Document{},
 		&logmodel.DocumentRenderLog{},
 		&cbmodel.DocumentCallbackAttempt{},
@@ -6,6 +6,7 @@ import (
 	"text/template"
 
 	sharedcsv "go-document-generator/internal/shared/csv"
+	"go-document-generator/internal/shared/templating"
 )
 
 // TmplCSVGenerator merender CSV menggunakan text/template (engine "tmpl").
@@ -23,9 +24,8 @@ func NewCSVGenerator() *TmplCSVGenerator {
 	}
 }
 
-func (g *TmplCSVGenerator) Generate(ctx context.Context, templateSource string, data any) ([]byte, string, error) {
-	tpl := template.New("csv").Funcs(template.FuncMap(g.funcs))
-	tpl, err := tpl.Parse(templateSource)
+func (g *TmplCSVGenerator) Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error) {
+	tpl, err := src.Text("csv", template.FuncMap(g.funcs))
 	if err != nil {
 		return nil, "", err
 	}
@@ -8,6 +8,8 @@ import (
 	"go-document-generator/internal/infrastructure/documents/csv"
 	"go-document-generator/internal/infrastructure/documents/html"
 	"go-document-generator/internal/infrastructure/documents/pdf"
+	"go-document-generator/internal/infrastructure/documents/xml"
+	"go-document-generator/internal/shared/templating"
 	usecasedoc "go-document-generator/internal/usecase/documents"
 )
 
@@ -22,6 +24,8 @@ func (s *Selector) Select(outputFormat string, engine string) usecasedoc.Generat
 		return pdf.NewWKHTMLToPDFGenerator()
 	case "HTML":
 		return html.NewGenerator()
+	case "XML":
+		return xml.NewGenerator()
 	case "DOCX":
 		return &unsupportedGenerator{format: "DOCX"}
 	default:
@@ -38,6 +42,6 @@ type unsupportedGenerator struct {
 	format string
 }
 
-func (g *unsupportedGenerator) Generate(_ context.Context, _ string, _ any) ([]byte, string, error) {
+func (g *unsupportedGenerator) Generate(_ context.Context, _ templating.Source, _ any) ([]byte, string, error) {
 	return nil, "", fmt.Errorf("output format %q not yet supported", g.format)
 }
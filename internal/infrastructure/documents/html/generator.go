@@ -3,18 +3,18 @@ package html
 import (
 	"bytes"
 	"context"
-	"html/template"
+
+	"go-document-generator/internal/shared/templating"
 )
 
-// Generator merender HTML dari template string (engine HTML).
+// Generator merender HTML dari template source (engine HTML).
 type Generator struct{}
 
 func NewGenerator() *Generator { return &Generator{} }
 
-func (g *Generator) Generate(ctx context.Context, templateSource string, data any) ([]byte, string, error) {
+func (g *Generator) Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error) {
 	_ = ctx
-	tpl := template.New("html")
-	tpl, err := tpl.Parse(templateSource)
+	tpl, err := src.HTML("html", nil)
 	if err != nil {
 		return nil, "", err
 	}
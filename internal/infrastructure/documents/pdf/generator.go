@@ -3,10 +3,10 @@ package pdf
 import (
 	"bytes"
 	"context"
-	"html/template"
 	"strings"
 
 	wkhtml "github.com/SebastiaanKlippert/go-wkhtmltopdf"
+	"go-document-generator/internal/shared/templating"
 )
 
 // WKHTMLToPDFGenerator mengubah HTML menjadi PDF menggunakan wkhtmltopdf.
@@ -28,10 +28,9 @@ func NewWKHTMLToPDFGenerator() *WKHTMLToPDFGenerator {
 	}
 }
 
-func (g *WKHTMLToPDFGenerator) Generate(ctx context.Context, templateSource string, data any) ([]byte, string, error) {
-	// 1) Render HTML dari template (html/template)
-	tpl := template.New("html")
-	tpl, err := tpl.Parse(templateSource)
+func (g *WKHTMLToPDFGenerator) Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error) {
+	// 1) Render HTML dari template (html/template, termasuk partial/layout)
+	tpl, err := src.HTML("html", nil)
 	if err != nil {
 		return nil, "", err
 	}
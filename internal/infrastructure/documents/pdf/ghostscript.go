@@ -0,0 +1,75 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-document-generator/internal/shared/pdfa"
+)
+
+// GhostscriptPDFA mengonversi PDF ke PDF/A dengan Ghostscript (pdfwrite): semua font di-embed,
+// output intent ICC dan metadata XMP dari PDFA_def, transparansi diratakan, associated file
+// (PDF/A-3) di-embed dari file sementara.
+type GhostscriptPDFA struct {
+	binary     string
+	iccProfile string
+}
+
+// NewGhostscriptPDFA iccProfile: path profil ICC RGB (mis. srgb.icc bawaan Ghostscript).
+func NewGhostscriptPDFA(iccProfile string) *GhostscriptPDFA {
+	return &GhostscriptPDFA{binary: "gs", iccProfile: iccProfile}
+}
+
+func (g *GhostscriptPDFA) ConvertPDFA(ctx context.Context, data []byte, level string, info pdfa.Info) ([]byte, error) {
+	part := pdfa.Part(level)
+	if part == 0 {
+		return nil, fmt.Errorf("unsupported conformance level %q", level)
+	}
+	if g.iccProfile == "" {
+		return nil, errors.New("pdf/a icc profile not configured")
+	}
+	dir, err := os.MkdirTemp("", "gs-pdfa-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in, def, out := filepath.Join(dir, "in.pdf"), filepath.Join(dir, "PDFA_def.ps"), filepath.Join(dir, "out.pdf")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return nil, err
+	}
+	atts := make([]pdfa.Attachment, len(info.Attachments))
+	for i, a := range info.Attachments {
+		a.Path = filepath.Join(dir, fmt.Sprintf("attachment-%d", i))
+		if err := os.WriteFile(a.Path, a.Data, 0o600); err != nil {
+			return nil, err
+		}
+		atts[i] = a
+	}
+	info.Attachments = atts
+	if err := os.WriteFile(def, pdfa.DefFile(g.iccProfile, info), 0o600); err != nil {
+		return nil, err
+	}
+
+	// PDFACompatibilityPolicy=1: fitur yang melanggar dibuang dan output tetap PDF/A; sisanya
+	// ditangkap validator. HaveTransparency=false meratakan transparansi (termasuk watermark).
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, g.binary,
+		"-q", "-dBATCH", "-dNOPAUSE", "-dNOOUTERSAVE",
+		"-dPDFA="+strconv.Itoa(part), "-dPDFACompatibilityPolicy=1",
+		"-dHaveTransparency=false", "-dEmbedAllFonts=true", "-dSubsetFonts=true",
+		"-sDEVICE=pdfwrite", "-sColorConversionStrategy=RGB", "-sProcessColorModel=DeviceRGB",
+		"--permit-file-read="+g.iccProfile, "--permit-file-read="+dir+string(filepath.Separator),
+		"-sOutputFile="+out, def, in)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gs: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return os.ReadFile(out)
+}
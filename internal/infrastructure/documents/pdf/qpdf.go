@@ -0,0 +1,134 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/shared/watermark"
+)
+
+// QPDFProcessor memproses PDF hasil render (watermark overlay, enkripsi) dengan binary qpdf.
+type QPDFProcessor struct {
+	binary string
+}
+
+func NewQPDFProcessor() *QPDFProcessor {
+	return &QPDFProcessor{binary: "qpdf"}
+}
+
+// Watermark menimpa halaman watermark ke halaman yang dipilih spec.Pages. Halaman overlay
+// diskalakan qpdf ke tengah setiap halaman tujuan.
+func (p *QPDFProcessor) Watermark(ctx context.Context, data []byte, spec watermark.Spec, img *watermark.Image) ([]byte, error) {
+	overlay, err := watermark.Page(spec, img)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "qpdf-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in, wm, out := filepath.Join(dir, "in.pdf"), filepath.Join(dir, "watermark.pdf"), filepath.Join(dir, "out.pdf")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(wm, overlay, 0o600); err != nil {
+		return nil, err
+	}
+
+	npages, err := p.run(ctx, "--show-npages", in)
+	if err != nil {
+		return nil, err
+	}
+	total, err := strconv.Atoi(strings.TrimSpace(string(npages)))
+	if err != nil {
+		return nil, fmt.Errorf("qpdf: page count %q: %w", npages, err)
+	}
+	pages, err := watermark.SelectPages(spec.Pages, total)
+	if err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return data, nil
+	}
+	if _, err := p.run(ctx, in, "--overlay", wm, "--to="+pageRanges(pages), "--from=1", "--repeat=1", "--", out); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(out)
+}
+
+// Encrypt mengenkripsi PDF dengan AES-256 (revisi 6) dan membatasi izin sesuai spec. Argumen
+// (termasuk password) dikirim lewat file @args agar tidak terlihat di daftar proses.
+func (p *QPDFProcessor) Encrypt(ctx context.Context, data []byte, userPassword, ownerPassword string, spec pdfsecurity.Spec) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "qpdf-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in, out, argFile := filepath.Join(dir, "in.pdf"), filepath.Join(dir, "out.pdf"), filepath.Join(dir, "args")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	args := []string{in, "--encrypt", userPassword, ownerPassword, "256"}
+	if spec.NoPrint {
+		args = append(args, "--print=none")
+	}
+	if spec.NoCopy {
+		args = append(args, "--extract=n")
+	}
+	if spec.NoModify {
+		args = append(args, "--modify=none")
+	}
+	args = append(args, "--", out)
+	if err := os.WriteFile(argFile, []byte(strings.Join(args, "\n")+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	if _, err := p.run(ctx, "@"+argFile); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(out)
+}
+
+// run menjalankan qpdf; exit code 3 (berhasil dengan warning) dianggap sukses.
+func (p *QPDFProcessor) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+			return nil, fmt.Errorf("qpdf: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// pageRanges menyingkat daftar halaman urut menjadi sintaks rentang qpdf ("1-3,5").
+func pageRanges(pages []int) string {
+	var b strings.Builder
+	for i := 0; i < len(pages); {
+		j := i
+		for j+1 < len(pages) && pages[j+1] == pages[j]+1 {
+			j++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(pages[i]))
+		if j > i {
+			b.WriteString("-" + strconv.Itoa(pages[j]))
+		}
+		i = j + 1
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PDFToTextExtractor mengekstrak teks PDF dengan binary pdftotext (poppler-utils),
+// dipakai golden test untuk membandingkan output PDF tanpa bergantung pada byte file.
+type PDFToTextExtractor struct {
+	binary string
+}
+
+func NewPDFToTextExtractor() *PDFToTextExtractor {
+	return &PDFToTextExtractor{binary: "pdftotext"}
+}
+
+// ExtractText mengembalikan teks (layout dipertahankan) dan jumlah halaman.
+func (e *PDFToTextExtractor) ExtractText(ctx context.Context, data []byte) (string, int, error) {
+	var out, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.binary, "-layout", "-enc", "UTF-8", "-", "-")
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", 0, fmt.Errorf("pdftotext: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// pdftotext mengakhiri setiap halaman dengan form feed.
+	text := out.String()
+	pages := strings.Count(text, "\f")
+	return strings.ReplaceAll(text, "\f", "\n"), pages, nil
+}
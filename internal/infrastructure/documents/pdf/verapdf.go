@@ -0,0 +1,62 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"go-document-generator/internal/shared/pdfa"
+)
+
+// VeraPDFValidator memvalidasi konformansi PDF/A dengan CLI veraPDF (laporan MRR).
+type VeraPDFValidator struct {
+	binary string
+}
+
+func NewVeraPDFValidator(binary string) *VeraPDFValidator {
+	return &VeraPDFValidator{binary: binary}
+}
+
+var (
+	veraCompliantRe = regexp.MustCompile(`isCompliant="(true|false)"`)
+	veraFailedRe    = regexp.MustCompile(`(?s)<rule [^>]*status="failed"[^>]*>.*?<description>(.*?)</description>`)
+)
+
+func (v *VeraPDFValidator) ValidatePDFA(ctx context.Context, data []byte, level string) (pdfa.Report, error) {
+	dir, err := os.MkdirTemp("", "verapdf-*")
+	if err != nil {
+		return pdfa.Report{}, err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "in.pdf")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return pdfa.Report{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, v.binary, "--flavour", pdfa.Flavour(level), "--format", "mrr", in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	// veraPDF keluar dengan kode bukan nol untuk file tidak konform; laporan tetap di stdout.
+	m := veraCompliantRe.FindSubmatch(stdout.Bytes())
+	if m == nil {
+		if runErr == nil {
+			runErr = errors.New("report has no compliance result")
+		}
+		return pdfa.Report{}, fmt.Errorf("verapdf: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+	}
+	r := pdfa.Report{Level: level, Compliant: string(m[1]) == "true", Validator: "verapdf", Verified: true, CheckedAt: time.Now().UTC()}
+	for _, f := range veraFailedRe.FindAllSubmatch(stdout.Bytes(), -1) {
+		r.Issues = append(r.Issues, html.UnescapeString(strings.TrimSpace(string(f[1]))))
+	}
+	return r, nil
+}
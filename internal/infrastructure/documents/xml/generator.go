@@ -0,0 +1,78 @@
+package xml
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+	"text/template/parse"
+
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/templating"
+)
+
+// Generator merender XML (mis. e-invoice UBL / CII) dengan text/template. Setiap aksi {{...}}
+// yang mencetak nilai di-escape otomatis (seperti html/template); output yang tidak well-formed ditolak.
+type Generator struct{}
+
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+func (g *Generator) Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error) {
+	tpl, err := src.Text("xml", template.FuncMap{"xml": einvoice.Escape})
+	if err != nil {
+		return nil, "", err
+	}
+	for _, t := range tpl.Templates() {
+		if t.Tree != nil {
+			escapeList(t.Tree, t.Tree.Root)
+		}
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, "", err
+	}
+	out := bytes.TrimSpace(buf.Bytes())
+	if err := einvoice.WellFormed(out); err != nil {
+		return nil, "", err
+	}
+	return out, "application/xml", nil
+}
+
+// escapeList menambahkan "| xml" di akhir setiap aksi yang mencetak, termasuk di dalam if/range/with.
+// Aksi yang sudah diakhiri xml (template lama) dan assignment variabel dibiarkan.
+func escapeList(tree *parse.Tree, list *parse.ListNode) {
+	if list == nil {
+		return
+	}
+	for _, n := range list.Nodes {
+		switch n := n.(type) {
+		case *parse.ActionNode:
+			escapeAction(tree, n)
+		case *parse.IfNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		case *parse.RangeNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		case *parse.WithNode:
+			escapeList(tree, n.List)
+			escapeList(tree, n.ElseList)
+		}
+	}
+}
+
+func escapeAction(tree *parse.Tree, n *parse.ActionNode) {
+	if len(n.Pipe.Decl) > 0 || len(n.Pipe.Cmds) == 0 {
+		return
+	}
+	last := n.Pipe.Cmds[len(n.Pipe.Cmds)-1]
+	if id, ok := last.Args[0].(*parse.IdentifierNode); ok && id.Ident == "xml" {
+		return
+	}
+	n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+		NodeType: parse.NodeCommand,
+		Pos:      n.Pos,
+		Args:     []parse.Node{parse.NewIdentifier("xml").SetTree(tree).SetPos(n.Pos)},
+	})
+}
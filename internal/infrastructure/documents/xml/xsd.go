@@ -0,0 +1,64 @@
+package xml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// XSDValidator memvalidasi XML terhadap XSD per syntax (UBL, CII) dengan xmllint (libxml2).
+type XSDValidator struct {
+	binary  string
+	schemas map[string]string // syntax -> path XSD
+}
+
+func NewXSDValidator(schemas map[string]string) *XSDValidator {
+	return &XSDValidator{binary: "xmllint", schemas: schemas}
+}
+
+// maxErrors jumlah baris error xmllint yang dikembalikan.
+const maxErrors = 10
+
+func (v *XSDValidator) ValidateXML(ctx context.Context, data []byte, syntax string) error {
+	xsd := v.schemas[syntax]
+	if xsd == "" {
+		return fmt.Errorf("no XSD configured for %s", syntax)
+	}
+	dir, err := os.MkdirTemp("", "xsd-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "invoice.xml")
+	if err := os.WriteFile(in, data, 0o600); err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, v.binary, "--noout", "--nonet", "--schema", xsd, in)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err == nil {
+		return nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("xmllint: %w", err)
+	}
+	// Baris terakhir xmllint hanya "<file> fails to validate"; path file sementara dibuang.
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		if l = strings.TrimSpace(strings.TrimPrefix(l, in+":")); l != "" && !strings.HasSuffix(l, "fails to validate") {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) > maxErrors {
+		lines = append(lines[:maxErrors], fmt.Sprintf("and %d more", len(lines)-maxErrors))
+	}
+	return fmt.Errorf("%s schema validation failed: %s", syntax, strings.Join(lines, "; "))
+}
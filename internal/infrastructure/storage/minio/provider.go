@@ -1,7 +1,6 @@
 package minio
 
 import (
-	"archive/zip"
 	"bytes"
 	"context"
 	"fmt"
@@ -75,25 +74,6 @@ func (p *provider) ProviderName() enums.StorageProvider {
 	return enums.StorageProviderMinio
 }
 
-// Zip membuat arsip ZIP dalam memori dari entries, lalu menyimpan ke MinIO.
-func (p *provider) Zip(ctx context.Context, documentID int64, requestID string, entries []sharedStorage.ZipEntry) (string, string, error) {
-	var buf bytes.Buffer
-	w := zip.NewWriter(&buf)
-	for _, e := range entries {
-		f, err := w.Create(e.Name)
-		if err != nil {
-			return "", "", fmt.Errorf("zip: create entry %s: %w", e.Name, err)
-		}
-		if _, err := f.Write(e.Data); err != nil {
-			return "", "", fmt.Errorf("zip: write entry %s: %w", e.Name, err)
-		}
-	}
-	if err := w.Close(); err != nil {
-		return "", "", fmt.Errorf("zip: close writer: %w", err)
-	}
-	return p.Save(ctx, documentID, requestID, "zip", buf.Bytes())
-}
-
 // Compose menggunakan ComposeObject MinIO (server-side, tanpa download setiap file).
 // Cocok untuk semua format. Untuk GCS gunakan storage.Compose API yang setara.
 func (p *provider) Compose(ctx context.Context, documentID int64, requestID string, srcPaths []string, ext string) (string, string, error) {
@@ -116,6 +96,33 @@ func (p *provider) Compose(ctx context.Context, documentID int64, requestID stri
 	return objectName, fileName, nil
 }
 
+func (p *provider) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	_, err := p.client.PutObject(ctx, p.bucket, key, bytes.NewReader(data), int64(len(data)), miniogo.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("minio: put object: %w", err)
+	}
+	return key, nil
+}
+
+// PutFile mengunggah file lokal; minio-go memakai multipart upload untuk file besar.
+func (p *provider) PutFile(ctx context.Context, key, contentType, srcPath string) (string, error) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	_, err := p.client.FPutObject(ctx, p.bucket, key, srcPath, miniogo.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("minio: put file: %w", err)
+	}
+	return key, nil
+}
+
 func sanitize(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
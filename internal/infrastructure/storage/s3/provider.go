@@ -44,10 +44,14 @@ func (p *provider) ProviderName() enums.StorageProvider {
 	return enums.StorageProviderS3
 }
 
-func (p *provider) Zip(ctx context.Context, documentID int64, requestID string, entries []sharedStorage.ZipEntry) (string, string, error) {
-	return p.inner.Zip(ctx, documentID, requestID, entries)
-}
-
 func (p *provider) Compose(ctx context.Context, documentID int64, requestID string, srcPaths []string, ext string) (string, string, error) {
 	return p.inner.Compose(ctx, documentID, requestID, srcPaths, ext)
 }
+
+func (p *provider) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	return p.inner.Put(ctx, key, contentType, data)
+}
+
+func (p *provider) PutFile(ctx context.Context, key, contentType, srcPath string) (string, error) {
+	return p.inner.PutFile(ctx, key, contentType, srcPath)
+}
@@ -0,0 +1,37 @@
+package documentarchives
+
+import (
+	"context"
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+type ListFilter struct {
+	TenantID *string
+	Kind     enums.ArchiveJobKind
+	Status   enums.ArchiveJobStatus
+	Page     pagination.Params
+}
+
+type DocumentArchivesRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, j archiveEntity.Job) (archiveEntity.Job, error)
+	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (archiveEntity.Job, error)
+	List(ctx context.Context, tx *gorm.DB, f ListFilter) ([]archiveEntity.Job, int64, error)
+
+	// ClaimNext mengunci job QUEUED tertua, atau job PROCESSING dengan heartbeat sebelum staleBefore
+	// (worker mati), dengan FOR UPDATE SKIP LOCKED; ErrNotFound bila tidak ada. tx wajib diisi.
+	ClaimNext(ctx context.Context, tx *gorm.DB, staleBefore time.Time) (archiveEntity.Job, error)
+	// Start menandai job PROCESSING, menambah attempts dan mengisi heartbeat.
+	Start(ctx context.Context, tx *gorm.DB, id int64, now time.Time) error
+	// SetDocuments menyimpan id dokumen hasil resolve filter beserta total.
+	SetDocuments(ctx context.Context, tx *gorm.DB, id int64, ids []int64) error
+	// Heartbeat menyimpan progress dan memperbarui heartbeat_at.
+	Heartbeat(ctx context.Context, tx *gorm.DB, id int64, processed int, now time.Time) error
+	// Finish menyimpan status final, output / error, processed, options dan completed_at.
+	Finish(ctx context.Context, tx *gorm.DB, j archiveEntity.Job) error
+}
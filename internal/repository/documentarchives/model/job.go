@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/archive"
+)
+
+type DocumentArchiveJob struct {
+	ID           int64                  `gorm:"primaryKey;column:id"`
+	TenantID     *string                `gorm:"column:tenant_id;type:uuid"`
+	Kind         enums.ArchiveJobKind   `gorm:"column:kind;type:archive_job_kind"`
+	Status       enums.ArchiveJobStatus `gorm:"column:status;type:archive_job_status"`
+	Label        *string                `gorm:"column:label"`
+	DocumentIDs  []int64                `gorm:"column:document_ids;serializer:json;type:jsonb"`
+	Filter       *archiveEntity.Filter  `gorm:"column:filter;serializer:json;type:jsonb"`
+	Options      archive.Options        `gorm:"column:options;serializer:json;type:jsonb"`
+	Total        int                    `gorm:"column:total"`
+	Processed    int                    `gorm:"column:processed"`
+	Attempts     int                    `gorm:"column:attempts"`
+	OutputPath   *string                `gorm:"column:output_path"`
+	OutputFormat *string                `gorm:"column:output_format"`
+	ErrorMessage *string                `gorm:"column:error_message"`
+	CreatedBy    *string                `gorm:"column:created_by"`
+	StartedAt    *time.Time             `gorm:"column:started_at"`
+	HeartbeatAt  *time.Time             `gorm:"column:heartbeat_at"`
+	CompletedAt  *time.Time             `gorm:"column:completed_at"`
+	CreatedAt    time.Time              `gorm:"column:created_at"`
+	UpdatedAt    time.Time              `gorm:"column:updated_at"`
+}
+
+func (DocumentArchiveJob) TableName() string { return "document_archive_jobs" }
+
+func ToEntity(m *DocumentArchiveJob) archiveEntity.Job {
+	if m == nil {
+		return archiveEntity.Job{}
+	}
+	return archiveEntity.Job{
+		ID:           m.ID,
+		TenantID:     m.TenantID,
+		Kind:         m.Kind,
+		Status:       m.Status,
+		Label:        m.Label,
+		DocumentIDs:  m.DocumentIDs,
+		Filter:       m.Filter,
+		Options:      m.Options,
+		Total:        m.Total,
+		Processed:    m.Processed,
+		Attempts:     m.Attempts,
+		OutputPath:   m.OutputPath,
+		OutputFormat: m.OutputFormat,
+		ErrorMessage: m.ErrorMessage,
+		CreatedBy:    m.CreatedBy,
+		StartedAt:    m.StartedAt,
+		HeartbeatAt:  m.HeartbeatAt,
+		CompletedAt:  m.CompletedAt,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func ToModel(e archiveEntity.Job) DocumentArchiveJob {
+	return DocumentArchiveJob{
+		ID:           e.ID,
+		TenantID:     e.TenantID,
+		Kind:         e.Kind,
+		Status:       e.Status,
+		Label:        e.Label,
+		DocumentIDs:  e.DocumentIDs,
+		Filter:       e.Filter,
+		Options:      e.Options,
+		Total:        e.Total,
+		Processed:    e.Processed,
+		Attempts:     e.Attempts,
+		OutputPath:   e.OutputPath,
+		OutputFormat: e.OutputFormat,
+		ErrorMessage: e.ErrorMessage,
+		CreatedBy:    e.CreatedBy,
+		StartedAt:    e.StartedAt,
+		HeartbeatAt:  e.HeartbeatAt,
+		CompletedAt:  e.CompletedAt,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
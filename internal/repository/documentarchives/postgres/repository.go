@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	repo "go-document-generator/internal/repository/documentarchives"
+	"go-document-generator/internal/repository/documentarchives/model"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentArchivesRepository(db *gorm.DB) repo.DocumentArchivesRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, j archiveEntity.Job) (archiveEntity.Job, error) {
+	m := model.ToModel(j)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return archiveEntity.Job{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (archiveEntity.Job, error) {
+	var m model.DocumentArchiveJob
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return archiveEntity.Job{}, apperror.ErrNotFound
+		}
+		return archiveEntity.Job{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) List(ctx context.Context, tx *gorm.DB, f repo.ListFilter) ([]archiveEntity.Job, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentArchiveJob{})
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.Kind != "" {
+		q = q.Where("kind = ?", f.Kind)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// document_ids bisa berisi ribuan id; list tidak memuatnya.
+	var rows []model.DocumentArchiveJob
+	if err := q.Omit("document_ids").Order("created_at DESC, id DESC").
+		Offset(pagination.Offset(f.Page.Page, f.Page.Limit)).Limit(f.Page.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]archiveEntity.Job, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *repository) ClaimNext(ctx context.Context, tx *gorm.DB, staleBefore time.Time) (archiveEntity.Job, error) {
+	if tx == nil {
+		return archiveEntity.Job{}, errors.New("documentarchives: ClaimNext requires a transaction")
+	}
+	var m model.DocumentArchiveJob
+	err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? OR (status = ? AND heartbeat_at < ?)",
+			enums.ArchiveJobStatusQueued, enums.ArchiveJobStatusProcessing, staleBefore).
+		Order("created_at ASC, id ASC").
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return archiveEntity.Job{}, apperror.ErrNotFound
+		}
+		return archiveEntity.Job{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) Start(ctx context.Context, tx *gorm.DB, id int64, now time.Time) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentArchiveJob{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       enums.ArchiveJobStatusProcessing,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"processed":    0,
+			"started_at":   gorm.Expr("COALESCE(started_at, ?)", now),
+			"heartbeat_at": now,
+			"updated_at":   now,
+		}).Error
+}
+
+func (r *repository) SetDocuments(ctx context.Context, tx *gorm.DB, id int64, ids []int64) error {
+	m := model.DocumentArchiveJob{DocumentIDs: ids, Total: len(ids), UpdatedAt: time.Now().UTC()}
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentArchiveJob{}).Where("id = ?", id).
+		Select("document_ids", "total", "updated_at").Updates(&m).Error
+}
+
+func (r *repository) Heartbeat(ctx context.Context, tx *gorm.DB, id int64, processed int, now time.Time) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentArchiveJob{}).Where("id = ?", id).
+		Updates(map[string]any{"processed": processed, "heartbeat_at": now, "updated_at": now}).Error
+}
+
+func (r *repository) Finish(ctx context.Context, tx *gorm.DB, j archiveEntity.Job) error {
+	m := model.ToModel(j)
+	m.UpdatedAt = time.Now().UTC()
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentArchiveJob{}).Where("id = ?", j.ID).
+		Select("status", "processed", "output_path", "output_format", "error_message", "options", "completed_at", "updated_at").
+		Updates(&m).Error
+}
@@ -0,0 +1,62 @@
+package documentbatches
+
+import (
+	"context"
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+type ListFilter struct {
+	TenantID *string
+	Status   enums.BatchStatus
+	Page     pagination.Params
+}
+
+type ItemFilter struct {
+	Status enums.BatchItemStatus
+	Page   pagination.Params
+}
+
+type DocumentBatchesRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, b batchEntity.Batch) (batchEntity.Batch, error)
+	// CreateItems menyimpan item batch dengan insert bertahap.
+	CreateItems(ctx context.Context, tx *gorm.DB, items []batchEntity.Item) error
+	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (batchEntity.Batch, error)
+	List(ctx context.Context, tx *gorm.DB, f ListFilter) ([]batchEntity.Batch, int64, error)
+	// Progress menghitung agregat status item per batch.
+	Progress(ctx context.Context, tx *gorm.DB, batchIDs []int64) (map[int64]batchEntity.Progress, error)
+	ListItems(ctx context.Context, tx *gorm.DB, batchID int64, f ItemFilter) ([]batchEntity.Item, int64, error)
+	// SetStatus mengubah status batch hanya bila status saat ini from; ErrNotFound bila tidak ada baris berubah.
+	SetStatus(ctx context.Context, tx *gorm.DB, id int64, tenantID *string, from, to enums.BatchStatus) error
+
+	// ClaimPendingItems mengunci item PENDING dari batch RUNNING yang tidak sedang di-lease
+	// (FOR UPDATE SKIP LOCKED); tx wajib diisi.
+	ClaimPendingItems(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Item, error)
+	// LeaseItems menahan item sampai until (claimed_until) selama dokumennya dibuat di luar transaksi.
+	LeaseItems(ctx context.Context, tx *gorm.DB, ids []int64, until time.Time) error
+	// UpdateItem menyimpan status, document_id dan error_message item yang masih PENDING lalu
+	// melepas lease.
+	UpdateItem(ctx context.Context, tx *gorm.DB, it batchEntity.Item) error
+	// CancelPendingItems menandai item PENDING batch yang tidak sedang di-lease sebagai CANCELLED.
+	CancelPendingItems(ctx context.Context, tx *gorm.DB, batchID int64, now time.Time) (int64, error)
+	// DocumentIDsByStatus id dokumen item SUBMITTED dengan status dokumen tertentu, urut seq.
+	DocumentIDsByStatus(ctx context.Context, tx *gorm.DB, batchID int64, status enums.DocumentStatus, limit int) ([]int64, error)
+
+	// ClaimOpen mengunci batch RUNNING / CANCELLING yang tidak sedang di-lease (FOR UPDATE SKIP LOCKED);
+	// tx wajib diisi.
+	ClaimOpen(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Batch, error)
+	// Lease menahan batch sampai until (claimed_until) selama pembatalan / finalisasi di luar transaksi;
+	// nil melepas lease.
+	Lease(ctx context.Context, tx *gorm.DB, id int64, until *time.Time) error
+	// Complete menyimpan status final, output dan completed_at lalu melepas lease; batch yang sudah
+	// final tidak diubah.
+	Complete(ctx context.Context, tx *gorm.DB, b batchEntity.Batch) error
+	// ClaimCallbacks mengunci batch final dengan callback PENDING / RETRYING yang jatuh tempo; tx wajib diisi.
+	ClaimCallbacks(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Batch, error)
+	UpdateCallback(ctx context.Context, tx *gorm.DB, id int64, status enums.CallbackStatus, attempts int, next *time.Time) error
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentBatch struct {
+	ID               int64                 `gorm:"primaryKey;column:id"`
+	TenantID         *string               `gorm:"column:tenant_id;type:uuid"`
+	Name             *string               `gorm:"column:name"`
+	Status           enums.BatchStatus     `gorm:"column:status;type:batch_status"`
+	TotalItems       int                   `gorm:"column:total_items"`
+	PostAction       enums.BatchPostAction `gorm:"column:post_action;type:batch_post_action"`
+	OutputPath       *string               `gorm:"column:output_path"`
+	OutputError      *string               `gorm:"column:output_error"`
+	Metadata         map[string]any        `gorm:"column:metadata;serializer:json;type:jsonb"`
+	HasCallback      bool                  `gorm:"column:has_callback"`
+	CallbackURL      *string               `gorm:"column:callback_url"`
+	CallbackStatus   enums.CallbackStatus  `gorm:"column:callback_status;type:callback_status"`
+	CallbackAttempts int                   `gorm:"column:callback_attempts"`
+	NextCallbackAt   *time.Time            `gorm:"column:next_callback_at"`
+	ClaimedUntil     *time.Time            `gorm:"column:claimed_until"`
+	CreatedBy        *string               `gorm:"column:created_by"`
+	CompletedAt      *time.Time            `gorm:"column:completed_at"`
+	CreatedAt        time.Time             `gorm:"column:created_at"`
+	UpdatedAt        time.Time             `gorm:"column:updated_at"`
+}
+
+func (DocumentBatch) TableName() string { return "document_batches" }
+
+type DocumentBatchItem struct {
+	ID           int64                 `gorm:"primaryKey;column:id"`
+	BatchID      int64                 `gorm:"column:batch_id"`
+	Seq          int                   `gorm:"column:seq"`
+	RequestID    string                `gorm:"column:request_id"`
+	Input        batchEntity.ItemInput `gorm:"column:input;serializer:json;type:jsonb"`
+	Status       enums.BatchItemStatus `gorm:"column:status;type:batch_item_status"`
+	DocumentID   *int64                `gorm:"column:document_id"`
+	ErrorMessage *string               `gorm:"column:error_message"`
+	ClaimedUntil *time.Time            `gorm:"column:claimed_until"`
+	CreatedAt    time.Time             `gorm:"column:created_at"`
+	UpdatedAt    time.Time             `gorm:"column:updated_at"`
+
+	// DocumentStatus hasil join documents; hanya dibaca.
+	DocumentStatus *enums.DocumentStatus `gorm:"column:document_status;->"`
+}
+
+func (DocumentBatchItem) TableName() string { return "document_batch_items" }
+
+func ToEntity(m *DocumentBatch) batchEntity.Batch {
+	if m == nil {
+		return batchEntity.Batch{}
+	}
+	return batchEntity.Batch{
+		ID:               m.ID,
+		TenantID:         m.TenantID,
+		Name:             m.Name,
+		Status:           m.Status,
+		TotalItems:       m.TotalItems,
+		PostAction:       m.PostAction,
+		OutputPath:       m.OutputPath,
+		OutputError:      m.OutputError,
+		Metadata:         m.Metadata,
+		HasCallback:      m.HasCallback,
+		CallbackURL:      m.CallbackURL,
+		CallbackStatus:   m.CallbackStatus,
+		CallbackAttempts: m.CallbackAttempts,
+		NextCallbackAt:   m.NextCallbackAt,
+		CreatedBy:        m.CreatedBy,
+		CompletedAt:      m.CompletedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func ToModel(e batchEntity.Batch) DocumentBatch {
+	return DocumentBatch{
+		ID:               e.ID,
+		TenantID:         e.TenantID,
+		Name:             e.Name,
+		Status:           e.Status,
+		TotalItems:       e.TotalItems,
+		PostAction:       e.PostAction,
+		OutputPath:       e.OutputPath,
+		OutputError:      e.OutputError,
+		Metadata:         e.Metadata,
+		HasCallback:      e.HasCallback,
+		CallbackURL:      e.CallbackURL,
+		CallbackStatus:   e.CallbackStatus,
+		CallbackAttempts: e.CallbackAttempts,
+		NextCallbackAt:   e.NextCallbackAt,
+		CreatedBy:        e.CreatedBy,
+		CompletedAt:      e.CompletedAt,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+func ItemToEntity(m *DocumentBatchItem) batchEntity.Item {
+	if m == nil {
+		return batchEntity.Item{}
+	}
+	e := batchEntity.Item{
+		ID:           m.ID,
+		BatchID:      m.BatchID,
+		Seq:          m.Seq,
+		RequestID:    m.RequestID,
+		Input:        m.Input,
+		Status:       m.Status,
+		DocumentID:   m.DocumentID,
+		ErrorMessage: m.ErrorMessage,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+	if m.DocumentStatus != nil {
+		e.DocumentStatus = *m.DocumentStatus
+	}
+	return e
+}
+
+func ItemToModel(e batchEntity.Item) DocumentBatchItem {
+	return DocumentBatchItem{
+		ID:           e.ID,
+		BatchID:      e.BatchID,
+		Seq:          e.Seq,
+		RequestID:    e.RequestID,
+		Input:        e.Input,
+		Status:       e.Status,
+		DocumentID:   e.DocumentID,
+		ErrorMessage: e.ErrorMessage,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
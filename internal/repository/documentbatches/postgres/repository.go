@@ -0,0 +1,333 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+	repo "go-document-generator/internal/repository/documentbatches"
+	"go-document-generator/internal/repository/documentbatches/model"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// itemInsertChunk jumlah item per INSERT saat membuat batch besar.
+const itemInsertChunk = 1000
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentBatchesRepository(db *gorm.DB) repo.DocumentBatchesRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, b batchEntity.Batch) (batchEntity.Batch, error) {
+	m := model.ToModel(b)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return batchEntity.Batch{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) CreateItems(ctx context.Context, tx *gorm.DB, items []batchEntity.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	rows := make([]model.DocumentBatchItem, len(items))
+	for i := range items {
+		rows[i] = model.ItemToModel(items[i])
+		rows[i].CreatedAt, rows[i].UpdatedAt = now, now
+	}
+	return r.conn(tx).WithContext(ctx).CreateInBatches(&rows, itemInsertChunk).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (batchEntity.Batch, error) {
+	var m model.DocumentBatch
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return batchEntity.Batch{}, apperror.ErrNotFound
+		}
+		return batchEntity.Batch{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) List(ctx context.Context, tx *gorm.DB, f repo.ListFilter) ([]batchEntity.Batch, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentBatch{})
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []model.DocumentBatch
+	if err := q.Order("created_at DESC, id DESC").Offset(pagination.Offset(f.Page.Page, f.Page.Limit)).Limit(f.Page.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]batchEntity.Batch, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *repository) Progress(ctx context.Context, tx *gorm.DB, batchIDs []int64) (map[int64]batchEntity.Progress, error) {
+	out := make(map[int64]batchEntity.Progress, len(batchIDs))
+	if len(batchIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		BatchID        int64
+		ItemStatus     enums.BatchItemStatus
+		DocumentStatus *enums.DocumentStatus
+		N              int64
+	}
+	err := r.conn(tx).WithContext(ctx).Table("document_batch_items i").
+		Select("i.batch_id, i.status AS item_status, d.status AS document_status, COUNT(*) AS n").
+		Joins("LEFT JOIN documents d ON d.id = i.document_id").
+		Where("i.batch_id IN ?", batchIDs).
+		Group("i.batch_id, i.status, d.status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		p := out[row.BatchID]
+		p.Total += row.N
+		switch row.ItemStatus {
+		case enums.BatchItemStatusPending:
+			p.Pending += row.N
+		case enums.BatchItemStatusRejected:
+			p.Failed += row.N
+		case enums.BatchItemStatusCancelled:
+			p.Cancelled += row.N
+		default:
+			// SUBMITTED: ikut status dokumen; dokumen yang sudah dihapus dihitung gagal.
+			switch {
+			case row.DocumentStatus == nil:
+				p.Failed += row.N
+			case *row.DocumentStatus == enums.DocumentStatusGenerated:
+				p.Generated += row.N
+			case *row.DocumentStatus == enums.DocumentStatusFailed:
+				p.Failed += row.N
+			case *row.DocumentStatus == enums.DocumentStatusCancelled:
+				p.Cancelled += row.N
+			case *row.DocumentStatus == enums.DocumentStatusProcessing:
+				p.Processing += row.N
+			default:
+				p.Queued += row.N
+			}
+		}
+		out[row.BatchID] = p
+	}
+	return out, nil
+}
+
+func (r *repository) ListItems(ctx context.Context, tx *gorm.DB, batchID int64, f repo.ItemFilter) ([]batchEntity.Item, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentBatchItem{}).Where("document_batch_items.batch_id = ?", batchID)
+	if f.Status != "" {
+		q = q.Where("document_batch_items.status = ?", f.Status)
+	}
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.DocumentBatchItem
+	if err := q.Select("document_batch_items.*, d.status AS document_status").
+		Joins("LEFT JOIN documents d ON d.id = document_batch_items.document_id").
+		Order("document_batch_items.seq ASC").
+		Offset(pagination.Offset(f.Page.Page, f.Page.Limit)).Limit(f.Page.Limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]batchEntity.Item, len(rows))
+	for i := range rows {
+		out[i] = model.ItemToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *repository) SetStatus(ctx context.Context, tx *gorm.DB, id int64, tenantID *string, from, to enums.BatchStatus) error {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentBatch{}).Where("id = ? AND status = ?", id, from)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Updates(map[string]any{"status": to, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) ClaimPendingItems(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Item, error) {
+	if tx == nil {
+		return nil, errors.New("documentbatches: ClaimPendingItems requires a transaction")
+	}
+	var rows []model.DocumentBatchItem
+	q := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ?", enums.BatchItemStatusPending).
+		Where("claimed_until IS NULL OR claimed_until <= ?", now).
+		Where("batch_id IN (?)", tx.Model(&model.DocumentBatch{}).Select("id").Where("status = ?", enums.BatchStatusRunning)).
+		Order("batch_id ASC, seq ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]batchEntity.Item, len(rows))
+	for i := range rows {
+		out[i] = model.ItemToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *repository) LeaseItems(ctx context.Context, tx *gorm.DB, ids []int64, until time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentBatchItem{}).Where("id IN ?", ids).
+		Update("claimed_until", until).Error
+}
+
+func (r *repository) UpdateItem(ctx context.Context, tx *gorm.DB, it batchEntity.Item) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentBatchItem{}).
+		Where("id = ? AND status = ?", it.ID, enums.BatchItemStatusPending).
+		Updates(map[string]any{
+			"status":        it.Status,
+			"document_id":   it.DocumentID,
+			"error_message": it.ErrorMessage,
+			"claimed_until": nil,
+			"updated_at":    time.Now().UTC(),
+		}).Error
+}
+
+func (r *repository) CancelPendingItems(ctx context.Context, tx *gorm.DB, batchID int64, now time.Time) (int64, error) {
+	res := r.conn(tx).WithContext(ctx).Model(&model.DocumentBatchItem{}).
+		Where("batch_id = ? AND status = ?", batchID, enums.BatchItemStatusPending).
+		Where("claimed_until IS NULL OR claimed_until <= ?", now).
+		Updates(map[string]any{"status": enums.BatchItemStatusCancelled, "updated_at": time.Now().UTC()})
+	return res.RowsAffected, res.Error
+}
+
+func (r *repository) DocumentIDsByStatus(ctx context.Context, tx *gorm.DB, batchID int64, status enums.DocumentStatus, limit int) ([]int64, error) {
+	var ids []int64
+	q := r.conn(tx).WithContext(ctx).Table("document_batch_items i").
+		Select("i.document_id").
+		Joins("JOIN documents d ON d.id = i.document_id").
+		Where("i.batch_id = ? AND i.status = ? AND d.status = ?", batchID, enums.BatchItemStatusSubmitted, status).
+		Order("i.seq ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Pluck("i.document_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *repository) ClaimOpen(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Batch, error) {
+	if tx == nil {
+		return nil, errors.New("documentbatches: ClaimOpen requires a transaction")
+	}
+	var rows []model.DocumentBatch
+	q := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status IN ?", []enums.BatchStatus{enums.BatchStatusRunning, enums.BatchStatusCancelling}).
+		Where("claimed_until IS NULL OR claimed_until <= ?", now).
+		Order("id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]batchEntity.Batch, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *repository) Lease(ctx context.Context, tx *gorm.DB, id int64, until *time.Time) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentBatch{}).Where("id = ?", id).
+		Update("claimed_until", until).Error
+}
+
+func (r *repository) Complete(ctx context.Context, tx *gorm.DB, b batchEntity.Batch) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentBatch{}).
+		Where("id = ? AND status IN ?", b.ID, []enums.BatchStatus{enums.BatchStatusRunning, enums.BatchStatusCancelling}).
+		Updates(map[string]any{
+			"status":        b.Status,
+			"output_path":   b.OutputPath,
+			"output_error":  b.OutputError,
+			"completed_at":  b.CompletedAt,
+			"claimed_until": nil,
+			"updated_at":    time.Now().UTC(),
+		}).Error
+}
+
+func (r *repository) ClaimCallbacks(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]batchEntity.Batch, error) {
+	if tx == nil {
+		return nil, errors.New("documentbatches: ClaimCallbacks requires a transaction")
+	}
+	var rows []model.DocumentBatch
+	q := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status IN ?", []enums.BatchStatus{enums.BatchStatusCompleted, enums.BatchStatusCancelled}).
+		Where("has_callback = TRUE AND callback_url IS NOT NULL").
+		Where("callback_status IN ?", []enums.CallbackStatus{enums.CallbackStatusPending, enums.CallbackStatusRetrying}).
+		Where("next_callback_at IS NULL OR next_callback_at <= ?", now).
+		Order("id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]batchEntity.Batch, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *repository) UpdateCallback(ctx context.Context, tx *gorm.DB, id int64, status enums.CallbackStatus, attempts int, next *time.Time) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentBatch{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"callback_status":   status,
+			"callback_attempts": attempts,
+			"next_callback_at":  next,
+			"updated_at":        time.Now().UTC(),
+		}).Error
+}
@@ -0,0 +1,24 @@
+package documentimports
+
+import (
+	"context"
+
+	importEntity "go-document-generator/internal/entity/documentimports"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+type ListFilter struct {
+	TenantID     *string
+	TemplateCode string
+	Page         pagination.Params
+}
+
+type DocumentImportsRepository interface {
+	CreateMapping(ctx context.Context, tx *gorm.DB, m importEntity.Mapping) (importEntity.Mapping, error)
+	GetMapping(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (importEntity.Mapping, error)
+	ListMappings(ctx context.Context, tx *gorm.DB, f ListFilter) ([]importEntity.Mapping, int64, error)
+	UpdateMapping(ctx context.Context, tx *gorm.DB, m importEntity.Mapping) (importEntity.Mapping, error)
+	DeleteMapping(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	importEntity "go-document-generator/internal/entity/documentimports"
+	"go-document-generator/internal/shared/mailmerge"
+)
+
+type DocumentImportMapping struct {
+	ID              int64             `gorm:"primaryKey;column:id"`
+	TenantID        *string           `gorm:"column:tenant_id;type:uuid"`
+	Name            string            `gorm:"column:name"`
+	Description     *string           `gorm:"column:description"`
+	TemplateCode    *string           `gorm:"column:template_code"`
+	Fields          []mailmerge.Field `gorm:"column:fields;serializer:json;type:jsonb"`
+	GroupBy         *string           `gorm:"column:group_by"`
+	RequestIDColumn *string           `gorm:"column:request_id_column"`
+	RequestIDPrefix *string           `gorm:"column:request_id_prefix"`
+	CreatedBy       *string           `gorm:"column:created_by"`
+	CreatedAt       time.Time         `gorm:"column:created_at"`
+	UpdatedAt       time.Time         `gorm:"column:updated_at"`
+}
+
+func (DocumentImportMapping) TableName() string { return "document_import_mappings" }
+
+func ToEntity(m *DocumentImportMapping) importEntity.Mapping {
+	if m == nil {
+		return importEntity.Mapping{}
+	}
+	return importEntity.Mapping{
+		ID:              m.ID,
+		TenantID:        m.TenantID,
+		Name:            m.Name,
+		Description:     m.Description,
+		TemplateCode:    m.TemplateCode,
+		Fields:          m.Fields,
+		GroupBy:         m.GroupBy,
+		RequestIDColumn: m.RequestIDColumn,
+		RequestIDPrefix: m.RequestIDPrefix,
+		CreatedBy:       m.CreatedBy,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+func ToModel(e importEntity.Mapping) DocumentImportMapping {
+	return DocumentImportMapping{
+		ID:              e.ID,
+		TenantID:        e.TenantID,
+		Name:            e.Name,
+		Description:     e.Description,
+		TemplateCode:    e.TemplateCode,
+		Fields:          e.Fields,
+		GroupBy:         e.GroupBy,
+		RequestIDColumn: e.RequestIDColumn,
+		RequestIDPrefix: e.RequestIDPrefix,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+}
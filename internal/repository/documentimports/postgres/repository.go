@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	importEntity "go-document-generator/internal/entity/documentimports"
+	repo "go-document-generator/internal/repository/documentimports"
+	"go-document-generator/internal/repository/documentimports/model"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentImportsRepository(db *gorm.DB) repo.DocumentImportsRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) CreateMapping(ctx context.Context, tx *gorm.DB, e importEntity.Mapping) (importEntity.Mapping, error) {
+	m := model.ToModel(e)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return importEntity.Mapping{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) GetMapping(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (importEntity.Mapping, error) {
+	var m model.DocumentImportMapping
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return importEntity.Mapping{}, apperror.ErrNotFound
+		}
+		return importEntity.Mapping{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) ListMappings(ctx context.Context, tx *gorm.DB, f repo.ListFilter) ([]importEntity.Mapping, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentImportMapping{})
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.TemplateCode != "" {
+		q = q.Where("template_code = ?", f.TemplateCode)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []model.DocumentImportMapping
+	if err := q.Order("name ASC, id ASC").Offset(pagination.Offset(f.Page.Page, f.Page.Limit)).Limit(f.Page.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]importEntity.Mapping, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *repository) UpdateMapping(ctx context.Context, tx *gorm.DB, e importEntity.Mapping) (importEntity.Mapping, error) {
+	m := model.ToModel(e)
+	m.UpdatedAt = time.Now().UTC()
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentImportMapping{}).Where("id = ?", e.ID)
+	if e.TenantID != nil {
+		q = q.Where("tenant_id = ?", *e.TenantID)
+	}
+	res := q.Select(
+		"name", "description", "template_code", "fields", "group_by",
+		"request_id_column", "request_id_prefix", "updated_at",
+	).Updates(&m)
+	if res.Error != nil {
+		return importEntity.Mapping{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return importEntity.Mapping{}, apperror.ErrNotFound
+	}
+	return r.GetMapping(ctx, tx, e.ID, e.TenantID)
+}
+
+func (r *repository) DeleteMapping(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error {
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Delete(&model.DocumentImportMapping{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
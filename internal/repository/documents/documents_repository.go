@@ -15,6 +15,7 @@ type ListFilter struct {
 	TenantID     *string
 	RequestID    string
 	Status       enums.DocumentStatus
+	Priority     enums.DocumentPriority
 	TemplateCode string
 	DmsStatus    enums.DmsStatus
 	CallbackStatus enums.CallbackStatus
@@ -28,6 +29,8 @@ type DocumentsRepository interface {
 	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (docEntity.Document, error)
 	GetByRequestID(ctx context.Context, tx *gorm.DB, requestID string, tenantID *string) (docEntity.Document, error)
 	List(ctx context.Context, tx *gorm.DB, f ListFilter) ([]docEntity.Document, int64, error)
+	// ListIDs id dokumen yang cocok dengan filter (tanpa paging), urut created_at lama ke baru.
+	ListIDs(ctx context.Context, tx *gorm.DB, f ListFilter, limit int) ([]int64, error)
 	Update(ctx context.Context, tx *gorm.DB, d docEntity.Document) (docEntity.Document, error)
 	SoftDelete(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error
 }
@@ -5,6 +5,9 @@ import (
 
 	docEntity "go-document-generator/internal/entity/documents"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pdfa"
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type Document struct {
@@ -18,14 +21,23 @@ type Document struct {
 	Payload           map[string]any        `gorm:"column:payload;serializer:json;type:jsonb"`
 	Metadata          map[string]any        `gorm:"column:metadata;serializer:json;type:jsonb"`
 	Status            enums.DocumentStatus  `gorm:"column:status;type:document_status"`
+	Priority          enums.DocumentPriority `gorm:"column:priority;type:document_priority"`
 	ErrorMessage      *string               `gorm:"column:error_message"`
 	OutputFormat      enums.OutputFormat    `gorm:"column:output_format;type:output_format"`
+	Locale            *string               `gorm:"column:locale"`
 	FileName          *string               `gorm:"column:file_name"`
 	FilePath          *string               `gorm:"column:file_path"`
 	StorageProvider   *enums.StorageProvider `gorm:"column:storage_provider;type:storage_provider"`
 	FileSize          *int64                `gorm:"column:file_size"`
 	Checksum          *string               `gorm:"column:checksum"`
 	ContentType       *string               `gorm:"column:content_type"`
+	Watermark         *watermark.Spec       `gorm:"column:watermark;serializer:json;type:jsonb"`
+	Encryption        *pdfsecurity.Spec     `gorm:"column:encryption;serializer:json;type:jsonb"`
+	EncryptionSecret  *string               `gorm:"column:encryption_secret"`
+	IsEncrypted       bool                  `gorm:"column:is_encrypted"`
+	PDFAConformance   *string               `gorm:"column:pdfa_conformance"`
+	PDFAReport        *pdfa.Report          `gorm:"column:pdfa_report;serializer:json;type:jsonb"`
+	Attachments       []docEntity.Attachment `gorm:"column:attachments;serializer:json;type:jsonb"`
 	IsSigned          bool                  `gorm:"column:is_signed"`
 	SignatureProvider *string               `gorm:"column:signature_provider"`
 	SignedAt          *time.Time            `gorm:"column:signed_at"`
@@ -63,14 +75,23 @@ func ToEntity(m *Document) docEntity.Document {
 		Payload:           m.Payload,
 		Metadata:          m.Metadata,
 		Status:            m.Status,
+		Priority:          m.Priority,
 		ErrorMessage:      m.ErrorMessage,
 		OutputFormat:      m.OutputFormat,
+		Locale:            m.Locale,
 		FileName:          m.FileName,
 		FilePath:          m.FilePath,
 		StorageProvider:   m.StorageProvider,
 		FileSize:          m.FileSize,
 		Checksum:          m.Checksum,
 		ContentType:       m.ContentType,
+		Watermark:         m.Watermark,
+		Encryption:        m.Encryption,
+		EncryptionSecret:  m.EncryptionSecret,
+		IsEncrypted:       m.IsEncrypted,
+		PDFAConformance:   m.PDFAConformance,
+		PDFAReport:        m.PDFAReport,
+		Attachments:       m.Attachments,
 		IsSigned:          m.IsSigned,
 		SignatureProvider: m.SignatureProvider,
 		SignedAt:          m.SignedAt,
@@ -104,14 +125,23 @@ func ToModel(e docEntity.Document) Document {
 		Payload:           e.Payload,
 		Metadata:          e.Metadata,
 		Status:            e.Status,
+		Priority:          e.Priority,
 		ErrorMessage:      e.ErrorMessage,
 		OutputFormat:      e.OutputFormat,
+		Locale:            e.Locale,
 		FileName:          e.FileName,
 		FilePath:          e.FilePath,
 		StorageProvider:   e.StorageProvider,
 		FileSize:          e.FileSize,
 		Checksum:          e.Checksum,
 		ContentType:       e.ContentType,
+		Watermark:         e.Watermark,
+		Encryption:        e.Encryption,
+		EncryptionSecret:  e.EncryptionSecret,
+		IsEncrypted:       e.IsEncrypted,
+		PDFAConformance:   e.PDFAConformance,
+		PDFAReport:        e.PDFAReport,
+		Attachments:       e.Attachments,
 		IsSigned:          e.IsSigned,
 		SignatureProvider: e.SignatureProvider,
 		SignedAt:          e.SignedAt,
@@ -74,31 +74,7 @@ func (r *repository) GetByRequestID(ctx context.Context, tx *gorm.DB, requestID
 }
 
 func (r *repository) List(ctx context.Context, tx *gorm.DB, f repo.ListFilter) ([]docEntity.Document, int64, error) {
-	q := r.conn(tx).WithContext(ctx).Model(&model.Document{}).Where("deleted_at IS NULL")
-	if f.TenantID != nil {
-		q = q.Where("tenant_id = ?", *f.TenantID)
-	}
-	if f.RequestID != "" {
-		q = q.Where("request_id = ?", f.RequestID)
-	}
-	if f.Status != "" {
-		q = q.Where("status = ?", f.Status)
-	}
-	if f.TemplateCode != "" {
-		q = q.Where("template_code = ?", f.TemplateCode)
-	}
-	if f.DmsStatus != "" {
-		q = q.Where("dms_status = ?", f.DmsStatus)
-	}
-	if f.CallbackStatus != "" {
-		q = q.Where("callback_status = ?", f.CallbackStatus)
-	}
-	if f.CreatedFrom != nil {
-		q = q.Where("created_at >= ?", *f.CreatedFrom)
-	}
-	if f.CreatedTo != nil {
-		q = q.Where("created_at <= ?", *f.CreatedTo)
-	}
+	q := filterQuery(r.conn(tx).WithContext(ctx), f)
 
 	var total int64
 	if err := q.Count(&total).Error; err != nil {
@@ -126,6 +102,51 @@ func (r *repository) List(ctx context.Context, tx *gorm.DB, f repo.ListFilter) (
 	return out, total, nil
 }
 
+func (r *repository) ListIDs(ctx context.Context, tx *gorm.DB, f repo.ListFilter, limit int) ([]int64, error) {
+	q := filterQuery(r.conn(tx).WithContext(ctx), f).Order("created_at ASC, id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	var ids []int64
+	if err := q.Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// filterQuery menerapkan ListFilter (tanpa paging) pada dokumen yang belum dihapus.
+func filterQuery(db *gorm.DB, f repo.ListFilter) *gorm.DB {
+	q := db.Model(&model.Document{}).Where("deleted_at IS NULL")
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.RequestID != "" {
+		q = q.Where("request_id = ?", f.RequestID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.Priority != "" {
+		q = q.Where("priority = ?", f.Priority)
+	}
+	if f.TemplateCode != "" {
+		q = q.Where("template_code = ?", f.TemplateCode)
+	}
+	if f.DmsStatus != "" {
+		q = q.Where("dms_status = ?", f.DmsStatus)
+	}
+	if f.CallbackStatus != "" {
+		q = q.Where("callback_status = ?", f.CallbackStatus)
+	}
+	if f.CreatedFrom != nil {
+		q = q.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		q = q.Where("created_at <= ?", *f.CreatedTo)
+	}
+	return q
+}
+
 func (r *repository) Update(ctx context.Context, tx *gorm.DB, d docEntity.Document) (docEntity.Document, error) {
 	m := model.ToModel(d)
 	m.UpdatedAt = time.Now().UTC()
@@ -0,0 +1,40 @@
+package documentschedules
+
+import (
+	"context"
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+)
+
+type ListFilter struct {
+	TenantID     *string
+	Status       enums.ScheduleStatus
+	TemplateCode string
+	Page         pagination.Params
+}
+
+type DocumentSchedulesRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, s schedEntity.Schedule) (schedEntity.Schedule, error)
+	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (schedEntity.Schedule, error)
+	List(ctx context.Context, tx *gorm.DB, f ListFilter) ([]schedEntity.Schedule, int64, error)
+	// Update menyimpan definisi jadwal beserta status dan next_run_at.
+	Update(ctx context.Context, tx *gorm.DB, s schedEntity.Schedule) (schedEntity.Schedule, error)
+	Delete(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error
+	// ClaimDue mengunci jadwal ACTIVE dengan next_run_at <= now yang tidak sedang di-lease
+	// (FOR UPDATE SKIP LOCKED); tx wajib diisi dan lock lepas saat commit/rollback.
+	ClaimDue(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]schedEntity.Schedule, error)
+	// Lease menandai jadwal sedang dijalankan sampai until (claimed_until) agar lock baris bisa dilepas
+	// selama fetch payload / create dokumen; lewat dari until jadwal bisa diklaim ulang.
+	Lease(ctx context.Context, tx *gorm.DB, id int64, until time.Time) error
+	// Advance mencatat hasil jadwal jalan: next_run_at berikutnya (nil = tidak ada lagi), last_run_at, status,
+	// dan melepas lease. Jadwal yang sudah tidak ACTIVE (mis. di-pause selama jalan) tidak diubah.
+	Advance(ctx context.Context, tx *gorm.DB, id int64, next, last *time.Time, status enums.ScheduleStatus) error
+	// CreateRun mengabaikan run yang sudah tercatat untuk waktu jadwal yang sama.
+	CreateRun(ctx context.Context, tx *gorm.DB, r schedEntity.Run) error
+	ListRuns(ctx context.Context, tx *gorm.DB, scheduleID int64, page pagination.Params) ([]schedEntity.Run, int64, error)
+}
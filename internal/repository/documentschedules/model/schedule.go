@@ -0,0 +1,160 @@
+package model
+
+import (
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentSchedule struct {
+	ID              int64                       `gorm:"primaryKey;column:id"`
+	TenantID        *string                     `gorm:"column:tenant_id;type:uuid"`
+	Name            string                      `gorm:"column:name"`
+	Description     *string                     `gorm:"column:description"`
+	TemplateCode    string                      `gorm:"column:template_code"`
+	TemplateVersion *int                        `gorm:"column:template_version"`
+	OutputFormat    *enums.OutputFormat         `gorm:"column:output_format;type:output_format"`
+	Locale          *string                     `gorm:"column:locale"`
+	Priority        *enums.DocumentPriority     `gorm:"column:priority;type:document_priority"`
+	CronExpression  *string                     `gorm:"column:cron_expression"`
+	RunAt           *time.Time                  `gorm:"column:run_at"`
+	Timezone        string                      `gorm:"column:timezone"`
+	Payload         map[string]any              `gorm:"column:payload;serializer:json;type:jsonb"`
+	PayloadSource   *schedEntity.PayloadSource  `gorm:"column:payload_source;serializer:json;type:jsonb"`
+	Metadata        map[string]any              `gorm:"column:metadata;serializer:json;type:jsonb"`
+	StoreToDms      bool                        `gorm:"column:store_to_dms"`
+	HasCallback     bool                        `gorm:"column:has_callback"`
+	CallbackURL     *string                     `gorm:"column:callback_url"`
+	MisfirePolicy   enums.ScheduleMisfirePolicy `gorm:"column:misfire_policy;type:schedule_misfire_policy"`
+	Status          enums.ScheduleStatus        `gorm:"column:status;type:schedule_status"`
+	NextRunAt       *time.Time                  `gorm:"column:next_run_at"`
+	LastRunAt       *time.Time                  `gorm:"column:last_run_at"`
+	ClaimedUntil    *time.Time                  `gorm:"column:claimed_until"`
+	CreatedBy       *string                     `gorm:"column:created_by"`
+	CreatedAt       time.Time                   `gorm:"column:created_at"`
+	UpdatedAt       time.Time                   `gorm:"column:updated_at"`
+}
+
+func (DocumentSchedule) TableName() string { return "document_schedules" }
+
+type DocumentScheduleRun struct {
+	ID           int64                   `gorm:"primaryKey;column:id"`
+	TenantID     *string                 `gorm:"column:tenant_id;type:uuid"`
+	ScheduleID   int64                   `gorm:"column:schedule_id"`
+	ScheduledFor time.Time               `gorm:"column:scheduled_for"`
+	Status       enums.ScheduleRunStatus `gorm:"column:status;type:schedule_run_status"`
+	RequestID    *string                 `gorm:"column:request_id"`
+	DocumentID   *int64                  `gorm:"column:document_id"`
+	ErrorMessage *string                 `gorm:"column:error_message"`
+	CreatedAt    time.Time               `gorm:"column:created_at"`
+}
+
+func (DocumentScheduleRun) TableName() string { return "document_schedule_runs" }
+
+func ToEntity(m *DocumentSchedule) schedEntity.Schedule {
+	if m == nil {
+		return schedEntity.Schedule{}
+	}
+	e := schedEntity.Schedule{
+		ID:              m.ID,
+		TenantID:        m.TenantID,
+		Name:            m.Name,
+		Description:     m.Description,
+		TemplateCode:    m.TemplateCode,
+		TemplateVersion: m.TemplateVersion,
+		Locale:          m.Locale,
+		CronExpression:  m.CronExpression,
+		RunAt:           m.RunAt,
+		Timezone:        m.Timezone,
+		Payload:         m.Payload,
+		PayloadSource:   m.PayloadSource,
+		Metadata:        m.Metadata,
+		StoreToDms:      m.StoreToDms,
+		HasCallback:     m.HasCallback,
+		CallbackURL:     m.CallbackURL,
+		MisfirePolicy:   m.MisfirePolicy,
+		Status:          m.Status,
+		NextRunAt:       m.NextRunAt,
+		LastRunAt:       m.LastRunAt,
+		CreatedBy:       m.CreatedBy,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+	if m.OutputFormat != nil {
+		e.OutputFormat = *m.OutputFormat
+	}
+	if m.Priority != nil {
+		e.Priority = *m.Priority
+	}
+	return e
+}
+
+func ToModel(e schedEntity.Schedule) DocumentSchedule {
+	m := DocumentSchedule{
+		ID:              e.ID,
+		TenantID:        e.TenantID,
+		Name:            e.Name,
+		Description:     e.Description,
+		TemplateCode:    e.TemplateCode,
+		TemplateVersion: e.TemplateVersion,
+		Locale:          e.Locale,
+		CronExpression:  e.CronExpression,
+		RunAt:           e.RunAt,
+		Timezone:        e.Timezone,
+		Payload:         e.Payload,
+		PayloadSource:   e.PayloadSource,
+		Metadata:        e.Metadata,
+		StoreToDms:      e.StoreToDms,
+		HasCallback:     e.HasCallback,
+		CallbackURL:     e.CallbackURL,
+		MisfirePolicy:   e.MisfirePolicy,
+		Status:          e.Status,
+		NextRunAt:       e.NextRunAt,
+		LastRunAt:       e.LastRunAt,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+	// Kolom enum nullable: nilai kosong disimpan sebagai NULL.
+	if e.OutputFormat != "" {
+		f := e.OutputFormat
+		m.OutputFormat = &f
+	}
+	if e.Priority != "" {
+		p := e.Priority
+		m.Priority = &p
+	}
+	return m
+}
+
+func RunToEntity(m *DocumentScheduleRun) schedEntity.Run {
+	if m == nil {
+		return schedEntity.Run{}
+	}
+	return schedEntity.Run{
+		ID:           m.ID,
+		TenantID:     m.TenantID,
+		ScheduleID:   m.ScheduleID,
+		ScheduledFor: m.ScheduledFor,
+		Status:       m.Status,
+		RequestID:    m.RequestID,
+		DocumentID:   m.DocumentID,
+		ErrorMessage: m.ErrorMessage,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func RunToModel(e schedEntity.Run) DocumentScheduleRun {
+	return DocumentScheduleRun{
+		ID:           e.ID,
+		TenantID:     e.TenantID,
+		ScheduleID:   e.ScheduleID,
+		ScheduledFor: e.ScheduledFor,
+		Status:       e.Status,
+		RequestID:    e.RequestID,
+		DocumentID:   e.DocumentID,
+		ErrorMessage: e.ErrorMessage,
+		CreatedAt:    e.CreatedAt,
+	}
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+	repo "go-document-generator/internal/repository/documentschedules"
+	"go-document-generator/internal/repository/documentschedules/model"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pagination"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentSchedulesRepository(db *gorm.DB) repo.DocumentSchedulesRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, s schedEntity.Schedule) (schedEntity.Schedule, error) {
+	m := model.ToModel(s)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return schedEntity.Schedule{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (schedEntity.Schedule, error) {
+	var m model.DocumentSchedule
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return schedEntity.Schedule{}, apperror.ErrNotFound
+		}
+		return schedEntity.Schedule{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) List(ctx context.Context, tx *gorm.DB, f repo.ListFilter) ([]schedEntity.Schedule, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentSchedule{})
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.TemplateCode != "" {
+		q = q.Where("template_code = ?", f.TemplateCode)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []model.DocumentSchedule
+	if err := q.Order("created_at DESC, id DESC").Offset(pagination.Offset(f.Page.Page, f.Page.Limit)).Limit(f.Page.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]schedEntity.Schedule, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *repository) Update(ctx context.Context, tx *gorm.DB, s schedEntity.Schedule) (schedEntity.Schedule, error) {
+	m := model.ToModel(s)
+	m.UpdatedAt = time.Now().UTC()
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentSchedule{}).Where("id = ?", s.ID)
+	if s.TenantID != nil {
+		q = q.Where("tenant_id = ?", *s.TenantID)
+	}
+	res := q.Select(
+		"name", "description", "template_code", "template_version", "output_format", "locale", "priority",
+		"cron_expression", "run_at", "timezone", "payload", "payload_source", "metadata",
+		"store_to_dms", "has_callback", "callback_url", "misfire_policy", "status", "next_run_at", "updated_at",
+	).Updates(&m)
+	if res.Error != nil {
+		return schedEntity.Schedule{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return schedEntity.Schedule{}, apperror.ErrNotFound
+	}
+	return r.GetByID(ctx, tx, s.ID, s.TenantID)
+}
+
+func (r *repository) Delete(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error {
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Delete(&model.DocumentSchedule{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) ClaimDue(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]schedEntity.Schedule, error) {
+	if tx == nil {
+		return nil, errors.New("documentschedules: ClaimDue requires a transaction")
+	}
+	var rows []model.DocumentSchedule
+	q := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", enums.ScheduleStatusActive, now).
+		Where("claimed_until IS NULL OR claimed_until <= ?", now).
+		Order("next_run_at ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]schedEntity.Schedule, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *repository) Lease(ctx context.Context, tx *gorm.DB, id int64, until time.Time) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentSchedule{}).Where("id = ?", id).
+		Update("claimed_until", until).Error
+}
+
+func (r *repository) Advance(ctx context.Context, tx *gorm.DB, id int64, next, last *time.Time, status enums.ScheduleStatus) error {
+	return r.conn(tx).WithContext(ctx).Model(&model.DocumentSchedule{}).
+		Where("id = ? AND status = ?", id, enums.ScheduleStatusActive).
+		Updates(map[string]any{
+			"next_run_at":   next,
+			"last_run_at":   last,
+			"status":        status,
+			"claimed_until": nil,
+			"updated_at":    time.Now().UTC(),
+		}).Error
+}
+
+func (r *repository) CreateRun(ctx context.Context, tx *gorm.DB, run schedEntity.Run) error {
+	m := model.RunToModel(run)
+	m.CreatedAt = time.Now().UTC()
+	return r.conn(tx).WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "schedule_id"}, {Name: "scheduled_for"}}, DoNothing: true}).
+		Create(&m).Error
+}
+
+func (r *repository) ListRuns(ctx context.Context, tx *gorm.DB, scheduleID int64, page pagination.Params) ([]schedEntity.Run, int64, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentScheduleRun{}).Where("schedule_id = ?", scheduleID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.DocumentScheduleRun
+	if err := q.Order("scheduled_for DESC, id DESC").
+		Offset(pagination.Offset(page.Page, page.Limit)).Limit(page.Limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]schedEntity.Run, len(rows))
+	for i := range rows {
+		out[i] = model.RunToEntity(&rows[i])
+	}
+	return out, total, nil
+}
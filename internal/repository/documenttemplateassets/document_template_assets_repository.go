@@ -0,0 +1,24 @@
+package documenttemplateassets
+
+import (
+	"context"
+
+	assetEntity "go-document-generator/internal/entity/documenttemplateassets"
+
+	"gorm.io/gorm"
+)
+
+type DocumentTemplateAssetsRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, a assetEntity.Asset) (assetEntity.Asset, error)
+	// UpdateContent mengganti isi asset (content type, ukuran, checksum, path).
+	UpdateContent(ctx context.Context, tx *gorm.DB, a assetEntity.Asset) (assetEntity.Asset, error)
+	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (assetEntity.Asset, error)
+	// GetByName mencari asset pada scope persis (tenant + template, template nil = tenant-wide).
+	GetByName(ctx context.Context, tx *gorm.DB, tenantID *string, templateID *int64, name string) (assetEntity.Asset, error)
+	// ResolveNames mengembalikan asset untuk nama-nama tersebut dengan prioritas
+	// template > tenant-wide > global; satu asset per nama.
+	ResolveNames(ctx context.Context, tx *gorm.DB, tenantID *string, templateID int64, names []string) ([]assetEntity.Asset, error)
+	// List dengan templateID nil mengembalikan asset tenant-wide (dan global).
+	List(ctx context.Context, tx *gorm.DB, tenantID *string, templateID *int64) ([]assetEntity.Asset, error)
+	Delete(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+
+	assetEntity "go-document-generator/internal/entity/documenttemplateassets"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentTemplateAsset struct {
+	ID              int64                 `gorm:"primaryKey;column:id"`
+	TenantID        *string               `gorm:"column:tenant_id;type:uuid"`
+	TemplateID      *int64                `gorm:"column:template_id"`
+	Name            string                `gorm:"column:name"`
+	ContentType     string                `gorm:"column:content_type"`
+	Size            int64                 `gorm:"column:size"`
+	Checksum        string                `gorm:"column:checksum"`
+	StoragePath     string                `gorm:"column:storage_path"`
+	StorageProvider enums.StorageProvider `gorm:"column:storage_provider;type:storage_provider"`
+	CreatedBy       *string               `gorm:"column:created_by"`
+	CreatedAt       time.Time             `gorm:"column:created_at"`
+	UpdatedAt       time.Time             `gorm:"column:updated_at"`
+}
+
+func (DocumentTemplateAsset) TableName() string { return "document_template_assets" }
+
+func ToEntity(m *DocumentTemplateAsset) assetEntity.Asset {
+	if m == nil {
+		return assetEntity.Asset{}
+	}
+	return assetEntity.Asset{
+		ID:              m.ID,
+		TenantID:        m.TenantID,
+		TemplateID:      m.TemplateID,
+		Name:            m.Name,
+		ContentType:     m.ContentType,
+		Size:            m.Size,
+		Checksum:        m.Checksum,
+		StoragePath:     m.StoragePath,
+		StorageProvider: m.StorageProvider,
+		CreatedBy:       m.CreatedBy,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+func ToModel(e assetEntity.Asset) DocumentTemplateAsset {
+	return DocumentTemplateAsset{
+		ID:              e.ID,
+		TenantID:        e.TenantID,
+		TemplateID:      e.TemplateID,
+		Name:            e.Name,
+		ContentType:     e.ContentType,
+		Size:            e.Size,
+		Checksum:        e.Checksum,
+		StoragePath:     e.StoragePath,
+		StorageProvider: e.StorageProvider,
+		CreatedBy:       e.CreatedBy,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	assetEntity "go-document-generator/internal/entity/documenttemplateassets"
+	repo "go-document-generator/internal/repository/documenttemplateassets"
+	"go-document-generator/internal/repository/documenttemplateassets/model"
+	"go-document-generator/internal/shared/apperror"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentTemplateAssetsRepository(db *gorm.DB) repo.DocumentTemplateAssetsRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, a assetEntity.Asset) (assetEntity.Asset, error) {
+	m := model.ToModel(a)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return assetEntity.Asset{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) UpdateContent(ctx context.Context, tx *gorm.DB, a assetEntity.Asset) (assetEntity.Asset, error) {
+	m := model.ToModel(a)
+	m.UpdatedAt = time.Now().UTC()
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentTemplateAsset{}).Where("id = ?", a.ID)
+	if a.TenantID != nil {
+		q = q.Where("tenant_id = ?", *a.TenantID)
+	}
+	res := q.Select("content_type", "size", "checksum", "storage_path", "storage_provider", "updated_at").Updates(&m)
+	if res.Error != nil {
+		return assetEntity.Asset{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return assetEntity.Asset{}, apperror.ErrNotFound
+	}
+	return r.GetByID(ctx, tx, a.ID, a.TenantID)
+}
+
+// GetByID: asset global (tenant_id NULL) bisa dibaca semua tenant.
+func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (assetEntity.Asset, error) {
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	}
+	return first(q)
+}
+
+func (r *repository) GetByName(ctx context.Context, tx *gorm.DB, tenantID *string, templateID *int64, name string) (assetEntity.Asset, error) {
+	q := r.conn(tx).WithContext(ctx).Where("name = ?", name)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	} else {
+		q = q.Where("tenant_id IS NULL")
+	}
+	if templateID != nil {
+		q = q.Where("template_id = ?", *templateID)
+	} else {
+		q = q.Where("template_id IS NULL")
+	}
+	return first(q)
+}
+
+func (r *repository) ResolveNames(ctx context.Context, tx *gorm.DB, tenantID *string, templateID int64, names []string) ([]assetEntity.Asset, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var rows []model.DocumentTemplateAsset
+	q := r.conn(tx).WithContext(ctx).
+		Where("name IN ?", names).
+		Where("(template_id = ? OR template_id IS NULL)", templateID)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	} else {
+		q = q.Where("tenant_id IS NULL")
+	}
+	if err := q.Order("template_id NULLS LAST, tenant_id NULLS LAST").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	out := make([]assetEntity.Asset, 0, len(rows))
+	for i := range rows {
+		if seen[rows[i].Name] {
+			continue
+		}
+		seen[rows[i].Name] = true
+		out = append(out, model.ToEntity(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *repository) List(ctx context.Context, tx *gorm.DB, tenantID *string, templateID *int64) ([]assetEntity.Asset, error) {
+	var rows []model.DocumentTemplateAsset
+	q := r.conn(tx).WithContext(ctx)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	}
+	if templateID != nil {
+		q = q.Where("template_id = ?", *templateID)
+	} else {
+		q = q.Where("template_id IS NULL")
+	}
+	if err := q.Order("name ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]assetEntity.Asset, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+// Delete hanya untuk asset milik tenant; asset global dihapus tanpa header tenant.
+func (r *repository) Delete(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) error {
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Delete(&model.DocumentTemplateAsset{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func first(q *gorm.DB) (assetEntity.Asset, error) {
+	var m model.DocumentTemplateAsset
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return assetEntity.Asset{}, apperror.ErrNotFound
+		}
+		return assetEntity.Asset{}, err
+	}
+	return model.ToEntity(&m), nil
+}
@@ -0,0 +1,34 @@
+package documenttemplatepartials
+
+import (
+	"context"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	"go-document-generator/internal/entity/enums"
+
+	"gorm.io/gorm"
+)
+
+type DocumentTemplatePartialsRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, p partialEntity.Partial) (partialEntity.Partial, error)
+	GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (partialEntity.Partial, error)
+	// GetByNames mengembalikan partial milik tenant atau global dengan nama tersebut;
+	// partial tenant didahulukan bila nama yang sama juga ada secara global.
+	GetByNames(ctx context.Context, tx *gorm.DB, names []string, tenantID *string) ([]partialEntity.Partial, error)
+	// List dengan kind kosong mengembalikan semua jenis.
+	List(ctx context.Context, tx *gorm.DB, tenantID *string, kind enums.TemplatePartialKind) ([]partialEntity.Partial, error)
+	UpdateDescription(ctx context.Context, tx *gorm.DB, id int64, tenantID *string, description *string) (partialEntity.Partial, error)
+	SetCurrentVersion(ctx context.Context, tx *gorm.DB, id int64, version int) error
+
+	NextVersionNumber(ctx context.Context, tx *gorm.DB, partialID int64) (int, error)
+	CreateVersion(ctx context.Context, tx *gorm.DB, v partialEntity.PartialVersion) (partialEntity.PartialVersion, error)
+	GetVersion(ctx context.Context, tx *gorm.DB, partialID, versionID int64) (partialEntity.PartialVersion, error)
+	GetVersionByNumber(ctx context.Context, tx *gorm.DB, partialID int64, version int) (partialEntity.PartialVersion, error)
+	ListVersions(ctx context.Context, tx *gorm.DB, partialID int64) ([]partialEntity.PartialVersion, error)
+	MarkVersionPublished(ctx context.Context, tx *gorm.DB, partialID, versionID int64) (partialEntity.PartialVersion, error)
+
+	// ReplaceDependencies mengganti seluruh dependensi partial sebuah versi template.
+	ReplaceDependencies(ctx context.Context, tx *gorm.DB, templateVersionID int64, deps []partialEntity.Dependency) error
+	// ListDependents mengembalikan versi template yang bergantung pada partial.
+	ListDependents(ctx context.Context, tx *gorm.DB, partialID int64) ([]partialEntity.Dependency, error)
+}
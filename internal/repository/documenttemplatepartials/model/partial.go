@@ -0,0 +1,139 @@
+package model
+
+import (
+	"time"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentTemplatePartial struct {
+	ID             int64                     `gorm:"primaryKey;column:id"`
+	TenantID       *string                   `gorm:"column:tenant_id;type:uuid"`
+	Name           string                    `gorm:"column:name"`
+	Kind           enums.TemplatePartialKind `gorm:"column:kind;type:template_partial_kind"`
+	Description    *string                   `gorm:"column:description"`
+	CurrentVersion *int                      `gorm:"column:current_version"`
+	CreatedBy      *string                   `gorm:"column:created_by"`
+	CreatedAt      time.Time                 `gorm:"column:created_at"`
+	UpdatedAt      time.Time                 `gorm:"column:updated_at"`
+}
+
+func (DocumentTemplatePartial) TableName() string { return "document_template_partials" }
+
+type DocumentTemplatePartialVersion struct {
+	ID          int64      `gorm:"primaryKey;column:id"`
+	PartialID   int64      `gorm:"column:partial_id"`
+	TenantID    *string    `gorm:"column:tenant_id;type:uuid"`
+	Version     int        `gorm:"column:version"`
+	Content     string     `gorm:"column:content"`
+	Checksum    *string    `gorm:"column:checksum"`
+	PublishedAt *time.Time `gorm:"column:published_at"`
+	CreatedBy   *string    `gorm:"column:created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+}
+
+func (DocumentTemplatePartialVersion) TableName() string { return "document_template_partial_versions" }
+
+type DocumentTemplatePartialDependency struct {
+	ID                int64     `gorm:"primaryKey;column:id"`
+	TenantID          *string   `gorm:"column:tenant_id;type:uuid"`
+	TemplateID        int64     `gorm:"column:template_id"`
+	TemplateVersionID int64     `gorm:"column:template_version_id"`
+	PartialID         int64     `gorm:"column:partial_id"`
+	PartialVersion    int       `gorm:"column:partial_version"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+}
+
+func (DocumentTemplatePartialDependency) TableName() string {
+	return "document_template_partial_dependencies"
+}
+
+func ToEntity(m *DocumentTemplatePartial) partialEntity.Partial {
+	if m == nil {
+		return partialEntity.Partial{}
+	}
+	return partialEntity.Partial{
+		ID:             m.ID,
+		TenantID:       m.TenantID,
+		Name:           m.Name,
+		Kind:           m.Kind,
+		Description:    m.Description,
+		CurrentVersion: m.CurrentVersion,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func ToModel(e partialEntity.Partial) DocumentTemplatePartial {
+	return DocumentTemplatePartial{
+		ID:             e.ID,
+		TenantID:       e.TenantID,
+		Name:           e.Name,
+		Kind:           e.Kind,
+		Description:    e.Description,
+		CurrentVersion: e.CurrentVersion,
+		CreatedBy:      e.CreatedBy,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+func VersionToEntity(m *DocumentTemplatePartialVersion) partialEntity.PartialVersion {
+	if m == nil {
+		return partialEntity.PartialVersion{}
+	}
+	return partialEntity.PartialVersion{
+		ID:          m.ID,
+		PartialID:   m.PartialID,
+		TenantID:    m.TenantID,
+		Version:     m.Version,
+		Content:     m.Content,
+		Checksum:    m.Checksum,
+		PublishedAt: m.PublishedAt,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func VersionToModel(e partialEntity.PartialVersion) DocumentTemplatePartialVersion {
+	return DocumentTemplatePartialVersion{
+		ID:          e.ID,
+		PartialID:   e.PartialID,
+		TenantID:    e.TenantID,
+		Version:     e.Version,
+		Content:     e.Content,
+		Checksum:    e.Checksum,
+		PublishedAt: e.PublishedAt,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+func DependencyToEntity(m *DocumentTemplatePartialDependency) partialEntity.Dependency {
+	if m == nil {
+		return partialEntity.Dependency{}
+	}
+	return partialEntity.Dependency{
+		ID:                m.ID,
+		TenantID:          m.TenantID,
+		TemplateID:        m.TemplateID,
+		TemplateVersionID: m.TemplateVersionID,
+		PartialID:         m.PartialID,
+		PartialVersion:    m.PartialVersion,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+func DependencyToModel(e partialEntity.Dependency) DocumentTemplatePartialDependency {
+	return DocumentTemplatePartialDependency{
+		ID:                e.ID,
+		TenantID:          e.TenantID,
+		TemplateID:        e.TemplateID,
+		TemplateVersionID: e.TemplateVersionID,
+		PartialID:         e.PartialID,
+		PartialVersion:    e.PartialVersion,
+		CreatedAt:         e.CreatedAt,
+	}
+}
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	"go-document-generator/internal/entity/enums"
+	repo "go-document-generator/internal/repository/documenttemplatepartials"
+	"go-document-generator/internal/repository/documenttemplatepartials/model"
+	"go-document-generator/internal/shared/apperror"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentTemplatePartialsRepository(db *gorm.DB) repo.DocumentTemplatePartialsRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, p partialEntity.Partial) (partialEntity.Partial, error) {
+	m := model.ToModel(p)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return partialEntity.Partial{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+// GetByID: partial global (tenant_id NULL) bisa dibaca semua tenant.
+func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, id int64, tenantID *string) (partialEntity.Partial, error) {
+	var m model.DocumentTemplatePartial
+	q := r.conn(tx).WithContext(ctx).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return partialEntity.Partial{}, apperror.ErrNotFound
+		}
+		return partialEntity.Partial{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) GetByNames(ctx context.Context, tx *gorm.DB, names []string, tenantID *string) ([]partialEntity.Partial, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var rows []model.DocumentTemplatePartial
+	q := r.conn(tx).WithContext(ctx).Where("name IN ?", names)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	} else {
+		q = q.Where("tenant_id IS NULL")
+	}
+	if err := q.Order("tenant_id NULLS LAST").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	out := make([]partialEntity.Partial, 0, len(rows))
+	for i := range rows {
+		if seen[rows[i].Name] {
+			continue
+		}
+		seen[rows[i].Name] = true
+		out = append(out, model.ToEntity(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *repository) List(ctx context.Context, tx *gorm.DB, tenantID *string, kind enums.TemplatePartialKind) ([]partialEntity.Partial, error) {
+	var rows []model.DocumentTemplatePartial
+	q := r.conn(tx).WithContext(ctx)
+	if tenantID != nil {
+		q = q.Where("(tenant_id = ? OR tenant_id IS NULL)", *tenantID)
+	}
+	if kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+	if err := q.Order("name ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]partialEntity.Partial, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+// UpdateDescription hanya untuk partial milik tenant; partial global dikelola tanpa tenant.
+func (r *repository) UpdateDescription(ctx context.Context, tx *gorm.DB, id int64, tenantID *string, description *string) (partialEntity.Partial, error) {
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentTemplatePartial{}).Where("id = ?", id)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Updates(map[string]any{"description": description, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return partialEntity.Partial{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return partialEntity.Partial{}, apperror.ErrNotFound
+	}
+	return r.GetByID(ctx, tx, id, tenantID)
+}
+
+func (r *repository) SetCurrentVersion(ctx context.Context, tx *gorm.DB, id int64, version int) error {
+	res := r.conn(tx).WithContext(ctx).Model(&model.DocumentTemplatePartial{}).Where("id = ?", id).
+		Updates(map[string]any{"current_version": version, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) NextVersionNumber(ctx context.Context, tx *gorm.DB, partialID int64) (int, error) {
+	var maxVersion int
+	err := r.conn(tx).WithContext(ctx).
+		Model(&model.DocumentTemplatePartialVersion{}).
+		Where("partial_id = ?", partialID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&maxVersion).Error
+	if err != nil {
+		return 0, err
+	}
+	return maxVersion + 1, nil
+}
+
+func (r *repository) CreateVersion(ctx context.Context, tx *gorm.DB, v partialEntity.PartialVersion) (partialEntity.PartialVersion, error) {
+	m := model.VersionToModel(v)
+	m.CreatedAt = time.Now().UTC()
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return partialEntity.PartialVersion{}, err
+	}
+	return model.VersionToEntity(&m), nil
+}
+
+func (r *repository) GetVersion(ctx context.Context, tx *gorm.DB, partialID, versionID int64) (partialEntity.PartialVersion, error) {
+	return r.firstVersion(r.conn(tx).WithContext(ctx).Where("partial_id = ? AND id = ?", partialID, versionID))
+}
+
+func (r *repository) GetVersionByNumber(ctx context.Context, tx *gorm.DB, partialID int64, version int) (partialEntity.PartialVersion, error) {
+	return r.firstVersion(r.conn(tx).WithContext(ctx).Where("partial_id = ? AND version = ?", partialID, version))
+}
+
+func (r *repository) firstVersion(q *gorm.DB) (partialEntity.PartialVersion, error) {
+	var m model.DocumentTemplatePartialVersion
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return partialEntity.PartialVersion{}, apperror.ErrNotFound
+		}
+		return partialEntity.PartialVersion{}, err
+	}
+	return model.VersionToEntity(&m), nil
+}
+
+func (r *repository) ListVersions(ctx context.Context, tx *gorm.DB, partialID int64) ([]partialEntity.PartialVersion, error) {
+	var rows []model.DocumentTemplatePartialVersion
+	if err := r.conn(tx).WithContext(ctx).Where("partial_id = ?", partialID).
+		Order("version DESC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]partialEntity.PartialVersion, len(rows))
+	for i := range rows {
+		out[i] = model.VersionToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+// MarkVersionPublished mengisi published_at saat versi pertama kali dipublish.
+func (r *repository) MarkVersionPublished(ctx context.Context, tx *gorm.DB, partialID, versionID int64) (partialEntity.PartialVersion, error) {
+	res := r.conn(tx).WithContext(ctx).Model(&model.DocumentTemplatePartialVersion{}).
+		Where("partial_id = ? AND id = ? AND published_at IS NULL", partialID, versionID).
+		Update("published_at", time.Now().UTC())
+	if res.Error != nil {
+		return partialEntity.PartialVersion{}, res.Error
+	}
+	return r.GetVersion(ctx, tx, partialID, versionID)
+}
+
+func (r *repository) ReplaceDependencies(ctx context.Context, tx *gorm.DB, templateVersionID int64, deps []partialEntity.Dependency) error {
+	db := r.conn(tx).WithContext(ctx)
+	if err := db.Where("template_version_id = ?", templateVersionID).
+		Delete(&model.DocumentTemplatePartialDependency{}).Error; err != nil {
+		return err
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	rows := make([]model.DocumentTemplatePartialDependency, len(deps))
+	for i, d := range deps {
+		rows[i] = model.DependencyToModel(d)
+		rows[i].TemplateVersionID = templateVersionID
+		rows[i].CreatedAt = now
+	}
+	return db.Create(&rows).Error
+}
+
+func (r *repository) ListDependents(ctx context.Context, tx *gorm.DB, partialID int64) ([]partialEntity.Dependency, error) {
+	var rows []model.DocumentTemplatePartialDependency
+	if err := r.conn(tx).WithContext(ctx).Where("partial_id = ?", partialID).
+		Order("template_id ASC, template_version_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]partialEntity.Dependency, len(rows))
+	for i := range rows {
+		out[i] = model.DependencyToEntity(&rows[i])
+	}
+	return out, nil
+}
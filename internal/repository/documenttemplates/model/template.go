@@ -5,22 +5,28 @@ import (
 
 	tplEntity "go-document-generator/internal/entity/documenttemplates"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type DocumentTemplate struct {
-	ID            int64              `gorm:"primaryKey;column:id"`
-	TenantID      *string            `gorm:"column:tenant_id;type:uuid"`
-	Code          string             `gorm:"column:code"`
-	Name          string             `gorm:"column:name"`
-	Description   *string            `gorm:"column:description"`
-	Engine        enums.TemplateEngine `gorm:"column:engine;type:template_engine"`
-	DefaultFormat enums.OutputFormat `gorm:"column:default_format;type:output_format"`
-	Category      *string            `gorm:"column:category"`
-	IsActive      bool               `gorm:"column:is_active"`
-	CreatedBy     *string            `gorm:"column:created_by"`
-	UpdatedBy     *string            `gorm:"column:updated_by"`
-	CreatedAt     time.Time          `gorm:"column:created_at"`
-	UpdatedAt     time.Time          `gorm:"column:updated_at"`
+	ID                 int64                    `gorm:"primaryKey;column:id"`
+	TenantID           *string                  `gorm:"column:tenant_id;type:uuid"`
+	Code               string                   `gorm:"column:code"`
+	Name               string                   `gorm:"column:name"`
+	Description        *string                  `gorm:"column:description"`
+	Engine             enums.TemplateEngine     `gorm:"column:engine;type:template_engine"`
+	DefaultFormat      enums.OutputFormat       `gorm:"column:default_format;type:output_format"`
+	Category           *string                  `gorm:"column:category"`
+	IsActive           bool                     `gorm:"column:is_active"`
+	RequiredApprovals  int                      `gorm:"column:required_approvals"`
+	PendingApprovals   *int                     `gorm:"column:pending_approvals"`
+	PendingApprovalsBy *string                  `gorm:"column:pending_approvals_by"`
+	SchemaCompatPolicy enums.SchemaCompatPolicy `gorm:"column:schema_compat_policy;type:schema_compat_policy;default:WARN"`
+	Watermark          *watermark.Spec          `gorm:"column:watermark;serializer:json;type:jsonb"`
+	CreatedBy          *string                  `gorm:"column:created_by"`
+	UpdatedBy          *string                  `gorm:"column:updated_by"`
+	CreatedAt          time.Time                `gorm:"column:created_at"`
+	UpdatedAt          time.Time                `gorm:"column:updated_at"`
 }
 
 func (DocumentTemplate) TableName() string { return "document_templates" }
@@ -30,36 +36,46 @@ func ToEntity(m *DocumentTemplate) tplEntity.Template {
 		return tplEntity.Template{}
 	}
 	return tplEntity.Template{
-		ID:            m.ID,
-		TenantID:      m.TenantID,
-		Code:          m.Code,
-		Name:          m.Name,
-		Description:   m.Description,
-		Engine:        m.Engine,
-		DefaultFormat: m.DefaultFormat,
-		Category:      m.Category,
-		IsActive:      m.IsActive,
-		CreatedBy:     m.CreatedBy,
-		UpdatedBy:     m.UpdatedBy,
-		CreatedAt:     m.CreatedAt,
-		UpdatedAt:     m.UpdatedAt,
+		ID:                 m.ID,
+		TenantID:           m.TenantID,
+		Code:               m.Code,
+		Name:               m.Name,
+		Description:        m.Description,
+		Engine:             m.Engine,
+		DefaultFormat:      m.DefaultFormat,
+		Category:           m.Category,
+		IsActive:           m.IsActive,
+		RequiredApprovals:  m.RequiredApprovals,
+		PendingApprovals:   m.PendingApprovals,
+		PendingApprovalsBy: m.PendingApprovalsBy,
+		SchemaCompatPolicy: m.SchemaCompatPolicy,
+		Watermark:          m.Watermark,
+		CreatedBy:          m.CreatedBy,
+		UpdatedBy:          m.UpdatedBy,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
 	}
 }
 
 func ToModel(e tplEntity.Template) DocumentTemplate {
 	return DocumentTemplate{
-		ID:            e.ID,
-		TenantID:      e.TenantID,
-		Code:          e.Code,
-		Name:          e.Name,
-		Description:   e.Description,
-		Engine:        e.Engine,
-		DefaultFormat: e.DefaultFormat,
-		Category:      e.Category,
-		IsActive:      e.IsActive,
-		CreatedBy:     e.CreatedBy,
-		UpdatedBy:     e.UpdatedBy,
-		CreatedAt:     e.CreatedAt,
-		UpdatedAt:     e.UpdatedAt,
+		ID:                 e.ID,
+		TenantID:           e.TenantID,
+		Code:               e.Code,
+		Name:               e.Name,
+		Description:        e.Description,
+		Engine:             e.Engine,
+		DefaultFormat:      e.DefaultFormat,
+		Category:           e.Category,
+		IsActive:           e.IsActive,
+		RequiredApprovals:  e.RequiredApprovals,
+		PendingApprovals:   e.PendingApprovals,
+		PendingApprovalsBy: e.PendingApprovalsBy,
+		SchemaCompatPolicy: e.SchemaCompatPolicy,
+		Watermark:          e.Watermark,
+		CreatedBy:          e.CreatedBy,
+		UpdatedBy:          e.UpdatedBy,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
 	}
 }
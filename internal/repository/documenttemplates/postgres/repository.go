@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
@@ -132,6 +133,21 @@ func (r *repository) Update(ctx context.Context, tx *gorm.DB, t tplEntity.Templa
 		updates["category"] = t.Category
 	}
 	updates["is_active"] = t.IsActive
+	updates["required_approvals"] = t.RequiredApprovals
+	updates["pending_approvals"] = t.PendingApprovals
+	updates["pending_approvals_by"] = t.PendingApprovalsBy
+	if t.SchemaCompatPolicy != "" {
+		updates["schema_compat_policy"] = t.SchemaCompatPolicy
+	}
+	// Updates(map) tidak melewati serializer json; watermark ditulis sebagai literal jsonb.
+	updates["watermark"] = nil
+	if t.Watermark != nil {
+		b, err := json.Marshal(t.Watermark)
+		if err != nil {
+			return tplEntity.Template{}, err
+		}
+		updates["watermark"] = gorm.Expr("?::jsonb", string(b))
+	}
 	if t.UpdatedBy != nil {
 		updates["updated_by"] = t.UpdatedBy
 	}
@@ -0,0 +1,20 @@
+package documenttemplatetests
+
+import (
+	"context"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+
+	"gorm.io/gorm"
+)
+
+type DocumentTemplateTestsRepository interface {
+	CreateCase(ctx context.Context, tx *gorm.DB, c testEntity.TestCase) (testEntity.TestCase, error)
+	GetCaseByID(ctx context.Context, tx *gorm.DB, templateID, caseID int64, tenantID *string) (testEntity.TestCase, error)
+	ListCases(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string) ([]testEntity.TestCase, error)
+	UpdateCase(ctx context.Context, tx *gorm.DB, c testEntity.TestCase) (testEntity.TestCase, error)
+	DeleteCase(ctx context.Context, tx *gorm.DB, templateID, caseID int64, tenantID *string) error
+	CreateResults(ctx context.Context, tx *gorm.DB, results []testEntity.TestResult) ([]testEntity.TestResult, error)
+	// ListLatestResults mengembalikan hasil terakhir tiap test case untuk sebuah versi.
+	ListLatestResults(ctx context.Context, tx *gorm.DB, versionID int64) ([]testEntity.TestResult, error)
+}
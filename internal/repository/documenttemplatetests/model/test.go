@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentTemplateTestCase struct {
+	ID             int64              `gorm:"primaryKey;column:id"`
+	TenantID       *string            `gorm:"column:tenant_id;type:uuid"`
+	TemplateID     int64              `gorm:"column:template_id"`
+	Name           string             `gorm:"column:name"`
+	Description    *string            `gorm:"column:description"`
+	Payload        map[string]any     `gorm:"column:payload;serializer:json;type:jsonb"`
+	OutputFormat   enums.OutputFormat `gorm:"column:output_format;type:output_format"`
+	ExpectedOutput string             `gorm:"column:expected_output"`
+	ExpectedPages  *int               `gorm:"column:expected_pages"`
+	CreatedBy      *string            `gorm:"column:created_by"`
+	CreatedAt      time.Time          `gorm:"column:created_at"`
+	UpdatedAt      time.Time          `gorm:"column:updated_at"`
+}
+
+func (DocumentTemplateTestCase) TableName() string { return "document_template_test_cases" }
+
+type DocumentTemplateTestResult struct {
+	ID           int64     `gorm:"primaryKey;column:id"`
+	TenantID     *string   `gorm:"column:tenant_id;type:uuid"`
+	TemplateID   int64     `gorm:"column:template_id"`
+	VersionID    int64     `gorm:"column:version_id"`
+	TestCaseID   int64     `gorm:"column:test_case_id"`
+	CaseName     string    `gorm:"column:case_name"`
+	Passed       bool      `gorm:"column:passed"`
+	Checksum     *string   `gorm:"column:checksum"`
+	ActualOutput string    `gorm:"column:actual_output"`
+	ActualPages  *int      `gorm:"column:actual_pages"`
+	Diff         *string   `gorm:"column:diff"`
+	Error        *string   `gorm:"column:error"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (DocumentTemplateTestResult) TableName() string { return "document_template_test_results" }
+
+func CaseToEntity(m *DocumentTemplateTestCase) testEntity.TestCase {
+	if m == nil {
+		return testEntity.TestCase{}
+	}
+	return testEntity.TestCase{
+		ID:             m.ID,
+		TenantID:       m.TenantID,
+		TemplateID:     m.TemplateID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Payload:        m.Payload,
+		OutputFormat:   m.OutputFormat,
+		ExpectedOutput: m.ExpectedOutput,
+		ExpectedPages:  m.ExpectedPages,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func CaseToModel(e testEntity.TestCase) DocumentTemplateTestCase {
+	return DocumentTemplateTestCase{
+		ID:             e.ID,
+		TenantID:       e.TenantID,
+		TemplateID:     e.TemplateID,
+		Name:           e.Name,
+		Description:    e.Description,
+		Payload:        e.Payload,
+		OutputFormat:   e.OutputFormat,
+		ExpectedOutput: e.ExpectedOutput,
+		ExpectedPages:  e.ExpectedPages,
+		CreatedBy:      e.CreatedBy,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+func ResultToEntity(m *DocumentTemplateTestResult) testEntity.TestResult {
+	if m == nil {
+		return testEntity.TestResult{}
+	}
+	return testEntity.TestResult{
+		ID:           m.ID,
+		TenantID:     m.TenantID,
+		TemplateID:   m.TemplateID,
+		VersionID:    m.VersionID,
+		TestCaseID:   m.TestCaseID,
+		CaseName:     m.CaseName,
+		Passed:       m.Passed,
+		Checksum:     m.Checksum,
+		ActualOutput: m.ActualOutput,
+		ActualPages:  m.ActualPages,
+		Diff:         m.Diff,
+		Error:        m.Error,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func ResultToModel(e testEntity.TestResult) DocumentTemplateTestResult {
+	return DocumentTemplateTestResult{
+		ID:           e.ID,
+		TenantID:     e.TenantID,
+		TemplateID:   e.TemplateID,
+		VersionID:    e.VersionID,
+		TestCaseID:   e.TestCaseID,
+		CaseName:     e.CaseName,
+		Passed:       e.Passed,
+		Checksum:     e.Checksum,
+		ActualOutput: e.ActualOutput,
+		ActualPages:  e.ActualPages,
+		Diff:         e.Diff,
+		Error:        e.Error,
+		CreatedAt:    e.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+	repo "go-document-generator/internal/repository/documenttemplatetests"
+	"go-document-generator/internal/repository/documenttemplatetests/model"
+	"go-document-generator/internal/shared/apperror"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentTemplateTestsRepository(db *gorm.DB) repo.DocumentTemplateTestsRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) CreateCase(ctx context.Context, tx *gorm.DB, c testEntity.TestCase) (testEntity.TestCase, error) {
+	m := model.CaseToModel(c)
+	now := time.Now().UTC()
+	m.CreatedAt, m.UpdatedAt = now, now
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return testEntity.TestCase{}, err
+	}
+	return model.CaseToEntity(&m), nil
+}
+
+func (r *repository) GetCaseByID(ctx context.Context, tx *gorm.DB, templateID, caseID int64, tenantID *string) (testEntity.TestCase, error) {
+	var m model.DocumentTemplateTestCase
+	q := r.conn(tx).WithContext(ctx).Where("id = ? AND template_id = ?", caseID, templateID)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return testEntity.TestCase{}, apperror.ErrNotFound
+		}
+		return testEntity.TestCase{}, err
+	}
+	return model.CaseToEntity(&m), nil
+}
+
+func (r *repository) ListCases(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string) ([]testEntity.TestCase, error) {
+	var rows []model.DocumentTemplateTestCase
+	q := r.conn(tx).WithContext(ctx).Where("template_id = ?", templateID)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	if err := q.Order("name ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]testEntity.TestCase, len(rows))
+	for i := range rows {
+		out[i] = model.CaseToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *repository) UpdateCase(ctx context.Context, tx *gorm.DB, c testEntity.TestCase) (testEntity.TestCase, error) {
+	m := model.CaseToModel(c)
+	m.UpdatedAt = time.Now().UTC()
+	q := r.conn(tx).WithContext(ctx).Model(&model.DocumentTemplateTestCase{}).
+		Where("id = ? AND template_id = ?", c.ID, c.TemplateID)
+	if c.TenantID != nil {
+		q = q.Where("tenant_id = ?", *c.TenantID)
+	}
+	res := q.Select("name", "description", "payload", "output_format", "expected_output", "expected_pages", "updated_at").
+		Updates(&m)
+	if res.Error != nil {
+		return testEntity.TestCase{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return testEntity.TestCase{}, apperror.ErrNotFound
+	}
+	return r.GetCaseByID(ctx, tx, c.TemplateID, c.ID, c.TenantID)
+}
+
+func (r *repository) DeleteCase(ctx context.Context, tx *gorm.DB, templateID, caseID int64, tenantID *string) error {
+	q := r.conn(tx).WithContext(ctx).Where("id = ? AND template_id = ?", caseID, templateID)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Delete(&model.DocumentTemplateTestCase{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *repository) CreateResults(ctx context.Context, tx *gorm.DB, results []testEntity.TestResult) ([]testEntity.TestResult, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+	now := time.Now().UTC()
+	rows := make([]model.DocumentTemplateTestResult, len(results))
+	for i, res := range results {
+		rows[i] = model.ResultToModel(res)
+		rows[i].CreatedAt = now
+	}
+	if err := r.conn(tx).WithContext(ctx).Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	return resultEntities(rows), nil
+}
+
+func (r *repository) ListLatestResults(ctx context.Context, tx *gorm.DB, versionID int64) ([]testEntity.TestResult, error) {
+	var rows []model.DocumentTemplateTestResult
+	if err := r.conn(tx).WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (test_case_id) * FROM document_template_test_results
+			WHERE version_id = ? ORDER BY test_case_id, created_at DESC, id DESC`, versionID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return resultEntities(rows), nil
+}
+
+func resultEntities(rows []model.DocumentTemplateTestResult) []testEntity.TestResult {
+	out := make([]testEntity.TestResult, len(rows))
+	for i := range rows {
+		out[i] = model.ResultToEntity(&rows[i])
+	}
+	return out
+}
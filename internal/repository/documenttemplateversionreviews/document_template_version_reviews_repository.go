@@ -0,0 +1,18 @@
+package documenttemplateversionreviews
+
+import (
+	"context"
+	"time"
+
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
+
+	"gorm.io/gorm"
+)
+
+type DocumentTemplateVersionReviewsRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, r reviewEntity.Review) (reviewEntity.Review, error)
+	// ListByVersionID mengembalikan audit review urut dari yang paling lama.
+	ListByVersionID(ctx context.Context, tx *gorm.DB, versionID int64) ([]reviewEntity.Review, error)
+	// ListApprovalsSince mengembalikan entri APPROVED sejak submit terakhir.
+	ListApprovalsSince(ctx context.Context, tx *gorm.DB, versionID int64, since time.Time) ([]reviewEntity.Review, error)
+}
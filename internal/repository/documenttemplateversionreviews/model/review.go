@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
+	"go-document-generator/internal/entity/enums"
+)
+
+type DocumentTemplateVersionReview struct {
+	ID         int64                             `gorm:"primaryKey;column:id"`
+	TenantID   *string                           `gorm:"column:tenant_id;type:uuid"`
+	TemplateID int64                             `gorm:"column:template_id"`
+	VersionID  int64                             `gorm:"column:version_id"`
+	Action     enums.TemplateVersionReviewAction `gorm:"column:action;type:template_version_review_action"`
+	Actor      string                            `gorm:"column:actor"`
+	Comment    *string                           `gorm:"column:comment"`
+	CreatedAt  time.Time                         `gorm:"column:created_at"`
+}
+
+func (DocumentTemplateVersionReview) TableName() string { return "document_template_version_reviews" }
+
+func ToEntity(m *DocumentTemplateVersionReview) reviewEntity.Review {
+	if m == nil {
+		return reviewEntity.Review{}
+	}
+	return reviewEntity.Review{
+		ID:         m.ID,
+		TenantID:   m.TenantID,
+		TemplateID: m.TemplateID,
+		VersionID:  m.VersionID,
+		Action:     m.Action,
+		Actor:      m.Actor,
+		Comment:    m.Comment,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func ToModel(e reviewEntity.Review) DocumentTemplateVersionReview {
+	return DocumentTemplateVersionReview{
+		ID:         e.ID,
+		TenantID:   e.TenantID,
+		TemplateID: e.TemplateID,
+		VersionID:  e.VersionID,
+		Action:     e.Action,
+		Actor:      e.Actor,
+		Comment:    e.Comment,
+		CreatedAt:  e.CreatedAt,
+	}
+}
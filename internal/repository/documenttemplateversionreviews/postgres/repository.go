@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
+	"go-document-generator/internal/entity/enums"
+	repo "go-document-generator/internal/repository/documenttemplateversionreviews"
+	"go-document-generator/internal/repository/documenttemplateversionreviews/model"
+
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewDocumentTemplateVersionReviewsRepository(db *gorm.DB) repo.DocumentTemplateVersionReviewsRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) conn(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+func (r *repository) Create(ctx context.Context, tx *gorm.DB, rv reviewEntity.Review) (reviewEntity.Review, error) {
+	m := model.ToModel(rv)
+	m.CreatedAt = time.Now().UTC()
+	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
+		return reviewEntity.Review{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) ListByVersionID(ctx context.Context, tx *gorm.DB, versionID int64) ([]reviewEntity.Review, error) {
+	var rows []model.DocumentTemplateVersionReview
+	if err := r.conn(tx).WithContext(ctx).
+		Where("version_id = ?", versionID).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(rows), nil
+}
+
+func (r *repository) ListApprovalsSince(ctx context.Context, tx *gorm.DB, versionID int64, since time.Time) ([]reviewEntity.Review, error) {
+	var rows []model.DocumentTemplateVersionReview
+	if err := r.conn(tx).WithContext(ctx).
+		Where("version_id = ? AND action = ? AND created_at >= ?", versionID, enums.TemplateVersionReviewActionApproved, since).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(rows), nil
+}
+
+func toEntities(rows []model.DocumentTemplateVersionReview) []reviewEntity.Review {
+	out := make([]reviewEntity.Review, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out
+}
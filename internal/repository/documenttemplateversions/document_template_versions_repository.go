@@ -2,19 +2,41 @@ package documenttemplateversions
 
 import (
 	"context"
+	"time"
 
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
 
 	"gorm.io/gorm"
 )
 
+type ListFilter struct {
+	TenantID    *string
+	IsPublished *bool
+	Status      enums.TemplateVersionStatus
+}
+
 type DocumentTemplateVersionsRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error)
+	// Update menyimpan field konten versi (content, schema, variables, sample_payload, output_format, checksum)
+	// hanya bila versi masih DRAFT dan tidak sedang direview (PENDING); selain itu apperror.ErrInvalidState.
+	Update(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error)
 	GetByID(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
-	ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string, isPublished *bool) ([]verEntity.TemplateVersion, error)
+	// GetByIDForUpdate seperti GetByID dengan SELECT ... FOR UPDATE; tx wajib.
+	GetByIDForUpdate(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
+	ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, f ListFilter) ([]verEntity.TemplateVersion, error)
 	GetLatestPublished(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string) (verEntity.TemplateVersion, error)
 	GetByTemplateAndVersion(ctx context.Context, tx *gorm.DB, templateID int64, version int, tenantID *string) (verEntity.TemplateVersion, error)
 	NextVersionNumber(ctx context.Context, tx *gorm.DB, templateID int64) (int, error)
-	Publish(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
-	UnpublishOthers(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) error
+	// UpdateStatus memindahkan versi ke status target hanya bila status saat ini termasuk from.
+	// Mengembalikan apperror.ErrInvalidState bila status saat ini tidak cocok.
+	UpdateStatus(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string, from []enums.TemplateVersionStatus, to enums.TemplateVersionStatus) (verEntity.TemplateVersion, error)
+	// SetReviewStatus memindahkan review_status versi DRAFT hanya bila status review saat ini termasuk from.
+	// to PENDING mencatat submitted_by/submitted_at; to NONE mengosongkannya.
+	SetReviewStatus(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string, from []enums.TemplateVersionReviewStatus, to enums.TemplateVersionReviewStatus, submittedBy *string) (verEntity.TemplateVersion, error)
+	// DeprecatePublished menandai versi PUBLISHED lain dari template yang sama menjadi DEPRECATED.
+	DeprecatePublished(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) ([]verEntity.TemplateVersion, error)
+	SetSchedule(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string, at *time.Time) (verEntity.TemplateVersion, error)
+	// ListDueScheduled mengambil versi dengan scheduled_publish_at <= now (lintas tenant).
+	ListDueScheduled(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]verEntity.TemplateVersion, error)
 }
@@ -5,23 +5,37 @@ import (
 
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
 )
 
 type DocumentTemplateVersion struct {
-	ID            int64              `gorm:"primaryKey;column:id"`
-	TenantID      *string            `gorm:"column:tenant_id;type:uuid"`
-	TemplateID    int64              `gorm:"column:template_id"`
-	Version       int                `gorm:"column:version"`
-	Content       string             `gorm:"column:content"`
-	Schema        map[string]any     `gorm:"column:schema;serializer:json;type:jsonb"`
-	Variables     []any              `gorm:"column:variables;serializer:json;type:jsonb"`
-	SamplePayload map[string]any     `gorm:"column:sample_payload;serializer:json;type:jsonb"`
-	OutputFormat  enums.OutputFormat `gorm:"column:output_format;type:output_format"`
-	Checksum      *string            `gorm:"column:checksum"`
-	IsPublished   bool               `gorm:"column:is_published"`
-	PublishedAt   *time.Time         `gorm:"column:published_at"`
-	CreatedBy     *string            `gorm:"column:created_by"`
-	CreatedAt     time.Time          `gorm:"column:created_at"`
+	ID                 int64                             `gorm:"primaryKey;column:id"`
+	TenantID           *string                           `gorm:"column:tenant_id;type:uuid"`
+	TemplateID         int64                             `gorm:"column:template_id"`
+	Version            int                               `gorm:"column:version"`
+	Content            string                            `gorm:"column:content"`
+	Schema             map[string]any                    `gorm:"column:schema;serializer:json;type:jsonb"`
+	Variables          []any                             `gorm:"column:variables;serializer:json;type:jsonb"`
+	SamplePayload      map[string]any                    `gorm:"column:sample_payload;serializer:json;type:jsonb"`
+	OutputFormat       enums.OutputFormat                `gorm:"column:output_format;type:output_format"`
+	Layout             *string                           `gorm:"column:layout"`
+	Translations       map[string]map[string]string      `gorm:"column:translations;serializer:json;type:jsonb"`
+	DefaultLocale      *string                           `gorm:"column:default_locale"`
+	PDFAConformance    *string                           `gorm:"column:pdfa_conformance"`
+	EInvoice           *einvoice.Spec                    `gorm:"column:e_invoice;serializer:json;type:jsonb"`
+	Checksum           *string                           `gorm:"column:checksum"`
+	Status             enums.TemplateVersionStatus       `gorm:"column:status;type:template_version_status;default:DRAFT"`
+	IsPublished        bool                              `gorm:"column:is_published"`
+	PublishedAt        *time.Time                        `gorm:"column:published_at"`
+	ScheduledPublishAt *time.Time                        `gorm:"column:scheduled_publish_at"`
+	DeprecatedAt       *time.Time                        `gorm:"column:deprecated_at"`
+	ArchivedAt         *time.Time                        `gorm:"column:archived_at"`
+	ReviewStatus       enums.TemplateVersionReviewStatus `gorm:"column:review_status;type:template_version_review_status;default:NONE"`
+	SubmittedBy        *string                           `gorm:"column:submitted_by"`
+	SubmittedAt        *time.Time                        `gorm:"column:submitted_at"`
+	CreatedBy          *string                           `gorm:"column:created_by"`
+	CreatedAt          time.Time                         `gorm:"column:created_at"`
+	UpdatedAt          time.Time                         `gorm:"column:updated_at"`
 }
 
 func (DocumentTemplateVersion) TableName() string { return "document_template_versions" }
@@ -30,39 +44,77 @@ func ToEntity(m *DocumentTemplateVersion) verEntity.TemplateVersion {
 	if m == nil {
 		return verEntity.TemplateVersion{}
 	}
+	status := m.Status
+	if status == "" {
+		// Baris lama (sebelum kolom status ada) hanya punya flag is_published.
+		status = enums.TemplateVersionStatusDraft
+		if m.IsPublished {
+			status = enums.TemplateVersionStatusPublished
+		}
+	}
+	reviewStatus := m.ReviewStatus
+	if reviewStatus == "" {
+		reviewStatus = enums.TemplateVersionReviewStatusNone
+	}
 	return verEntity.TemplateVersion{
-		ID:            m.ID,
-		TenantID:      m.TenantID,
-		TemplateID:    m.TemplateID,
-		Version:       m.Version,
-		Content:       m.Content,
-		Schema:        m.Schema,
-		Variables:     m.Variables,
-		SamplePayload: m.SamplePayload,
-		OutputFormat:  m.OutputFormat,
-		Checksum:      m.Checksum,
-		IsPublished:   m.IsPublished,
-		PublishedAt:   m.PublishedAt,
-		CreatedBy:     m.CreatedBy,
-		CreatedAt:     m.CreatedAt,
+		ID:                 m.ID,
+		TenantID:           m.TenantID,
+		TemplateID:         m.TemplateID,
+		Version:            m.Version,
+		Content:            m.Content,
+		Schema:             m.Schema,
+		Variables:          m.Variables,
+		SamplePayload:      m.SamplePayload,
+		OutputFormat:       m.OutputFormat,
+		Layout:             m.Layout,
+		Translations:       m.Translations,
+		DefaultLocale:      m.DefaultLocale,
+		PDFAConformance:    m.PDFAConformance,
+		EInvoice:           m.EInvoice,
+		Checksum:           m.Checksum,
+		Status:             status,
+		IsPublished:        m.IsPublished,
+		PublishedAt:        m.PublishedAt,
+		ScheduledPublishAt: m.ScheduledPublishAt,
+		DeprecatedAt:       m.DeprecatedAt,
+		ArchivedAt:         m.ArchivedAt,
+		ReviewStatus:       reviewStatus,
+		SubmittedBy:        m.SubmittedBy,
+		SubmittedAt:        m.SubmittedAt,
+		CreatedBy:          m.CreatedBy,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
 	}
 }
 
 func ToModel(e verEntity.TemplateVersion) DocumentTemplateVersion {
 	return DocumentTemplateVersion{
-		ID:            e.ID,
-		TenantID:      e.TenantID,
-		TemplateID:    e.TemplateID,
-		Version:       e.Version,
-		Content:       e.Content,
-		Schema:        e.Schema,
-		Variables:     e.Variables,
-		SamplePayload: e.SamplePayload,
-		OutputFormat:  e.OutputFormat,
-		Checksum:      e.Checksum,
-		IsPublished:   e.IsPublished,
-		PublishedAt:   e.PublishedAt,
-		CreatedBy:     e.CreatedBy,
-		CreatedAt:     e.CreatedAt,
+		ID:                 e.ID,
+		TenantID:           e.TenantID,
+		TemplateID:         e.TemplateID,
+		Version:            e.Version,
+		Content:            e.Content,
+		Schema:             e.Schema,
+		Variables:          e.Variables,
+		SamplePayload:      e.SamplePayload,
+		OutputFormat:       e.OutputFormat,
+		Layout:             e.Layout,
+		Translations:       e.Translations,
+		DefaultLocale:      e.DefaultLocale,
+		PDFAConformance:    e.PDFAConformance,
+		EInvoice:           e.EInvoice,
+		Checksum:           e.Checksum,
+		Status:             e.Status,
+		IsPublished:        e.IsPublished,
+		PublishedAt:        e.PublishedAt,
+		ScheduledPublishAt: e.ScheduledPublishAt,
+		DeprecatedAt:       e.DeprecatedAt,
+		ArchivedAt:         e.ArchivedAt,
+		ReviewStatus:       e.ReviewStatus,
+		SubmittedBy:        e.SubmittedBy,
+		SubmittedAt:        e.SubmittedAt,
+		CreatedBy:          e.CreatedBy,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
 	}
 }
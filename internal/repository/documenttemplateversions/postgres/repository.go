@@ -6,11 +6,13 @@ import (
 	"time"
 
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
 	repo "go-document-generator/internal/repository/documenttemplateversions"
 	"go-document-generator/internal/repository/documenttemplateversions/model"
 	"go-document-generator/internal/shared/apperror"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type repository struct {
@@ -31,12 +33,50 @@ func (r *repository) conn(tx *gorm.DB) *gorm.DB {
 func (r *repository) Create(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
 	m := model.ToModel(v)
 	m.CreatedAt = time.Now().UTC()
+	m.UpdatedAt = m.CreatedAt
 	if err := r.conn(tx).WithContext(ctx).Create(&m).Error; err != nil {
 		return verEntity.TemplateVersion{}, err
 	}
 	return model.ToEntity(&m), nil
 }
 
+func (r *repository) Update(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
+	m := model.ToModel(v)
+	q := r.conn(tx).WithContext(ctx).
+		Model(&model.DocumentTemplateVersion{}).
+		Where("id = ? AND template_id = ? AND status = ?", v.ID, v.TemplateID, enums.TemplateVersionStatusDraft).
+		Where("review_status <> ?", enums.TemplateVersionReviewStatusPending)
+	if v.TenantID != nil {
+		q = q.Where("tenant_id = ?", *v.TenantID)
+	}
+	res := q.Select("content", "schema", "variables", "sample_payload", "output_format", "layout", "translations", "default_locale", "pdfa_conformance", "e_invoice", "checksum", "updated_at").
+		Updates(&model.DocumentTemplateVersion{
+			Content:         m.Content,
+			Schema:          m.Schema,
+			Variables:       m.Variables,
+			SamplePayload:   m.SamplePayload,
+			OutputFormat:    m.OutputFormat,
+			Layout:          m.Layout,
+			Translations:    m.Translations,
+			DefaultLocale:   m.DefaultLocale,
+			PDFAConformance: m.PDFAConformance,
+			EInvoice:        m.EInvoice,
+			Checksum:        m.Checksum,
+			UpdatedAt:       time.Now().UTC(),
+		})
+	if res.Error != nil {
+		return verEntity.TemplateVersion{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		// Versi sudah tidak DRAFT (mis. dipublish bersamaan) atau sedang direview: jangan timpa kontennya.
+		if _, err := r.GetByID(ctx, tx, v.TemplateID, v.ID, v.TenantID); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		return verEntity.TemplateVersion{}, apperror.ErrInvalidState
+	}
+	return r.GetByID(ctx, tx, v.TemplateID, v.ID, v.TenantID)
+}
+
 func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
 	var m model.DocumentTemplateVersion
 	q := r.conn(tx).WithContext(ctx).Where("id = ? AND template_id = ?", versionID, templateID)
@@ -52,13 +92,32 @@ func (r *repository) GetByID(ctx context.Context, tx *gorm.DB, templateID, versi
 	return model.ToEntity(&m), nil
 }
 
-func (r *repository) ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string, isPublished *bool) ([]verEntity.TemplateVersion, error) {
-	q := r.conn(tx).WithContext(ctx).Where("template_id = ?", templateID)
+func (r *repository) GetByIDForUpdate(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+	var m model.DocumentTemplateVersion
+	q := r.conn(tx).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND template_id = ?", versionID, templateID)
 	if tenantID != nil {
 		q = q.Where("tenant_id = ?", *tenantID)
 	}
-	if isPublished != nil {
-		q = q.Where("is_published = ?", *isPublished)
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return verEntity.TemplateVersion{}, apperror.ErrNotFound
+		}
+		return verEntity.TemplateVersion{}, err
+	}
+	return model.ToEntity(&m), nil
+}
+
+func (r *repository) ListByTemplateID(ctx context.Context, tx *gorm.DB, templateID int64, f repo.ListFilter) ([]verEntity.TemplateVersion, error) {
+	q := r.conn(tx).WithContext(ctx).Where("template_id = ?", templateID)
+	if f.TenantID != nil {
+		q = q.Where("tenant_id = ?", *f.TenantID)
+	}
+	if f.IsPublished != nil {
+		q = q.Where("is_published = ?", *f.IsPublished)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
 	}
 	q = q.Order("version DESC")
 
@@ -75,7 +134,7 @@ func (r *repository) ListByTemplateID(ctx context.Context, tx *gorm.DB, template
 
 func (r *repository) GetLatestPublished(ctx context.Context, tx *gorm.DB, templateID int64, tenantID *string) (verEntity.TemplateVersion, error) {
 	published := true
-	rows, err := r.ListByTemplateID(ctx, tx, templateID, tenantID, &published)
+	rows, err := r.ListByTemplateID(ctx, tx, templateID, repo.ListFilter{TenantID: tenantID, IsPublished: &published})
 	if err != nil {
 		return verEntity.TemplateVersion{}, err
 	}
@@ -113,19 +172,139 @@ func (r *repository) NextVersionNumber(ctx context.Context, tx *gorm.DB, templat
 	return maxVersion + 1, nil
 }
 
-func (r *repository) UnpublishOthers(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) error {
-	return r.conn(tx).WithContext(ctx).
+func (r *repository) UpdateStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	templateID, versionID int64,
+	tenantID *string,
+	from []enums.TemplateVersionStatus,
+	to enums.TemplateVersionStatus,
+) (verEntity.TemplateVersion, error) {
+	now := time.Now().UTC()
+	fields := map[string]any{
+		"status":     to,
+		"updated_at": now,
+	}
+	switch to {
+	case enums.TemplateVersionStatusPublished:
+		fields["is_published"] = true
+		fields["published_at"] = now
+		fields["scheduled_publish_at"] = nil
+		fields["deprecated_at"] = nil
+	case enums.TemplateVersionStatusDeprecated:
+		fields["is_published"] = false
+		fields["deprecated_at"] = now
+	case enums.TemplateVersionStatusArchived:
+		fields["is_published"] = false
+		fields["scheduled_publish_at"] = nil
+		fields["archived_at"] = now
+	}
+
+	q := r.conn(tx).WithContext(ctx).
+		Model(&model.DocumentTemplateVersion{}).
+		Where("id = ? AND template_id = ?", versionID, templateID).
+		Where("status IN ?", from)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Updates(fields)
+	if res.Error != nil {
+		return verEntity.TemplateVersion{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		if _, err := r.GetByID(ctx, tx, templateID, versionID, tenantID); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		return verEntity.TemplateVersion{}, apperror.ErrInvalidState
+	}
+	return r.GetByID(ctx, tx, templateID, versionID, tenantID)
+}
+
+func (r *repository) SetReviewStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	templateID, versionID int64,
+	tenantID *string,
+	from []enums.TemplateVersionReviewStatus,
+	to enums.TemplateVersionReviewStatus,
+	submittedBy *string,
+) (verEntity.TemplateVersion, error) {
+	now := time.Now().UTC()
+	fields := map[string]any{
+		"review_status": to,
+		"updated_at":    now,
+	}
+	switch to {
+	case enums.TemplateVersionReviewStatusPending:
+		fields["submitted_by"] = submittedBy
+		fields["submitted_at"] = now
+	case enums.TemplateVersionReviewStatusNone:
+		fields["submitted_by"] = nil
+		fields["submitted_at"] = nil
+	}
+
+	q := r.conn(tx).WithContext(ctx).
 		Model(&model.DocumentTemplateVersion{}).
+		Where("id = ? AND template_id = ?", versionID, templateID).
+		Where("status = ?", enums.TemplateVersionStatusDraft).
+		Where("review_status IN ?", from)
+	if tenantID != nil {
+		q = q.Where("tenant_id = ?", *tenantID)
+	}
+	res := q.Updates(fields)
+	if res.Error != nil {
+		return verEntity.TemplateVersion{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		if _, err := r.GetByID(ctx, tx, templateID, versionID, tenantID); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		return verEntity.TemplateVersion{}, apperror.ErrInvalidState
+	}
+	return r.GetByID(ctx, tx, templateID, versionID, tenantID)
+}
+
+func (r *repository) DeprecatePublished(ctx context.Context, tx *gorm.DB, templateID, exceptVersionID int64) ([]verEntity.TemplateVersion, error) {
+	var rows []model.DocumentTemplateVersion
+	err := r.conn(tx).WithContext(ctx).
 		Where("template_id = ? AND id <> ?", templateID, exceptVersionID).
 		Where("is_published = ?", true).
+		Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ids := make([]int64, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	// published_at dipertahankan sebagai riwayat (dipakai untuk rollback).
+	err = r.conn(tx).WithContext(ctx).
+		Model(&model.DocumentTemplateVersion{}).
+		Where("id IN ?", ids).
 		Updates(map[string]any{
-			"is_published": false,
-			"published_at": nil,
+			"status":        enums.TemplateVersionStatusDeprecated,
+			"is_published":  false,
+			"deprecated_at": now,
+			"updated_at":    now,
 		}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]verEntity.TemplateVersion, len(rows))
+	for i := range rows {
+		rows[i].Status = enums.TemplateVersionStatusDeprecated
+		rows[i].IsPublished = false
+		rows[i].DeprecatedAt = &now
+		rows[i].UpdatedAt = now
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
 }
 
-func (r *repository) Publish(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
-	now := time.Now().UTC()
+func (r *repository) SetSchedule(ctx context.Context, tx *gorm.DB, templateID, versionID int64, tenantID *string, at *time.Time) (verEntity.TemplateVersion, error) {
 	q := r.conn(tx).WithContext(ctx).
 		Model(&model.DocumentTemplateVersion{}).
 		Where("id = ? AND template_id = ?", versionID, templateID)
@@ -133,8 +312,8 @@ func (r *repository) Publish(ctx context.Context, tx *gorm.DB, templateID, versi
 		q = q.Where("tenant_id = ?", *tenantID)
 	}
 	res := q.Updates(map[string]any{
-		"is_published": true,
-		"published_at": now,
+		"scheduled_publish_at": at,
+		"updated_at":           time.Now().UTC(),
 	})
 	if res.Error != nil {
 		return verEntity.TemplateVersion{}, res.Error
@@ -144,3 +323,25 @@ func (r *repository) Publish(ctx context.Context, tx *gorm.DB, templateID, versi
 	}
 	return r.GetByID(ctx, tx, templateID, versionID, tenantID)
 }
+
+func (r *repository) ListDueScheduled(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]verEntity.TemplateVersion, error) {
+	var rows []model.DocumentTemplateVersion
+	q := r.conn(tx).WithContext(ctx).
+		Where("scheduled_publish_at IS NOT NULL AND scheduled_publish_at <= ?", now).
+		Where("status IN ?", []enums.TemplateVersionStatus{
+			enums.TemplateVersionStatusDraft,
+			enums.TemplateVersionStatusDeprecated,
+		}).
+		Order("scheduled_publish_at ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]verEntity.TemplateVersion, len(rows))
+	for i := range rows {
+		out[i] = model.ToEntity(&rows[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+// Package actor membawa identitas pemanggil yang sudah diautentikasi (dari API key) lewat context,
+// dipakai audit dan aturan four-eyes. Nilai dari body request tidak pernah dipercaya sebagai actor.
+package actor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HeaderDevActor header identitas saat auth dinonaktifkan (dev mode); diabaikan bila API key aktif.
+const HeaderDevActor = "X-Actor"
+
+type ctxKey struct{}
+
+// With menyimpan actor ke context.
+func With(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, strings.TrimSpace(name))
+}
+
+// From mengembalikan actor dari context; kosong bila request tidak membawa identitas.
+func From(ctx context.Context) string {
+	name, _ := ctx.Value(ctxKey{}).(string)
+	return name
+}
+
+// ParseKey memecah entri konfigurasi API key "nama=key"; tanpa nama, actor diturunkan dari
+// fingerprint key agar tetap stabil tanpa membocorkan key.
+func ParseKey(entry string) (name, key string) {
+	entry = strings.TrimSpace(entry)
+	if i := strings.Index(entry, "="); i > 0 {
+		return strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+	}
+	sum := sha256.Sum256([]byte(entry))
+	return "apikey-" + hex.EncodeToString(sum[:4]), entry
+}
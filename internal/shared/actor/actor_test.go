@@ -0,0 +1,25 @@
+package actor
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	if name, key := ParseKey(" alice = s3cret "); name != "alice" || key != "s3cret" {
+		t.Errorf("ParseKey named = %q, %q", name, key)
+	}
+	name, key := ParseKey("s3cret")
+	if key != "s3cret" || len(name) != len("apikey-")+8 {
+		t.Errorf("ParseKey plain = %q, %q", name, key)
+	}
+	if other, _ := ParseKey("other"); other == name {
+		t.Error("different keys share an actor")
+	}
+	if got := From(With(context.Background(), " bob ")); got != "bob" {
+		t.Errorf("From = %q", got)
+	}
+	if got := From(context.Background()); got != "" {
+		t.Errorf("From(empty) = %q", got)
+	}
+}
@@ -8,6 +8,7 @@ var (
 	ErrInvalidInput  = errors.New("invalid input")
 	ErrInvalidState  = errors.New("invalid state")
 	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
 )
 
 type APIError struct {
@@ -0,0 +1,228 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Entry satu dokumen dalam arsip beserta atribut untuk layout dan manifest.
+type Entry struct {
+	DocumentID      int64
+	RequestID       string
+	TemplateCode    string
+	TemplateVersion int
+	CreatedAt       time.Time
+	Metadata        map[string]any
+	FileName        string
+	Data            []byte
+	// Encrypted file PDF terproteksi password; disimpan apa adanya dan ditandai di manifest.
+	Encrypted bool
+}
+
+// ManifestItem satu baris manifest.
+type ManifestItem struct {
+	Path            string `json:"path"`
+	DocumentID      int64  `json:"document_id"`
+	RequestID       string `json:"request_id"`
+	TemplateCode    string `json:"template_code"`
+	TemplateVersion int    `json:"template_version"`
+	Size            int    `json:"size"`
+	SHA256          string `json:"sha256"`
+	Encrypted       bool   `json:"encrypted"`
+}
+
+type file struct {
+	name     string
+	data     []byte
+	modified time.Time
+}
+
+// Writer menulis arsip langsung ke io.Writer satu entry demi satu entry sehingga hanya satu
+// dokumen yang perlu ada di memori. Nama file ganda dalam folder yang sama diberi akhiran
+// " (2)", " (3)", ...; manifest (bila diminta) ditulis di root arsip saat Close.
+type Writer struct {
+	opts         Options
+	now          time.Time
+	manifestName string
+	names        *namer
+	items        []ManifestItem
+	lvl          int
+	zw           *zip.Writer
+	gz           *gzip.Writer
+	tw           *tar.Writer
+}
+
+// NewWriter menyiapkan Writer arsip ke w sesuai opts.
+func NewWriter(w io.Writer, opts Options, now time.Time) (*Writer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	a := &Writer{opts: opts, now: now}
+	if opts.Manifest != "" {
+		a.manifestName = "manifest." + opts.Manifest
+	}
+	a.names = newNamer(a.manifestName)
+	if opts.Extension() == FormatTarGz {
+		gz, err := gzip.NewWriterLevel(w, level(opts, gzip.DefaultCompression))
+		if err != nil {
+			return nil, fmt.Errorf("tar.gz: %w", err)
+		}
+		a.gz, a.tw = gz, tar.NewWriter(gz)
+		return a, nil
+	}
+	a.lvl = level(opts, flate.DefaultCompression)
+	a.zw = zip.NewWriter(w)
+	lvl := a.lvl
+	a.zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, lvl)
+	})
+	return a, nil
+}
+
+// Add menulis satu entry ke arsip; e.Data boleh dibuang setelah Add kembali.
+func (a *Writer) Add(e Entry) error {
+	base := e.FileName
+	if base == "" {
+		base = strconv.FormatInt(e.DocumentID, 10)
+	}
+	name := SafeName(base)
+	if dir := folder(a.opts.Layout, e); dir != "" {
+		name = dir + "/" + name
+	}
+	name = a.names.unique(name)
+	sum := sha256.Sum256(e.Data)
+	a.items = append(a.items, ManifestItem{
+		Path: name, DocumentID: e.DocumentID, RequestID: e.RequestID,
+		TemplateCode: e.TemplateCode, TemplateVersion: e.TemplateVersion,
+		Size: len(e.Data), SHA256: hex.EncodeToString(sum[:]), Encrypted: e.Encrypted,
+	})
+	return a.write(file{name: name, data: e.Data, modified: e.CreatedAt})
+}
+
+// Close menulis manifest lalu menutup arsip. Writer tujuan tidak ikut ditutup.
+func (a *Writer) Close() error {
+	if a.manifestName != "" {
+		data, err := manifest(a.items, a.opts.Manifest, a.now)
+		if err != nil {
+			return err
+		}
+		if err := a.write(file{name: a.manifestName, data: data, modified: a.now}); err != nil {
+			return err
+		}
+	}
+	if a.tw != nil {
+		if err := a.tw.Close(); err != nil {
+			return fmt.Errorf("tar.gz: close tar: %w", err)
+		}
+		if err := a.gz.Close(); err != nil {
+			return fmt.Errorf("tar.gz: close gzip: %w", err)
+		}
+		return nil
+	}
+	if err := a.zw.Close(); err != nil {
+		return fmt.Errorf("zip: close writer: %w", err)
+	}
+	return nil
+}
+
+func (a *Writer) write(f file) error {
+	if a.tw != nil {
+		return writeTar(a.tw, f)
+	}
+	return writeZip(a.zw, f, a.lvl, a.opts.Password)
+}
+
+// Build membuat arsip dari entries di memori; untuk arsip besar gunakan NewWriter ke file.
+func Build(entries []Entry, opts Options, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	a, err := NewWriter(&buf, opts, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := a.Add(e); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func manifest(items []ManifestItem, format string, now time.Time) ([]byte, error) {
+	if format == ManifestCSV {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write([]string{"path", "document_id", "request_id", "template_code", "template_version", "size", "sha256", "encrypted"})
+		for _, it := range items {
+			_ = w.Write([]string{
+				it.Path, strconv.FormatInt(it.DocumentID, 10), it.RequestID, it.TemplateCode,
+				strconv.Itoa(it.TemplateVersion), strconv.Itoa(it.Size), it.SHA256, strconv.FormatBool(it.Encrypted),
+			})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+	return json.MarshalIndent(struct {
+		GeneratedAt time.Time      `json:"generated_at"`
+		Count       int            `json:"count"`
+		Documents   []ManifestItem `json:"documents"`
+	}{now.UTC(), len(items), items}, "", "  ")
+}
+
+func level(opts Options, def int) int {
+	if opts.CompressionLevel != nil {
+		return *opts.CompressionLevel
+	}
+	return def
+}
+
+func writeZip(zw *zip.Writer, f file, lvl int, password string) error {
+	if password != "" {
+		if err := writeEncrypted(zw, f, lvl, password); err != nil {
+			return fmt.Errorf("zip: entry %s: %w", f.name, err)
+		}
+		return nil
+	}
+	fh := &zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: f.modified}
+	if lvl == flate.NoCompression {
+		fh.Method = zip.Store
+	}
+	fw, err := zw.CreateHeader(fh)
+	if err != nil {
+		return fmt.Errorf("zip: create entry %s: %w", f.name, err)
+	}
+	if _, err := fw.Write(f.data); err != nil {
+		return fmt.Errorf("zip: write entry %s: %w", f.name, err)
+	}
+	return nil
+}
+
+func writeTar(tw *tar.Writer, f file) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     f.name,
+		Mode:     0o644,
+		Size:     int64(len(f.data)),
+		ModTime:  f.modified,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("tar.gz: header %s: %w", f.name, err)
+	}
+	if _, err := tw.Write(f.data); err != nil {
+		return fmt.Errorf("tar.gz: write %s: %w", f.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var created = time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+func entries() []Entry {
+	return []Entry{
+		{DocumentID: 1, RequestID: "r1", TemplateCode: "INVOICE", TemplateVersion: 2, CreatedAt: created,
+			Metadata: map[string]any{"branch": "JKT/01"}, FileName: "invoice.pdf", Data: []byte("one")},
+		{DocumentID: 2, RequestID: "r2", TemplateCode: "INVOICE", TemplateVersion: 2, CreatedAt: created,
+			Metadata: map[string]any{"branch": "JKT/01"}, FileName: "INVOICE.pdf", Data: []byte("two")},
+		{DocumentID: 3, RequestID: "r3", TemplateCode: "SLIP", TemplateVersion: 1, CreatedAt: created,
+			FileName: "../slip.pdf", Data: []byte("three"), Encrypted: true},
+	}
+}
+
+func readZip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		out[f.Name] = b
+	}
+	return out
+}
+
+func TestBuildZipLayoutDedupeManifest(t *testing.T) {
+	data, err := Build(entries(), Options{Layout: "{template_code}/{year}-{month}/{metadata.branch}", Manifest: ManifestJSON}, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := readZip(t, data)
+	for name, want := range map[string]string{
+		"INVOICE/2026-03/JKT_01/invoice.pdf":     "one",
+		"INVOICE/2026-03/JKT_01/INVOICE (2).pdf": "two",
+		"SLIP/2026-03/unknown/_slip.pdf":         "three",
+	} {
+		if string(files[name]) != want {
+			t.Errorf("%s = %q, want %q (files %v)", name, files[name], want, keys(files))
+		}
+	}
+	var m struct {
+		Count     int            `json:"count"`
+		Documents []ManifestItem `json:"documents"`
+	}
+	if err := json.Unmarshal(files["manifest.json"], &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Count != 3 || m.Documents[1].DocumentID != 2 || m.Documents[1].Path != "INVOICE/2026-03/JKT_01/INVOICE (2).pdf" {
+		t.Errorf("manifest = %+v", m)
+	}
+	if m.Documents[0].SHA256 != "7692c3ad3540bb803c020b3aee66cd8887123234ea0c6e7143c0add73ff431ed" {
+		t.Errorf("sha256 = %s", m.Documents[0].SHA256)
+	}
+	if m.Documents[0].Encrypted || !m.Documents[2].Encrypted {
+		t.Errorf("encrypted flags = %v, %v", m.Documents[0].Encrypted, m.Documents[2].Encrypted)
+	}
+}
+
+func TestBuildManifestNameReserved(t *testing.T) {
+	es := []Entry{{DocumentID: 1, FileName: "manifest.csv", Data: []byte("x")}}
+	data, err := Build(es, Options{Manifest: ManifestCSV, CompressionLevel: new(int)}, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := readZip(t, data)
+	if string(files["manifest (2).csv"]) != "x" {
+		t.Errorf("files = %v", keys(files))
+	}
+	if !strings.HasPrefix(string(files["manifest.csv"]), "path,document_id,request_id,") {
+		t.Errorf("manifest.csv = %q", files["manifest.csv"])
+	}
+}
+
+func TestBuildTarGz(t *testing.T) {
+	data, err := Build(entries(), Options{Format: FormatTarGz, Layout: "{document_id}"}, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, h.Name)
+	}
+	if strings.Join(names, ",") != "1/invoice.pdf,2/INVOICE.pdf,3/_slip.pdf" {
+		t.Errorf("names = %v", names)
+	}
+}
+
+func TestBuildEncryptedZip(t *testing.T) {
+	data, err := Build(entries()[:1], Options{Password: "rahasia"}, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := zr.File[0]
+	if f.Method != methodWinZipAES || f.Flags&flagEncrypted == 0 {
+		t.Fatalf("method = %d flags = %x", f.Method, f.Flags)
+	}
+	rc, err := f.OpenRaw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(rc)
+	plain, ok := decryptAES(t, raw, "rahasia")
+	if !ok {
+		t.Fatal("MAC or password verifier mismatch")
+	}
+	out, _ := io.ReadAll(flate.NewReader(bytes.NewReader(plain)))
+	if string(out) != "one" {
+		t.Errorf("decrypted = %q", out)
+	}
+	if _, ok := decryptAES(t, raw, "salah"); ok {
+		t.Error("wrong password accepted")
+	}
+}
+
+// decryptAES kebalikan encryptAES untuk verifikasi.
+func decryptAES(t *testing.T, raw []byte, password string) ([]byte, bool) {
+	t.Helper()
+	salt, pwv := raw[:aesSaltSize], raw[aesSaltSize:aesSaltSize+2]
+	ct, mac := raw[aesSaltSize+2:len(raw)-aesMACSize], raw[len(raw)-aesMACSize:]
+	key, err := pbkdf2.Key(sha1.New, password, salt, aesIterations, 2*aesKeySize+2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key[2*aesKeySize:], pwv) {
+		return nil, false
+	}
+	h := hmac.New(sha1.New, key[aesKeySize:2*aesKeySize])
+	h.Write(ct)
+	if !hmac.Equal(h.Sum(nil)[:aesMACSize], mac) {
+		return nil, false
+	}
+	block, _ := aes.NewCipher(key[:aesKeySize])
+	out := append([]byte(nil), ct...)
+	var counter, stream [aes.BlockSize]byte
+	for i := 0; i < len(out); i += aes.BlockSize {
+		counter[0]++
+		block.Encrypt(stream[:], counter[:])
+		for j := i; j < len(out) && j < i+aes.BlockSize; j++ {
+			out[j] ^= stream[j-i]
+		}
+	}
+	return out, true
+}
+
+func TestOptionsValidate(t *testing.T) {
+	nine, ten := 9, 10
+	valid := []Options{
+		{},
+		{Format: FormatTarGz, Manifest: ManifestCSV, CompressionLevel: &nine},
+		{Layout: "{template_code}/{date}/{metadata.customer-id}", Password: "x"},
+	}
+	for _, o := range valid {
+		if err := o.Validate(); err != nil {
+			t.Errorf("%+v: %v", o, err)
+		}
+	}
+	invalid := []Options{
+		{Format: "rar"},
+		{Manifest: "xml"},
+		{CompressionLevel: &ten},
+		{Format: FormatTarGz, Password: "x"},
+		{Layout: "{customer}"},
+		{Layout: "{metadata.}"},
+		{Layout: "{template_code"},
+	}
+	for _, o := range invalid {
+		if err := o.Validate(); err == nil {
+			t.Errorf("%+v: expected error", o)
+		}
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	for in, want := range map[string]string{
+		"a:b*c.pdf": "a_b_c.pdf",
+		" .. ":      "_",
+		"..":        "_",
+		"tab\there": "tab_here",
+	} {
+		if got := SafeName(in); got != want {
+			t.Errorf("SafeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func keys(m map[string][]byte) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package archive
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// folder mengisi placeholder layout dengan atribut entry. Setiap segmen dibersihkan sehingga nilai
+// metadata tidak bisa keluar dari folder arsip; nilai kosong menjadi "unknown".
+func folder(layout string, e Entry) string {
+	if layout == "" {
+		return ""
+	}
+	created := e.CreatedAt.UTC()
+	expanded := placeholderRe.ReplaceAllStringFunc(layout, func(m string) string {
+		name := m[1 : len(m)-1]
+		var v string
+		switch name {
+		case "template_code":
+			v = e.TemplateCode
+		case "template_version":
+			v = strconv.Itoa(e.TemplateVersion)
+		case "document_id":
+			v = strconv.FormatInt(e.DocumentID, 10)
+		case "request_id":
+			v = e.RequestID
+		case "date":
+			v = created.Format("2006-01-02")
+		case "year":
+			v = created.Format("2006")
+		case "month":
+			v = created.Format("01")
+		case "day":
+			v = created.Format("02")
+		default:
+			if key, ok := strings.CutPrefix(name, "metadata."); ok && e.Metadata != nil {
+				if x, ok := e.Metadata[key]; ok && x != nil {
+					v = fmt.Sprint(x)
+				}
+			}
+		}
+		if strings.TrimSpace(v) == "" {
+			return "unknown"
+		}
+		// Garis miring di dalam nilai bukan pemisah folder.
+		return strings.NewReplacer("/", "_", `\`, "_").Replace(v)
+	})
+	var segs []string
+	for _, seg := range strings.Split(expanded, "/") {
+		if strings.TrimSpace(seg) == "" {
+			continue
+		}
+		segs = append(segs, SafeName(seg))
+	}
+	return strings.Join(segs, "/")
+}
+
+// SafeName membersihkan satu segmen path: karakter yang tidak valid di Windows / POSIX dan
+// karakter kontrol menjadi "_", spasi dan titik di tepi dibuang. Hasil kosong menjadi "_".
+func SafeName(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 0x20, r == 0x7f:
+			b.WriteRune('_')
+		case strings.ContainsRune(`<>:"/\|?*`, r):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	out := strings.Trim(b.String(), " .")
+	if out == "" {
+		return "_"
+	}
+	return out
+}
+
+// namer membuat path unik dalam arsip (case-insensitive) dengan akhiran " (2)", " (3)", ...
+type namer struct {
+	used map[string]bool
+}
+
+func newNamer(reserved ...string) *namer {
+	n := &namer{used: make(map[string]bool)}
+	for _, r := range reserved {
+		n.used[strings.ToLower(r)] = true
+	}
+	return n
+}
+
+func (n *namer) unique(p string) string {
+	if !n.used[strings.ToLower(p)] {
+		n.used[strings.ToLower(p)] = true
+		return p
+	}
+	dir, base := path.Split(p)
+	ext := path.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	for i := 2; ; i++ {
+		c := fmt.Sprintf("%s%s (%d)%s", dir, stem, i, ext)
+		if !n.used[strings.ToLower(c)] {
+			n.used[strings.ToLower(c)] = true
+			return c
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package archive
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Format arsip.
+const (
+	FormatZip   = "zip"
+	FormatTarGz = "tar.gz"
+)
+
+// Format manifest; kosong berarti tanpa manifest.
+const (
+	ManifestJSON = "json"
+	ManifestCSV  = "csv"
+)
+
+const (
+	maxLayoutLength   = 200
+	maxPasswordLength = 128
+)
+
+// Options opsi pembuatan arsip. Nilai nol menghasilkan ZIP deflate tanpa folder dan manifest.
+type Options struct {
+	Format string `json:"format,omitempty"`
+	// Layout template folder tiap file, mis. "{template_code}/{year}-{month}" atau "{metadata.branch}".
+	Layout string `json:"layout,omitempty"`
+	// Manifest menambah manifest.json / manifest.csv berisi path, id dokumen dan sha256 tiap file.
+	Manifest string `json:"manifest,omitempty"`
+	// CompressionLevel 0 (tanpa kompresi) sampai 9; nil memakai level default.
+	CompressionLevel *int `json:"compression_level,omitempty"`
+	// Password mengenkripsi setiap entry ZIP dengan AES-256 (WinZip AE-2). Tidak berlaku untuk tar.gz.
+	Password string `json:"password,omitempty"`
+}
+
+var placeholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Placeholder layout selain "metadata.<key>".
+var placeholders = map[string]bool{
+	"template_code":    true,
+	"template_version": true,
+	"document_id":      true,
+	"request_id":       true,
+	"date":             true,
+	"year":             true,
+	"month":            true,
+	"day":              true,
+}
+
+// Validate memeriksa format, manifest, level kompresi, password dan placeholder layout.
+func (o Options) Validate() error {
+	switch o.Format {
+	case "", FormatZip, FormatTarGz:
+	default:
+		return fmt.Errorf("unknown format %q (zip, tar.gz)", o.Format)
+	}
+	switch o.Manifest {
+	case "", ManifestJSON, ManifestCSV:
+	default:
+		return fmt.Errorf("unknown manifest %q (json, csv)", o.Manifest)
+	}
+	if l := o.CompressionLevel; l != nil && (*l < 0 || *l > 9) {
+		return fmt.Errorf("compression_level must be between 0 and 9")
+	}
+	if o.Password != "" {
+		if o.Extension() != FormatZip {
+			return fmt.Errorf("password is only supported for zip")
+		}
+		if len(o.Password) > maxPasswordLength {
+			return fmt.Errorf("password exceeds %d characters", maxPasswordLength)
+		}
+	}
+	if len(o.Layout) > maxLayoutLength {
+		return fmt.Errorf("layout exceeds %d characters", maxLayoutLength)
+	}
+	for _, m := range placeholderRe.FindAllStringSubmatch(o.Layout, -1) {
+		name := m[1]
+		if key, ok := strings.CutPrefix(name, "metadata."); ok {
+			if strings.TrimSpace(key) == "" {
+				return fmt.Errorf("layout: empty metadata key")
+			}
+			continue
+		}
+		if !placeholders[name] {
+			return fmt.Errorf("layout: unknown placeholder {%s}", name)
+		}
+	}
+	if rest := placeholderRe.ReplaceAllString(o.Layout, ""); strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("layout: unbalanced braces")
+	}
+	return nil
+}
+
+// Extension ekstensi file arsip tanpa titik.
+func (o Options) Extension() string {
+	if o.Format == FormatTarGz {
+		return FormatTarGz
+	}
+	return FormatZip
+}
+
+// ContentType MIME type file arsip.
+func (o Options) ContentType() string {
+	if o.Format == FormatTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
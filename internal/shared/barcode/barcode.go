@@ -0,0 +1,126 @@
+// Package barcode encoder barcode pure-Go (Code128, EAN-13, QR, DataMatrix) dengan output SVG atau PNG.
+package barcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Jenis barcode yang didukung.
+const (
+	KindCode128    = "code128"
+	KindEAN13      = "ean13"
+	KindQR         = "qr"
+	KindDataMatrix = "datamatrix"
+)
+
+// Matrix modul barcode (true = gelap). Barcode 1D punya H = 1.
+type Matrix struct {
+	W, H int
+	bits []bool
+}
+
+func newMatrix(w, h int) Matrix {
+	return Matrix{W: w, H: h, bits: make([]bool, w*h)}
+}
+
+func (m Matrix) At(x, y int) bool { return m.bits[y*m.W+x] }
+
+func (m Matrix) set(x, y int, dark bool) { m.bits[y*m.W+x] = dark }
+
+// Linear true untuk barcode 1D (bar ditarik setinggi Height saat render).
+func (m Matrix) Linear() bool { return m.H == 1 }
+
+// Options pengaturan render; nilai 0 memakai default per jenis barcode.
+type Options struct {
+	Size      int    // sisi barcode 2D dalam px
+	Width     int    // lebar barcode 1D dalam px
+	Height    int    // tinggi barcode 1D dalam px
+	QuietZone int    // margin dalam modul; -1 = default jenis barcode
+	ECC       string // QR: L, M, Q, H
+	Format    string // svg (default) atau png
+}
+
+// ParseOptions membaca opsi "key=value" dari template, mis. "size=160" "ecc=H" "quiet=2" "format=png".
+func ParseOptions(args ...string) (Options, error) {
+	o := Options{QuietZone: -1}
+	for _, a := range args {
+		k, v, ok := strings.Cut(a, "=")
+		if !ok {
+			return o, fmt.Errorf("barcode option %q must be key=value", a)
+		}
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+		switch k {
+		case "ecc":
+			o.ECC = strings.ToUpper(v)
+		case "format":
+			o.Format = strings.ToLower(v)
+		case "size", "width", "height", "quiet":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return o, fmt.Errorf("barcode option %s must be a non-negative integer", k)
+			}
+			switch k {
+			case "size":
+				o.Size = n
+			case "width":
+				o.Width = n
+			case "height":
+				o.Height = n
+			case "quiet":
+				o.QuietZone = n
+			}
+		default:
+			return o, fmt.Errorf("unknown barcode option %q", k)
+		}
+	}
+	if o.Format != "" && o.Format != "svg" && o.Format != "png" {
+		return o, fmt.Errorf("barcode format must be svg or png")
+	}
+	return o, nil
+}
+
+// Encode mengubah value menjadi matrix modul sesuai jenis barcode.
+func Encode(kind, value string, o Options) (Matrix, error) {
+	switch strings.ToLower(kind) {
+	case KindCode128:
+		return encodeCode128(value)
+	case KindEAN13:
+		return encodeEAN13(value)
+	case KindQR:
+		return encodeQR(value, o.ECC)
+	case KindDataMatrix:
+		return encodeDataMatrix(value)
+	default:
+		return Matrix{}, fmt.Errorf("unknown barcode type %q", kind)
+	}
+}
+
+// Render meng-encode lalu merender barcode; mengembalikan content type dan isi file.
+func Render(kind, value string, o Options) (string, []byte, error) {
+	m, err := Encode(kind, value, o)
+	if err != nil {
+		return "", nil, err
+	}
+	if o.QuietZone < 0 {
+		o.QuietZone = defaultQuietZone(kind)
+	}
+	if o.Format == "png" {
+		data, err := m.PNG(o)
+		return "image/png", data, err
+	}
+	return "image/svg+xml", m.SVG(o), nil
+}
+
+// defaultQuietZone margin minimum yang disarankan spesifikasi masing-masing simbol.
+func defaultQuietZone(kind string) int {
+	switch strings.ToLower(kind) {
+	case KindQR:
+		return 4
+	case KindDataMatrix:
+		return 1
+	default:
+		return 10
+	}
+}
@@ -0,0 +1,99 @@
+package barcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonVectors(t *testing.T) {
+	// "HELLO WORLD" QR 1-Q (data codeword dari encoding alphanumeric).
+	mode, bits, n := qrSegment("HELLO WORLD")
+	if mode != qrAlnum || n != 11 {
+		t.Fatalf("expected alphanumeric segment of 11 chars, got mode %v n %d", mode, n)
+	}
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236}
+	if got := qrField.ecc(data, 13, 0); !bytes.Equal(got, []byte{168, 72, 22, 82, 217, 54, 156, 0, 46, 15, 180, 122, 16}) {
+		t.Errorf("qr ecc = %v", got)
+	}
+	if len(bits) != 61 {
+		t.Errorf("alphanumeric bits = %d, want 61", len(bits))
+	}
+
+	// "123456" DataMatrix 10x10.
+	cw := dmASCII([]byte("123456"))
+	if !bytes.Equal(cw, []byte{142, 164, 186}) {
+		t.Fatalf("datamatrix codewords = %v", cw)
+	}
+	if got := dmField.ecc(cw, 5, 1); !bytes.Equal(got, []byte{114, 25, 5, 88, 102}) {
+		t.Errorf("datamatrix ecc = %v", got)
+	}
+}
+
+func TestLinearSymbols(t *testing.T) {
+	for _, p := range code128Patterns[:106] {
+		sum := 0
+		for _, r := range p {
+			sum += int(r - '0')
+		}
+		if sum != 11 {
+			t.Fatalf("code128 pattern %s has width %d", p, sum)
+		}
+	}
+	// Start B, "Wikipedia" ... checksum dihitung dari nilai simbol.
+	if got := code128Values("Wikipedia"); got[0] != c128StartB || len(got) != 10 {
+		t.Errorf("code128 values = %v", got)
+	}
+	if got := code128Values("123456"); len(got) != 4 || got[0] != c128StartC || got[1] != 12 {
+		t.Errorf("code128 digits should use code set C, got %v", got)
+	}
+	if m, _ := encodeCode128("ABC-123"); m.W%11 != 2 {
+		t.Errorf("code128 width %d is not 11n+2", m.W)
+	}
+
+	if d := EAN13CheckDigit("400638133393"); d != 1 {
+		t.Errorf("ean13 check digit = %d, want 1", d)
+	}
+	m, err := encodeEAN13("4006381333931")
+	if err != nil || m.W != 95 {
+		t.Fatalf("ean13 width = %d, err %v", m.W, err)
+	}
+	if _, err := encodeEAN13("4006381333932"); err == nil {
+		t.Error("ean13 should reject wrong check digit")
+	}
+}
+
+func TestMatrixSymbols(t *testing.T) {
+	q, err := encodeQR("https://example.com/invoice/INV-2026-0001", "M")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.W != 29 { // versi 3
+		t.Errorf("qr size = %d, want 29", q.W)
+	}
+	// finder pattern kiri atas: baris pertama 7 modul gelap lalu separator terang.
+	for x := 0; x < 8; x++ {
+		if q.At(x, 0) != (x < 7) {
+			t.Fatalf("qr finder pattern broken at x=%d", x)
+		}
+	}
+
+	d, err := encodeDataMatrix("123456")
+	if err != nil || d.W != 10 {
+		t.Fatalf("datamatrix size = %d, err %v", d.W, err)
+	}
+	for i := 0; i < 10; i++ {
+		if !d.At(0, i) || !d.At(i, 9) || d.At(i, 0) != (i%2 == 0) {
+			t.Fatalf("datamatrix finder/timing broken at %d", i)
+		}
+	}
+
+	_, svg, err := Render(KindQR, "hello", Options{QuietZone: -1, Size: 120})
+	if err != nil || !strings.HasPrefix(string(svg), "<svg") || !strings.Contains(string(svg), `viewBox="0 0 29 29"`) {
+		t.Errorf("qr svg = %s, err %v", svg, err)
+	}
+	ct, img, err := Render(KindDataMatrix, "ABC", Options{QuietZone: -1, Format: "png"})
+	if err != nil || ct != "image/png" || !bytes.HasPrefix(img, []byte("\x89PNG")) {
+		t.Errorf("png render failed: %s %v", ct, err)
+	}
+}
@@ -0,0 +1,155 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// code128Patterns lebar bar/spasi tiap simbol (nilai 0-105, 106 = stop).
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	c128CodeC  = 99
+	c128CodeB  = 100
+	c128CodeA  = 101
+	c128StartA = 103
+	c128StartB = 104
+	c128StartC = 105
+	c128Stop   = 106
+)
+
+// encodeCode128 memilih code set otomatis: C untuk deret digit panjang, A untuk karakter kontrol, selain itu B.
+func encodeCode128(value string) (Matrix, error) {
+	if value == "" {
+		return Matrix{}, errors.New("code128: value is empty")
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] > 127 {
+			return Matrix{}, fmt.Errorf("code128: non-ASCII character at position %d", i)
+		}
+	}
+	codes := code128Values(value)
+	sum := codes[0]
+	for i := 1; i < len(codes); i++ {
+		sum += i * codes[i]
+	}
+	codes = append(codes, sum%103, c128Stop)
+
+	var widths []int
+	for _, c := range codes {
+		for _, r := range code128Patterns[c] {
+			widths = append(widths, int(r-'0'))
+		}
+	}
+	return linear(widths), nil
+}
+
+func code128Values(s string) []int {
+	digitRun := func(i int) int {
+		n := 0
+		for i+n < len(s) && s[i+n] >= '0' && s[i+n] <= '9' {
+			n++
+		}
+		return n
+	}
+	// Set C hanya menguntungkan untuk >= 4 digit (>= 6 di tengah data).
+	useC := func(i int) bool {
+		n := digitRun(i)
+		return n >= 6 || (n >= 4 && (i == 0 || i+n == len(s)))
+	}
+	needA := func(i int) bool {
+		for ; i < len(s) && !useC(i); i++ {
+			if s[i] < 32 {
+				return true
+			}
+			if s[i] >= 96 {
+				return false
+			}
+		}
+		return false
+	}
+
+	var out []int
+	set := 0
+	switch {
+	case useC(0):
+		out, set = append(out, c128StartC), c128CodeC
+	case needA(0):
+		out, set = append(out, c128StartA), c128CodeA
+	default:
+		out, set = append(out, c128StartB), c128CodeB
+	}
+	for i := 0; i < len(s); {
+		if set == c128CodeC {
+			if digitRun(i) >= 2 {
+				out = append(out, int(s[i]-'0')*10+int(s[i+1]-'0'))
+				i += 2
+				continue
+			}
+			set = c128CodeB
+			if needA(i) {
+				set = c128CodeA
+			}
+			out = append(out, set)
+			continue
+		}
+		if useC(i) {
+			n := digitRun(i)
+			if n%2 == 1 {
+				// digit ganjil dikeluarkan di set sekarang agar sisanya genap.
+				out = append(out, code128Char(s[i], set))
+				i++
+			}
+			set = c128CodeC
+			out = append(out, set)
+			continue
+		}
+		c := s[i]
+		if set == c128CodeB && c < 32 {
+			set = c128CodeA
+			out = append(out, set)
+		} else if set == c128CodeA && c >= 96 {
+			set = c128CodeB
+			out = append(out, set)
+		}
+		out = append(out, code128Char(c, set))
+		i++
+	}
+	return out
+}
+
+func code128Char(c byte, set int) int {
+	if set == c128CodeA && c < 32 {
+		return int(c) + 64
+	}
+	return int(c) - 32
+}
+
+// linear membangun matrix 1D dari lebar bar/spasi bergantian, dimulai dengan bar.
+func linear(widths []int) Matrix {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	m := newMatrix(total, 1)
+	x := 0
+	for i, w := range widths {
+		for j := 0; j < w; j++ {
+			m.set(x+j, 0, i%2 == 0)
+		}
+		x += w
+	}
+	return m
+}
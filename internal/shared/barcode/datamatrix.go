@@ -0,0 +1,217 @@
+package barcode
+
+import "errors"
+
+// dmSymbol ukuran simbol ECC200 persegi: sisi, sisi data region, region per sisi, codeword data/ECC, jumlah blok.
+type dmSymbol struct {
+	size, region, regions, data, ecc, blocks int
+}
+
+var dmSymbols = []dmSymbol{
+	{10, 8, 1, 3, 5, 1}, {12, 10, 1, 5, 7, 1}, {14, 12, 1, 8, 10, 1}, {16, 14, 1, 12, 12, 1},
+	{18, 16, 1, 18, 14, 1}, {20, 18, 1, 22, 18, 1}, {22, 20, 1, 30, 20, 1}, {24, 22, 1, 36, 24, 1},
+	{26, 24, 1, 44, 28, 1}, {32, 14, 2, 62, 36, 1}, {36, 16, 2, 86, 42, 1}, {40, 18, 2, 114, 48, 1},
+	{44, 20, 2, 144, 56, 1}, {48, 22, 2, 174, 68, 1}, {52, 24, 2, 204, 84, 2}, {64, 14, 4, 280, 112, 2},
+	{72, 16, 4, 368, 144, 4}, {80, 18, 4, 456, 192, 4}, {88, 20, 4, 576, 224, 4}, {96, 22, 4, 696, 272, 4},
+	{104, 24, 4, 816, 336, 6}, {120, 18, 6, 1050, 408, 6}, {132, 20, 6, 1304, 496, 8},
+}
+
+// encodeDataMatrix memakai encodation ASCII (pasangan digit dipadatkan, byte > 127 lewat upper shift).
+func encodeDataMatrix(value string) (Matrix, error) {
+	if value == "" {
+		return Matrix{}, errors.New("datamatrix: value is empty")
+	}
+	cw := dmASCII([]byte(value))
+	var sym dmSymbol
+	for _, s := range dmSymbols {
+		if len(cw) <= s.data {
+			sym = s
+			break
+		}
+	}
+	if sym.size == 0 {
+		return Matrix{}, errors.New("datamatrix: value is too long")
+	}
+	cw = dmPad(cw, sym.data)
+	cw = dmAddECC(cw, sym)
+
+	n := sym.region * sym.regions
+	grid := dmPlace(cw, n, n)
+	m := newMatrix(sym.size, sym.size)
+	step := sym.region + 2
+	for ry := 0; ry < sym.regions; ry++ {
+		for rx := 0; rx < sym.regions; rx++ {
+			ox, oy := rx*step, ry*step
+			// finder "L" kiri & bawah, timing bergantian di atas & kanan.
+			for i := 0; i < step; i++ {
+				m.set(ox, oy+i, true)
+				m.set(ox+i, oy+step-1, true)
+				m.set(ox+i, oy, i%2 == 0)
+				m.set(ox+step-1, oy+i, i%2 == 1)
+			}
+		}
+	}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			x := c/sym.region*step + 1 + c%sym.region
+			y := r/sym.region*step + 1 + r%sym.region
+			m.set(x, y, grid[r][c])
+		}
+	}
+	return m, nil
+}
+
+func dmASCII(b []byte) []byte {
+	var out []byte
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case i+1 < len(b) && isDigit(c) && isDigit(b[i+1]):
+			out = append(out, 130+(c-'0')*10+(b[i+1]-'0'))
+			i++
+		case c < 128:
+			out = append(out, c+1)
+		default:
+			out = append(out, 235, c-127)
+		}
+	}
+	return out
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// dmPad mengisi sisa kapasitas: pad pertama 129, selanjutnya 129 teracak (253-state).
+func dmPad(cw []byte, capacity int) []byte {
+	if len(cw) < capacity {
+		cw = append(cw, 129)
+	}
+	for len(cw) < capacity {
+		pos := len(cw) + 1
+		v := 129 + (149*pos)%253 + 1
+		if v > 254 {
+			v -= 254
+		}
+		cw = append(cw, byte(v))
+	}
+	return cw
+}
+
+// dmAddECC menghitung ECC per blok; codeword blok b ada di indeks b, b+blocks, ...
+func dmAddECC(data []byte, sym dmSymbol) []byte {
+	eccPer := sym.ecc / sym.blocks
+	out := make([]byte, sym.data+sym.ecc)
+	copy(out, data)
+	for b := 0; b < sym.blocks; b++ {
+		var block []byte
+		for i := b; i < sym.data; i += sym.blocks {
+			block = append(block, data[i])
+		}
+		for j, e := range dmField.ecc(block, eccPer, 1) {
+			out[sym.data+b+j*sym.blocks] = e
+		}
+	}
+	return out
+}
+
+// dmPlace penempatan codeword ECC200 (ISO/IEC 16022 Annex F) pada matrix data nrow x ncol.
+func dmPlace(cw []byte, nrow, ncol int) [][]bool {
+	grid := make([][]int, nrow)
+	for i := range grid {
+		grid[i] = make([]int, ncol)
+		for j := range grid[i] {
+			grid[i][j] = -1
+		}
+	}
+	module := func(row, col, chr, bit int) {
+		if row < 0 {
+			row += nrow
+			col += 4 - (nrow+4)%8
+		}
+		if col < 0 {
+			col += ncol
+			row += 4 - (ncol+4)%8
+		}
+		v := 0
+		if chr < len(cw) && (cw[chr]>>(8-bit))&1 == 1 {
+			v = 1
+		}
+		grid[row][col] = v
+	}
+	utah := func(row, col, chr int) {
+		module(row-2, col-2, chr, 1)
+		module(row-2, col-1, chr, 2)
+		module(row-1, col-2, chr, 3)
+		module(row-1, col-1, chr, 4)
+		module(row-1, col, chr, 5)
+		module(row, col-2, chr, 6)
+		module(row, col-1, chr, 7)
+		module(row, col, chr, 8)
+	}
+	corner := func(chr int, pos [8][2]int) {
+		for i, p := range pos {
+			module(p[0], p[1], chr, i+1)
+		}
+	}
+
+	chr, row, col := 0, 4, 0
+	for {
+		if row == nrow && col == 0 {
+			corner(chr, [8][2]int{{nrow - 1, 0}, {nrow - 1, 1}, {nrow - 1, 2}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}, {2, ncol - 1}, {3, ncol - 1}})
+			chr++
+		}
+		if row == nrow-2 && col == 0 && ncol%4 != 0 {
+			corner(chr, [8][2]int{{nrow - 3, 0}, {nrow - 2, 0}, {nrow - 1, 0}, {0, ncol - 4}, {0, ncol - 3}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}})
+			chr++
+		}
+		if row == nrow-2 && col == 0 && ncol%8 == 4 {
+			corner(chr, [8][2]int{{nrow - 3, 0}, {nrow - 2, 0}, {nrow - 1, 0}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 1}, {2, ncol - 1}, {3, ncol - 1}})
+			chr++
+		}
+		if row == nrow+4 && col == 2 && ncol%8 == 0 {
+			corner(chr, [8][2]int{{nrow - 1, 0}, {nrow - 1, ncol - 1}, {0, ncol - 3}, {0, ncol - 2}, {0, ncol - 1}, {1, ncol - 3}, {1, ncol - 2}, {1, ncol - 1}})
+			chr++
+		}
+		for {
+			if row < nrow && col >= 0 && grid[row][col] < 0 {
+				utah(row, col, chr)
+				chr++
+			}
+			row -= 2
+			col += 2
+			if row < 0 || col >= ncol {
+				break
+			}
+		}
+		row++
+		col += 3
+		for {
+			if row >= 0 && col < ncol && grid[row][col] < 0 {
+				utah(row, col, chr)
+				chr++
+			}
+			row += 2
+			col -= 2
+			if row >= nrow || col < 0 {
+				break
+			}
+		}
+		row += 3
+		col++
+		if row >= nrow && col >= ncol {
+			break
+		}
+	}
+	if grid[nrow-1][ncol-1] < 0 {
+		grid[nrow-1][ncol-1], grid[nrow-2][ncol-2] = 1, 1
+		grid[nrow-1][ncol-2], grid[nrow-2][ncol-1] = 0, 0
+	}
+
+	out := make([][]bool, nrow)
+	for r := range grid {
+		out[r] = make([]bool, ncol)
+		for c, v := range grid[r] {
+			out[r][c] = v == 1
+		}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	eanL      = [10]string{"0001101", "0011001", "0010011", "0111101", "0100011", "0110001", "0101111", "0111011", "0110111", "0001011"}
+	eanParity = [10]string{"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG", "LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL"}
+)
+
+// EAN13CheckDigit digit ke-13 untuk 12 digit pertama.
+func EAN13CheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// encodeEAN13 menerima 12 digit (check digit dihitung) atau 13 digit (check digit divalidasi).
+func encodeEAN13(value string) (Matrix, error) {
+	value = strings.TrimSpace(value)
+	if len(value) != 12 && len(value) != 13 {
+		return Matrix{}, fmt.Errorf("ean13: value must have 12 or 13 digits")
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return Matrix{}, fmt.Errorf("ean13: value must contain digits only")
+		}
+	}
+	check := EAN13CheckDigit(value)
+	if len(value) == 13 && int(value[12]-'0') != check {
+		return Matrix{}, fmt.Errorf("ean13: invalid check digit, expected %d", check)
+	}
+	if len(value) == 12 {
+		value += string(rune('0' + check))
+	}
+
+	var b strings.Builder
+	b.WriteString("101")
+	parity := eanParity[value[0]-'0']
+	for i := 1; i <= 6; i++ {
+		l := eanL[value[i]-'0']
+		if parity[i-1] == 'G' {
+			l = reverse(invert(l))
+		}
+		b.WriteString(l)
+	}
+	b.WriteString("01010")
+	for i := 7; i <= 12; i++ {
+		b.WriteString(invert(eanL[value[i]-'0']))
+	}
+	b.WriteString("101")
+
+	bits := b.String()
+	m := newMatrix(len(bits), 1)
+	for i := range bits {
+		m.set(i, 0, bits[i] == '1')
+	}
+	return m, nil
+}
+
+func invert(s string) string {
+	out := []byte(s)
+	for i, c := range out {
+		if c == '0' {
+			out[i] = '1'
+		} else {
+			out[i] = '0'
+		}
+	}
+	return string(out)
+}
+
+func reverse(s string) string {
+	out := []byte(s)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
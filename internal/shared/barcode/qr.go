@@ -0,0 +1,486 @@
+package barcode
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tabel ISO/IEC 18004 per level koreksi (L, M, Q, H) dan versi 1-40 (indeks 0 tidak dipakai).
+var (
+	qrECCPerBlock = [4][41]int{
+		{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+		{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	}
+	qrBlocks = [4][41]int{
+		{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+		{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+		{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+		{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+	}
+	// qrFormatECC nilai 2 bit level koreksi pada format information.
+	qrFormatECC = [4]int{1, 0, 3, 2}
+)
+
+const qrAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+type qrMode struct {
+	indicator int
+	countBits [3]int // versi 1-9, 10-26, 27-40
+}
+
+var (
+	qrNumeric = qrMode{0x1, [3]int{10, 12, 14}}
+	qrAlnum   = qrMode{0x2, [3]int{9, 11, 13}}
+	qrByte    = qrMode{0x4, [3]int{8, 16, 16}}
+)
+
+func (m qrMode) charCountBits(ver int) int {
+	switch {
+	case ver <= 9:
+		return m.countBits[0]
+	case ver <= 26:
+		return m.countBits[1]
+	default:
+		return m.countBits[2]
+	}
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (v>>i)&1 == 1)
+	}
+}
+
+func qrECCLevel(s string) (int, error) {
+	switch strings.ToUpper(s) {
+	case "L":
+		return 0, nil
+	case "", "M":
+		return 1, nil
+	case "Q":
+		return 2, nil
+	case "H":
+		return 3, nil
+	default:
+		return 0, fmt.Errorf("qr: error correction level must be L, M, Q or H")
+	}
+}
+
+// encodeQR memilih mode (numeric, alphanumeric, byte) dan versi terkecil yang muat.
+func encodeQR(text string, level string) (Matrix, error) {
+	ecl, err := qrECCLevel(level)
+	if err != nil {
+		return Matrix{}, err
+	}
+	if text == "" {
+		return Matrix{}, errors.New("qr: value is empty")
+	}
+	mode, data, count := qrSegment(text)
+
+	ver := 0
+	for v := 1; v <= 40; v++ {
+		cc := mode.charCountBits(v)
+		if count < 1<<cc && 4+cc+len(data) <= qrDataCodewords(v, ecl)*8 {
+			ver = v
+			break
+		}
+	}
+	if ver == 0 {
+		return Matrix{}, errors.New("qr: value is too long")
+	}
+
+	var bits bitBuffer
+	bits.append(mode.indicator, 4)
+	bits.append(count, mode.charCountBits(ver))
+	bits = append(bits, data...)
+	capacity := qrDataCodewords(ver, ecl) * 8
+	term := capacity - len(bits)
+	if term > 4 {
+		term = 4
+	}
+	bits.append(0, term)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i, b := range bits {
+		if b {
+			codewords[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+
+	q := newQRSymbol(ver, ecl)
+	q.drawFunctionPatterns()
+	q.drawCodewords(qrInterleave(codewords, ver, ecl))
+	q.applyBestMask()
+	return q.matrix(), nil
+}
+
+func qrSegment(text string) (qrMode, bitBuffer, int) {
+	numeric, alnum := true, true
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			numeric = false
+		}
+		if !strings.ContainsRune(qrAlphanumeric, r) {
+			alnum = false
+		}
+	}
+	var bits bitBuffer
+	switch {
+	case numeric:
+		for i := 0; i < len(text); i += 3 {
+			end := min(i+3, len(text))
+			v := 0
+			for _, c := range text[i:end] {
+				v = v*10 + int(c-'0')
+			}
+			bits.append(v, (end-i)*3+1)
+		}
+		return qrNumeric, bits, len(text)
+	case alnum:
+		for i := 0; i+1 < len(text); i += 2 {
+			bits.append(strings.IndexByte(qrAlphanumeric, text[i])*45+strings.IndexByte(qrAlphanumeric, text[i+1]), 11)
+		}
+		if len(text)%2 == 1 {
+			bits.append(strings.IndexByte(qrAlphanumeric, text[len(text)-1]), 6)
+		}
+		return qrAlnum, bits, len(text)
+	default:
+		for i := 0; i < len(text); i++ {
+			bits.append(int(text[i]), 8)
+		}
+		return qrByte, bits, len(text)
+	}
+}
+
+// qrRawModules jumlah modul data (termasuk remainder bit) untuk versi ver.
+func qrRawModules(ver int) int {
+	n := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		align := ver/7 + 2
+		n -= (25*align-10)*align - 55
+		if ver >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+func qrDataCodewords(ver, ecl int) int {
+	return qrRawModules(ver)/8 - qrECCPerBlock[ecl][ver]*qrBlocks[ecl][ver]
+}
+
+// qrInterleave membagi data ke blok, menambah ECC tiap blok, lalu menyisipkan codeword antar blok.
+func qrInterleave(data []byte, ver, ecl int) []byte {
+	numBlocks := qrBlocks[ecl][ver]
+	eccLen := qrECCPerBlock[ecl][ver]
+	raw := qrRawModules(ver) / 8
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw/numBlocks - eccLen
+
+	dataBlocks := make([][]byte, numBlocks)
+	eccBlocks := make([][]byte, numBlocks)
+	k := 0
+	for i := range dataBlocks {
+		n := shortLen
+		if i >= numShort {
+			n++
+		}
+		dataBlocks[i] = data[k : k+n]
+		eccBlocks[i] = qrField.ecc(dataBlocks[i], eccLen, 0)
+		k += n
+	}
+	out := make([]byte, 0, raw)
+	for i := 0; i <= shortLen; i++ {
+		for _, b := range dataBlocks {
+			if i < len(b) {
+				out = append(out, b[i])
+			}
+		}
+	}
+	for i := 0; i < eccLen; i++ {
+		for _, b := range eccBlocks {
+			out = append(out, b[i])
+		}
+	}
+	return out
+}
+
+type qrSymbol struct {
+	ver, ecl, size int
+	modules        [][]bool
+	function       [][]bool
+}
+
+func newQRSymbol(ver, ecl int) *qrSymbol {
+	size := ver*4 + 17
+	q := &qrSymbol{ver: ver, ecl: ecl, size: size}
+	q.modules = make([][]bool, size)
+	q.function = make([][]bool, size)
+	for i := range q.modules {
+		q.modules[i] = make([]bool, size)
+		q.function[i] = make([]bool, size)
+	}
+	return q
+}
+
+func (q *qrSymbol) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+func (q *qrSymbol) drawFunctionPatterns() {
+	for i := 0; i < q.size; i++ {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+
+	pos := q.alignmentPositions()
+	n := len(pos)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (i == 0 && j == 0) || (i == 0 && j == n-1) || (i == n-1 && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(pos[i]+dx, pos[j]+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	q.drawFormatBits(0) // placeholder; ditimpa setelah mask dipilih
+	q.drawVersion()
+}
+
+func (q *qrSymbol) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= q.size || y < 0 || y >= q.size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			q.setFunction(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+func (q *qrSymbol) alignmentPositions() []int {
+	if q.ver == 1 {
+		return nil
+	}
+	n := q.ver/7 + 2
+	step := (q.ver*4 + n*2 + 1) / (n*2 - 2) * 2
+	if q.ver == 32 {
+		step = 26
+	}
+	out := make([]int, n)
+	out[0] = 6
+	for i, p := n-1, q.size-7; i >= 1; i, p = i-1, p-step {
+		out[i] = p
+	}
+	return out
+}
+
+func (q *qrSymbol) drawFormatBits(mask int) {
+	data := qrFormatECC[q.ecl]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 == 1 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+func (q *qrSymbol) drawVersion() {
+	if q.ver < 7 {
+		return
+	}
+	rem := q.ver
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := q.ver<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 == 1
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(a, b, dark)
+		q.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords menempatkan bit secara zigzag dua kolom dari kanan bawah.
+func (q *qrSymbol) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < q.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !q.function[y][x] && i < len(data)*8 {
+					q.modules[y][x] = (data[i>>3]>>(7-uint(i&7)))&1 == 1
+					i++
+				}
+			}
+		}
+	}
+}
+
+func qrMaskBit(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (q *qrSymbol) applyMask(mask int) {
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			if !q.function[y][x] && qrMaskBit(mask, x, y) {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask mencoba 8 mask dan memakai yang penalty-nya terkecil.
+func (q *qrSymbol) applyBestMask() {
+	best, bestScore := 0, -1
+	for m := 0; m < 8; m++ {
+		q.applyMask(m)
+		q.drawFormatBits(m)
+		if s := q.penalty(); bestScore < 0 || s < bestScore {
+			best, bestScore = m, s
+		}
+		q.applyMask(m) // XOR: kembali ke kondisi tanpa mask
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+}
+
+var (
+	qrFinderLike    = []bool{true, false, true, true, true, false, true, false, false, false, false}
+	qrFinderLikeRev = []bool{false, false, false, false, true, false, true, true, true, false, true}
+)
+
+func (q *qrSymbol) penalty() int {
+	n := q.size
+	score := 0
+	line := make([]bool, n)
+	for pass := 0; pass < 2; pass++ {
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				if pass == 0 {
+					line[b] = q.modules[a][b]
+				} else {
+					line[b] = q.modules[b][a]
+				}
+			}
+			run := 1
+			for b := 1; b <= n; b++ {
+				if b < n && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+			for b := 0; b+11 <= n; b++ {
+				if matches(line[b:b+11], qrFinderLike) || matches(line[b:b+11], qrFinderLikeRev) {
+					score += 40
+				}
+			}
+		}
+	}
+	dark := 0
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			c := q.modules[y][x]
+			if c {
+				dark++
+			}
+			if x+1 < n && y+1 < n && c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+				score += 3
+			}
+		}
+	}
+	total := n * n
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return score + k*10
+}
+
+func matches(a, b []bool) bool {
+	for i := range b {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *qrSymbol) matrix() Matrix {
+	m := newMatrix(q.size, q.size)
+	for y := 0; y < q.size; y++ {
+		for x := 0; x < q.size; x++ {
+			m.set(x, y, q.modules[y][x])
+		}
+	}
+	return m
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
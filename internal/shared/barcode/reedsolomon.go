@@ -0,0 +1,63 @@
+package barcode
+
+// gf256 aritmetika GF(2^8) dengan polinom primitif tertentu (QR: 0x11D, DataMatrix: 0x12D).
+type gf256 struct {
+	exp [510]int
+	log [256]int
+}
+
+func newGF256(poly int) *gf256 {
+	f := &gf256{}
+	x := 1
+	for i := 0; i < 255; i++ {
+		f.exp[i] = x
+		f.log[x] = i
+		x <<= 1
+		if x >= 256 {
+			x ^= poly
+		}
+	}
+	for i := 255; i < len(f.exp); i++ {
+		f.exp[i] = f.exp[i-255]
+	}
+	return f
+}
+
+func (f *gf256) mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+// ecc menghitung n codeword Reed-Solomon untuk data; generator berakar α^first .. α^(first+n-1).
+func (f *gf256) ecc(data []byte, n, first int) []byte {
+	gen := []int{1}
+	for i := 0; i < n; i++ {
+		next := make([]int, len(gen)+1)
+		for j, c := range gen {
+			next[j] ^= c
+			next[j+1] ^= f.mul(c, f.exp[(i+first)%255])
+		}
+		gen = next
+	}
+	rem := make([]int, n)
+	for _, d := range data {
+		factor := int(d) ^ rem[0]
+		copy(rem, rem[1:])
+		rem[n-1] = 0
+		for j := 0; j < n; j++ {
+			rem[j] ^= f.mul(gen[j+1], factor)
+		}
+	}
+	out := make([]byte, n)
+	for i, r := range rem {
+		out[i] = byte(r)
+	}
+	return out
+}
+
+var (
+	qrField = newGF256(0x11D)
+	dmField = newGF256(0x12D)
+)
@@ -0,0 +1,114 @@
+package barcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+const (
+	defaultSize   = 160 // px, sisi barcode 2D
+	defaultHeight = 60  // px, tinggi barcode 1D
+	defaultModule = 2   // px per modul barcode 1D bila Width tidak diisi
+)
+
+// dimensions ukuran akhir dalam px untuk m dengan quiet zone q.
+func (m Matrix) dimensions(o Options) (w, h int) {
+	cols := m.W + 2*o.QuietZone
+	if m.Linear() {
+		w, h = o.Width, o.Height
+		if w == 0 {
+			w = cols * defaultModule
+		}
+		if h == 0 {
+			h = defaultHeight
+		}
+		return w, h
+	}
+	w = o.Size
+	if w == 0 {
+		w = defaultSize
+	}
+	return w, w * (m.H + 2*o.QuietZone) / cols
+}
+
+// SVG merender matrix sebagai SVG; modul digambar dalam satuan viewBox sehingga skala tetap tajam.
+func (m Matrix) SVG(o Options) []byte {
+	q := o.QuietZone
+	if q < 0 {
+		q = 0
+	}
+	o.QuietZone = q
+	w, h := m.dimensions(o)
+	vw, vh := m.W+2*q, m.H+2*q
+	aspect := ""
+	if m.Linear() {
+		vh = 1
+		aspect = ` preserveAspectRatio="none"`
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d"%s shape-rendering="crispEdges">`, w, h, vw, vh, aspect)
+	b.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+	for y := 0; y < m.H; y++ {
+		top := y + q
+		if m.Linear() {
+			top = 0
+		}
+		for x := 0; x < m.W; {
+			if !m.At(x, y) {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < m.W && m.At(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", x+q, top, run, run)
+			x += run
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes()
+}
+
+// PNG merender matrix sebagai PNG hitam-putih; ukuran modul dibulatkan ke px utuh (minimal 1).
+func (m Matrix) PNG(o Options) ([]byte, error) {
+	if o.QuietZone < 0 {
+		o.QuietZone = 0
+	}
+	q := o.QuietZone
+	w, h := m.dimensions(o)
+	cols := m.W + 2*q
+	scale := w / cols
+	if scale < 1 {
+		scale = 1
+	}
+	w = cols * scale
+	rows := m.H + 2*q
+	if !m.Linear() {
+		h = rows * scale
+	}
+	img := image.NewPaletted(image.Rect(0, 0, w, h), color.Palette{color.White, color.Black})
+	for py := 0; py < h; py++ {
+		y := 0
+		if !m.Linear() {
+			y = py/scale - q
+			if y < 0 || y >= m.H {
+				continue
+			}
+		}
+		for px := 0; px < w; px++ {
+			x := px/scale - q
+			if x >= 0 && x < m.W && m.At(x, y) {
+				img.SetColorIndex(px, py, 1)
+			}
+		}
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,283 @@
+// Package chart merender grafik bar, line dan pie sebagai SVG inline untuk template laporan.
+// SVG hanya memakai atribut presentasi (tanpa CSS/JS) agar tampil sama di HTML dan wkhtmltopdf.
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-document-generator/internal/shared/format"
+)
+
+// Series satu deret nilai; panjang Values sama dengan Data.Labels.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// Data kategori sumbu X (atau label slice pie) beserta deretnya.
+type Data struct {
+	Labels []string
+	Series []Series
+}
+
+// DefaultPalette warna deret bila opsi palette tidak diisi.
+var DefaultPalette = []string{"#2563eb", "#f59e0b", "#10b981", "#ef4444", "#8b5cf6", "#14b8a6", "#f97316", "#64748b"}
+
+// colorPattern warna palette yang diterima: hex (#rgb, #rrggbb, #rrggbbaa) atau nama warna CSS.
+// Nilai masuk ke atribut fill/stroke SVG yang disisipkan sebagai HTML, jadi tidak boleh ada karakter lain.
+var colorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3,4}|#[0-9A-Fa-f]{6}|#[0-9A-Fa-f]{8}|[A-Za-z]{3,30})$`)
+
+// Options pengaturan grafik dari template ("key=value").
+type Options struct {
+	X, Y        string   // nama field kategori dan field nilai (Y dipisah koma untuk beberapa deret)
+	Names       []string // nama deret untuk legend; default nama field Y
+	Title       string
+	XLabel      string
+	YLabel      string
+	Width       int
+	Height      int
+	Palette     []string
+	Stacked     bool
+	Legend      *bool // nil = tampil bila deret > 1 (pie: selalu)
+	Min, Max    *float64
+	Ticks       int
+	Format      string // number (default), plain, percent, atau kode mata uang (IDR, USD, ...)
+	Locale      string
+	ShowValues  bool
+	Donut       bool
+	Smooth      bool
+	ShowMarkers bool
+}
+
+// ParseOptions membaca opsi "key=value", mis. "x=month" "y=revenue,cost" "title=Penjualan" "stacked=true".
+func ParseOptions(args ...string) (Options, error) {
+	o := Options{Width: 600, Height: 300, Ticks: 5, Locale: "id", ShowMarkers: true}
+	for _, a := range args {
+		k, v, ok := strings.Cut(a, "=")
+		if !ok {
+			return o, fmt.Errorf("chart option %q must be key=value", a)
+		}
+		k, v = strings.ToLower(strings.TrimSpace(k)), strings.TrimSpace(v)
+		var err error
+		switch k {
+		case "x", "label":
+			o.X = v
+		case "y", "value":
+			o.Y = v
+		case "names":
+			o.Names = splitList(v)
+		case "title":
+			o.Title = v
+		case "xlabel":
+			o.XLabel = v
+		case "ylabel":
+			o.YLabel = v
+		case "palette":
+			o.Palette = splitList(v)
+			for _, c := range o.Palette {
+				if !colorPattern.MatchString(c) {
+					err = errors.New("not a color")
+				}
+			}
+		case "format":
+			o.Format = v
+		case "locale":
+			o.Locale = v
+		case "width", "height", "ticks":
+			var n int
+			if n, err = strconv.Atoi(v); err == nil && n <= 0 {
+				err = errors.New("must be positive")
+			}
+			switch k {
+			case "width":
+				o.Width = n
+			case "height":
+				o.Height = n
+			default:
+				o.Ticks = n
+			}
+		case "min", "max":
+			var f float64
+			if f, err = strconv.ParseFloat(v, 64); err == nil {
+				if k == "min" {
+					o.Min = &f
+				} else {
+					o.Max = &f
+				}
+			}
+		case "stacked", "legend", "values", "donut", "smooth", "markers":
+			var b bool
+			if b, err = strconv.ParseBool(v); err == nil {
+				switch k {
+				case "stacked":
+					o.Stacked = b
+				case "legend":
+					o.Legend = &b
+				case "values":
+					o.ShowValues = b
+				case "donut":
+					o.Donut = b
+				case "smooth":
+					o.Smooth = b
+				default:
+					o.ShowMarkers = b
+				}
+			}
+		default:
+			return o, fmt.Errorf("unknown chart option %q", k)
+		}
+		if err != nil {
+			return o, fmt.Errorf("chart option %s: invalid value %q", k, v)
+		}
+	}
+	return o, nil
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// FromPayload membangun Data dari array payload. Bentuk yang diterima:
+//   - list object: label dari field X, nilai dari field Y (beberapa field dipisah koma);
+//     field yang tidak ada dihitung 0
+//   - list angka: label 1..n
+//   - object label -> angka: label diurutkan
+func FromPayload(v any, o Options) (Data, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return Data{}, errors.New("chart data is empty")
+	}
+	fields := splitList(o.Y)
+	switch rv.Kind() {
+	case reflect.Map:
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, fmt.Sprint(k.Interface()))
+		}
+		sort.Strings(keys)
+		s := Series{Name: seriesName(o, 0, "")}
+		for _, k := range keys {
+			f, err := format.ToFloat(rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return Data{}, fmt.Errorf("chart value %s: %w", k, err)
+			}
+			s.Values = append(s.Values, f)
+		}
+		return Data{Labels: keys, Series: []Series{s}}, nil
+	case reflect.Slice, reflect.Array:
+	default:
+		return Data{}, fmt.Errorf("chart data must be a list or object, got %T", v)
+	}
+
+	var d Data
+	if len(fields) == 0 {
+		s := Series{Name: seriesName(o, 0, "")}
+		for i := 0; i < rv.Len(); i++ {
+			f, err := format.ToFloat(rv.Index(i).Interface())
+			if err != nil {
+				return Data{}, fmt.Errorf("chart item %d: %w (set y=<field> for lists of objects)", i, err)
+			}
+			d.Labels = append(d.Labels, strconv.Itoa(i+1))
+			s.Values = append(s.Values, f)
+		}
+		d.Series = []Series{s}
+		return d, nil
+	}
+	d.Series = make([]Series, len(fields))
+	for j, f := range fields {
+		d.Series[j].Name = seriesName(o, j, f)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+		label := strconv.Itoa(i + 1)
+		if o.X != "" {
+			if l := field(item, o.X); l != nil {
+				label = labelString(l)
+			}
+		}
+		d.Labels = append(d.Labels, label)
+		for j, f := range fields {
+			val, err := format.ToFloat(field(item, f))
+			if err != nil {
+				return Data{}, fmt.Errorf("chart item %d field %s: %w", i, f, err)
+			}
+			d.Series[j].Values = append(d.Series[j].Values, val)
+		}
+	}
+	return d, nil
+}
+
+func seriesName(o Options, i int, field string) string {
+	if i < len(o.Names) {
+		return o.Names[i]
+	}
+	return field
+}
+
+func field(item any, name string) any {
+	rv := reflect.ValueOf(item)
+	if !rv.IsValid() || rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+	v := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func labelString(v any) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func (o Options) color(i int) string {
+	p := o.Palette
+	if len(p) == 0 {
+		p = DefaultPalette
+	}
+	// Options bisa dibangun tanpa ParseOptions; warna tidak valid tidak pernah ditulis ke SVG.
+	if c := p[i%len(p)]; colorPattern.MatchString(c) {
+		return c
+	}
+	return DefaultPalette[i%len(DefaultPalette)]
+}
+
+func (o Options) showLegend(series int) bool {
+	if o.Legend != nil {
+		return *o.Legend
+	}
+	return series > 1
+}
+
+// formatValue menulis nilai sumbu/label sesuai opsi format.
+func (o Options) formatValue(v float64) string {
+	decimals := 0
+	if v != float64(int64(v)) {
+		decimals = 2
+	}
+	switch strings.ToLower(o.Format) {
+	case "", "number":
+		return format.Number(v, decimals, o.Locale)
+	case "plain":
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case "percent":
+		return format.Number(v, decimals, o.Locale) + "%"
+	default:
+		return format.Currency(o.Format, v)
+	}
+}
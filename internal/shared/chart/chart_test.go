@@ -0,0 +1,87 @@
+package chart
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNiceScale(t *testing.T) {
+	cases := []struct {
+		min, max     float64
+		lo, hi, step float64
+	}{
+		{0, 97, 0, 100, 20},
+		{-300, 1500, -500, 1500, 500},
+		{3, 3, 0, 3, 1},
+	}
+	for _, c := range cases {
+		lo, hi, step := niceScale(c.min, c.max, 5)
+		if lo != c.lo || hi != c.hi || step != c.step {
+			t.Errorf("niceScale(%v, %v) = %v %v %v, want %v %v %v", c.min, c.max, lo, hi, step, c.lo, c.hi, c.step)
+		}
+	}
+}
+
+func TestFromPayload(t *testing.T) {
+	o, err := ParseOptions("x=month", "y=revenue,cost", "names=Omzet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := FromPayload([]any{
+		map[string]any{"month": "Jan", "revenue": 10, "cost": "4"},
+		map[string]any{"month": "Feb", "revenue": 12.5, "cost": 6},
+	}, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(d.Labels, ",") != "Jan,Feb" || len(d.Series) != 2 {
+		t.Fatalf("unexpected data %+v", d)
+	}
+	if d.Series[0].Name != "Omzet" || d.Series[1].Name != "cost" || d.Series[1].Values[0] != 4 {
+		t.Errorf("unexpected series %+v", d.Series)
+	}
+
+	d, err = FromPayload([]any{map[string]any{"month": "Jan"}}, o)
+	if err != nil || d.Series[0].Values[0] != 0 {
+		t.Errorf("missing field should count as 0, got %+v %v", d, err)
+	}
+	if _, err := FromPayload([]any{map[string]any{"revenue": "n/a"}}, o); err == nil {
+		t.Error("expected error for non-numeric value")
+	}
+}
+
+func TestRenderWellFormed(t *testing.T) {
+	o, _ := ParseOptions("title=A & B <test>", "values=true")
+	d, err := FromPayload(map[string]any{"a": 1, "b": 2, "c": 0}, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, draw := range map[string]func(Data, Options) (string, error){"bar": Bar, "line": Line, "pie": Pie} {
+		svg, err := draw(d, o)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		dec := xml.NewDecoder(strings.NewReader(svg))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if err != io.EOF {
+					t.Errorf("%s: invalid svg: %v", name, err)
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestPaletteRejectsMarkup(t *testing.T) {
+	if _, err := ParseOptions("palette=#fff,red,#12345678"); err != nil {
+		t.Fatalf("valid palette: %v", err)
+	}
+	for _, p := range []string{`palette=red"/><script>alert(1)</script>`, "palette=url(x)", "palette=#12345"} {
+		if _, err := ParseOptions(p); err == nil {
+			t.Errorf("%s: expected error", p)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"go-document-generator/internal/shared/format"
+)
+
+const (
+	fontFamily = "Helvetica, Arial, sans-serif"
+	charWidth  = 6.5 // perkiraan lebar karakter font 11px untuk tata letak label
+	gridColor  = "#e5e7eb"
+	axisColor  = "#9ca3af"
+	textColor  = "#374151"
+)
+
+type canvas struct {
+	b    strings.Builder
+	w, h int
+}
+
+func newCanvas(o Options) *canvas {
+	c := &canvas{w: o.Width, h: o.Height}
+	fmt.Fprintf(&c.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s" font-size="11">`,
+		c.w, c.h, c.w, c.h, fontFamily)
+	fmt.Fprintf(&c.b, `<rect width="%d" height="%d" fill="#fff"/>`, c.w, c.h)
+	return c
+}
+
+func (c *canvas) printf(f string, args ...any) { fmt.Fprintf(&c.b, f, args...) }
+
+func (c *canvas) text(x, y float64, anchor, fill, s string, extra string) {
+	c.printf(`<text x="%s" y="%s" text-anchor="%s" fill="%s"%s>%s</text>`, num(x), num(y), anchor, fill, extra, html.EscapeString(s))
+}
+
+func (c *canvas) String() string {
+	c.b.WriteString("</svg>")
+	return c.b.String()
+}
+
+func num(f float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+}
+
+// plot area grafik bersumbu setelah dikurangi judul, label sumbu dan legend.
+type plot struct {
+	x, y, w, h float64
+	lo, hi     float64
+	step       float64
+}
+
+func (p plot) yOf(v float64) float64 {
+	return p.y + p.h - (v-p.lo)/(p.hi-p.lo)*p.h
+}
+
+// axes menggambar judul, grid, label sumbu dan legend, lalu mengembalikan plot area.
+func axes(c *canvas, d Data, o Options, dataMin, dataMax float64) plot {
+	if o.Min != nil {
+		dataMin = *o.Min
+	}
+	if o.Max != nil {
+		dataMax = *o.Max
+	}
+	lo, hi, step := niceScale(dataMin, dataMax, o.Ticks)
+
+	top, bottom := 12.0, 28.0
+	if o.Title != "" {
+		top += 22
+		c.text(float64(c.w)/2, 20, "middle", textColor, o.Title, ` font-size="14" font-weight="bold"`)
+	}
+	if o.XLabel != "" {
+		bottom += 16
+	}
+	legend := o.showLegend(len(d.Series))
+	if legend {
+		bottom += 22
+	}
+	labelW := 0.0
+	for _, t := range ticks(lo, hi, step) {
+		labelW = math.Max(labelW, float64(len([]rune(o.formatValue(t))))*charWidth)
+	}
+	left := 12 + labelW + 6
+	if o.YLabel != "" {
+		left += 16
+	}
+	p := plot{x: left, y: top, w: float64(c.w) - left - 16, h: float64(c.h) - top - bottom, lo: lo, hi: hi, step: step}
+
+	for _, t := range ticks(lo, hi, step) {
+		y := p.yOf(t)
+		c.printf(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="1"/>`, num(p.x), num(y), num(p.x+p.w), num(y), gridColor)
+		c.text(p.x-6, y+4, "end", textColor, o.formatValue(t), "")
+	}
+	base := p.yOf(math.Max(lo, math.Min(0, hi)))
+	c.printf(`<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="1"/>`, num(p.x), num(base), num(p.x+p.w), num(base), axisColor)
+
+	n := len(d.Labels)
+	if n > 0 {
+		band := p.w / float64(n)
+		longest := 0
+		for _, l := range d.Labels {
+			longest = max(longest, min(len([]rune(l)), 14))
+		}
+		every := int(math.Ceil(float64(longest)*charWidth/band + 0.001))
+		if every < 1 {
+			every = 1
+		}
+		for i, l := range d.Labels {
+			if i%every != 0 {
+				continue
+			}
+			c.text(p.x+band*(float64(i)+0.5), p.y+p.h+16, "middle", textColor, truncate(l, 14), "")
+		}
+	}
+	if o.XLabel != "" {
+		c.text(p.x+p.w/2, p.y+p.h+32, "middle", textColor, o.XLabel, ` font-weight="bold"`)
+	}
+	if o.YLabel != "" {
+		x, y := 14.0, p.y+p.h/2
+		c.text(x, y, "middle", textColor, o.YLabel, fmt.Sprintf(` font-weight="bold" transform="rotate(-90 %s %s)"`, num(x), num(y)))
+	}
+	if legend {
+		drawLegendRow(c, d, o, float64(c.h)-14)
+	}
+	return p
+}
+
+func drawLegendRow(c *canvas, d Data, o Options, y float64) {
+	total := 0.0
+	for _, s := range d.Series {
+		total += 18 + float64(len([]rune(s.Name)))*charWidth + 12
+	}
+	x := (float64(c.w) - total) / 2
+	for i, s := range d.Series {
+		c.printf(`<rect x="%s" y="%s" width="10" height="10" fill="%s"/>`, num(x), num(y-9), o.color(i))
+		c.text(x+14, y, "start", textColor, s.Name, "")
+		x += 18 + float64(len([]rune(s.Name)))*charWidth + 12
+	}
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// niceScale membulatkan rentang sumbu ke kelipatan 1, 2 atau 5 dan selalu menyertakan 0.
+func niceScale(dataMin, dataMax float64, ticks int) (lo, hi, step float64) {
+	if ticks < 2 {
+		ticks = 2
+	}
+	lo, hi = math.Min(dataMin, 0), math.Max(dataMax, 0)
+	if hi == lo {
+		hi = lo + 1
+	}
+	step = niceNum((hi-lo)/float64(ticks-1), true)
+	return math.Floor(lo/step) * step, math.Ceil(hi/step) * step, step
+}
+
+// ticks nilai grid dari lo sampai hi; dihitung per indeks agar tidak menumpuk galat float.
+func ticks(lo, hi, step float64) []float64 {
+	var out []float64
+	for i := 0; ; i++ {
+		t := lo + float64(i)*step
+		if t > hi+step/2 {
+			return out
+		}
+		out = append(out, math.Round(t/step*1e6)/1e6*step)
+	}
+}
+
+func niceNum(r float64, round bool) float64 {
+	exp := math.Floor(math.Log10(r))
+	f := r / math.Pow(10, exp)
+	var nf float64
+	switch {
+	case round && f < 1.5, !round && f <= 1:
+		nf = 1
+	case round && f < 3, !round && f <= 2:
+		nf = 2
+	case round && f < 7, !round && f <= 5:
+		nf = 5
+	default:
+		nf = 10
+	}
+	return nf * math.Pow(10, exp)
+}
+
+func validate(d Data) error {
+	if len(d.Labels) == 0 || len(d.Series) == 0 {
+		return errors.New("chart data is empty")
+	}
+	return nil
+}
+
+// Bar grafik batang berkelompok, atau bertumpuk bila Stacked.
+func Bar(d Data, o Options) (string, error) {
+	if err := validate(d); err != nil {
+		return "", err
+	}
+	lo, hi := 0.0, 0.0
+	for i := range d.Labels {
+		pos, neg := 0.0, 0.0
+		for _, s := range d.Series {
+			v := s.Values[i]
+			if o.Stacked {
+				if v >= 0 {
+					pos += v
+				} else {
+					neg += v
+				}
+				continue
+			}
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+		lo, hi = math.Min(lo, neg), math.Max(hi, pos)
+	}
+	c := newCanvas(o)
+	p := axes(c, d, o, lo, hi)
+	band := p.w / float64(len(d.Labels))
+	for i := range d.Labels {
+		if o.Stacked {
+			barW := band * 0.6
+			x := p.x + band*float64(i) + (band-barW)/2
+			pos, neg := 0.0, 0.0
+			for j, s := range d.Series {
+				v := s.Values[i]
+				from := pos
+				if v < 0 {
+					from = neg
+				}
+				to := from + v
+				if v >= 0 {
+					pos = to
+				} else {
+					neg = to
+				}
+				bar(c, p, o, x, barW, from, to, o.color(j), v, true)
+			}
+			continue
+		}
+		groupW := band * 0.75
+		barW := groupW / float64(len(d.Series))
+		for j, s := range d.Series {
+			x := p.x + band*float64(i) + (band-groupW)/2 + barW*float64(j)
+			bar(c, p, o, x, barW-1, 0, s.Values[i], o.color(j), s.Values[i], false)
+		}
+	}
+	return c.String(), nil
+}
+
+func bar(c *canvas, p plot, o Options, x, w, from, to float64, color string, v float64, inside bool) {
+	y1, y2 := p.yOf(math.Max(from, to)), p.yOf(math.Min(from, to))
+	c.printf(`<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`, num(x), num(y1), num(math.Max(w, 1)), num(y2-y1), color)
+	if !o.ShowValues || v == 0 {
+		return
+	}
+	switch {
+	case inside && y2-y1 >= 14:
+		c.text(x+w/2, (y1+y2)/2+4, "middle", "#fff", o.formatValue(v), ` font-size="10"`)
+	case !inside && v > 0:
+		c.text(x+w/2, y1-4, "middle", textColor, o.formatValue(v), ` font-size="10"`)
+	case !inside:
+		c.text(x+w/2, y2+12, "middle", textColor, o.formatValue(v), ` font-size="10"`)
+	}
+}
+
+// Line grafik garis; Smooth memakai kurva Catmull-Rom.
+func Line(d Data, o Options) (string, error) {
+	if err := validate(d); err != nil {
+		return "", err
+	}
+	lo, hi := 0.0, 0.0
+	for _, s := range d.Series {
+		for _, v := range s.Values {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	c := newCanvas(o)
+	p := axes(c, d, o, lo, hi)
+	band := p.w / float64(len(d.Labels))
+	for j, s := range d.Series {
+		pts := make([][2]float64, len(s.Values))
+		for i, v := range s.Values {
+			pts[i] = [2]float64{p.x + band*(float64(i)+0.5), p.yOf(v)}
+		}
+		c.printf(`<path d="%s" fill="none" stroke="%s" stroke-width="2" stroke-linejoin="round"/>`, linePath(pts, o.Smooth), o.color(j))
+		for i, pt := range pts {
+			if o.ShowMarkers {
+				c.printf(`<circle cx="%s" cy="%s" r="3" fill="%s" stroke="#fff" stroke-width="1"/>`, num(pt[0]), num(pt[1]), o.color(j))
+			}
+			if o.ShowValues {
+				c.text(pt[0], pt[1]-7, "middle", textColor, o.formatValue(s.Values[i]), ` font-size="10"`)
+			}
+		}
+	}
+	return c.String(), nil
+}
+
+func linePath(pts [][2]float64, smooth bool) string {
+	var b strings.Builder
+	for i, pt := range pts {
+		switch {
+		case i == 0:
+			fmt.Fprintf(&b, "M%s %s", num(pt[0]), num(pt[1]))
+		case !smooth:
+			fmt.Fprintf(&b, "L%s %s", num(pt[0]), num(pt[1]))
+		default:
+			p0, p1, p2 := pts[max(i-2, 0)], pts[i-1], pt
+			p3 := pts[min(i+1, len(pts)-1)]
+			fmt.Fprintf(&b, "C%s %s %s %s %s %s",
+				num(p1[0]+(p2[0]-p0[0])/6), num(p1[1]+(p2[1]-p0[1])/6),
+				num(p2[0]-(p3[0]-p1[0])/6), num(p2[1]-(p3[1]-p1[1])/6),
+				num(p2[0]), num(p2[1]))
+		}
+	}
+	return b.String()
+}
+
+// Pie grafik lingkaran dari deret pertama; nilai negatif diabaikan. Donut menampilkan total di tengah.
+func Pie(d Data, o Options) (string, error) {
+	if err := validate(d); err != nil {
+		return "", err
+	}
+	values := d.Series[0].Values
+	total := 0.0
+	for _, v := range values {
+		total += math.Max(v, 0)
+	}
+	if total <= 0 {
+		return "", errors.New("pie chart needs at least one positive value")
+	}
+
+	c := newCanvas(o)
+	top := 8.0
+	if o.Title != "" {
+		top += 22
+		c.text(float64(c.w)/2, 20, "middle", textColor, o.Title, ` font-size="14" font-weight="bold"`)
+	}
+	legend := o.Legend == nil || *o.Legend
+	areaW := float64(c.w)
+	if legend {
+		areaW *= 0.6
+	}
+	h := float64(c.h) - top - 8
+	r := math.Min(areaW, h)/2 - 8
+	cx, cy := areaW/2, top+h/2
+
+	angle := -math.Pi / 2
+	for i, v := range values {
+		if v <= 0 {
+			continue
+		}
+		frac := v / total
+		if frac >= 0.9999 {
+			c.printf(`<circle cx="%s" cy="%s" r="%s" fill="%s"/>`, num(cx), num(cy), num(r), o.color(i))
+		} else {
+			end := angle + frac*2*math.Pi
+			large := 0
+			if frac > 0.5 {
+				large = 1
+			}
+			c.printf(`<path d="M%s %sL%s %sA%s %s 0 %d 1 %s %sZ" fill="%s" stroke="#fff" stroke-width="1"/>`,
+				num(cx), num(cy), num(cx+r*math.Cos(angle)), num(cy+r*math.Sin(angle)),
+				num(r), num(r), large, num(cx+r*math.Cos(end)), num(cy+r*math.Sin(end)), o.color(i))
+			angle = end
+		}
+		if frac >= 0.04 {
+			mid := angle - frac*math.Pi
+			if frac >= 0.9999 {
+				mid = -math.Pi / 2
+			}
+			lr := r * 0.65
+			if o.Donut {
+				lr = r * 0.78
+			}
+			c.text(cx+lr*math.Cos(mid), cy+lr*math.Sin(mid)+4, "middle", "#fff", percent(frac, o.Locale), ` font-size="10" font-weight="bold"`)
+		}
+	}
+	if o.Donut {
+		c.printf(`<circle cx="%s" cy="%s" r="%s" fill="#fff"/>`, num(cx), num(cy), num(r*0.55))
+		c.text(cx, cy+5, "middle", textColor, o.formatValue(total), ` font-size="13" font-weight="bold"`)
+	}
+	if legend {
+		x := areaW + 8
+		y := cy - float64(len(d.Labels))*18/2 + 10
+		for i, l := range d.Labels {
+			c.printf(`<rect x="%s" y="%s" width="10" height="10" fill="%s"/>`, num(x), num(y-9), o.color(i))
+			c.text(x+14, y, "start", textColor, truncate(l, 22)+" ("+o.formatValue(values[i])+")", "")
+			y += 18
+		}
+	}
+	return c.String(), nil
+}
+
+func percent(frac float64, locale string) string {
+	v := math.Round(frac*1000) / 10
+	dec := 1
+	if v == math.Trunc(v) {
+		dec = 0
+	}
+	return format.Number(v, dec, locale) + "%"
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	from := time.Date(2026, 10, 19, 6, 30, 15, 0, time.UTC)
+	cases := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"0 6 * * *", from, time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)},
+		{"@monthly", from, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", from, time.Date(2026, 10, 19, 6, 45, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2026, 10, 23, 10, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", from, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Tanggal dan hari sama-sama dibatasi: cocok salah satunya.
+		{"0 0 13 * 5", from, time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", from, time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 6 * * *", from.In(jakarta), time.Date(2026, 10, 20, 6, 0, 0, 0, jakarta)},
+		{"0 0 30 2 *", from, time.Time{}},
+	}
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", c.expr, err)
+		}
+		if got := s.Next(c.from); !got.Equal(c.want) {
+			t.Errorf("%s from %s: got %s, want %s", c.expr, c.from, got, c.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "* * * FOO *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedLineDiff(t *testing.T) {
+	a := SplitLines("<h1>{{.title}}</h1>\n<p>a</p>\n<p>b</p>\n<p>c</p>\n")
+	b := SplitLines("<h1>{{.title}}</h1>\n<p>a</p>\n<p>B</p>\n<p>c</p>\n<p>d</p>\n")
+
+	ops := Lines(a, b)
+	added, removed := Stats(ops)
+	if added != 2 || removed != 1 {
+		t.Fatalf("expected +2 -1, got +%d -%d", added, removed)
+	}
+
+	got := Unified("v1", "v2", ops, 1)
+	want := strings.Join([]string{
+		"--- v1",
+		"+++ v2",
+		"@@ -2,3 +2,4 @@",
+		" <p>a</p>",
+		"-<p>b</p>",
+		"+<p>B</p>",
+		" <p>c</p>",
+		"+<p>d</p>",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected unified diff:\n%s", got)
+	}
+
+	if Unified("v1", "v1", Lines(a, a), 3) != "" {
+		t.Fatal("identical content must produce empty diff")
+	}
+}
+
+func TestSchemaChanges(t *testing.T) {
+	from := map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string"},
+			"total": map[string]any{"type": "integer"},
+			"note":  map[string]any{"type": "string"},
+		},
+	}
+	to := map[string]any{
+		"type":     "object",
+		"required": []any{"name", "email"},
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string"},
+			"total": map[string]any{"type": "number"},
+			"email": map[string]any{"type": "string"},
+		},
+	}
+
+	got := map[string]ChangeKind{}
+	for _, c := range Schema(from, to) {
+		got[string(c.Kind)+":"+c.Path] = c.Kind
+	}
+	for _, key := range []string{"required_added:email", "added:email", "removed:note", "type_changed:total"} {
+		if _, ok := got[key]; !ok {
+			t.Fatalf("missing change %s in %v", key, got)
+		}
+	}
+	if len(got) != 4 {
+		t.Fatalf("expected 4 changes, got %v", got)
+	}
+}
+
+func TestValueChanges(t *testing.T) {
+	from := map[string]any{"name": "A", "items": []any{map[string]any{"qty": 1.0}}}
+	to := map[string]any{"name": "B", "items": []any{map[string]any{"qty": 1.0}, map[string]any{"qty": 2.0}}}
+
+	changes := Values(from, to)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", changes)
+	}
+	if changes[0].Path != "items[1]" || changes[0].Kind != ChangeAdded {
+		t.Fatalf("unexpected change %+v", changes[0])
+	}
+	if changes[1].Path != "name" || changes[1].Kind != ChangeModified {
+		t.Fatalf("unexpected change %+v", changes[1])
+	}
+}
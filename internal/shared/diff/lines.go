@@ -0,0 +1,207 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OpKind int
+
+const (
+	OpEqual OpKind = iota
+	OpDelete
+	OpInsert
+)
+
+// LineOp satu langkah edit script baris.
+type LineOp struct {
+	Kind OpKind
+	Text string
+}
+
+// maxEditDistance membatasi memori algoritma Myers; di atas batas ini seluruh isi
+// dianggap diganti (semua baris lama dihapus, semua baris baru ditambah).
+const maxEditDistance = 2000
+
+// Lines menghitung edit script terpendek a → b (algoritma Myers).
+func Lines(a, b []string) []LineOp {
+	n, m := len(a), len(b)
+	if n+m == 0 {
+		return nil
+	}
+
+	// trace[d][k+d] = x terjauh pada diagonal k setelah d edit.
+	var trace [][]int
+	var prev []int
+	for d := 0; d <= n+m; d++ {
+		if d > maxEditDistance {
+			return replaceAll(a, b)
+		}
+		cur := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+				x = 0
+			case k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]):
+				x = prev[k+1+d-1]
+			default:
+				x = prev[k-1+d-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			cur[k+d] = x
+			if x >= n && y >= m {
+				trace = append(trace, cur)
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, cur)
+		prev = cur
+	}
+	return replaceAll(a, b)
+}
+
+func backtrack(a, b []string, trace [][]int) []LineOp {
+	var ops []LineOp
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, LineOp{Kind: OpEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, LineOp{Kind: OpInsert, Text: b[prevY]})
+		} else {
+			ops = append(ops, LineOp{Kind: OpDelete, Text: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, LineOp{Kind: OpEqual, Text: a[x-1]})
+		x--
+		y--
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+func replaceAll(a, b []string) []LineOp {
+	ops := make([]LineOp, 0, len(a)+len(b))
+	for _, l := range a {
+		ops = append(ops, LineOp{Kind: OpDelete, Text: l})
+	}
+	for _, l := range b {
+		ops = append(ops, LineOp{Kind: OpInsert, Text: l})
+	}
+	return ops
+}
+
+// SplitLines memecah teks per baris; newline di akhir teks tidak menghasilkan baris kosong.
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Stats menghitung jumlah baris yang ditambah dan dihapus.
+func Stats(ops []LineOp) (added, removed int) {
+	for _, op := range ops {
+		switch op.Kind {
+		case OpInsert:
+			added++
+		case OpDelete:
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Unified memformat edit script sebagai unified diff dengan context baris di sekitar perubahan.
+// Mengembalikan string kosong bila tidak ada perbedaan.
+func Unified(fromName, toName string, ops []LineOp, context int) string {
+	if context < 0 {
+		context = 0
+	}
+	// aPos[i]/bPos[i] = jumlah baris a/b sebelum op ke-i.
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if op.Kind != OpInsert {
+			aPos[i+1]++
+		}
+		if op.Kind != OpDelete {
+			bPos[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].Kind == OpEqual {
+			i++
+			continue
+		}
+		start := max(0, i-context)
+		end := i
+		// Gabungkan perubahan yang jaraknya ≤ 2*context ke hunk yang sama.
+		for j := i; j < len(ops); j++ {
+			if ops[j].Kind != OpEqual {
+				end = j
+				continue
+			}
+			if j-end > 2*context {
+				break
+			}
+		}
+		stop := min(len(ops), end+context+1)
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		aCount, bCount := aPos[stop]-aPos[start], bPos[stop]-bPos[start]
+		aStart, bStart := aPos[start]+1, bPos[start]+1
+		if aCount == 0 {
+			aStart--
+		}
+		if bCount == 0 {
+			bStart--
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+		for _, op := range ops[start:stop] {
+			switch op.Kind {
+			case OpEqual:
+				sb.WriteByte(' ')
+			case OpDelete:
+				sb.WriteByte('-')
+			case OpInsert:
+				sb.WriteByte('+')
+			}
+			sb.WriteString(op.Text)
+			sb.WriteByte('\n')
+		}
+		i = stop
+	}
+	return sb.String()
+}
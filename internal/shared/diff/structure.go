@@ -0,0 +1,217 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded           ChangeKind = "added"
+	ChangeRemoved         ChangeKind = "removed"
+	ChangeModified        ChangeKind = "changed"
+	ChangeTypeChanged     ChangeKind = "type_changed"
+	ChangeRequiredAdded   ChangeKind = "required_added"
+	ChangeRequiredRemoved ChangeKind = "required_removed"
+)
+
+// Change satu perbedaan struktural pada path tertentu (mis. "customer.name", "items[].qty").
+type Change struct {
+	Path string
+	Kind ChangeKind
+	From any
+	To   any
+}
+
+// Schema membandingkan dua JSON Schema: properti yang ditambah/dihapus, perubahan type,
+// dan perubahan daftar required. Sub-schema properties dan items ditelusuri rekursif.
+func Schema(from, to map[string]any) []Change {
+	var out []Change
+	walkSchema("", from, to, &out)
+	return out
+}
+
+func walkSchema(path string, from, to map[string]any, out *[]Change) {
+	if ft, tt := SchemaType(from), SchemaType(to); ft != "" && tt != "" && ft != tt {
+		*out = append(*out, Change{Path: displayPath(path), Kind: ChangeTypeChanged, From: ft, To: tt})
+	}
+
+	fromReq, toReq := stringSet(from["required"]), stringSet(to["required"])
+	for _, name := range sortedKeys(toReq) {
+		if !fromReq[name] {
+			*out = append(*out, Change{Path: joinPath(path, name), Kind: ChangeRequiredAdded})
+		}
+	}
+	for _, name := range sortedKeys(fromReq) {
+		if !toReq[name] {
+			*out = append(*out, Change{Path: joinPath(path, name), Kind: ChangeRequiredRemoved})
+		}
+	}
+
+	fromProps, toProps := asMap(from["properties"]), asMap(to["properties"])
+	for _, name := range unionKeys(fromProps, toProps) {
+		fp, inFrom := fromProps[name]
+		tp, inTo := toProps[name]
+		p := joinPath(path, name)
+		switch {
+		case !inFrom:
+			*out = append(*out, Change{Path: p, Kind: ChangeAdded, To: SchemaType(asMap(tp))})
+		case !inTo:
+			*out = append(*out, Change{Path: p, Kind: ChangeRemoved, From: SchemaType(asMap(fp))})
+		default:
+			walkSchema(p, asMap(fp), asMap(tp), out)
+		}
+	}
+
+	fromItems, toItems := asMap(from["items"]), asMap(to["items"])
+	if fromItems != nil && toItems != nil {
+		walkSchema(path+"[]", fromItems, toItems, out)
+	}
+}
+
+// SchemaType mengembalikan "type" sebuah sub-schema; union type digabung dengan "|" (terurut).
+func SchemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, p := range t {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, "|")
+	case []string:
+		parts := append([]string(nil), t...)
+		sort.Strings(parts)
+		return strings.Join(parts, "|")
+	}
+	return ""
+}
+
+// SchemaAt mengambil sub-schema pada path ("customer.name", "items[].qty"); nil bila tidak ada.
+func SchemaAt(schema map[string]any, path string) map[string]any {
+	cur := schema
+	if path == "" {
+		return cur
+	}
+	for _, seg := range strings.Split(path, ".") {
+		name := strings.TrimRight(seg, "[]")
+		cur = asMap(asMap(cur["properties"])[name])
+		for i := len(name); i+1 < len(seg) && cur != nil; i += 2 {
+			cur = asMap(cur["items"])
+		}
+		if cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// Values membandingkan dua nilai JSON (map/slice/skalar) secara rekursif.
+func Values(from, to any) []Change {
+	var out []Change
+	walkValues("", from, to, &out)
+	return out
+}
+
+func walkValues(path string, from, to any, out *[]Change) {
+	fm, fIsMap := from.(map[string]any)
+	tm, tIsMap := to.(map[string]any)
+	if fIsMap && tIsMap {
+		for _, k := range unionKeys(fm, tm) {
+			fv, inFrom := fm[k]
+			tv, inTo := tm[k]
+			p := joinPath(path, k)
+			switch {
+			case !inFrom:
+				*out = append(*out, Change{Path: p, Kind: ChangeAdded, To: tv})
+			case !inTo:
+				*out = append(*out, Change{Path: p, Kind: ChangeRemoved, From: fv})
+			default:
+				walkValues(p, fv, tv, out)
+			}
+		}
+		return
+	}
+
+	fs, fIsSlice := from.([]any)
+	ts, tIsSlice := to.([]any)
+	if fIsSlice && tIsSlice {
+		for i := 0; i < max(len(fs), len(ts)); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(fs):
+				*out = append(*out, Change{Path: p, Kind: ChangeAdded, To: ts[i]})
+			case i >= len(ts):
+				*out = append(*out, Change{Path: p, Kind: ChangeRemoved, From: fs[i]})
+			default:
+				walkValues(p, fs[i], ts[i], out)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*out = append(*out, Change{Path: displayPath(path), Kind: ChangeModified, From: from, To: to})
+	}
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func displayPath(p string) string {
+	if p == "" {
+		return "$"
+	}
+	return p
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func stringSet(v any) map[string]bool {
+	set := map[string]bool{}
+	switch list := v.(type) {
+	case []any:
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				set[s] = true
+			}
+		}
+	case []string:
+		for _, s := range list {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys(a, b map[string]any) []string {
+	seen := make(map[string]any, len(a)+len(b))
+	for k := range a {
+		seen[k] = nil
+	}
+	for k := range b {
+		seen[k] = nil
+	}
+	return sortedKeys(seen)
+}
@@ -0,0 +1,173 @@
+// Package einvoice berisi konfigurasi e-invoice terstruktur (UBL / CII): pemeriksaan dasar XML hasil
+// render sebelum validasi XSD dan metadata Factur-X untuk PDF/A-3 hybrid dengan XML ter-embed.
+package einvoice
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Syntax e-invoice; menentukan XSD yang dipakai validasi.
+const (
+	SyntaxUBL = "UBL"
+	SyntaxCII = "CII"
+)
+
+// Profil Factur-X (fx:ConformanceLevel).
+const (
+	ProfileMinimum  = "MINIMUM"
+	ProfileBasicWL  = "BASIC WL"
+	ProfileBasic    = "BASIC"
+	ProfileEN16931  = "EN 16931"
+	ProfileExtended = "EXTENDED"
+)
+
+// FacturXFileName nama file XML yang di-embed di PDF hybrid (wajib menurut Factur-X).
+const FacturXFileName = "factur-x.xml"
+
+const facturXNamespace = "urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#"
+
+var profiles = []string{ProfileMinimum, ProfileBasicWL, ProfileBasic, ProfileEN16931, ProfileExtended}
+
+// rootNamespaces namespace elemen root yang sah per syntax.
+var rootNamespaces = map[string][]string{
+	SyntaxUBL: {
+		"urn:oasis:names:specification:ubl:schema:xsd:Invoice-2",
+		"urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2",
+	},
+	SyntaxCII: {"urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100"},
+}
+
+// Spec e-invoice pada versi template. Output XML: content versi adalah template XML dan Content
+// kosong. Output PDF (hybrid Factur-X): Content berisi template XML CII yang di-embed ke PDF/A-3.
+type Spec struct {
+	Syntax  string `json:"syntax"`
+	Content string `json:"content,omitempty"`
+	Profile string `json:"profile,omitempty"` // hybrid saja; default EN 16931
+}
+
+// Hybrid true bila spec menghasilkan XML pendamping untuk PDF.
+func (s Spec) Hybrid() bool {
+	return strings.TrimSpace(s.Content) != ""
+}
+
+// Validate memeriksa spec; hybrid true untuk versi ber-output PDF.
+func (s Spec) Validate(hybrid bool) error {
+	if _, ok := rootNamespaces[s.Syntax]; !ok {
+		return fmt.Errorf("unsupported syntax %q (UBL or CII)", s.Syntax)
+	}
+	if !hybrid {
+		if s.Content != "" || s.Profile != "" {
+			return errors.New("content and profile are only used with PDF output")
+		}
+		return nil
+	}
+	if !s.Hybrid() {
+		return errors.New("content is required for PDF output")
+	}
+	if s.Syntax != SyntaxCII {
+		return errors.New("Factur-X embeds CII; syntax must be CII")
+	}
+	if s.Profile != "" && !slices.Contains(profiles, s.Profile) {
+		return fmt.Errorf("unsupported profile %q", s.Profile)
+	}
+	return nil
+}
+
+// WithDefaults mengisi profil default.
+func (s Spec) WithDefaults() Spec {
+	if s.Hybrid() && s.Profile == "" {
+		s.Profile = ProfileEN16931
+	}
+	return s
+}
+
+// CheckRoot memastikan XML well-formed dan elemen root sesuai syntax.
+func CheckRoot(data []byte, syntax string) error {
+	if err := WellFormed(data); err != nil {
+		return err
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return errors.New("xml has no root element")
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			if !slices.Contains(rootNamespaces[syntax], el.Name.Space) {
+				return fmt.Errorf("root element {%s}%s is not a %s invoice", el.Name.Space, el.Name.Local, syntax)
+			}
+			return nil
+		}
+	}
+}
+
+// WellFormed memastikan data adalah dokumen XML yang well-formed dengan satu elemen root.
+func WellFormed(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	roots := 0
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("xml is not well-formed: %w", err)
+		}
+		switch tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	if roots != 1 {
+		return fmt.Errorf("xml must have exactly one root element, found %d", roots)
+	}
+	return nil
+}
+
+// Escape meng-escape nilai untuk teks/atribut XML (fungsi template {{xml .x}}).
+func Escape(v any) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(fmt.Sprint(v)))
+	return b.String()
+}
+
+// FacturXMetadata blok XMP Factur-X (properti fx dan skema ekstensi PDF/A) untuk PDF hybrid.
+func FacturXMetadata(profile string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<rdf:Description rdf:about="" xmlns:fx="%s">`, facturXNamespace)
+	b.WriteString(`<fx:DocumentType>INVOICE</fx:DocumentType>`)
+	fmt.Fprintf(&b, `<fx:DocumentFileName>%s</fx:DocumentFileName>`, FacturXFileName)
+	b.WriteString(`<fx:Version>1.0</fx:Version>`)
+	fmt.Fprintf(&b, `<fx:ConformanceLevel>%s</fx:ConformanceLevel>`, Escape(profile))
+	b.WriteString(`</rdf:Description>`)
+	b.WriteString(`<rdf:Description rdf:about="" xmlns:pdfaExtension="http://www.aiim.org/pdfa/ns/extension/"` +
+		` xmlns:pdfaSchema="http://www.aiim.org/pdfa/ns/schema#" xmlns:pdfaProperty="http://www.aiim.org/pdfa/ns/property#">`)
+	b.WriteString(`<pdfaExtension:schemas><rdf:Bag><rdf:li rdf:parseType="Resource">`)
+	b.WriteString(`<pdfaSchema:schema>Factur-X PDFA Extension Schema</pdfaSchema:schema>`)
+	fmt.Fprintf(&b, `<pdfaSchema:namespaceURI>%s</pdfaSchema:namespaceURI>`, facturXNamespace)
+	b.WriteString(`<pdfaSchema:prefix>fx</pdfaSchema:prefix><pdfaSchema:property><rdf:Seq>`)
+	for _, p := range [][2]string{
+		{"DocumentFileName", "name of the embedded XML invoice file"},
+		{"DocumentType", "INVOICE"},
+		{"Version", "the actual version of the Factur-X XML schema"},
+		{"ConformanceLevel", "the conformance level of the embedded Factur-X data"},
+	} {
+		fmt.Fprintf(&b, `<rdf:li rdf:parseType="Resource"><pdfaProperty:name>%s</pdfaProperty:name>`+
+			`<pdfaProperty:valueType>Text</pdfaProperty:valueType><pdfaProperty:category>external</pdfaProperty:category>`+
+			`<pdfaProperty:description>%s</pdfaProperty:description></rdf:li>`, p[0], p[1])
+	}
+	b.WriteString(`</rdf:Seq></pdfaSchema:property></rdf:li></rdf:Bag></pdfaExtension:schemas></rdf:Description>`)
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package einvoice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		spec   Spec
+		hybrid bool
+		ok     bool
+	}{
+		{Spec{Syntax: SyntaxUBL}, false, true},
+		{Spec{Syntax: SyntaxCII}, false, true},
+		{Spec{Syntax: SyntaxCII, Content: "<rsm:CrossIndustryInvoice/>"}, true, true},
+		{Spec{Syntax: SyntaxCII, Content: "<x/>", Profile: ProfileBasicWL}, true, true},
+		{Spec{Syntax: "EDIFACT"}, false, false},
+		{Spec{Syntax: SyntaxUBL, Content: "<x/>"}, false, false},
+		{Spec{Syntax: SyntaxCII}, true, false},
+		{Spec{Syntax: SyntaxUBL, Content: "<x/>"}, true, false},
+		{Spec{Syntax: SyntaxCII, Content: "<x/>", Profile: "XRECHNUNG"}, true, false},
+	}
+	for _, c := range cases {
+		if err := c.spec.Validate(c.hybrid); (err == nil) != c.ok {
+			t.Errorf("%+v (hybrid %v): err = %v", c.spec, c.hybrid, err)
+		}
+	}
+	if p := (Spec{Syntax: SyntaxCII, Content: "<x/>"}).WithDefaults().Profile; p != ProfileEN16931 {
+		t.Errorf("default profile = %q", p)
+	}
+}
+
+func TestCheckRoot(t *testing.T) {
+	ubl := `<?xml version="1.0"?><Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"><ID>1</ID></Invoice>`
+	cii := `<rsm:CrossIndustryInvoice xmlns:rsm="urn:un:unece:uncefact:data:standard:CrossIndustryInvoice:100"/>`
+	if err := CheckRoot([]byte(ubl), SyntaxUBL); err != nil {
+		t.Error(err)
+	}
+	if err := CheckRoot([]byte(cii), SyntaxCII); err != nil {
+		t.Error(err)
+	}
+	if err := CheckRoot([]byte(ubl), SyntaxCII); err == nil {
+		t.Error("UBL accepted as CII")
+	}
+	for _, bad := range []string{"<a><b></a>", "<a/><b/>", "", "text"} {
+		if err := WellFormed([]byte(bad)); err == nil {
+			t.Errorf("WellFormed(%q): expected error", bad)
+		}
+	}
+}
+
+func TestEscapeAndMetadata(t *testing.T) {
+	if got := Escape(`PT A&B <"x">`); got != "PT A&amp;B &lt;&#34;x&#34;&gt;" {
+		t.Errorf("Escape = %s", got)
+	}
+	if got := Escape(12.5); got != "12.5" {
+		t.Errorf("Escape(number) = %s", got)
+	}
+	md := FacturXMetadata(ProfileEN16931)
+	for _, want := range []string{
+		"<fx:DocumentFileName>factur-x.xml</fx:DocumentFileName>",
+		"<fx:ConformanceLevel>EN 16931</fx:ConformanceLevel>",
+		"<pdfaSchema:prefix>fx</pdfaSchema:prefix>",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if err := WellFormed([]byte(`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` + md + `</rdf:RDF>`)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Layout singkatan yang bisa dipakai selain layout Go.
+var namedLayouts = map[string]string{
+	"date":     "2 January 2006",
+	"short":    "02/01/2006",
+	"datetime": "2 January 2006 15:04",
+	"iso":      "2006-01-02",
+	"rfc3339":  time.RFC3339,
+}
+
+var inputLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"02/01/2006",
+}
+
+// ParseTime menerima time.Time, string (RFC3339, "2006-01-02", "2006-01-02 15:04:05", "02/01/2006")
+// atau angka unix detik.
+func ParseTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, fmt.Errorf("time is nil")
+		}
+		return *t, nil
+	case string:
+		s := strings.TrimSpace(t)
+		for _, l := range inputLayouts {
+			if parsed, err := time.Parse(l, s); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("%q is not a recognized date", t)
+	default:
+		f, err := ToFloat(v)
+		if err != nil || v == nil {
+			return time.Time{}, fmt.Errorf("%v (%T) is not a date", v, v)
+		}
+		return time.Unix(int64(f), 0).UTC(), nil
+	}
+}
+
+// InTimezone mengonversi waktu ke zona IANA (mis. "Asia/Jakarta").
+func InTimezone(tz string, v any) (time.Time, error) {
+	t, err := ParseTime(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown timezone %q", tz)
+	}
+	return t.In(loc), nil
+}
+
+var (
+	idNames = map[string]string{
+		"January": "Januari", "February": "Februari", "March": "Maret", "April": "April",
+		"May": "Mei", "June": "Juni", "July": "Juli", "August": "Agustus",
+		"September": "September", "October": "Oktober", "November": "November", "December": "Desember",
+		"Jan": "Jan", "Feb": "Feb", "Mar": "Mar", "Apr": "Apr", "Jun": "Jun", "Jul": "Jul",
+		"Aug": "Agu", "Sep": "Sep", "Oct": "Okt", "Nov": "Nov", "Dec": "Des",
+		"Monday": "Senin", "Tuesday": "Selasa", "Wednesday": "Rabu", "Thursday": "Kamis",
+		"Friday": "Jumat", "Saturday": "Sabtu", "Sunday": "Minggu",
+		"Mon": "Sen", "Tue": "Sel", "Wed": "Rab", "Thu": "Kam", "Fri": "Jum", "Sat": "Sab", "Sun": "Min",
+	}
+	englishName = regexp.MustCompile(`\b(January|February|March|April|May|June|July|August|September|October|November|December|Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday|Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Oct|Nov|Dec|Mon|Tue|Wed|Thu|Fri|Sat|Sun)\b`)
+)
+
+// FormatDate memformat waktu dengan layout Go (atau nama di namedLayouts) dan nama bulan/hari
+// sesuai locale ("id" atau "en").
+func FormatDate(locale, layout string, v any) (string, error) {
+	t, err := ParseTime(v)
+	if err != nil {
+		return "", err
+	}
+	if named, ok := namedLayouts[layout]; ok {
+		layout = named
+	}
+	out := t.Format(layout)
+	if baseLocale(locale) == "id" {
+		out = englishName.ReplaceAllStringFunc(out, func(m string) string { return idNames[m] })
+	}
+	return out, nil
+}
@@ -0,0 +1,149 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Separator ribuan/desimal per locale.
+type separators struct {
+	thousand string
+	decimal  string
+}
+
+var localeSeparators = map[string]separators{
+	"id": {thousand: ".", decimal: ","},
+	"en": {thousand: ",", decimal: "."},
+}
+
+func separatorsFor(locale string) separators {
+	if s, ok := localeSeparators[baseLocale(locale)]; ok {
+		return s
+	}
+	return localeSeparators["en"]
+}
+
+// baseLocale "id-ID" / "id_ID" -> "id".
+func baseLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		locale = locale[:i]
+	}
+	return locale
+}
+
+// Number memformat angka dengan pemisah ribuan locale dan jumlah desimal tetap.
+func Number(v float64, decimals int, locale string) string {
+	return grouped(v, decimals, separatorsFor(locale))
+}
+
+func grouped(v float64, decimals int, sep separators) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	intPart, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(sep.thousand)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(sep.decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+type currency struct {
+	symbol   string
+	decimals int
+	locale   string
+}
+
+// Simbol dan desimal mata uang yang umum dipakai; kode lain ditulis "XXX 1,234.00".
+var currencies = map[string]currency{
+	"IDR": {symbol: "Rp ", decimals: 0, locale: "id"},
+	"USD": {symbol: "$", decimals: 2, locale: "en"},
+	"EUR": {symbol: "€", decimals: 2, locale: "en"},
+	"GBP": {symbol: "£", decimals: 2, locale: "en"},
+	"SGD": {symbol: "S$", decimals: 2, locale: "en"},
+	"MYR": {symbol: "RM", decimals: 2, locale: "en"},
+	"AUD": {symbol: "A$", decimals: 2, locale: "en"},
+	"JPY": {symbol: "¥", decimals: 0, locale: "en"},
+	"CNY": {symbol: "CN¥", decimals: 2, locale: "en"},
+}
+
+// Currency memformat nominal dengan simbol mata uang ISO 4217.
+// Mata uang tanpa desimal (IDR, JPY) tetap menampilkan sen bila nominal tidak bulat.
+func Currency(code string, v float64) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	c, ok := currencies[code]
+	if !ok {
+		c = currency{symbol: code + " ", decimals: 2, locale: "en"}
+	}
+	decimals := c.decimals
+	if decimals == 0 && math.Abs(v-math.Round(v)) >= 0.005 {
+		decimals = 2
+	}
+	s := grouped(math.Abs(v), decimals, separatorsFor(c.locale))
+	if v < 0 && s != grouped(0, decimals, separatorsFor(c.locale)) {
+		return "-" + c.symbol + s
+	}
+	return c.symbol + s
+}
+
+// Rupiah singkatan Currency("IDR", v): "Rp 1.250.000".
+func Rupiah(v float64) string {
+	return Currency("IDR", v)
+}
+
+// ToFloat mengubah nilai payload (angka JSON, integer, string angka) menjadi float64; nil menjadi 0.
+func ToFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int8:
+		return float64(n), nil
+	case int16:
+		return float64(n), nil
+	case int32:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint:
+		return float64(n), nil
+	case uint8:
+		return float64(n), nil
+	case uint16:
+		return float64(n), nil
+	case uint32:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case json.Number:
+		return n.Float64()
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", n)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("%v (%T) is not a number", v, v)
+	}
+}
@@ -0,0 +1,148 @@
+package format
+
+import (
+	"math"
+	"strings"
+)
+
+var satuan = []string{"", "satu", "dua", "tiga", "empat", "lima", "enam", "tujuh", "delapan", "sembilan", "sepuluh", "sebelas"}
+
+// Terbilang menuliskan angka dalam Bahasa Indonesia, mis. 1250000 -> "satu juta dua ratus lima puluh ribu".
+// Pecahan dibaca per digit setelah "koma" (maks. 2 digit).
+func Terbilang(v float64) string {
+	whole, cents := splitCents(v)
+	out := terbilangInt(whole)
+	if cents > 0 {
+		out += " koma " + digitsWords(cents, satuan[1:10], "nol")
+	}
+	if v < 0 && (whole > 0 || cents > 0) {
+		out = "minus " + out
+	}
+	return out
+}
+
+// TerbilangRupiah seperti Terbilang dengan satuan mata uang: "... rupiah", sen ditulis "... sen".
+func TerbilangRupiah(v float64) string {
+	whole, cents := splitCents(v)
+	out := terbilangInt(whole) + " rupiah"
+	if cents > 0 {
+		out += " " + terbilangInt(cents) + " sen"
+	}
+	if v < 0 && (whole > 0 || cents > 0) {
+		out = "minus " + out
+	}
+	return out
+}
+
+func terbilangInt(n int64) string {
+	if n == 0 {
+		return "nol"
+	}
+	return strings.Join(strings.Fields(terbilangRec(n)), " ")
+}
+
+func terbilangRec(n int64) string {
+	switch {
+	case n < 12:
+		return satuan[n]
+	case n < 20:
+		return terbilangRec(n-10) + " belas"
+	case n < 100:
+		return terbilangRec(n/10) + " puluh " + terbilangRec(n%10)
+	case n < 200:
+		return "seratus " + terbilangRec(n-100)
+	case n < 1000:
+		return terbilangRec(n/100) + " ratus " + terbilangRec(n%100)
+	case n < 2000:
+		return "seribu " + terbilangRec(n-1000)
+	case n < 1e6:
+		return terbilangRec(n/1e3) + " ribu " + terbilangRec(n%1e3)
+	case n < 1e9:
+		return terbilangRec(n/1e6) + " juta " + terbilangRec(n%1e6)
+	case n < 1e12:
+		return terbilangRec(n/1e9) + " miliar " + terbilangRec(n%1e9)
+	case n < 1e15:
+		return terbilangRec(n/1e12) + " triliun " + terbilangRec(n%1e12)
+	default:
+		return terbilangRec(n/1e15) + " kuadriliun " + terbilangRec(n%1e15)
+	}
+}
+
+var (
+	enOnes = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	enScales = []struct {
+		value int64
+		name  string
+	}{{1e15, "quadrillion"}, {1e12, "trillion"}, {1e9, "billion"}, {1e6, "million"}, {1e3, "thousand"}}
+)
+
+// InWords menuliskan angka dalam bahasa Inggris, mis. 1250 -> "one thousand two hundred fifty".
+// Pecahan dibaca per digit setelah "point" (maks. 2 digit).
+func InWords(v float64) string {
+	whole, cents := splitCents(v)
+	out := "zero"
+	if whole > 0 {
+		out = englishInt(whole)
+	}
+	if cents > 0 {
+		out += " point " + digitsWords(cents, enOnes[1:10], "zero")
+	}
+	if v < 0 && (whole > 0 || cents > 0) {
+		out = "minus " + out
+	}
+	return out
+}
+
+func englishInt(n int64) string {
+	var parts []string
+	for _, s := range enScales {
+		if n >= s.value {
+			parts = append(parts, englishHundreds(n/s.value), s.name)
+			n %= s.value
+		}
+	}
+	if n > 0 {
+		parts = append(parts, englishHundreds(n))
+	}
+	return strings.Join(parts, " ")
+}
+
+func englishHundreds(n int64) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, enOnes[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		parts = append(parts, enTens[n/10]+"-"+enOnes[n%10])
+	case n >= 20:
+		parts = append(parts, enTens[n/10])
+	case n > 0:
+		parts = append(parts, enOnes[n])
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitCents memisah nilai absolut menjadi bagian bulat dan 2 digit pecahan (dibulatkan).
+func splitCents(v float64) (int64, int64) {
+	total := int64(math.Round(math.Abs(v) * 100))
+	return total / 100, total % 100
+}
+
+// digitsWords membaca 2 digit pecahan per digit; nol di belakang dibuang (0,50 -> "lima").
+func digitsWords(cents int64, ones []string, zero string) string {
+	d1, d2 := cents/10, cents%10
+	word := func(d int64) string {
+		if d == 0 {
+			return zero
+		}
+		return ones[d-1]
+	}
+	if d2 == 0 {
+		return word(d1)
+	}
+	return word(d1) + " " + word(d2)
+}
@@ -0,0 +1,44 @@
+package format
+
+import "testing"
+
+func TestTerbilang(t *testing.T) {
+	cases := map[float64]string{
+		0:          "nol",
+		11:         "sebelas",
+		19:         "sembilan belas",
+		100:        "seratus",
+		115:        "seratus lima belas",
+		1000:       "seribu",
+		1250000:    "satu juta dua ratus lima puluh ribu",
+		2001000000: "dua miliar satu juta",
+		-75:        "minus tujuh puluh lima",
+		12.5:       "dua belas koma lima",
+	}
+	for in, want := range cases {
+		if got := Terbilang(in); got != want {
+			t.Errorf("Terbilang(%v) = %q, want %q", in, got, want)
+		}
+	}
+	if got, want := TerbilangRupiah(1500.25), "seribu lima ratus rupiah dua puluh lima sen"; got != want {
+		t.Errorf("TerbilangRupiah = %q, want %q", got, want)
+	}
+}
+
+func TestInWordsAndCurrency(t *testing.T) {
+	if got, want := InWords(1234567), "one million two hundred thirty-four thousand five hundred sixty-seven"; got != want {
+		t.Errorf("InWords = %q, want %q", got, want)
+	}
+	cases := map[string]string{
+		Rupiah(1234567):           "Rp 1.234.567",
+		Rupiah(1500.5):            "Rp 1.500,50",
+		Currency("USD", -1234.5):  "-$1,234.50",
+		Currency("CHF", 10):       "CHF 10.00",
+		Number(1234.567, 2, "id"): "1.234,57",
+	}
+	for got, want := range cases {
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
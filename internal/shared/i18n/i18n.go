@@ -0,0 +1,184 @@
+// Package i18n bundle terjemahan template: lookup per locale dengan rantai fallback,
+// pluralisasi sederhana (key.zero / key.one / key.other) dan interpolasi {nama}.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-document-generator/internal/shared/format"
+)
+
+// Bundles pesan per locale: locale -> key -> pesan.
+type Bundles map[string]map[string]string
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// ValidLocale true untuk tag BCP 47 sederhana ("id", "en-US", "zh_Hant_TW").
+func ValidLocale(l string) bool {
+	return localePattern.MatchString(l)
+}
+
+// Normalize menyeragamkan tag locale: huruf kecil dengan pemisah "-".
+func Normalize(l string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(l), "_", "-"))
+}
+
+// Chain menyusun rantai fallback dari locale berurutan; tiap locale diikuti induknya,
+// mis. ("en-US", "id") -> [en-us en id]. Locale kosong dan duplikat dilewati.
+func Chain(locales ...string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, l := range locales {
+		l = Normalize(l)
+		for l != "" {
+			if !seen[l] {
+				seen[l] = true
+				out = append(out, l)
+			}
+			i := strings.LastIndex(l, "-")
+			if i < 0 {
+				break
+			}
+			l = l[:i]
+		}
+	}
+	return out
+}
+
+// Translator me-resolve pesan dari Bundles mengikuti rantai fallback. Zero value mengembalikan key apa adanya.
+type Translator struct {
+	locale  string
+	chain   []string
+	bundles map[string]map[string]string
+}
+
+// New membuat Translator; locales urut prioritas (mis. locale dokumen lalu default versi).
+func New(b Bundles, locales ...string) Translator {
+	norm := make(map[string]map[string]string, len(b))
+	for l, msgs := range b {
+		norm[Normalize(l)] = msgs
+	}
+	t := Translator{chain: Chain(locales...), bundles: norm}
+	for _, l := range locales {
+		if l = strings.TrimSpace(l); l != "" {
+			t.locale = l
+			break
+		}
+	}
+	return t
+}
+
+// Locale locale pertama yang tidak kosong, seperti ditulis pemanggil (mis. "en-US").
+func (t Translator) Locale() string {
+	return t.locale
+}
+
+// Lookup mencari key di sepanjang rantai fallback.
+func (t Translator) Lookup(key string) (string, bool) {
+	for _, l := range t.chain {
+		if msg, ok := t.bundles[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// T menerjemahkan key. args berupa pasangan nama/nilai ("name", .customer.name) atau satu map;
+// nilai "count" memilih bentuk jamak key.zero / key.one / key.other bila ada.
+// Key yang tidak ditemukan dikembalikan apa adanya agar render tidak gagal.
+func (t Translator) T(key string, args ...any) (string, error) {
+	vars, err := pairs(args)
+	if err != nil {
+		return "", fmt.Errorf("t %q: %w", key, err)
+	}
+	msg, ok := "", false
+	if c, has := vars["count"]; has {
+		msg, ok = t.plural(key, c)
+	}
+	if !ok {
+		if msg, ok = t.Lookup(key); !ok {
+			return key, nil
+		}
+	}
+	return interpolate(msg, vars), nil
+}
+
+func (t Translator) plural(key string, count any) (string, bool) {
+	n, err := format.ToFloat(count)
+	if err != nil {
+		return "", false
+	}
+	var forms []string
+	switch n {
+	case 0:
+		forms = []string{"zero", "other"}
+	case 1:
+		forms = []string{"one", "other"}
+	default:
+		forms = []string{"other"}
+	}
+	for _, f := range forms {
+		if msg, ok := t.Lookup(key + "." + f); ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+func pairs(args []any) (map[string]any, error) {
+	if len(args) == 1 {
+		if m, ok := args[0].(map[string]any); ok {
+			return m, nil
+		}
+	}
+	if len(args)%2 != 0 {
+		return nil, errors.New("arguments must be name/value pairs")
+	}
+	out := make(map[string]any, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		name, ok := args[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("argument name %v must be a string", args[i])
+		}
+		out[name] = args[i+1]
+	}
+	return out, nil
+}
+
+var placeholder = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// interpolate mengganti {nama} dengan nilai; placeholder tanpa nilai dibiarkan.
+func interpolate(msg string, vars map[string]any) string {
+	if len(vars) == 0 {
+		return msg
+	}
+	return placeholder.ReplaceAllStringFunc(msg, func(m string) string {
+		if v, ok := vars[m[1:len(m)-1]]; ok {
+			if f, isFloat := v.(float64); isFloat {
+				return strconv.FormatFloat(f, 'f', -1, 64)
+			}
+			return fmt.Sprint(v)
+		}
+		return m
+	})
+}
+
+// Missing key yang tidak bisa di-resolve untuk locale tertentu (dengan fallback ke def), urut sesuai keys.
+func Missing(b Bundles, locale, def string, keys []string) []string {
+	t := New(b, locale, def)
+	var out []string
+	for _, k := range keys {
+		if _, ok := t.Lookup(k); ok {
+			continue
+		}
+		if _, ok := t.plural(k, 2); ok {
+			continue
+		}
+		out = append(out, k)
+	}
+	return out
+}
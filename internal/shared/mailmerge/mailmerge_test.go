@@ -0,0 +1,184 @@
+package mailmerge
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	data := "\xef\xbb\xbfno;nama;\"alamat\"\n1;Budi;\"Jl. A; No. 2\"\n\n2;Sari\n"
+	recs, err := Read([]byte(data), FormatCSV, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2", len(recs))
+	}
+	if recs[0].Line != 2 || recs[0].Values["alamat"] != "Jl. A; No. 2" {
+		t.Errorf("record 0 = %+v", recs[0])
+	}
+	if recs[1].Line != 4 || recs[1].Values["alamat"] != "" {
+		t.Errorf("record 1 = %+v", recs[1])
+	}
+	if _, err := Read([]byte("a,b\n1,2\n3,4\n"), FormatCSV, 1); err == nil {
+		t.Error("expected row limit error")
+	}
+	if _, err := Read([]byte("a,a\n1,2\n"), FormatCSV, 0); err == nil {
+		t.Error("expected duplicate header error")
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	recs, err := Read([]byte("{\"a\":1}\n\n{\"a\":{\"b\":true}}\n"), FormatJSONL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[1].Line != 3 {
+		t.Fatalf("records = %+v", recs)
+	}
+	if _, err := Read([]byte("[1,2]\n"), FormatJSONL, 0); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	if got := DetectFormat("DATA.JSONL", nil); got != FormatJSONL {
+		t.Errorf("got %s", got)
+	}
+	if got := DetectFormat("upload", []byte("  {\"a\":1}")); got != FormatJSONL {
+		t.Errorf("got %s", got)
+	}
+	if got := DetectFormat("upload", []byte("a,b")); got != FormatCSV {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestBuildGrouped(t *testing.T) {
+	csv := "invoice,tanggal,customer,sku,qty,harga\n" +
+		"INV-1,19/10/2026,Budi,A,2,\"1.500,5\"\n" +
+		"INV-2,20/10/2026,Sari,B,1,100\n" +
+		"INV-1,19/10/2026,Budi,C,3,10\n" +
+		"INV-2,20/10/2026,Sari,,,\n"
+	recs, err := Read([]byte(csv), FormatCSV, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := Mapping{
+		Fields: []Field{
+			{Column: "invoice", Path: "invoice_no", Required: true},
+			{Column: "tanggal", Path: "meta.date", Type: TypeDate, Format: "02/01/2006"},
+			{Column: "customer", Path: "customer.name"},
+			{Column: "sku", Path: "items[].sku"},
+			{Column: "qty", Path: "items[].qty", Type: TypeInteger},
+			{Column: "harga", Path: "items[].price", Type: TypeNumber, Format: ","},
+		},
+		GroupBy:         "invoice",
+		RequestIDColumn: "invoice",
+		RequestIDPrefix: "imp-",
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	rows, errs := Build(recs, m)
+	if len(errs) != 0 {
+		t.Fatalf("errors = %+v", errs)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows", len(rows))
+	}
+	got, _ := json.Marshal(rows[0].Payload)
+	want := `{"customer":{"name":"Budi"},"invoice_no":"INV-1","items":[{"price":1500.5,"qty":2,"sku":"A"},{"price":10,"qty":3,"sku":"C"}],"meta":{"date":"2026-10-19"}}`
+	if string(got) != want {
+		t.Errorf("payload\n got %s\nwant %s", got, want)
+	}
+	if rows[0].RequestID != "imp-INV-1" || len(rows[0].Lines) != 2 || rows[0].Lines[1] != 4 {
+		t.Errorf("row 0 = %+v", rows[0])
+	}
+	if items := rows[1].Payload["items"].([]any); len(items) != 1 {
+		t.Errorf("empty array element not skipped: %v", items)
+	}
+}
+
+func TestBuildRowErrors(t *testing.T) {
+	recs := []Record{
+		{Line: 2, Values: map[string]any{"id": "a", "qty": "x"}},
+		{Line: 3, Values: map[string]any{"id": "b", "qty": "1"}},
+		{Line: 4, Values: map[string]any{"id": "b", "qty": "2"}},
+		{Line: 5, Values: map[string]any{"id": "", "qty": ""}},
+	}
+	m := Mapping{
+		Fields:          []Field{{Column: "qty", Path: "qty", Type: TypeInteger, Required: true}},
+		RequestIDColumn: "id",
+	}
+	rows, errs := Build(recs, m)
+	if len(rows) != 1 || rows[0].RequestID != "b" {
+		t.Fatalf("rows = %+v", rows)
+	}
+	lines := map[int]bool{}
+	for _, e := range errs {
+		lines[e.Line] = true
+	}
+	if len(errs) != 3 || !lines[2] || !lines[4] || !lines[5] {
+		t.Errorf("errors = %+v", errs)
+	}
+}
+
+func TestBuildIdentity(t *testing.T) {
+	recs, _ := Read([]byte("{\"a\":{\"b\":1}}\n{\"c\":\"x\"}\n"), FormatJSONL, 0)
+	rows, errs := Build(recs, Mapping{})
+	if len(errs) != 0 || len(rows) != 2 {
+		t.Fatalf("rows = %+v errors = %+v", rows, errs)
+	}
+	if got, _ := json.Marshal(rows[0].Payload); string(got) != `{"a":{"b":1}}` {
+		t.Errorf("payload = %s", got)
+	}
+	if rows[1].Payload["c"] != "x" {
+		t.Errorf("payload = %v", rows[1].Payload)
+	}
+}
+
+func TestCoerce(t *testing.T) {
+	cases := []struct {
+		in   any
+		f    Field
+		want any
+	}{
+		{"ya", Field{Type: TypeBoolean}, true},
+		{float64(3), Field{Type: TypeInteger}, int64(3)},
+		{float64(12), Field{Type: TypeString}, "12"},
+		{"2026-10-19T07:00:00+07:00", Field{Type: TypeDateTime}, "2026-10-19T07:00:00+07:00"},
+		{`{"x":[1]}`, Field{Type: TypeJSON}, map[string]any{"x": []any{float64(1)}}},
+	}
+	for _, c := range cases {
+		got, empty, err := Coerce(c.in, c.f)
+		if err != nil || empty {
+			t.Errorf("%v: err=%v empty=%v", c.in, err, empty)
+			continue
+		}
+		g, _ := json.Marshal(got)
+		w, _ := json.Marshal(c.want)
+		if string(g) != string(w) {
+			t.Errorf("%v: got %s, want %s", c.in, g, w)
+		}
+	}
+	if _, _, err := Coerce("1.5", Field{Type: TypeInteger}); err == nil {
+		t.Error("expected integer error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []Mapping{
+		{Fields: []Field{{Column: "a", Path: "x[].y[].z"}}},
+		{Fields: []Field{{Column: "a", Path: "x..y"}}},
+		{Fields: []Field{{Column: "a", Path: "x[]y"}}},
+		{Fields: []Field{{Column: "a", Path: "x", Type: "money"}}},
+		{Fields: []Field{{Column: "a", Path: "x", Format: "02/01"}}},
+		{Fields: []Field{{Column: "a", Path: "x"}, {Column: "b", Path: "x"}}},
+	}
+	for i, m := range bad {
+		if err := m.Validate(); err == nil {
+			t.Errorf("case %d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package mailmerge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tipe koersi nilai kolom.
+const (
+	TypeAny      = ""
+	TypeString   = "string"
+	TypeInteger  = "integer"
+	TypeNumber   = "number"
+	TypeBoolean  = "boolean"
+	TypeDate     = "date"
+	TypeDateTime = "datetime"
+	TypeJSON     = "json"
+)
+
+// Field memetakan satu kolom ke path payload. Path memakai titik untuk objek bersarang dan "[]"
+// untuk array ("items[].qty"): setiap baris dalam satu grup menambah satu elemen.
+// Format: layout Go untuk date/datetime, atau "," untuk number dengan koma desimal (1.234,5).
+type Field struct {
+	Column   string `json:"column"`
+	Path     string `json:"path"`
+	Type     string `json:"type,omitempty"`
+	Format   string `json:"format,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// Mapping aturan baris -> payload. Tanpa Fields, setiap kolom dipetakan ke path bernama sama.
+// GroupBy menggabungkan baris berurutan maupun tidak dengan nilai kolom sama menjadi satu dokumen.
+type Mapping struct {
+	Fields          []Field `json:"fields"`
+	GroupBy         string  `json:"group_by,omitempty"`
+	RequestIDColumn string  `json:"request_id_column,omitempty"`
+	RequestIDPrefix string  `json:"request_id_prefix,omitempty"`
+}
+
+// RowError kesalahan satu baris input.
+type RowError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Row satu dokumen hasil pemetaan; Lines baris input pembentuknya.
+type Row struct {
+	Lines     []int
+	RequestID string
+	Payload   map[string]any
+}
+
+// Validate memeriksa kolom, path dan tipe mapping.
+func (m Mapping) Validate() error {
+	seen := make(map[string]bool, len(m.Fields))
+	for i, f := range m.Fields {
+		if strings.TrimSpace(f.Column) == "" {
+			return fmt.Errorf("fields[%d]: column is required", i)
+		}
+		if _, _, err := splitPath(f.Path); err != nil {
+			return fmt.Errorf("fields[%d]: %w", i, err)
+		}
+		if seen[f.Path] {
+			return fmt.Errorf("fields[%d]: duplicate path %q", i, f.Path)
+		}
+		seen[f.Path] = true
+		switch f.Type {
+		case TypeAny, TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeDate, TypeDateTime, TypeJSON:
+		default:
+			return fmt.Errorf("fields[%d]: unknown type %q", i, f.Type)
+		}
+		if f.Format != "" && f.Type != TypeDate && f.Type != TypeDateTime && f.Type != TypeNumber {
+			return fmt.Errorf("fields[%d]: format only applies to date, datetime and number", i)
+		}
+	}
+	return nil
+}
+
+// Build memetakan record menjadi payload. Grup yang salah satu barisnya gagal tidak dihasilkan;
+// kesalahannya dilaporkan per baris. Nilai skalar grup diambil dari baris pertama.
+func Build(records []Record, m Mapping) ([]Row, []RowError) {
+	fields := m.Fields
+	if len(fields) == 0 {
+		fields = identityFields(records)
+	}
+
+	type group struct {
+		records []Record
+	}
+	var order []string
+	groups := make(map[string]*group)
+	var errs []RowError
+	for i, rec := range records {
+		key := strconv.Itoa(i)
+		if m.GroupBy != "" {
+			key = text(rec.Values[m.GroupBy])
+			if key == "" {
+				errs = append(errs, RowError{Line: rec.Line, Column: m.GroupBy, Message: "group column is empty"})
+				continue
+			}
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.records = append(g.records, rec)
+	}
+
+	var rows []Row
+	requestIDs := make(map[string]int)
+	for _, key := range order {
+		g := groups[key]
+		row, rowErrs := buildGroup(g.records, fields)
+		if m.RequestIDColumn != "" && len(rowErrs) == 0 {
+			first := g.records[0]
+			id := text(first.Values[m.RequestIDColumn])
+			switch prev, dup := requestIDs[id]; {
+			case id == "":
+				rowErrs = append(rowErrs, RowError{Line: first.Line, Column: m.RequestIDColumn, Message: "request id is empty"})
+			case dup:
+				rowErrs = append(rowErrs, RowError{Line: first.Line, Column: m.RequestIDColumn, Message: fmt.Sprintf("duplicate request id (first seen on line %d)", prev)})
+			default:
+				requestIDs[id] = first.Line
+				row.RequestID = m.RequestIDPrefix + id
+			}
+		}
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, errs
+}
+
+// identityFields memetakan gabungan kolom seluruh record ke path bernama sama.
+func identityFields(records []Record) []Field {
+	seen := make(map[string]bool)
+	var fields []Field
+	for _, rec := range records {
+		for col := range rec.Values {
+			if !seen[col] {
+				seen[col] = true
+				fields = append(fields, Field{Column: col, Path: col})
+			}
+		}
+	}
+	return fields
+}
+
+func buildGroup(records []Record, fields []Field) (Row, []RowError) {
+	row := Row{Payload: map[string]any{}}
+	var errs []RowError
+	for ri, rec := range records {
+		row.Lines = append(row.Lines, rec.Line)
+		elements := make(map[string]map[string]any)
+		var arrays []string
+		scalars := make(map[string]any)
+		for _, f := range fields {
+			array, rest, err := splitPath(f.Path)
+			if err != nil {
+				errs = append(errs, RowError{Line: rec.Line, Column: f.Column, Message: err.Error()})
+				continue
+			}
+			if array == "" && ri > 0 {
+				continue
+			}
+			v, empty, err := Coerce(rec.Values[f.Column], f)
+			if err != nil {
+				errs = append(errs, RowError{Line: rec.Line, Column: f.Column, Message: err.Error()})
+				continue
+			}
+			if empty {
+				if f.Required {
+					errs = append(errs, RowError{Line: rec.Line, Column: f.Column, Message: "value is required"})
+				}
+				continue
+			}
+			if array == "" {
+				setPath(row.Payload, rest, v)
+				continue
+			}
+			if rest == "" {
+				scalars[array] = v
+				if _, ok := elements[array]; !ok {
+					arrays = append(arrays, array)
+				}
+				continue
+			}
+			el, ok := elements[array]
+			if !ok {
+				el = map[string]any{}
+				elements[array] = el
+				arrays = append(arrays, array)
+			}
+			setPath(el, rest, v)
+		}
+		for _, array := range arrays {
+			var v any = elements[array]
+			if s, ok := scalars[array]; ok {
+				v = s
+			}
+			appendPath(row.Payload, array, v)
+		}
+	}
+	return row, errs
+}
+
+// splitPath memisah "a.b[].c.d" menjadi bagian array ("a.b") dan sisa path ("c.d").
+// Path tanpa "[]" mengembalikan array kosong dan path utuh.
+func splitPath(path string) (array, rest string, err error) {
+	if strings.TrimSpace(path) == "" {
+		return "", "", fmt.Errorf("path is required")
+	}
+	if strings.Count(path, "[]") > 1 {
+		return "", "", fmt.Errorf("path %q: nested arrays are not supported", path)
+	}
+	if i := strings.Index(path, "[]"); i >= 0 {
+		array, rest = path[:i], path[i+2:]
+		if rest != "" && !strings.HasPrefix(rest, ".") {
+			return "", "", fmt.Errorf("path %q: \"[]\" must end a segment", path)
+		}
+		rest = strings.TrimPrefix(rest, ".")
+		if err := checkSegments(array, path); err != nil {
+			return "", "", err
+		}
+		if rest != "" {
+			if err := checkSegments(rest, path); err != nil {
+				return "", "", err
+			}
+		}
+		return array, rest, nil
+	}
+	return "", path, checkSegments(path, path)
+}
+
+func checkSegments(p, full string) error {
+	for _, seg := range strings.Split(p, ".") {
+		if seg == "" {
+			return fmt.Errorf("path %q: empty segment", full)
+		}
+	}
+	return nil
+}
+
+func setPath(m map[string]any, path string, v any) {
+	segs := strings.Split(path, ".")
+	for _, seg := range segs[:len(segs)-1] {
+		next, ok := m[seg].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[seg] = next
+		}
+		m = next
+	}
+	m[segs[len(segs)-1]] = v
+}
+
+func appendPath(m map[string]any, path string, v any) {
+	segs := strings.Split(path, ".")
+	for _, seg := range segs[:len(segs)-1] {
+		next, ok := m[seg].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[seg] = next
+		}
+		m = next
+	}
+	last := segs[len(segs)-1]
+	list, _ := m[last].([]any)
+	m[last] = append(list, v)
+}
+
+// Coerce mengubah nilai sel sesuai tipe field. empty true bila sel kosong (nil atau string kosong).
+func Coerce(v any, f Field) (out any, empty bool, err error) {
+	if v == nil {
+		return nil, true, nil
+	}
+	s, isString := v.(string)
+	if isString && strings.TrimSpace(s) == "" {
+		return nil, true, nil
+	}
+	if !isString {
+		switch f.Type {
+		case TypeAny, TypeJSON:
+			return v, false, nil
+		case TypeString:
+			return text(v), false, nil
+		case TypeInteger:
+			if n, ok := v.(float64); ok && n == float64(int64(n)) {
+				return int64(n), false, nil
+			}
+		case TypeNumber:
+			if n, ok := v.(float64); ok {
+				return n, false, nil
+			}
+		case TypeBoolean:
+			if b, ok := v.(bool); ok {
+				return b, false, nil
+			}
+		}
+		s = text(v)
+	}
+	t := strings.TrimSpace(s)
+	switch f.Type {
+	case TypeAny, TypeString:
+		return s, false, nil
+	case TypeInteger:
+		n, err := strconv.ParseInt(t, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q is not an integer", t)
+		}
+		return n, false, nil
+	case TypeNumber:
+		if f.Format == "," {
+			t = strings.ReplaceAll(strings.ReplaceAll(t, ".", ""), ",", ".")
+		}
+		n, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q is not a number", t)
+		}
+		return n, false, nil
+	case TypeBoolean:
+		switch strings.ToLower(t) {
+		case "true", "t", "1", "yes", "y", "ya":
+			return true, false, nil
+		case "false", "f", "0", "no", "n", "tidak":
+			return false, false, nil
+		}
+		return nil, false, fmt.Errorf("%q is not a boolean", t)
+	case TypeDate:
+		layout := f.Format
+		if layout == "" {
+			layout = "2006-01-02"
+		}
+		d, err := time.Parse(layout, t)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q does not match date format %q", t, layout)
+		}
+		return d.Format("2006-01-02"), false, nil
+	case TypeDateTime:
+		layout := f.Format
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		d, err := time.Parse(layout, t)
+		if err != nil {
+			return nil, false, fmt.Errorf("%q does not match datetime format %q", t, layout)
+		}
+		return d.Format(time.RFC3339), false, nil
+	case TypeJSON:
+		var out any
+		if err := json.Unmarshal([]byte(t), &out); err != nil {
+			return nil, false, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return out, false, nil
+	}
+	return nil, false, fmt.Errorf("unknown type %q", f.Type)
+}
+
+func text(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case json.Number:
+		return x.String()
+	default:
+		b, _ := json.Marshal(x)
+		return string(b)
+	}
+}
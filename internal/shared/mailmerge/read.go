@@ -0,0 +1,156 @@
+package mailmerge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Record satu baris input; Line nomor baris di file (1-based, header CSV = baris 1).
+type Record struct {
+	Line   int
+	Values map[string]any
+}
+
+// DetectFormat menebak format dari nama file, lalu dari isi (baris pertama diawali '{' = JSONL).
+func DetectFormat(fileName string, head []byte) string {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".jsonl"), strings.HasSuffix(lower, ".ndjson"):
+		return FormatJSONL
+	case strings.HasSuffix(lower, ".csv"), strings.HasSuffix(lower, ".tsv"):
+		return FormatCSV
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(trimBOM(head)), []byte("{")) {
+		return FormatJSONL
+	}
+	return FormatCSV
+}
+
+// Read membaca data CSV atau JSONL menjadi Record; maxRows > 0 membatasi jumlah baris data.
+func Read(data []byte, format string, maxRows int) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(data, maxRows)
+	case FormatJSONL:
+		return readJSONL(data, maxRows)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// readCSV: baris pertama header. Pemisah dideteksi dari header (koma, titik koma, atau tab)
+// karena ekspor spreadsheet berlocale Indonesia memakai titik koma.
+func readCSV(data []byte, maxRows int) ([]Record, error) {
+	data = trimBOM(data)
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectDelimiter(data)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, errors.New("csv: missing header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv: %w", err)
+	}
+	seen := make(map[string]bool, len(header))
+	for i, h := range header {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			return nil, fmt.Errorf("csv: header column %d is empty", i+1)
+		}
+		if seen[h] {
+			return nil, fmt.Errorf("csv: duplicate header column %q", h)
+		}
+		seen[h] = true
+		header[i] = h
+	}
+
+	var out []Record
+	for {
+		line, _ := r.FieldPos(0)
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		if maxRows > 0 && len(out) == maxRows {
+			return nil, fmt.Errorf("file has more than %d rows", maxRows)
+		}
+		if line, _ = r.FieldPos(0); line == 0 {
+			line = len(out) + 2
+		}
+		if len(row) > len(header) {
+			return nil, fmt.Errorf("csv: line %d has %d columns, header has %d", line, len(row), len(header))
+		}
+		values := make(map[string]any, len(header))
+		for i, h := range header {
+			if i < len(row) {
+				values[h] = row[i]
+			} else {
+				values[h] = ""
+			}
+		}
+		out = append(out, Record{Line: line, Values: values})
+	}
+	return out, nil
+}
+
+func readJSONL(data []byte, maxRows int) ([]Record, error) {
+	sc := bufio.NewScanner(bytes.NewReader(trimBOM(data)))
+	sc.Buffer(make([]byte, 64*1024), 16<<20)
+	var out []Record
+	line := 0
+	for sc.Scan() {
+		line++
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if maxRows > 0 && len(out) == maxRows {
+			return nil, fmt.Errorf("file has more than %d rows", maxRows)
+		}
+		var values map[string]any
+		if err := json.Unmarshal(text, &values); err != nil || values == nil {
+			return nil, fmt.Errorf("jsonl: line %d is not a JSON object", line)
+		}
+		out = append(out, Record{Line: line, Values: values})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("jsonl: %w", err)
+	}
+	return out, nil
+}
+
+func detectDelimiter(data []byte) rune {
+	first := data
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		first = data[:i]
+	}
+	best, bestN := ',', bytes.Count(first, []byte(","))
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(first, []byte(string(d))); n > bestN {
+			best, bestN = d, n
+		}
+	}
+	return best
+}
+
+func trimBOM(b []byte) []byte {
+	return bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+}
@@ -0,0 +1,108 @@
+// Package netguard membatasi request HTTP keluar ke alamat publik agar URL dari tenant
+// (payload source, dsb.) tidak bisa dipakai menjangkau jaringan internal (SSRF).
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrBlocked dikembalikan bila tujuan request bukan alamat publik dan host tidak di-allowlist.
+var ErrBlocked = errors.New("destination is not a public address")
+
+const maxRedirects = 5
+
+// Carrier-grade NAT (100.64.0.0/10) tidak tercakup net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Public true bila ip bisa dirutekan di internet (bukan loopback, privat, link-local, dsb.).
+func Public(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip))
+}
+
+// Guard memeriksa tujuan request; host di Allowed boleh ke alamat mana pun (mis. service internal).
+type Guard struct {
+	Allowed []string
+}
+
+func (g Guard) allowed(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, h := range g.Allowed {
+		if strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), ".") == host {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckHost validasi awal (saat definisi disimpan): menolak localhost dan IP literal non-publik.
+// Nama host lain baru diperiksa saat dial karena hasil DNS bisa berubah.
+func (g Guard) CheckHost(host string) error {
+	if g.allowed(host) {
+		return nil
+	}
+	if h := strings.TrimSuffix(strings.ToLower(host), "."); h == "localhost" || strings.HasSuffix(h, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrBlocked, host)
+	}
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil && !Public(ip) {
+		return fmt.Errorf("%w: %s", ErrBlocked, host)
+	}
+	return nil
+}
+
+// Client http.Client yang hanya dial ke IP publik (dicek setelah resolve, termasuk setiap redirect)
+// dan mengabaikan proxy environment.
+func (g Guard) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := &http.Transport{
+		Proxy:               nil,
+		DialContext:         g.dialContext(dialer),
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// dialContext me-resolve host sendiri lalu dial ke IP yang sudah dicek, sehingga DNS rebinding
+// antara pengecekan dan koneksi tidak bisa mengarahkan ke alamat internal.
+func (g Guard) dialContext(d *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if g.allowed(host) {
+			return d.DialContext(ctx, network, addr)
+		}
+		ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if !Public(ip.IP) {
+				return nil, fmt.Errorf("%w: %s resolves to %s", ErrBlocked, host, ip.IP)
+			}
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("%s: no address", host)
+		}
+		return d.DialContext(ctx, network, net.JoinHostPort(ips[0].IP.String(), port))
+	}
+}
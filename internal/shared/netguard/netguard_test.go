@@ -0,0 +1,59 @@
+package netguard
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPublic(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	} {
+		if got := Public(net.ParseIP(ip)); got != want {
+			t.Errorf("Public(%s) = %v", ip, got)
+		}
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	g := Guard{Allowed: []string{"reports.internal"}}
+	for _, h := range []string{"localhost", "api.localhost", "127.0.0.1", "[::1]", "169.254.169.254"} {
+		if err := g.CheckHost(h); !errors.Is(err, ErrBlocked) {
+			t.Errorf("CheckHost(%s) = %v", h, err)
+		}
+	}
+	for _, h := range []string{"example.com", "8.8.8.8", "Reports.Internal"} {
+		if err := g.CheckHost(h); err != nil {
+			t.Errorf("CheckHost(%s) = %v", h, err)
+		}
+	}
+}
+
+func TestClientBlocksLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	if _, err := (Guard{}).Client(time.Second).Get(srv.URL); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("loopback request: %v", err)
+	}
+	resp, err := (Guard{Allowed: []string{"127.0.0.1"}}).Client(time.Second).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("allowed host: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,220 @@
+package pdfa
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Check preflight bawaan atas aturan PDF/A yang paling sering dilanggar: enkripsi, output intent,
+// identifikasi XMP, font tidak ter-embed dan transparansi. Object stream terkompresi ikut diperiksa.
+// Bukan pengganti validator penuh seperti veraPDF; hasil kosong berarti tidak ditemukan pelanggaran.
+func Check(data []byte, level string) []string {
+	part, conformance, ok := parse(level)
+	if !ok {
+		return []string{fmt.Sprintf("unknown conformance level %q", level)}
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return []string{"not a PDF file"}
+	}
+	var issues []string
+	seen := map[string]bool{}
+	add := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		if !seen[msg] {
+			seen[msg] = true
+			issues = append(issues, msg)
+		}
+	}
+
+	if bytes.HasPrefix(data, []byte("%PDF-2.")) {
+		add("PDF 2.0 is not allowed in PDF/A-%d", part)
+	}
+	if encryptRe.Match(data) {
+		add("document is encrypted")
+	}
+
+	var hasCatalog, hasIntent, hasXMP bool
+	for _, o := range objects(data) {
+		d := o.dict
+		if catalogRe.MatchString(d) {
+			hasCatalog = true
+			if !strings.Contains(d, "/OutputIntents") {
+				add("catalog has no OutputIntents")
+			}
+			if !strings.Contains(d, "/Metadata") {
+				add("catalog has no XMP metadata")
+			}
+		}
+		if strings.Contains(d, "/GTS_PDFA1") && strings.Contains(d, "/DestOutputProfile") {
+			hasIntent = true
+		}
+		if strings.Contains(d, "/JavaScript") {
+			add("JavaScript is not allowed")
+		}
+		if fontRe.MatchString(d) {
+			if m := fontSubtypeRe.FindStringSubmatch(d); m != nil && m[1] != "Type0" && m[1] != "Type3" &&
+				!strings.Contains(d, "/FontDescriptor") {
+				add("font %s is not embedded", name(baseFontRe, d))
+			}
+		}
+		if fontDescRe.MatchString(d) && !fontFileRe.MatchString(d) {
+			add("font %s is not embedded", name(fontNameRe, d))
+		}
+		for _, m := range smaskRe.FindAllStringSubmatch(d, -1) {
+			if m[1] != "/None" {
+				add("transparency: soft mask")
+			}
+		}
+		for _, m := range alphaRe.FindAllStringSubmatch(d, -1) {
+			if v, err := strconv.ParseFloat(m[2], 64); err == nil && v < 1 {
+				add("transparency: /%s %s", m[1], m[2])
+			}
+		}
+		if m := blendRe.FindStringSubmatch(d); m != nil && m[1] != "Normal" && m[1] != "Compatible" {
+			add("transparency: blend mode %s", m[1])
+		}
+		if groupRe.MatchString(d) {
+			add("transparency: transparency group")
+		}
+		if part >= 3 && filespecRe.MatchString(d) && strings.Contains(d, "/EF") && !strings.Contains(d, "/AFRelationship") {
+			add("embedded file %s has no AFRelationship", name(filespecNameRe, d))
+		}
+
+		if bytes.Contains(o.stream, []byte("pdfaid:part")) {
+			hasXMP = true
+			if m := xmpPartRe.FindSubmatch(o.stream); m == nil || string(m[1]) != strconv.Itoa(part) {
+				add("XMP pdfaid:part is not %d", part)
+			}
+			if m := xmpConfRe.FindSubmatch(o.stream); m == nil || !strings.EqualFold(string(m[1]), conformance) {
+				add("XMP pdfaid:conformance is not %s", conformance)
+			}
+		}
+	}
+	if !hasCatalog {
+		add("document catalog not found")
+	}
+	if !hasIntent {
+		add("no GTS_PDFA1 output intent with an ICC profile")
+	}
+	if !hasXMP {
+		add("XMP metadata has no PDF/A identification")
+	}
+	return issues
+}
+
+var (
+	objRe          = regexp.MustCompile(`(?s)\d+\s+\d+\s+obj\b(.*?)\bendobj`)
+	encryptRe      = regexp.MustCompile(`/Encrypt\s+\d+\s+\d+\s+R`)
+	catalogRe      = regexp.MustCompile(`/Type\s*/Catalog\b`)
+	fontRe         = regexp.MustCompile(`/Type\s*/Font\b`)
+	fontDescRe     = regexp.MustCompile(`/Type\s*/FontDescriptor\b`)
+	objStmRe       = regexp.MustCompile(`/Type\s*/ObjStm\b`)
+	filespecRe     = regexp.MustCompile(`/Type\s*/Filespec\b`)
+	filespecNameRe = regexp.MustCompile(`/UF\s*\(([^)]*)\)`)
+	fontSubtypeRe  = regexp.MustCompile(`/Subtype\s*/(\w+)`)
+	baseFontRe     = regexp.MustCompile(`/BaseFont\s*/([^\s/<>\[\]()]+)`)
+	fontNameRe     = regexp.MustCompile(`/FontName\s*/([^\s/<>\[\]()]+)`)
+	fontFileRe     = regexp.MustCompile(`/FontFile[23]?\b`)
+	smaskRe        = regexp.MustCompile(`/SMask\s*(/\w+|\d+\s+\d+\s+R|<<)`)
+	alphaRe        = regexp.MustCompile(`/(ca|CA)\s+([\d.]+)`)
+	blendRe        = regexp.MustCompile(`/BM\s*/(\w+)`)
+	groupRe        = regexp.MustCompile(`/S\s*/Transparency\b`)
+	xmpPartRe      = regexp.MustCompile(`pdfaid:part(?:="|>)\s*(\d)`)
+	xmpConfRe      = regexp.MustCompile(`pdfaid:conformance(?:="|>)\s*([A-Za-z])`)
+	objStmNRe      = regexp.MustCompile(`/N\s+(\d+)`)
+	objStmFirstRe  = regexp.MustCompile(`/First\s+(\d+)`)
+)
+
+func name(re *regexp.Regexp, dict string) string {
+	if m := re.FindStringSubmatch(dict); m != nil {
+		return m[1]
+	}
+	return "(unnamed)"
+}
+
+type object struct {
+	dict   string
+	stream []byte // sudah di-inflate; nil bila tidak ada stream atau filter tidak didukung
+}
+
+// objects mengumpulkan objek tingkat atas beserta objek di dalam object stream (/Type /ObjStm).
+func objects(data []byte) []object {
+	var out []object
+	for _, m := range objRe.FindAllSubmatch(data, -1) {
+		body := m[1]
+		i := bytes.Index(body, []byte("stream"))
+		if i < 0 {
+			out = append(out, object{dict: string(body)})
+			continue
+		}
+		dict := string(body[:i])
+		raw := body[i+len("stream"):]
+		raw = bytes.TrimPrefix(bytes.TrimPrefix(raw, []byte("\r")), []byte("\n"))
+		if j := bytes.LastIndex(raw, []byte("endstream")); j >= 0 {
+			raw = raw[:j]
+		}
+		o := object{dict: dict, stream: decode(dict, raw)}
+		out = append(out, o)
+		if objStmRe.MatchString(dict) && o.stream != nil {
+			out = append(out, embedded(dict, o.stream)...)
+		}
+	}
+	return out
+}
+
+func decode(dict string, raw []byte) []byte {
+	if !strings.Contains(dict, "/Filter") {
+		return raw
+	}
+	if !strings.Contains(dict, "/FlateDecode") {
+		return nil
+	}
+	r, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil && len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// embedded memecah isi object stream: header "objnum offset ..." lalu objek mulai dari /First.
+func embedded(dict string, data []byte) []object {
+	nm, fm := objStmNRe.FindStringSubmatch(dict), objStmFirstRe.FindStringSubmatch(dict)
+	if nm == nil || fm == nil {
+		return nil
+	}
+	n, _ := strconv.Atoi(nm[1])
+	first, _ := strconv.Atoi(fm[1])
+	if first > len(data) {
+		return nil
+	}
+	fields := strings.Fields(string(data[:first]))
+	if len(fields) < 2*n {
+		return nil
+	}
+	offsets := make([]int, n)
+	for i := range offsets {
+		offsets[i], _ = strconv.Atoi(fields[2*i+1])
+	}
+	var out []object
+	for i, off := range offsets {
+		end := len(data) - first
+		if i+1 < n {
+			end = offsets[i+1]
+		}
+		if off < 0 || off > end || first+end > len(data) {
+			continue
+		}
+		out = append(out, object{dict: string(data[first+off : first+end])})
+	}
+	return out
+}
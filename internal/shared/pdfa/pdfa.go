@@ -0,0 +1,170 @@
+// Package pdfa berisi mode arsip PDF/A: level konformansi, definisi PostScript untuk konversi
+// Ghostscript (output intent ICC + metadata dokumen) dan preflight bawaan atas PDF hasil konversi.
+package pdfa
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Level konformansi yang didukung.
+const (
+	Level2B = "PDF/A-2b"
+	// Level3B mengizinkan file ter-embed sembarang format (associated file), dipakai Factur-X.
+	Level3B = "PDF/A-3b"
+)
+
+// Validator bawaan yang tercatat di Report bila veraPDF tidak dikonfigurasi.
+const ValidatorBuiltin = "builtin"
+
+// Valid true bila level dikenal.
+func Valid(level string) bool {
+	_, _, ok := parse(level)
+	return ok
+}
+
+// Part mengembalikan bagian PDF/A (2 untuk PDF/A-2b); 0 bila level tidak dikenal.
+func Part(level string) int {
+	part, _, _ := parse(level)
+	return part
+}
+
+// Flavour level dalam notasi veraPDF ("2b").
+func Flavour(level string) string {
+	part, conf, _ := parse(level)
+	return fmt.Sprintf("%d%s", part, strings.ToLower(conf))
+}
+
+func parse(level string) (part int, conformance string, ok bool) {
+	switch level {
+	case Level2B:
+		return 2, "B", true
+	case Level3B:
+		return 3, "B", true
+	}
+	return 0, "", false
+}
+
+// Info metadata dokumen yang ditulis ke DOCINFO dan XMP, beserta associated file (PDF/A-3).
+type Info struct {
+	TemplateCode string
+	RequestID    string
+	DocumentID   int64
+	CreatedAt    time.Time
+	Attachments  []Attachment
+	// XMPExtension blok rdf:Description tambahan (mis. metadata Factur-X).
+	XMPExtension string
+}
+
+// Attachment file yang di-embed sebagai associated file di katalog (/AF).
+type Attachment struct {
+	Name         string
+	ContentType  string
+	Relationship string // Data, Source, Alternative, Supplement, Unspecified
+	Description  string
+	Data         []byte
+	Path         string // lokasi file saat konversi; diisi converter
+}
+
+// Report hasil validasi yang disimpan di dokumen untuk audit arsip.
+type Report struct {
+	Level     string `json:"level"`
+	Compliant bool   `json:"compliant"`
+	Validator string `json:"validator"`
+	// Verified true bila hasil berasal dari validator penuh (veraPDF); preflight bawaan hanya
+	// memeriksa sebagian aturan sehingga Compliant-nya belum menjadi bukti konformansi.
+	Verified  bool      `json:"verified"`
+	Issues    []string  `json:"issues,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Summary ringkasan issue untuk pesan error (maksimal n issue).
+func (r Report) Summary(n int) string {
+	if len(r.Issues) <= n {
+		return strings.Join(r.Issues, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(r.Issues[:n], "; "), len(r.Issues)-n)
+}
+
+// DefFile membuat PDFA_def.ps untuk Ghostscript: DOCINFO (diubah gs menjadi XMP beserta
+// pdfaid) dan output intent GTS_PDFA1 dengan profil ICC RGB dari iccPath.
+func DefFile(iccPath string, info Info) []byte {
+	var b bytes.Buffer
+	b.WriteString("%!\n")
+	fmt.Fprintf(&b, "/ICCProfile %s def\n", psString(iccPath))
+	fmt.Fprintf(&b, "[ /Title %s\n", psString(info.TemplateCode))
+	fmt.Fprintf(&b, "  /Subject %s\n", psString("request_id "+info.RequestID))
+	fmt.Fprintf(&b, "  /Keywords %s\n", psString(fmt.Sprintf("template_code=%s; request_id=%s; document_id=%d",
+		info.TemplateCode, info.RequestID, info.DocumentID)))
+	b.WriteString("  /Creator (go-document-generator)\n")
+	fmt.Fprintf(&b, "  /CreationDate (%s)\n", pdfDate(info.CreatedAt))
+	b.WriteString("  /DOCINFO pdfmark\n")
+	b.WriteString(`[/_objdef {icc_PDFA} /type /stream /OBJ pdfmark
+[{icc_PDFA} << /N 3 >> /PUT pdfmark
+[{icc_PDFA} ICCProfile (r) file /PUT pdfmark
+[/_objdef {OutputIntent_PDFA} /type /dict /OBJ pdfmark
+[{OutputIntent_PDFA} <<
+  /Type /OutputIntent
+  /S /GTS_PDFA1
+  /DestOutputProfile {icc_PDFA}
+  /OutputConditionIdentifier (sRGB IEC61966-2.1)
+  /Info (sRGB IEC61966-2.1)
+>> /PUT pdfmark
+[{Catalog} << /OutputIntents [ {OutputIntent_PDFA} ] >> /PUT pdfmark
+`)
+	if info.XMPExtension != "" {
+		fmt.Fprintf(&b, "[/XML %s /Ext_Metadata pdfmark\n", psString(info.XMPExtension))
+	}
+	if len(info.Attachments) == 0 {
+		return b.Bytes()
+	}
+	var specs []string
+	for i, a := range info.Attachments {
+		stream, spec := fmt.Sprintf("{AFStream%d}", i), fmt.Sprintf("{AFSpec%d}", i)
+		specs = append(specs, spec)
+		fmt.Fprintf(&b, "[/_objdef %s /type /stream /OBJ pdfmark\n", stream)
+		fmt.Fprintf(&b, "[%s << /Type /EmbeddedFile /Subtype %s cvn /Params << /ModDate (%s) >> >> /PUT pdfmark\n",
+			stream, psString(a.ContentType), pdfDate(info.CreatedAt))
+		fmt.Fprintf(&b, "[%s %s (r) file /PUT pdfmark\n", stream, psString(a.Path))
+		fmt.Fprintf(&b, "[%s /CLOSE pdfmark\n", stream)
+		fmt.Fprintf(&b, "[/_objdef %s /type /dict /OBJ pdfmark\n", spec)
+		fmt.Fprintf(&b, "[%s << /Type /Filespec /F %s /UF %s /Desc %s /AFRelationship /%s /EF << /F %s /UF %s >> >> /PUT pdfmark\n",
+			spec, psString(a.Name), psString(a.Name), psString(a.Description), a.Relationship, stream, stream)
+		fmt.Fprintf(&b, "[/Name %s /FS %s /EMBED pdfmark\n", psString(a.Name), spec)
+	}
+	fmt.Fprintf(&b, "[{Catalog} << /AF [ %s ] >> /PUT pdfmark\n", strings.Join(specs, " "))
+	return b.Bytes()
+}
+
+// psString literal string PostScript; teks non-ASCII ditulis sebagai hex UTF-16BE dengan BOM.
+func psString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r > 0x7e || (r < 0x20 && r != '\t') {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		r := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+		return "(" + r.Replace(s) + ")"
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// pdfDate format tanggal PDF (D:YYYYMMDDHHmmSSZ) dalam UTC.
+func pdfDate(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return "D:" + t.UTC().Format("20060102150405") + "Z"
+}
@@ -0,0 +1,158 @@
+package pdfa
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const xmp = `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF><rdf:Description pdfaid:part="2" pdfaid:conformance="B"/></rdf:RDF></x:xmpmeta>`
+
+// buildPDF menyusun PDF minimal dari body objek (nomor objek mulai 1, tanpa xref yang valid).
+func buildPDF(objs ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	for i, o := range objs {
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func conformant() []string {
+	return []string{
+		"<< /Type /Catalog /Pages 2 0 R /Metadata 3 0 R /OutputIntents [4 0 R] >>",
+		"<< /Type /Pages /Kids [] /Count 0 >>",
+		fmt.Sprintf("<< /Type /Metadata /Subtype /XML /Length %d >>\nstream\n%s\nendstream", len(xmp), xmp),
+		"<< /Type /OutputIntent /S /GTS_PDFA1 /DestOutputProfile 5 0 R >>",
+		"<< /N 3 /Length 0 >>\nstream\n\nendstream",
+		"<< /Type /Font /Subtype /TrueType /BaseFont /ABCDEF+DejaVuSans /FontDescriptor 7 0 R >>",
+		"<< /Type /FontDescriptor /FontName /ABCDEF+DejaVuSans /FontFile2 8 0 R >>",
+		"<< /Type /ExtGState /ca 1 /CA 1 /SMask /None >>",
+	}
+}
+
+func TestCheckConformant(t *testing.T) {
+	if issues := Check(buildPDF(conformant()...), Level2B); len(issues) != 0 {
+		t.Errorf("issues = %v", issues)
+	}
+}
+
+func TestCheckViolations(t *testing.T) {
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [] /Count 0 >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+		"<< /Type /ExtGState /ca 0.15 /CA 0.15 >>",
+		"<< /Type /XObject /Subtype /Image /SMask 6 0 R >>",
+		"<< /Type /Group /S /Transparency >>",
+	}
+	data := append(buildPDF(objs...), []byte("trailer\n<< /Encrypt 9 0 R >>\n")...)
+	want := []string{
+		"document is encrypted",
+		"catalog has no OutputIntents",
+		"catalog has no XMP metadata",
+		"font Helvetica-Bold is not embedded",
+		"transparency: /ca 0.15",
+		"transparency: /CA 0.15",
+		"transparency: soft mask",
+		"transparency: transparency group",
+		"no GTS_PDFA1 output intent with an ICC profile",
+		"XMP metadata has no PDF/A identification",
+	}
+	if got := Check(data, Level2B); !reflect.DeepEqual(got, want) {
+		t.Errorf("issues =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCheckObjectStream(t *testing.T) {
+	objs := conformant()
+	// Font tanpa FontDescriptor disembunyikan di object stream terkompresi.
+	inner := "<< /Type /Font /Subtype /TrueType /BaseFont /Arial >>"
+	header := "9 0 "
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	w.Write([]byte(header + inner))
+	w.Close()
+	objs = append(objs, fmt.Sprintf("<< /Type /ObjStm /N 1 /First %d /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+		len(header), z.Len(), z.Bytes()))
+	if got := Check(buildPDF(objs...), Level2B); !reflect.DeepEqual(got, []string{"font Arial is not embedded"}) {
+		t.Errorf("issues = %q", got)
+	}
+}
+
+func TestCheckXMPMismatch(t *testing.T) {
+	objs := conformant()
+	bad := strings.Replace(xmp, `pdfaid:part="2"`, `pdfaid:part="1"`, 1)
+	objs[2] = fmt.Sprintf("<< /Type /Metadata /Subtype /XML /Length %d >>\nstream\n%s\nendstream", len(bad), bad)
+	if got := Check(buildPDF(objs...), Level2B); !reflect.DeepEqual(got, []string{"XMP pdfaid:part is not 2"}) {
+		t.Errorf("issues = %q", got)
+	}
+	if got := Check([]byte("<html>"), Level2B); len(got) != 1 {
+		t.Errorf("non-PDF issues = %q", got)
+	}
+}
+
+func TestDefFile(t *testing.T) {
+	def := string(DefFile("/icc/srgb (v4).icc", Info{
+		TemplateCode: "INVOICE", RequestID: "req-1", DocumentID: 42,
+		CreatedAt: time.Date(2026, 3, 1, 8, 30, 0, 0, time.FixedZone("WIB", 7*3600)),
+	}))
+	for _, want := range []string{
+		`/ICCProfile (/icc/srgb \(v4\).icc) def`,
+		"/Title (INVOICE)",
+		"/Keywords (template_code=INVOICE; request_id=req-1; document_id=42)",
+		"/CreationDate (D:20260301013000Z)",
+		"/S /GTS_PDFA1",
+		"[{Catalog} << /OutputIntents [ {OutputIntent_PDFA} ] >> /PUT pdfmark",
+	} {
+		if !strings.Contains(def, want) {
+			t.Errorf("missing %q in\n%s", want, def)
+		}
+	}
+	if got := psString("Faktur ü"); got != "<FEFF00460061006B007400750072002000FC>" {
+		t.Errorf("psString = %s", got)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	if !Valid(Level2B) || Valid("PDF/A-1a") || Part(Level2B) != 2 || Flavour(Level2B) != "2b" {
+		t.Error("level helpers")
+	}
+	r := Report{Issues: []string{"a", "b", "c"}}
+	if got := r.Summary(2); got != "a; b; and 1 more" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func TestDefFileAttachments(t *testing.T) {
+	def := string(DefFile("srgb.icc", Info{
+		TemplateCode: "INVOICE", XMPExtension: `<rdf:Description rdf:about=""/>`,
+		Attachments: []Attachment{{Name: "factur-x.xml", ContentType: "text/xml", Relationship: "Data",
+			Description: "Factur-X invoice", Path: "/tmp/x/factur-x.xml"}},
+	}))
+	for _, want := range []string{
+		`[/XML (<rdf:Description rdf:about=""/>) /Ext_Metadata pdfmark`,
+		"[{AFStream0} << /Type /EmbeddedFile /Subtype (text/xml) cvn",
+		"[{AFStream0} (/tmp/x/factur-x.xml) (r) file /PUT pdfmark",
+		"/UF (factur-x.xml) /Desc (Factur-X invoice) /AFRelationship /Data /EF << /F {AFStream0}",
+		"[/Name (factur-x.xml) /FS {AFSpec0} /EMBED pdfmark",
+		"[{Catalog} << /AF [ {AFSpec0} ] >> /PUT pdfmark",
+	} {
+		if !strings.Contains(def, want) {
+			t.Errorf("missing %q in\n%s", want, def)
+		}
+	}
+}
+
+func TestCheckAssociatedFile(t *testing.T) {
+	objs := append(conformant(), "<< /Type /Filespec /F (factur-x.xml) /UF (factur-x.xml) /EF << /F 10 0 R >> >>")
+	data := bytes.Replace(buildPDF(objs...), []byte(`pdfaid:part="2"`), []byte(`pdfaid:part="3"`), 1)
+	if got := Check(data, Level3B); !reflect.DeepEqual(got, []string{"embedded file factur-x.xml has no AFRelationship"}) {
+		t.Errorf("issues = %q", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Package pdfsecurity berisi spesifikasi enkripsi PDF (password user + pembatasan izin) dan
+// penyimpanan aman password dari request selama dokumen menunggu di antrean.
+package pdfsecurity
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AlgorithmAES256 satu-satunya algoritma yang didukung (PDF 2.0 / revisi 6).
+const AlgorithmAES256 = "AES-256"
+
+// MaxPasswordBytes batas panjang password AES-256 PDF (UTF-8).
+const MaxPasswordBytes = 127
+
+// Spec enkripsi yang disimpan di dokumen. Password user diambil dari payload (PasswordPath) atau dari
+// field request yang disimpan terpisah dalam bentuk tersegel; Spec sendiri tidak pernah memuat password.
+type Spec struct {
+	PasswordPath string `json:"password_path,omitempty"`
+	NoPrint      bool   `json:"no_print,omitempty"`
+	NoCopy       bool   `json:"no_copy,omitempty"`
+	NoModify     bool   `json:"no_modify,omitempty"`
+	// Algorithm diisi service.
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+// Validate memeriksa spec; hasPassword true bila request membawa password langsung.
+func (s Spec) Validate(hasPassword bool) error {
+	path := strings.TrimSpace(s.PasswordPath)
+	if (path == "") == !hasPassword {
+		return errors.New("exactly one of password or password_path is required")
+	}
+	if len(path) > 200 {
+		return errors.New("password_path exceeds 200 characters")
+	}
+	if path != "" {
+		for _, part := range strings.Split(path, ".") {
+			if part == "" {
+				return fmt.Errorf("invalid password_path %q", s.PasswordPath)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPassword memastikan password dapat dipakai untuk AES-256.
+func CheckPassword(pw string) error {
+	if pw == "" {
+		return errors.New("password is empty")
+	}
+	if len(pw) > MaxPasswordBytes {
+		return fmt.Errorf("password exceeds %d bytes", MaxPasswordBytes)
+	}
+	if strings.ContainsAny(pw, "\r\n") {
+		return errors.New("password must not contain line breaks")
+	}
+	return nil
+}
+
+// Lookup mengambil password dari payload dengan path bertitik ("employee.nik", "items.0.id").
+// Angka diformat tanpa eksponen; nilai kosong, objek dan boolean ditolak.
+func Lookup(payload map[string]any, path string) (string, error) {
+	var cur any = payload
+	for _, part := range strings.Split(strings.TrimSpace(path), ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[part]
+			if !ok {
+				return "", fmt.Errorf("payload has no %q", path)
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return "", fmt.Errorf("payload has no %q", path)
+			}
+			cur = v[i]
+		default:
+			return "", fmt.Errorf("payload has no %q", path)
+		}
+	}
+	var pw string
+	switch v := cur.(type) {
+	case string:
+		pw = v
+	case float64:
+		pw = strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		pw = strconv.Itoa(v)
+	case int64:
+		pw = strconv.FormatInt(v, 10)
+	case json.Number:
+		pw = v.String()
+	default:
+		return "", fmt.Errorf("payload %q is not a string or number", path)
+	}
+	if err := CheckPassword(pw); err != nil {
+		return "", fmt.Errorf("payload %q: %w", path, err)
+	}
+	return pw, nil
+}
+
+// Sealer menyegel password request (AES-256-GCM, kunci SHA-256 dari secret config) agar tidak
+// tersimpan plaintext di database.
+type Sealer struct {
+	aead cipher.AEAD
+}
+
+// NewSealer nil bila secret kosong (password request tidak bisa dipakai).
+func NewSealer(secret string) *Sealer {
+	if secret == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, _ := aes.NewCipher(key[:])
+	aead, _ := cipher.NewGCM(block)
+	return &Sealer{aead: aead}
+}
+
+// Seal mengembalikan base64(nonce || ciphertext).
+func (s *Sealer) Seal(plain string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(s.aead.Seal(nonce, nonce, []byte(plain), nil)), nil
+}
+
+func (s *Sealer) Open(sealed string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil || len(raw) < s.aead.NonceSize() {
+		return "", errors.New("sealed password is malformed")
+	}
+	n := s.aead.NonceSize()
+	plain, err := s.aead.Open(nil, raw[:n], raw[n:], nil)
+	if err != nil {
+		return "", errors.New("sealed password cannot be opened (secret changed?)")
+	}
+	return string(plain), nil
+}
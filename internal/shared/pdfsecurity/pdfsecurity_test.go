@@ -0,0 +1,75 @@
+package pdfsecurity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		spec        Spec
+		hasPassword bool
+		ok          bool
+	}{
+		{Spec{PasswordPath: "employee.nik"}, false, true},
+		{Spec{NoPrint: true, NoCopy: true}, true, true},
+		{Spec{}, false, false},
+		{Spec{PasswordPath: "employee.nik"}, true, false},
+		{Spec{PasswordPath: "employee..nik"}, false, false},
+	}
+	for _, c := range cases {
+		if err := c.spec.Validate(c.hasPassword); (err == nil) != c.ok {
+			t.Errorf("%+v (password %v): err = %v", c.spec, c.hasPassword, err)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(`{"employee":{"nik":"3171010101900001","birth":19900101,"empty":"","flag":true},
+		"items":[{"id":"A-1"}]}`), &payload); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{
+		"employee.nik":   "3171010101900001",
+		"employee.birth": "19900101",
+		"items.0.id":     "A-1",
+	} {
+		got, err := Lookup(payload, path)
+		if err != nil || got != want {
+			t.Errorf("Lookup(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	for _, path := range []string{"employee.missing", "employee.empty", "employee.flag", "employee", "items.1.id", "items.x"} {
+		if _, err := Lookup(payload, path); err == nil {
+			t.Errorf("Lookup(%q): expected error", path)
+		}
+	}
+	if _, err := Lookup(map[string]any{"pw": strings.Repeat("x", MaxPasswordBytes+1)}, "pw"); err == nil {
+		t.Error("expected error for long password")
+	}
+}
+
+func TestSealer(t *testing.T) {
+	if NewSealer("") != nil {
+		t.Error("empty secret should disable sealing")
+	}
+	s := NewSealer("config-secret")
+	sealed, err := s.Seal("19900101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sealed, "19900101") {
+		t.Error("sealed value contains plaintext")
+	}
+	if got, err := s.Open(sealed); err != nil || got != "19900101" {
+		t.Errorf("Open = %q, %v", got, err)
+	}
+	if _, err := NewSealer("other").Open(sealed); err == nil {
+		t.Error("opened with wrong secret")
+	}
+	if _, err := s.Open("!!"); err == nil {
+		t.Error("opened malformed value")
+	}
+}
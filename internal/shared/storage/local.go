@@ -56,6 +56,8 @@ func ExtensionForFormat(format string) string {
 		return "html"
 	case "DOCX":
 		return "docx"
+	case "XML":
+		return "xml"
 	default:
 		return "out"
 	}
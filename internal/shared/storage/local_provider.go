@@ -1,11 +1,12 @@
 package storage
 
 import (
-	"archive/zip"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"go-document-generator/internal/entity/enums"
@@ -44,24 +45,6 @@ func (p *localProvider) ProviderName() enums.StorageProvider {
 	return enums.StorageProviderLocal
 }
 
-func (p *localProvider) Zip(_ context.Context, documentID int64, requestID string, entries []ZipEntry) (string, string, error) {
-	var buf bytes.Buffer
-	w := zip.NewWriter(&buf)
-	for _, e := range entries {
-		f, err := w.Create(e.Name)
-		if err != nil {
-			return "", "", fmt.Errorf("zip: create entry %s: %w", e.Name, err)
-		}
-		if _, err := f.Write(e.Data); err != nil {
-			return "", "", fmt.Errorf("zip: write entry %s: %w", e.Name, err)
-		}
-	}
-	if err := w.Close(); err != nil {
-		return "", "", fmt.Errorf("zip: close writer: %w", err)
-	}
-	return SaveDocument(p.baseDir, documentID, requestID, "zip", buf.Bytes())
-}
-
 // Compose menggabungkan byte file secara berurutan.
 // Hanya cocok untuk format teks (HTML, CSV). Untuk PDF gunakan pdfcpu.
 func (p *localProvider) Compose(_ context.Context, documentID int64, requestID string, srcPaths []string, ext string) (string, string, error) {
@@ -75,3 +58,39 @@ func (p *localProvider) Compose(_ context.Context, documentID int64, requestID s
 	}
 	return SaveDocument(p.baseDir, documentID, requestID, ext, buf.Bytes())
 }
+
+// Put menulis data ke baseDir/key; key memakai "/" sebagai pemisah.
+func (p *localProvider) Put(_ context.Context, key, _ string, data []byte) (string, error) {
+	path := filepath.Join(p.baseDir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (p *localProvider) PutFile(_ context.Context, key, _ string, srcPath string) (string, error) {
+	path := filepath.Join(p.baseDir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		return "", err
+	}
+	return path, nil
+}
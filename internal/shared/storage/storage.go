@@ -7,12 +7,6 @@ import (
 	"go-document-generator/internal/entity/enums"
 )
 
-// ZipEntry satu file dalam arsip ZIP.
-type ZipEntry struct {
-	Name string
-	Data []byte
-}
-
 // Provider abstraksi penyimpanan file dokumen.
 type Provider interface {
 	// Save menyimpan data ke storage, mengembalikan path dan nama file.
@@ -23,11 +17,13 @@ type Provider interface {
 	PresignedURL(ctx context.Context, path string, ttl time.Duration) (string, error)
 	// ProviderName mengembalikan identifier enum provider ini.
 	ProviderName() enums.StorageProvider
-	// Zip membuat arsip ZIP dari sekumpulan file dan menyimpannya ke storage.
-	Zip(ctx context.Context, documentID int64, requestID string, entries []ZipEntry) (path, fileName string, err error)
 	// Compose menggabungkan beberapa file dalam storage menjadi satu.
 	// Provider yang mendukung server-side compose (MinIO, GCS) melakukannya tanpa download.
 	// Provider lokal membaca setiap file dan menggabungkan byte-nya.
 	// CATATAN: untuk PDF gunakan library seperti pdfcpu — byte concat tidak menghasilkan PDF valid.
 	Compose(ctx context.Context, documentID int64, requestID string, srcPaths []string, ext string) (path, fileName string, err error)
+	// Put menyimpan data dengan key relatif bebas (mis. asset template), mengembalikan path yang disimpan di DB.
+	Put(ctx context.Context, key, contentType string, data []byte) (path string, err error)
+	// PutFile seperti Put tetapi membaca isi dari file lokal (di-stream, multipart bila besar).
+	PutFile(ctx context.Context, key, contentType, srcPath string) (path string, err error)
 }
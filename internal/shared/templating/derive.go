@@ -0,0 +1,165 @@
+package templating
+
+import (
+	"sort"
+	"strings"
+
+	"go-document-generator/internal/shared/diff"
+)
+
+// Variable satu path data yang dipakai template, type hasil inferensi, dan lokasi pemakaiannya.
+// Type kosong bila tidak bisa diinfer (mis. hanya dicetak tanpa schema/sample).
+type Variable struct {
+	Path   string
+	Type   string
+	Usages []Ref
+}
+
+// Derive mengelompokkan refs per path, termasuk parent implisit ("customer" untuk
+// "customer.name"), lalu menginfer type dengan urutan: schema, sample payload, cara pemakaian.
+func Derive(refs []Ref, schema, sample map[string]any) []Variable {
+	byPath := map[string]*Variable{}
+	ensure := func(p string) *Variable {
+		v, ok := byPath[p]
+		if !ok {
+			v = &Variable{Path: p}
+			byPath[p] = v
+		}
+		return v
+	}
+	for _, r := range refs {
+		for _, p := range parents(r.Path) {
+			ensure(p)
+		}
+		v := ensure(r.Path)
+		v.Usages = append(v.Usages, r)
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for p := range byPath {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	out := make([]Variable, 0, len(paths))
+	for _, p := range paths {
+		v := byPath[p]
+		v.Type = inferType(p, v.Usages, paths, schema, sample)
+		out = append(out, *v)
+	}
+	return out
+}
+
+// SchemaSkeleton membangun JSON Schema minimal dari hasil Derive: struktur object/array dan
+// type yang diketahui saja, tanpa required, supaya payload yang sudah berjalan tetap lolos.
+func SchemaSkeleton(vars []Variable) map[string]any {
+	root := map[string]any{"type": "object", "properties": map[string]any{}}
+	for _, v := range vars {
+		if node := schemaNode(root, v.Path); v.Type != "" {
+			node["type"] = v.Type
+		}
+	}
+	return root
+}
+
+// parents mengembalikan path parent dari p: "items[].qty" -> ["items", "items[]"].
+func parents(p string) []string {
+	var out []string
+	for i := 1; i < len(p); i++ {
+		if p[i] == '.' || p[i] == '[' {
+			out = append(out, p[:i])
+		}
+	}
+	return out
+}
+
+func inferType(p string, usages []Ref, paths []string, schema, sample map[string]any) string {
+	if s := diff.SchemaAt(schema, p); len(schema) > 0 && s != nil {
+		if t := diff.SchemaType(s); t != "" {
+			return t
+		}
+	}
+	if v, ok := valueAt(sample, p); ok {
+		if t := jsonType(v); t != "" {
+			return t
+		}
+	}
+	for _, q := range paths {
+		switch {
+		case strings.HasPrefix(q, p+"[]"):
+			return "array"
+		case strings.HasPrefix(q, p+"."):
+			return "object"
+		}
+	}
+	for _, u := range usages {
+		if u.Usage == UsageRange {
+			return "array"
+		}
+	}
+	return ""
+}
+
+// valueAt mengambil nilai sample pada path; elemen array diwakili elemen pertama.
+func valueAt(sample map[string]any, p string) (any, bool) {
+	if len(sample) == 0 {
+		return nil, false
+	}
+	var cur any = sample
+	for _, seg := range strings.Split(p, ".") {
+		name := strings.TrimRight(seg, "[]")
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[name]; !ok {
+			return nil, false
+		}
+		for i := len(name); i+1 < len(seg); i += 2 {
+			list, ok := cur.([]any)
+			if !ok || len(list) == 0 {
+				return nil, false
+			}
+			cur = list[0]
+		}
+	}
+	return cur, true
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, float32, int, int32, int64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return ""
+}
+
+func schemaNode(root map[string]any, p string) map[string]any {
+	cur := root
+	for _, seg := range strings.Split(p, ".") {
+		name := strings.TrimRight(seg, "[]")
+		cur = child(child(cur, "properties"), name)
+		for i := len(name); i+1 < len(seg); i += 2 {
+			cur["type"] = "array"
+			cur = child(cur, "items")
+		}
+	}
+	return cur
+}
+
+func child(m map[string]any, key string) map[string]any {
+	c, ok := m[key].(map[string]any)
+	if !ok {
+		c = map[string]any{}
+		m[key] = c
+	}
+	return c
+}
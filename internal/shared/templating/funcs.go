@@ -0,0 +1,300 @@
+package templating
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go-document-generator/internal/shared/barcode"
+	"go-document-generator/internal/shared/chart"
+	"go-document-generator/internal/shared/format"
+)
+
+// Funcs pustaka helper bawaan yang tersedia di semua engine (HTML, PDF, CSV).
+// Argumen nilai selalu di posisi terakhir agar bisa dipakai dalam pipeline, mis. {{ .total | rupiah }}.
+func Funcs() map[string]any {
+	return map[string]any{
+		// angka & mata uang
+		"formatNumber": func(decimals int, v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.Number(f, decimals, "id"), err
+		},
+		"formatNumberLocale": func(locale string, decimals int, v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.Number(f, decimals, locale), err
+		},
+		"formatCurrency": func(code string, v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.Currency(code, f), err
+		},
+		"rupiah": func(v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.Rupiah(f), err
+		},
+		"terbilang": func(v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.Terbilang(f), err
+		},
+		"terbilangRupiah": func(v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.TerbilangRupiah(f), err
+		},
+		"inWords": func(v any) (string, error) {
+			f, err := format.ToFloat(v)
+			return format.InWords(f), err
+		},
+
+		// tanggal
+		"formatDate": func(layout string, v any) (string, error) {
+			return format.FormatDate("id", layout, v)
+		},
+		"formatDateLocale": func(locale, layout string, v any) (string, error) {
+			return format.FormatDate(locale, layout, v)
+		},
+		"inTimezone": format.InTimezone,
+
+		// aritmetika
+		"add":   arith(func(a, b float64) (float64, error) { return a + b, nil }),
+		"sub":   arith(func(a, b float64) (float64, error) { return a - b, nil }),
+		"mul":   arith(func(a, b float64) (float64, error) { return a * b, nil }),
+		"div":   arith(divide),
+		"mod":   arith(modulo),
+		"round": roundTo,
+		"sum":   sum,
+
+		// nilai default
+		"default":  defaultValue,
+		"coalesce": coalesce,
+
+		// string
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"title": title,
+		"trim":  strings.TrimSpace,
+
+		// akses aman array/map: nil bila tidak ada, bukan error
+		"at":  at,
+		"get": get,
+		"dig": dig,
+
+		// barcode: data URI untuk <img src>, atau SVG inline
+		"barcode":    barcodeURI,
+		"barcodeSVG": barcodeSVG,
+		"qrcode": func(value any, opts ...string) (htmltemplate.URL, error) {
+			return barcodeURI(barcode.KindQR, value, opts...)
+		},
+
+		// grafik SVG inline: {{barChart .monthly "x=month" "y=revenue,cost" "title=Omzet"}}
+		"barChart":  chartFunc(chart.Bar),
+		"lineChart": chartFunc(chart.Line),
+		"pieChart":  chartFunc(chart.Pie),
+	}
+}
+
+func chartFunc(draw func(chart.Data, chart.Options) (string, error)) func(data any, opts ...string) (htmltemplate.HTML, error) {
+	return func(data any, opts ...string) (htmltemplate.HTML, error) {
+		o, err := chart.ParseOptions(opts...)
+		if err != nil {
+			return "", err
+		}
+		d, err := chart.FromPayload(data, o)
+		if err != nil {
+			return "", err
+		}
+		svg, err := draw(d, o)
+		return htmltemplate.HTML(svg), err
+	}
+}
+
+// barcodeURI merender barcode (code128, ean13, qr, datamatrix) sebagai data URI SVG/PNG:
+// {{barcode "code128" .awb "height=50"}}, {{qrcode .url "size=120" "ecc=H" "quiet=2"}}.
+func barcodeURI(kind string, value any, opts ...string) (htmltemplate.URL, error) {
+	o, err := barcode.ParseOptions(opts...)
+	if err != nil {
+		return "", err
+	}
+	ct, data, err := barcode.Render(kind, barcodeValue(value), o)
+	if err != nil {
+		return "", err
+	}
+	return htmltemplate.URL(DataURI(Asset{ContentType: ct, Data: data})), nil
+}
+
+// barcodeSVG seperti barcodeURI tetapi menghasilkan elemen <svg> untuk disisipkan langsung ke HTML.
+func barcodeSVG(kind string, value any, opts ...string) (htmltemplate.HTML, error) {
+	o, err := barcode.ParseOptions(opts...)
+	if err != nil {
+		return "", err
+	}
+	o.Format = "svg"
+	_, data, err := barcode.Render(kind, barcodeValue(value), o)
+	return htmltemplate.HTML(data), err
+}
+
+// barcodeValue angka JSON (float64) ditulis tanpa eksponen agar EAN/nomor resi tetap utuh.
+func barcodeValue(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// arith membungkus operasi biner; {{ .price | mul .qty }} menghitung qty * price.
+func arith(op func(a, b float64) (float64, error)) func(a, b any) (float64, error) {
+	return func(a, b any) (float64, error) {
+		x, err := format.ToFloat(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := format.ToFloat(b)
+		if err != nil {
+			return 0, err
+		}
+		return op(x, y)
+	}
+}
+
+func divide(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return a / b, nil
+}
+
+func modulo(a, b float64) (float64, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return math.Mod(a, b), nil
+}
+
+func roundTo(places int, v any) (float64, error) {
+	f, err := format.ToFloat(v)
+	if err != nil {
+		return 0, err
+	}
+	p := math.Pow(10, float64(places))
+	return math.Round(f*p) / p, nil
+}
+
+// sum menjumlahkan list angka; dengan field, menjumlahkan field tsb dari list objek:
+// {{ sum .items }} atau {{ sum .items "amount" }}.
+func sum(list any, field ...string) (float64, error) {
+	rv := reflect.ValueOf(list)
+	if !rv.IsValid() {
+		return 0, nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return 0, fmt.Errorf("sum expects a list, got %T", list)
+	}
+	var total float64
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+		if len(field) > 0 {
+			item = get(field[0], item)
+		}
+		f, err := format.ToFloat(item)
+		if err != nil {
+			return 0, err
+		}
+		total += f
+	}
+	return total, nil
+}
+
+func defaultValue(def, v any) any {
+	if empty(v) {
+		return def
+	}
+	return v
+}
+
+func coalesce(vals ...any) any {
+	for _, v := range vals {
+		if !empty(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+// empty mengikuti aturan truthiness template: nil, zero value, dan koleksi kosong.
+func empty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+// title membesarkan huruf awal setiap kata: "budi santoso" -> "Budi Santoso".
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		defer func() { prev = r }()
+		if unicode.IsSpace(prev) || prev == '-' || prev == '(' {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+func at(i int, list any) any {
+	rv := reflect.ValueOf(list)
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil
+	}
+	if i < 0 {
+		i += rv.Len()
+	}
+	if i < 0 || i >= rv.Len() {
+		return nil
+	}
+	return rv.Index(i).Interface()
+}
+
+func get(key string, m any) any {
+	rv := reflect.ValueOf(m)
+	if !rv.IsValid() || rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+	val := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+	if !val.IsValid() {
+		return nil
+	}
+	return val.Interface()
+}
+
+// dig menelusuri path bertitik pada map/list bersarang: {{ dig "customer.addresses.0.city" . }}.
+func dig(path string, v any) any {
+	for _, seg := range strings.Split(path, ".") {
+		if v == nil {
+			return nil
+		}
+		if i, err := strconv.Atoi(seg); err == nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+				v = at(i, v)
+				continue
+			}
+		}
+		v = get(seg, v)
+	}
+	return v
+}
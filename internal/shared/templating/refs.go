@@ -0,0 +1,246 @@
+package templating
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+// Usage cara sebuah path dipakai di template.
+type Usage string
+
+const (
+	UsageOutput    Usage = "output"    // {{.name}}
+	UsageCondition Usage = "condition" // {{if .paid}}
+	UsageRange     Usage = "range"     // {{range .items}}
+	UsageWith      Usage = "with"      // {{with .customer}}
+	UsageArg       Usage = "arg"       // argumen fungsi / template lain
+)
+
+// Ref satu referensi data pada template. Path memakai notasi "customer.name" dan
+// "items[].qty" untuk elemen di dalam range.
+type Ref struct {
+	Path  string
+	Usage Usage
+	Line  int
+	Col   int
+}
+
+// ParseError error parse template beserta posisinya (Col 0 bila tidak diketahui).
+type ParseError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string { return e.Msg }
+
+// TreeName nama template yang dipakai Refs; pakai nama yang sama saat render agar Position bekerja.
+const TreeName = "tpl"
+
+// Error di content yang memakai layout dilaporkan dengan nama ContentBlock.
+var posPattern = regexp.MustCompile(`(?:` + TreeName + `|` + ContentBlock + `):(\d+)(?::(\d+))?:\s*`)
+
+// Position mengambil line/col dari pesan error text/template atau html/template
+// yang template-nya bernama TreeName.
+func Position(err error) (line, col int) {
+	if err == nil {
+		return 0, 0
+	}
+	m := posPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, 0
+	}
+	line, _ = strconv.Atoi(m[1])
+	col, _ = strconv.Atoi(m[2])
+	return line, col
+}
+
+// Message membuang prefix "template: tpl:L:C:" dari pesan error template.
+func Message(err error) string {
+	return strings.TrimPrefix(posPattern.ReplaceAllString(err.Error(), ""), "template: ")
+}
+
+// Refs mem-parse template Go (text/template syntax) dan mengembalikan semua referensi data
+// dengan posisi baris/kolom. Fungsi yang tidak dikenal tidak dianggap error; partial
+// ({{template "x"}} / {{> x}}) tidak ditelusuri.
+func Refs(src string) ([]Ref, error) {
+	t := parse.New(TreeName)
+	t.Mode = parse.SkipFuncCheck
+	trees := map[string]*parse.Tree{}
+	if _, err := t.Parse(NormalizePartialSyntax(src), "", "", trees); err != nil {
+		line, col := Position(err)
+		return nil, &ParseError{Line: line, Col: col, Msg: Message(err)}
+	}
+
+	w := &walker{}
+	names := make([]string, 0, len(trees))
+	for name := range trees {
+		names = append(names, name)
+	}
+	// Tree utama lebih dulu agar urutan ref stabil.
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == TreeName || names[j] == TreeName {
+			return names[i] == TreeName
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		tree := trees[name]
+		if tree.Root == nil {
+			continue
+		}
+		w.tree = tree
+		w.node(tree.Root, scope{})
+	}
+	return w.refs, nil
+}
+
+type scope struct {
+	dot  string
+	vars map[string]string
+}
+
+func (s scope) with(dot string) scope {
+	return scope{dot: dot, vars: s.vars}
+}
+
+func (s scope) declare(name, path string) scope {
+	vars := make(map[string]string, len(s.vars)+1)
+	for k, v := range s.vars {
+		vars[k] = v
+	}
+	vars[name] = path
+	return scope{dot: s.dot, vars: vars}
+}
+
+type walker struct {
+	tree *parse.Tree
+	refs []Ref
+}
+
+func (w *walker) node(n parse.Node, sc scope) scope {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return sc
+		}
+		for _, c := range n.Nodes {
+			// Deklarasi variabel ($x := ...) berlaku untuk node berikutnya di list yang sama.
+			sc = w.node(c, sc)
+		}
+	case *parse.ActionNode:
+		usage := UsageOutput
+		if len(n.Pipe.Decl) > 0 {
+			usage = UsageArg
+		}
+		path := w.pipe(n.Pipe, sc, usage)
+		for _, d := range n.Pipe.Decl {
+			sc = sc.declare(d.Ident[0], path)
+		}
+	case *parse.IfNode:
+		w.pipe(n.Pipe, sc, UsageCondition)
+		w.node(n.List, sc)
+		w.node(n.ElseList, sc)
+	case *parse.RangeNode:
+		path := w.pipe(n.Pipe, sc, UsageRange)
+		elem := ""
+		if path != "" {
+			elem = path + "[]"
+		}
+		inner := sc.with(elem)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner = inner.declare(n.Pipe.Decl[0].Ident[0], elem)
+		case 2:
+			inner = inner.declare(n.Pipe.Decl[1].Ident[0], elem)
+		}
+		w.node(n.List, inner)
+		w.node(n.ElseList, sc)
+	case *parse.WithNode:
+		path := w.pipe(n.Pipe, sc, UsageWith)
+		w.node(n.List, sc.with(path))
+		w.node(n.ElseList, sc)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			w.pipe(n.Pipe, sc, UsageArg)
+		}
+	}
+	return sc
+}
+
+// pipe mencatat referensi di pipeline dan mengembalikan path hasil pipeline bila
+// pipeline hanya satu field (dipakai untuk dot di dalam range/with).
+func (w *walker) pipe(p *parse.PipeNode, sc scope, usage Usage) string {
+	if p == nil {
+		return ""
+	}
+	simple := len(p.Cmds) == 1 && len(p.Cmds[0].Args) == 1
+	result := ""
+	for _, cmd := range p.Cmds {
+		for _, arg := range cmd.Args {
+			u := UsageArg
+			if simple {
+				u = usage
+			}
+			if path := w.arg(arg, sc, u); simple {
+				result = path
+			}
+		}
+	}
+	return result
+}
+
+func (w *walker) arg(n parse.Node, sc scope, usage Usage) string {
+	var path string
+	switch n := n.(type) {
+	case *parse.FieldNode:
+		path = join(sc.dot, n.Ident)
+	case *parse.VariableNode:
+		base, ok := "", n.Ident[0] == "$"
+		if !ok {
+			base, ok = sc.vars[n.Ident[0]]
+		}
+		if !ok {
+			return ""
+		}
+		path = join(base, n.Ident[1:])
+	case *parse.DotNode:
+		path = sc.dot
+	case *parse.PipeNode:
+		w.pipe(n, sc, UsageArg)
+		return ""
+	default:
+		return ""
+	}
+	if path == "" {
+		return ""
+	}
+	line, col := w.position(n)
+	w.refs = append(w.refs, Ref{Path: path, Usage: usage, Line: line, Col: col})
+	return path
+}
+
+func (w *walker) position(n parse.Node) (int, int) {
+	loc, _ := w.tree.ErrorContext(n)
+	parts := strings.Split(loc, ":")
+	if len(parts) < 3 {
+		return 0, 0
+	}
+	line, _ := strconv.Atoi(parts[len(parts)-2])
+	col, _ := strconv.Atoi(parts[len(parts)-1])
+	return line, col
+}
+
+func join(base string, idents []string) string {
+	if len(idents) == 0 {
+		return base
+	}
+	rest := strings.Join(idents, ".")
+	if base == "" {
+		return rest
+	}
+	return base + "." + rest
+}
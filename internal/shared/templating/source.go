@@ -0,0 +1,235 @@
+package templating
+
+import (
+	"encoding/base64"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"go-document-generator/internal/shared/i18n"
+)
+
+// ContentBlock nama block yang diisi content versi saat template memakai layout.
+// Layout merender content lewat {{block "content" .}}...{{end}} atau {{template "content" .}}.
+const ContentBlock = "content"
+
+// Source content template beserta partial dan layout yang sudah di-resolve, siap dirender generator.
+type Source struct {
+	Content  string
+	Layout   string            // isi layout; kosong bila tanpa layout
+	Partials map[string]string // nama partial -> isi (layout tidak termasuk)
+	// Versions versi partial/layout yang dipakai (nama -> versi), untuk pencatatan dependensi.
+	Versions map[string]int
+	// Assets file yang direferensikan lewat {{asset "nama"}}, di-embed sebagai data URI saat render.
+	Assets map[string]Asset
+	// Translator bundle terjemahan untuk {{t "key"}}; zero value mengembalikan key apa adanya.
+	Translator i18n.Translator
+}
+
+// Asset isi file template (gambar, font, stylesheet).
+type Asset struct {
+	ContentType string
+	Data        []byte
+}
+
+// Inline membungkus content tanpa partial/layout.
+func Inline(content string) Source {
+	return Source{Content: content}
+}
+
+// MissingAssetsError asset yang direferensikan template tetapi tidak ada di asset store.
+type MissingAssetsError struct {
+	Names []string
+}
+
+func (e *MissingAssetsError) Error() string {
+	return fmt.Sprintf("unknown asset(s): %s", strings.Join(e.Names, ", "))
+}
+
+// MissingPartialsError partial/layout yang direferensikan tetapi tidak ada (atau belum dipublish).
+type MissingPartialsError struct {
+	Names []string
+}
+
+func (e *MissingPartialsError) Error() string {
+	return fmt.Sprintf("unknown partial(s): %s", strings.Join(e.Names, ", "))
+}
+
+var (
+	// {{> header}} / {{> item line}} (Handlebars/Mustache) ditulis ulang menjadi {{template "header" .}}.
+	mustachePartial = regexp.MustCompile(`\{\{(-?)\s*>\s*([A-Za-z0-9_./-]+)(?:\s+([A-Za-z0-9_.$]+))?\s*(-?)\}\}`)
+	templateCall    = regexp.MustCompile(`\{\{-?\s*template\s+"([^"]+)"`)
+	// {{block}} sekaligus mendefinisikan template dengan isi default.
+	defineCall  = regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"([^"]+)"`)
+	contentCall = regexp.MustCompile(`\{\{-?\s*(?:template|block)\s+"` + ContentBlock + `"`)
+	action      = regexp.MustCompile(`\{\{.*?\}\}`)
+	assetCall   = regexp.MustCompile(`\basset\s+"([^"]+)"`)
+	tCall       = regexp.MustCompile(`(?:^|[\s({|])t\s+"([^"]+)"`)
+)
+
+// NormalizePartialSyntax menulis ulang sintaks partial Handlebars/Mustache ke Go template.
+func NormalizePartialSyntax(src string) string {
+	return mustachePartial.ReplaceAllStringFunc(src, func(m string) string {
+		g := mustachePartial.FindStringSubmatch(m)
+		arg := "."
+		if g[3] != "" {
+			arg = g[3]
+			if !strings.HasPrefix(arg, ".") && !strings.HasPrefix(arg, "$") {
+				arg = "." + arg
+			}
+		}
+		return fmt.Sprintf("{{%stemplate %q %s%s}}", g[1], g[2], arg, g[4])
+	})
+}
+
+// PartialNames mengembalikan nama template yang dipanggil src tetapi tidak didefinisikan di src sendiri.
+func PartialNames(src string) []string {
+	src = NormalizePartialSyntax(src)
+	defined := map[string]bool{}
+	for _, n := range DefinedNames(src) {
+		defined[n] = true
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range templateCall.FindAllStringSubmatch(src, -1) {
+		name := m[1]
+		if defined[name] || seen[name] || name == ContentBlock {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+// DefinedNames mengembalikan nama template yang didefinisikan src lewat {{define}}/{{block}}.
+func DefinedNames(src string) []string {
+	var out []string
+	for _, m := range defineCall.FindAllStringSubmatch(src, -1) {
+		out = append(out, m[1])
+	}
+	return out
+}
+
+// UsesContentBlock melaporkan apakah layout merender content versi lewat block/template "content".
+func UsesContentBlock(layout string) bool {
+	return contentCall.MatchString(layout)
+}
+
+// AssetNames mengembalikan nama asset yang dipanggil content, layout dan partial (urut, unik).
+func (s Source) AssetNames() []string {
+	return s.literalArgs(assetCall)
+}
+
+// TranslationKeys mengembalikan key literal {{t "key"}} di content, layout dan partial (urut, unik).
+func (s Source) TranslationKeys() []string {
+	return s.literalArgs(tCall)
+}
+
+// literalArgs argumen string literal dari pemanggilan fungsi yang cocok dengan call.
+func (s Source) literalArgs(call *regexp.Regexp) []string {
+	bodies := []string{s.Content, s.Layout}
+	for _, p := range s.partialNames() {
+		bodies = append(bodies, s.Partials[p])
+	}
+	seen := map[string]bool{}
+	var out []string
+	for _, b := range bodies {
+		for _, a := range action.FindAllString(b, -1) {
+			for _, m := range call.FindAllStringSubmatch(a, -1) {
+				if !seen[m[1]] {
+					seen[m[1]] = true
+					out = append(out, m[1])
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// funcs fungsi bawaan yang selalu tersedia untuk template; funcs dari generator ditambahkan setelahnya.
+func (s Source) funcs(extra map[string]any) map[string]any {
+	out := Funcs()
+	out["asset"] = func(name string) (htmltemplate.URL, error) {
+		a, ok := s.Assets[name]
+		if !ok {
+			return "", fmt.Errorf("asset %q not found", name)
+		}
+		return htmltemplate.URL(DataURI(a)), nil
+	}
+	out["t"] = s.Translator.T
+	out["locale"] = s.Translator.Locale
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// DataURI meng-encode asset sebagai data URI base64.
+func DataURI(a Asset) string {
+	ct := a.ContentType
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	return "data:" + ct + ";base64," + base64.StdEncoding.EncodeToString(a.Data)
+}
+
+// HTML mem-parse source dengan html/template. Tanpa layout, content menjadi template utama
+// bernama name; dengan layout, layout menjadi template utama dan content mengisi ContentBlock
+// ({{define}} di content menimpa block layout).
+func (s Source) HTML(name string, funcs htmltemplate.FuncMap) (*htmltemplate.Template, error) {
+	root := htmltemplate.New(name).Funcs(s.funcs(funcs))
+	if _, err := root.Parse(NormalizePartialSyntax(s.main())); err != nil {
+		return nil, err
+	}
+	for _, p := range s.partialNames() {
+		if _, err := root.New(p).Parse(NormalizePartialSyntax(s.Partials[p])); err != nil {
+			return nil, err
+		}
+	}
+	if s.Layout != "" {
+		if _, err := root.New(ContentBlock).Parse(NormalizePartialSyntax(s.Content)); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// Text sama dengan HTML untuk text/template (CSV dan format teks lain).
+func (s Source) Text(name string, funcs texttemplate.FuncMap) (*texttemplate.Template, error) {
+	root := texttemplate.New(name).Funcs(s.funcs(funcs))
+	if _, err := root.Parse(NormalizePartialSyntax(s.main())); err != nil {
+		return nil, err
+	}
+	for _, p := range s.partialNames() {
+		if _, err := root.New(p).Parse(NormalizePartialSyntax(s.Partials[p])); err != nil {
+			return nil, err
+		}
+	}
+	if s.Layout != "" {
+		if _, err := root.New(ContentBlock).Parse(NormalizePartialSyntax(s.Content)); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+func (s Source) main() string {
+	if s.Layout != "" {
+		return s.Layout
+	}
+	return s.Content
+}
+
+func (s Source) partialNames() []string {
+	names := make([]string, 0, len(s.Partials))
+	for n := range s.Partials {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,46 @@
+package watermark
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"strconv"
+)
+
+// HTML menyisipkan overlay watermark (posisi fixed, di atas konten, tidak menangkap klik) sebelum
+// </body>; tanpa </body> overlay ditambahkan di akhir dokumen. s sebaiknya sudah WithDefaults.
+func HTML(doc []byte, s Spec, img *Image) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<div class="dg-watermark" aria-hidden="true" style="position:fixed;top:0;left:0;width:100%%;height:100%%;`+
+		`display:flex;align-items:center;justify-content:center;pointer-events:none;z-index:2147483647;opacity:%s">`,
+		num(s.Opacity))
+	rotation := DefaultRotation
+	if s.Rotation != nil {
+		rotation = *s.Rotation
+	}
+	// CSS memutar searah jarum jam, spec berlawanan arah.
+	fmt.Fprintf(&b, `<div style="transform:rotate(%sdeg);text-align:center">`, num(-rotation))
+	if img != nil {
+		fmt.Fprintf(&b, `<img src="data:%s;base64,%s" alt="" style="display:block;margin:0 auto;max-width:50vw;max-height:50vh">`,
+			html.EscapeString(img.ContentType), base64.StdEncoding.EncodeToString(img.Data))
+	}
+	if s.Text != "" {
+		fmt.Fprintf(&b, `<span style="font:bold %spx Helvetica,Arial,sans-serif;color:%s;white-space:nowrap">%s</span>`,
+			num(s.FontSize), html.EscapeString(s.Color), html.EscapeString(s.Text))
+	}
+	b.WriteString(`</div></div>`)
+
+	i := bytes.LastIndex(bytes.ToLower(doc), []byte("</body>"))
+	if i < 0 {
+		return append(append([]byte(nil), doc...), b.Bytes()...)
+	}
+	out := make([]byte, 0, len(doc)+b.Len())
+	out = append(out, doc[:i]...)
+	out = append(out, b.Bytes()...)
+	return append(out, doc[i:]...)
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
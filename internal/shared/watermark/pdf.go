@@ -0,0 +1,258 @@
+package watermark
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PageSize sisi halaman overlay (pt). Halaman persegi ini diskalakan ke tengah halaman tujuan
+// saat overlay, sehingga watermark proporsional untuk ukuran/orientasi kertas apa pun.
+const PageSize = 600
+
+// helveticaBold lebar glyph Helvetica-Bold (1/1000 em) untuk karakter 32..126.
+var helveticaBold = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// Page membuat PDF satu halaman berisi watermark s (sebaiknya sudah WithDefaults) untuk ditimpa
+// ke halaman dokumen. Teks memakai font standar Helvetica-Bold (WinAnsi; karakter di luar
+// Latin-1 diganti "?"); gambar PNG atau JPEG dipusatkan di atas teks.
+func Page(s Spec, img *Image) ([]byte, error) {
+	var im *pdfImage
+	if img != nil {
+		var err error
+		if im, err = encodeImage(img.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	var content bytes.Buffer
+	rotation := DefaultRotation
+	if s.Rotation != nil {
+		rotation = *s.Rotation
+	}
+	rad := rotation * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	fmt.Fprintf(&content, "q\n/GS1 gs\n1 0 0 1 %s %s cm\n%s %s %s %s 0 0 cm\n",
+		pdfNum(PageSize/2), pdfNum(PageSize/2), pdfNum(cos), pdfNum(sin), pdfNum(-sin), pdfNum(cos))
+
+	text, units := winAnsi(s.Text)
+	size := s.FontSize
+	if size <= 0 {
+		size = DefaultFontSize
+	}
+	// Teks dikecilkan agar muat 90% sisi halaman.
+	tw := float64(units) * size / 1000
+	if limit := PageSize * 0.9; tw > limit {
+		size *= limit / tw
+		tw = limit
+	}
+	th := 0.72 * size // cap height Helvetica
+	if text == "" {
+		th = 0
+	}
+
+	var iw, ih, gap float64
+	if im != nil {
+		scale := math.Min(PageSize/2/float64(im.width), PageSize/2/float64(im.height))
+		iw, ih = float64(im.width)*scale, float64(im.height)*scale
+		if text != "" {
+			gap = 0.3 * size
+		}
+	}
+	bottom := -(ih + gap + th) / 2
+	if im != nil {
+		fmt.Fprintf(&content, "q %s 0 0 %s %s %s cm /Im1 Do Q\n", pdfNum(iw), pdfNum(ih), pdfNum(-iw/2), pdfNum(bottom+th+gap))
+	}
+	if text != "" {
+		r, g, b := rgb(s.Color)
+		fmt.Fprintf(&content, "BT /F1 %s Tf %s %s %s rg %s %s Td (%s) Tj ET\n",
+			pdfNum(size), pdfNum(r), pdfNum(g), pdfNum(b), pdfNum(-tw/2), pdfNum(bottom), text)
+	}
+	content.WriteString("Q\n")
+
+	opacity := s.Opacity
+	if opacity <= 0 || opacity > 1 {
+		opacity = DefaultOpacity
+	}
+	resources := "/Font << /F1 5 0 R >> /ExtGState << /GS1 6 0 R >>"
+	if im != nil {
+		resources += " /XObject << /Im1 7 0 R >>"
+	}
+
+	w := newPDFWriter()
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << %s >> /Contents 4 0 R >>",
+		PageSize, PageSize, resources))
+	w.stream("", content.Bytes())
+	w.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	w.object(fmt.Sprintf("<< /Type /ExtGState /ca %s /CA %s >>", pdfNum(opacity), pdfNum(opacity)))
+	if im != nil {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			im.width, im.height, im.colorSpace, im.filter)
+		if im.alpha != nil {
+			dict += " /SMask 8 0 R"
+		}
+		w.stream(dict, im.data)
+		if im.alpha != nil {
+			w.stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode",
+				im.width, im.height), im.alpha)
+		}
+	}
+	return w.finish(), nil
+}
+
+// winAnsi mengubah teks menjadi isi literal string PDF (WinAnsi, karakter khusus di-escape) dan
+// mengembalikan lebarnya dalam 1/1000 em.
+func winAnsi(s string) (string, int) {
+	var b strings.Builder
+	units := 0
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+			units += 556
+			continue
+		default:
+			r = '?'
+			b.WriteRune(r)
+		}
+		units += helveticaBold[r-32]
+	}
+	return b.String(), units
+}
+
+type pdfImage struct {
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+	alpha         []byte // nil bila gambar opaque
+}
+
+// encodeImage menyiapkan gambar untuk XObject: JPEG disisipkan apa adanya (DCTDecode), PNG
+// di-decode menjadi RGB + alpha (SMask) terkompresi Flate.
+func encodeImage(data []byte) (*pdfImage, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("watermark image must be PNG or JPEG")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > 25_000_000 {
+		return nil, fmt.Errorf("watermark image size %dx%d not supported", cfg.Width, cfg.Height)
+	}
+	switch format {
+	case "jpeg":
+		cs := "DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			cs = "DeviceGray"
+		case color.CMYKModel:
+			cs = "DeviceCMYK"
+		}
+		return &pdfImage{width: cfg.Width, height: cfg.Height, colorSpace: cs, filter: "DCTDecode", data: data}, nil
+	case "png":
+		m, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		bounds := m.Bounds()
+		rgbData := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+		alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+		opaque := true
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.NRGBAModel.Convert(m.At(x, y)).(color.NRGBA)
+				rgbData = append(rgbData, c.R, c.G, c.B)
+				alpha = append(alpha, c.A)
+				opaque = opaque && c.A == 0xff
+			}
+		}
+		im := &pdfImage{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB", filter: "FlateDecode"}
+		if im.data, err = deflate(rgbData); err != nil {
+			return nil, err
+		}
+		if !opaque {
+			if im.alpha, err = deflate(alpha); err != nil {
+				return nil, err
+			}
+		}
+		return im, nil
+	}
+	return nil, errors.New("watermark image must be PNG or JPEG")
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	zw := zlib.NewWriter(&b)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// pdfWriter penulis PDF sederhana: objek bernomor urut mulai 1 dan tabel xref klasik.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	return w
+}
+
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+// stream menulis objek stream; dict berisi entry tambahan tanpa << >> dan /Length.
+func (w *pdfWriter) stream(dict string, data []byte) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(w.offsets), strings.TrimSpace(dict), len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *pdfWriter) finish() []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, off := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+	return w.buf.Bytes()
+}
+
+// pdfNum angka PDF dengan maksimum 4 desimal.
+func pdfNum(f float64) string {
+	f = math.Round(f*10000) / 10000
+	if f == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
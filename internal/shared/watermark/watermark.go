@@ -0,0 +1,182 @@
+// Package watermark berisi spesifikasi watermark dokumen (stempel teks DRAFT/COPY/CONFIDENTIAL atau
+// logo) beserta penerapannya: overlay CSS untuk HTML dan halaman overlay PDF yang ditimpa ke output.
+package watermark
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Source asal watermark yang diterapkan pada dokumen.
+type Source string
+
+const (
+	SourceRequest  Source = "REQUEST"
+	SourceTemplate Source = "TEMPLATE"
+	SourcePreview  Source = "PREVIEW"
+)
+
+// Pilihan halaman PDF selain daftar rentang ("1-3,5").
+const (
+	PagesAll   = "all"
+	PagesFirst = "first"
+	PagesLast  = "last"
+)
+
+// Nilai default bila field spec kosong.
+const (
+	DefaultOpacity  = 0.15
+	DefaultRotation = 45.0
+	DefaultFontSize = 72.0
+	DefaultColor    = "#808080"
+)
+
+// Spec watermark teks dan/atau gambar. Image adalah nama asset template (mis. logo tenant).
+// Rotation dalam derajat berlawanan arah jarum jam; Pages hanya berlaku untuk PDF.
+type Spec struct {
+	Text     string   `json:"text,omitempty"`
+	Image    string   `json:"image,omitempty"`
+	Opacity  float64  `json:"opacity,omitempty"`
+	Rotation *float64 `json:"rotation,omitempty"`
+	FontSize float64  `json:"font_size,omitempty"`
+	Color    string   `json:"color,omitempty"`
+	Pages    string   `json:"pages,omitempty"`
+	// Source diisi service saat watermark di-resolve, bukan oleh pemanggil.
+	Source Source `json:"source,omitempty"`
+}
+
+// Image isi gambar watermark (PNG atau JPEG).
+type Image struct {
+	ContentType string
+	Data        []byte
+}
+
+var (
+	colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	pagesPattern = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+)
+
+// Draft watermark otomatis untuk preview.
+func Draft() Spec {
+	return Spec{Text: "DRAFT", Source: SourcePreview}
+}
+
+// IsZero true bila spec tidak berisi teks maupun gambar (dipakai untuk menghapus watermark template).
+func (s Spec) IsZero() bool {
+	return strings.TrimSpace(s.Text) == "" && strings.TrimSpace(s.Image) == ""
+}
+
+func (s Spec) Validate() error {
+	if s.IsZero() {
+		return errors.New("text or image is required")
+	}
+	if utf8.RuneCountInString(s.Text) > 100 {
+		return errors.New("text exceeds 100 characters")
+	}
+	if len(s.Image) > 255 {
+		return errors.New("image exceeds 255 characters")
+	}
+	if s.Opacity < 0 || s.Opacity > 1 {
+		return errors.New("opacity must be between 0 and 1")
+	}
+	if s.Rotation != nil && (*s.Rotation < -360 || *s.Rotation > 360) {
+		return errors.New("rotation must be between -360 and 360")
+	}
+	if s.FontSize != 0 && (s.FontSize < 6 || s.FontSize > 300) {
+		return errors.New("font_size must be between 6 and 300")
+	}
+	if s.Color != "" && !colorPattern.MatchString(s.Color) {
+		return errors.New("color must be #RRGGBB")
+	}
+	switch p := strings.ReplaceAll(s.Pages, " ", ""); p {
+	case "", PagesAll, PagesFirst, PagesLast:
+	default:
+		if !pagesPattern.MatchString(p) {
+			return fmt.Errorf("invalid pages %q (all, first, last or ranges like 1-3,5)", s.Pages)
+		}
+	}
+	return nil
+}
+
+// WithDefaults mengisi field kosong dengan nilai default.
+func (s Spec) WithDefaults() Spec {
+	s.Text = strings.TrimSpace(s.Text)
+	s.Image = strings.TrimSpace(s.Image)
+	if s.Opacity == 0 {
+		s.Opacity = DefaultOpacity
+	}
+	if s.Rotation == nil {
+		r := DefaultRotation
+		s.Rotation = &r
+	}
+	if s.FontSize == 0 {
+		s.FontSize = DefaultFontSize
+	}
+	if s.Color == "" {
+		s.Color = DefaultColor
+	}
+	s.Pages = strings.ReplaceAll(s.Pages, " ", "")
+	if s.Pages == "" {
+		s.Pages = PagesAll
+	}
+	return s
+}
+
+// SelectPages nomor halaman (mulai 1, urut, unik) yang diberi watermark dari total halaman.
+// Rentang di luar jumlah halaman diabaikan.
+func SelectPages(pages string, total int) ([]int, error) {
+	if total <= 0 {
+		return nil, nil
+	}
+	switch p := strings.ReplaceAll(pages, " ", ""); p {
+	case "", PagesAll:
+		out := make([]int, total)
+		for i := range out {
+			out[i] = i + 1
+		}
+		return out, nil
+	case PagesFirst:
+		return []int{1}, nil
+	case PagesLast:
+		return []int{total}, nil
+	default:
+		if !pagesPattern.MatchString(p) {
+			return nil, fmt.Errorf("invalid pages %q", pages)
+		}
+		seen := make(map[int]bool)
+		for _, part := range strings.Split(p, ",") {
+			from, to, ok := strings.Cut(part, "-")
+			lo, _ := strconv.Atoi(from)
+			hi := lo
+			if ok {
+				hi, _ = strconv.Atoi(to)
+			}
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			for n := max(lo, 1); n <= hi && n <= total; n++ {
+				seen[n] = true
+			}
+		}
+		out := make([]int, 0, len(seen))
+		for n := range seen {
+			out = append(out, n)
+		}
+		sort.Ints(out)
+		return out, nil
+	}
+}
+
+// rgb warna #RRGGBB sebagai komponen 0..1.
+func rgb(color string) (r, g, b float64) {
+	if !colorPattern.MatchString(color) {
+		color = DefaultColor
+	}
+	v, _ := strconv.ParseUint(color[1:], 16, 32)
+	return float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255
+}
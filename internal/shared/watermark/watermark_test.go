@@ -0,0 +1,164 @@
+package watermark
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	zero := 0.0
+	valid := []Spec{
+		{Text: "DRAFT"},
+		{Image: "logo.png", Opacity: 0.3, Rotation: &zero, Pages: "first"},
+		{Text: "COPY", Color: "#FF0000", FontSize: 48, Pages: "1-3, 5"},
+	}
+	for _, s := range valid {
+		if err := s.Validate(); err != nil {
+			t.Errorf("%+v: %v", s, err)
+		}
+	}
+	big := 400.0
+	invalid := []Spec{
+		{},
+		{Text: "  "},
+		{Text: "X", Opacity: 1.5},
+		{Text: "X", Rotation: &big},
+		{Text: "X", FontSize: 2},
+		{Text: "X", Color: "red"},
+		{Text: "X", Pages: "odd"},
+		{Text: "X", Pages: "1-"},
+		{Text: strings.Repeat("x", 101)},
+	}
+	for _, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%+v: expected error", s)
+		}
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	zero := 0.0
+	s := Spec{Text: " DRAFT ", Rotation: &zero, Pages: "1, 2"}.WithDefaults()
+	if s.Text != "DRAFT" || s.Opacity != DefaultOpacity || *s.Rotation != 0 || s.FontSize != DefaultFontSize ||
+		s.Color != DefaultColor || s.Pages != "1,2" {
+		t.Errorf("WithDefaults = %+v", s)
+	}
+	if r := (Spec{Text: "X"}).WithDefaults().Rotation; r == nil || *r != DefaultRotation {
+		t.Errorf("rotation = %v", r)
+	}
+}
+
+func TestSelectPages(t *testing.T) {
+	cases := []struct {
+		pages string
+		total int
+		want  []int
+	}{
+		{"", 3, []int{1, 2, 3}},
+		{"all", 2, []int{1, 2}},
+		{"first", 5, []int{1}},
+		{"last", 5, []int{5}},
+		{"2-3,1,3", 5, []int{1, 2, 3}},
+		{"4-2", 5, []int{2, 3, 4}},
+		{"3-9", 4, []int{3, 4}},
+		{"7", 4, []int{}},
+		{"all", 0, nil},
+	}
+	for _, c := range cases {
+		got, err := SelectPages(c.pages, c.total)
+		if err != nil {
+			t.Fatalf("%q: %v", c.pages, err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SelectPages(%q, %d) = %v, want %v", c.pages, c.total, got, c.want)
+		}
+	}
+	if _, err := SelectPages("x", 3); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestHTML(t *testing.T) {
+	s := Spec{Text: "<CONFIDENTIAL>"}.WithDefaults()
+	out := string(HTML([]byte("<html><BODY><p>isi</p></BODY></html>"), s, &Image{ContentType: "image/png", Data: []byte{1, 2}}))
+	if !strings.HasPrefix(out, "<html><BODY><p>isi</p><div class=\"dg-watermark\"") || !strings.HasSuffix(out, "</div></div></BODY></html>") {
+		t.Errorf("overlay not before </body>: %s", out)
+	}
+	for _, want := range []string{"&lt;CONFIDENTIAL&gt;", "opacity:0.15", "rotate(-45deg)", "color:#808080", "data:image/png;base64,AQI="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+	if out := string(HTML([]byte("<p>fragment</p>"), s, nil)); !strings.HasPrefix(out, "<p>fragment</p><div") {
+		t.Errorf("fragment = %s", out)
+	}
+}
+
+func TestPageText(t *testing.T) {
+	data, err := Page(Spec{Text: "DRAFT (1) ü", Color: "#FF0000"}.WithDefaults(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkXref(t, data)
+	for _, want := range []string{"/BaseFont /Helvetica-Bold", "/ca 0.15 /CA 0.15", "1 0 0 rg", "(DRAFT \\(1\\) \\374) Tj",
+		"0.7071 0.7071 -0.7071 0.7071 0 0 cm"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if bytes.Contains(data, []byte("/XObject")) {
+		t.Error("unexpected image resource")
+	}
+}
+
+func TestPageImage(t *testing.T) {
+	m := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	m.Set(0, 0, color.NRGBA{R: 255, A: 128})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := Page(Spec{Image: "logo.png"}.WithDefaults(), &Image{ContentType: "image/png", Data: buf.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkXref(t, data)
+	for _, want := range []string{"/XObject << /Im1 7 0 R >>", "/Width 4 /Height 2", "/SMask 8 0 R", "/Im1 Do"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if _, err := Page(Spec{Image: "x.svg"}, &Image{Data: []byte("<svg/>")}); err == nil {
+		t.Error("expected error for svg")
+	}
+}
+
+// checkXref memastikan setiap entry xref menunjuk ke awal objek bernomor sama.
+func checkXref(t *testing.T, data []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("startxref missing")
+	}
+	start, _ := strconv.Atoi(string(m[1]))
+	lines := strings.Split(string(data[start:]), "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("startxref points to %q", lines[0])
+	}
+	var n int
+	fmt.Sscanf(lines[1], "0 %d", &n)
+	for i := 1; i < n; i++ {
+		off, _ := strconv.Atoi(lines[2+i][:10])
+		if want := fmt.Sprintf("%d 0 obj\n", i); !bytes.HasPrefix(data[off:], []byte(want)) {
+			t.Errorf("xref object %d at %d: %q", i, off, data[off:off+10])
+		}
+	}
+}
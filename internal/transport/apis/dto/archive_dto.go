@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/archive"
+	ucArchive "go-document-generator/internal/usecase/documentarchives"
+)
+
+// ArchiveJobRequest body POST /documents/zip dan /documents/merge: isi ids atau filter, tidak keduanya.
+// Filter memilih dokumen GENERATED milik tenant; options hanya untuk zip.
+type ArchiveJobRequest struct {
+	IDs       []int64               `json:"ids"`
+	Filter    *archiveEntity.Filter `json:"filter"`
+	Options   archive.Options       `json:"options"`
+	Label     *string               `json:"label"`
+	CreatedBy *string               `json:"created_by"`
+}
+
+func (r ArchiveJobRequest) ToInput(tenantID *string, kind enums.ArchiveJobKind) ucArchive.CreateInput {
+	return ucArchive.CreateInput{
+		TenantID: tenantID, Kind: kind, DocumentIDs: r.IDs, Filter: r.Filter, Options: r.Options,
+		Label: r.Label, CreatedBy: r.CreatedBy,
+	}
+}
+
+type ArchiveJobResponse struct {
+	ID           int64                  `json:"id"`
+	TenantID     *string                `json:"tenant_id"`
+	Kind         enums.ArchiveJobKind   `json:"kind"`
+	Status       enums.ArchiveJobStatus `json:"status"`
+	Label        *string                `json:"label"`
+	DocumentIDs  []int64                `json:"document_ids,omitempty"`
+	Filter       *archiveEntity.Filter  `json:"filter"`
+	Options      ArchiveOptionsResponse `json:"options"`
+	Total        int                    `json:"total"`
+	Processed    int                    `json:"processed"`
+	HasOutput    bool                   `json:"has_output"`
+	OutputFormat *string                `json:"output_format"`
+	ErrorMessage *string                `json:"error_message"`
+	CreatedBy    *string                `json:"created_by"`
+	StartedAt    *time.Time             `json:"started_at"`
+	CompletedAt  *time.Time             `json:"completed_at"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// ArchiveOptionsResponse opsi arsip tanpa password.
+type ArchiveOptionsResponse struct {
+	Format           string `json:"format"`
+	Layout           string `json:"layout,omitempty"`
+	Manifest         string `json:"manifest,omitempty"`
+	CompressionLevel *int   `json:"compression_level,omitempty"`
+}
+
+type ArchiveJobListResponse struct {
+	Data []ArchiveJobResponse `json:"data"`
+	Meta PaginationMeta       `json:"meta"`
+}
+
+func ArchiveJobFromEntity(j archiveEntity.Job) ArchiveJobResponse {
+	return ArchiveJobResponse{
+		ID: j.ID, TenantID: j.TenantID, Kind: j.Kind, Status: j.Status, Label: j.Label,
+		DocumentIDs: j.DocumentIDs, Filter: j.Filter, Total: j.Total, Processed: j.Processed,
+		Options: ArchiveOptionsResponse{
+			Format: j.Options.Extension(), Layout: j.Options.Layout, Manifest: j.Options.Manifest,
+			CompressionLevel: j.Options.CompressionLevel,
+		},
+		HasOutput: j.OutputPath != nil, OutputFormat: j.OutputFormat, ErrorMessage: j.ErrorMessage,
+		CreatedBy: j.CreatedBy, StartedAt: j.StartedAt, CompletedAt: j.CompletedAt,
+		CreatedAt: j.CreatedAt, UpdatedAt: j.UpdatedAt,
+	}
+}
@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+	ucBatch "go-document-generator/internal/usecase/documentbatches"
+)
+
+// CreateBatchRequest field template di level batch menjadi default untuk item yang tidak mengisinya.
+type CreateBatchRequest struct {
+	Name            *string                `json:"name"`
+	TemplateCode    string                 `json:"template_code"`
+	TemplateVersion *int                   `json:"template_version"`
+	OutputFormat    enums.OutputFormat     `json:"output_format"`
+	Locale          *string                `json:"locale"`
+	Priority        enums.DocumentPriority `json:"priority"` // default LOW
+	StoreToDms      bool                   `json:"store_to_dms"`
+	ExpiredAt       *time.Time             `json:"expired_at"`
+	Items           []BatchItemRequest     `json:"items"`
+	PostAction      enums.BatchPostAction  `json:"post_action"` // NONE | ZIP | MERGE
+	Metadata        map[string]any         `json:"metadata"`
+	HasCallback     bool                   `json:"has_callback"`
+	CallbackURL     *string                `json:"callback_url"`
+	CreatedBy       *string                `json:"created_by"`
+}
+
+type BatchItemRequest struct {
+	RequestID       string                 `json:"request_id"` // default batch-<batch_id>-<seq>
+	TemplateCode    string                 `json:"template_code"`
+	TemplateVersion *int                   `json:"template_version"`
+	OutputFormat    enums.OutputFormat     `json:"output_format"`
+	Locale          *string                `json:"locale"`
+	Priority        enums.DocumentPriority `json:"priority"`
+	Payload         map[string]any         `json:"payload"`
+	Metadata        map[string]any         `json:"metadata"`
+	StoreToDms      bool                   `json:"store_to_dms"`
+	ExpiredAt       *time.Time             `json:"expired_at"`
+}
+
+type BatchResponse struct {
+	ID               int64                 `json:"id"`
+	TenantID         *string               `json:"tenant_id"`
+	Name             *string               `json:"name"`
+	Status           enums.BatchStatus     `json:"status"`
+	TotalItems       int                   `json:"total_items"`
+	Progress         batchEntity.Progress  `json:"progress"`
+	PostAction       enums.BatchPostAction `json:"post_action"`
+	HasOutput        bool                  `json:"has_output"`
+	OutputError      *string               `json:"output_error"`
+	Metadata         map[string]any        `json:"metadata"`
+	HasCallback      bool                  `json:"has_callback"`
+	CallbackURL      *string               `json:"callback_url"`
+	CallbackStatus   enums.CallbackStatus  `json:"callback_status"`
+	CallbackAttempts int                   `json:"callback_attempts"`
+	CreatedBy        *string               `json:"created_by"`
+	CompletedAt      *time.Time            `json:"completed_at"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+type BatchListResponse struct {
+	Data []BatchResponse `json:"data"`
+	Meta PaginationMeta  `json:"meta"`
+}
+
+type BatchItemResponse struct {
+	ID             int64                 `json:"id"`
+	Seq            int                   `json:"seq"`
+	RequestID      string                `json:"request_id"`
+	TemplateCode   string                `json:"template_code"`
+	Status         enums.BatchItemStatus `json:"status"`
+	DocumentID     *int64                `json:"document_id"`
+	DocumentStatus enums.DocumentStatus  `json:"document_status,omitempty"`
+	ErrorMessage   *string               `json:"error_message"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type BatchItemListResponse struct {
+	Data []BatchItemResponse `json:"data"`
+	Meta PaginationMeta      `json:"meta"`
+}
+
+func (r CreateBatchRequest) ToInput(tenantID *string) ucBatch.CreateInput {
+	items := make([]ucBatch.CreateItem, len(r.Items))
+	for i, it := range r.Items {
+		items[i] = ucBatch.CreateItem{
+			RequestID: it.RequestID,
+			Input: batchEntity.ItemInput{
+				TemplateCode: it.TemplateCode, TemplateVersion: it.TemplateVersion,
+				OutputFormat: it.OutputFormat, Locale: it.Locale, Priority: it.Priority,
+				Payload: it.Payload, Metadata: it.Metadata, StoreToDms: it.StoreToDms, ExpiredAt: it.ExpiredAt,
+			},
+		}
+	}
+	return ucBatch.CreateInput{
+		TenantID: tenantID, Name: r.Name,
+		Defaults: batchEntity.ItemInput{
+			TemplateCode: r.TemplateCode, TemplateVersion: r.TemplateVersion,
+			OutputFormat: r.OutputFormat, Locale: r.Locale, Priority: r.Priority,
+			StoreToDms: r.StoreToDms, ExpiredAt: r.ExpiredAt,
+		},
+		Items: items, PostAction: r.PostAction, Metadata: r.Metadata,
+		HasCallback: r.HasCallback, CallbackURL: r.CallbackURL, CreatedBy: r.CreatedBy,
+	}
+}
+
+func BatchFromEntity(b batchEntity.Batch) BatchResponse {
+	return BatchResponse{
+		ID: b.ID, TenantID: b.TenantID, Name: b.Name, Status: b.Status,
+		TotalItems: b.TotalItems, Progress: b.Progress,
+		PostAction: b.PostAction, HasOutput: b.OutputPath != nil, OutputError: b.OutputError,
+		Metadata: b.Metadata, HasCallback: b.HasCallback, CallbackURL: b.CallbackURL,
+		CallbackStatus: b.CallbackStatus, CallbackAttempts: b.CallbackAttempts,
+		CreatedBy: b.CreatedBy, CompletedAt: b.CompletedAt, CreatedAt: b.CreatedAt, UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func BatchItemFromEntity(it batchEntity.Item) BatchItemResponse {
+	return BatchItemResponse{
+		ID: it.ID, Seq: it.Seq, RequestID: it.RequestID, TemplateCode: it.Input.TemplateCode,
+		Status: it.Status, DocumentID: it.DocumentID, DocumentStatus: it.DocumentStatus,
+		ErrorMessage: it.ErrorMessage, UpdatedAt: it.UpdatedAt,
+	}
+}
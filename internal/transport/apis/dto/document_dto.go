@@ -7,6 +7,9 @@ import (
 	cbEntity "go-document-generator/internal/entity/documentcallbackattempts"
 	logEntity "go-document-generator/internal/entity/documentrenderlogs"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/pdfa"
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/shared/watermark"
 	ucDoc "go-document-generator/internal/usecase/documents"
 )
 
@@ -21,14 +24,22 @@ type GeneratedDocumentResponse struct {
 	Payload           map[string]any         `json:"payload"`
 	Metadata          map[string]any         `json:"metadata"`
 	Status            enums.DocumentStatus   `json:"status"`
+	Priority          enums.DocumentPriority `json:"priority"`
 	ErrorMessage      *string                `json:"error_message"`
 	OutputFormat      enums.OutputFormat     `json:"output_format"`
+	Locale            *string                `json:"locale"`
 	FileName          *string                `json:"file_name"`
 	FilePath          *string                `json:"file_path"`
 	StorageProvider   *enums.StorageProvider `json:"storage_provider"`
 	FileSize          *int64                 `json:"file_size"`
 	Checksum          *string                `json:"checksum"`
 	ContentType       *string                `json:"content_type"`
+	Watermark         *watermark.Spec        `json:"watermark"`
+	Encryption        *pdfsecurity.Spec      `json:"encryption"`
+	IsEncrypted       bool                   `json:"is_encrypted"`
+	PDFAConformance   *string                `json:"pdfa_conformance"`
+	PDFAReport        *pdfa.Report           `json:"pdfa_report"`
+	Attachments       []docEntity.Attachment `json:"attachments"`
 	IsSigned          bool                   `json:"is_signed"`
 	SignatureProvider *string                `json:"signature_provider"`
 	SignedAt          *time.Time             `json:"signed_at"`
@@ -50,18 +61,32 @@ type GeneratedDocumentResponse struct {
 }
 
 type CreateDocumentRequest struct {
-	TenantID        *string            `json:"tenant_id"`
-	RequestID       string             `json:"request_id"`
-	TemplateCode    string             `json:"template_code"`
-	TemplateVersion *int               `json:"template_version"`
-	OutputFormat    enums.OutputFormat `json:"output_format"`
-	Payload         map[string]any     `json:"payload"`
-	Metadata        map[string]any     `json:"metadata"`
-	StoreToDms      bool               `json:"store_to_dms"`
-	HasCallback     bool               `json:"has_callback"`
-	CallbackURL     *string            `json:"callback_url"`
-	ExpiredAt       *time.Time         `json:"expired_at"`
-	CreatedBy       *string            `json:"created_by"`
+	TenantID        *string                `json:"tenant_id"`
+	RequestID       string                 `json:"request_id"`
+	TemplateCode    string                 `json:"template_code"`
+	TemplateVersion *int                   `json:"template_version"`
+	OutputFormat    enums.OutputFormat     `json:"output_format"`
+	Locale          *string                `json:"locale"`   // mis. "en-US"; default metadata.locale lalu locale tenant
+	Priority        enums.DocumentPriority `json:"priority"` // HIGH | NORMAL | LOW; default tenant (bulk: LOW)
+	Payload         map[string]any         `json:"payload"`
+	Metadata        map[string]any         `json:"metadata"`
+	StoreToDms      bool                   `json:"store_to_dms"`
+	HasCallback     bool                   `json:"has_callback"`
+	CallbackURL     *string                `json:"callback_url"`
+	ExpiredAt       *time.Time             `json:"expired_at"`
+	CreatedBy       *string                `json:"created_by"`
+	Watermark       *watermark.Spec        `json:"watermark"`  // PDF/HTML; kosong: default template
+	Encryption      *EncryptionRequest     `json:"encryption"` // PDF saja
+}
+
+// EncryptionRequest password user langsung (password) atau dari payload (password_path), plus
+// pembatasan izin. Password tidak pernah dikembalikan di response.
+type EncryptionRequest struct {
+	Password     string `json:"password"`
+	PasswordPath string `json:"password_path"`
+	NoPrint      bool   `json:"no_print"`
+	NoCopy       bool   `json:"no_copy"`
+	NoModify     bool   `json:"no_modify"`
 }
 
 type PatchDocumentRequest struct {
@@ -130,10 +155,11 @@ func DocumentFromEntity(d docEntity.Document) GeneratedDocumentResponse {
 		ID: d.ID, TenantID: d.TenantID, RequestID: d.RequestID,
 		TemplateID: d.TemplateID, TemplateVersionID: d.TemplateVersionID,
 		TemplateCode: d.TemplateCode, TemplateVersion: d.TemplateVersion,
-		Payload: d.Payload, Metadata: d.Metadata, Status: d.Status, ErrorMessage: d.ErrorMessage,
-		OutputFormat: d.OutputFormat, FileName: d.FileName, FilePath: d.FilePath,
+		Payload: d.Payload, Metadata: d.Metadata, Status: d.Status, Priority: d.Priority, ErrorMessage: d.ErrorMessage,
+		OutputFormat: d.OutputFormat, Locale: d.Locale, FileName: d.FileName, FilePath: d.FilePath,
 		StorageProvider: d.StorageProvider, FileSize: d.FileSize, Checksum: d.Checksum,
-		ContentType: d.ContentType, IsSigned: d.IsSigned, SignatureProvider: d.SignatureProvider,
+		ContentType: d.ContentType, Watermark: d.Watermark, Encryption: d.Encryption, IsEncrypted: d.IsEncrypted,
+		PDFAConformance: d.PDFAConformance, PDFAReport: d.PDFAReport, Attachments: d.Attachments, IsSigned: d.IsSigned, SignatureProvider: d.SignatureProvider,
 		SignedAt: d.SignedAt, StoreToDms: d.StoreToDms, DmsDocumentID: d.DmsDocumentID,
 		DmsStatus: d.DmsStatus, HasCallback: d.HasCallback, CallbackURL: d.CallbackURL,
 		CallbackStatus: d.CallbackStatus, CallbackLastAt: d.CallbackLastAt,
@@ -176,13 +202,21 @@ func ApplyPatchDocument(existing docEntity.Document, req PatchDocumentRequest) d
 
 func (r CreateDocumentRequest) ToInput(headerTenant *string) ucDoc.CreateInput {
 	tid := ResolveTenant(headerTenant, r.TenantID)
-	return ucDoc.CreateInput{
+	in := ucDoc.CreateInput{
 		TenantID: tid, RequestID: r.RequestID, TemplateCode: r.TemplateCode,
-		TemplateVersion: r.TemplateVersion, OutputFormat: r.OutputFormat,
+		TemplateVersion: r.TemplateVersion, OutputFormat: r.OutputFormat, Locale: r.Locale, Priority: r.Priority,
 		Payload: r.Payload, Metadata: r.Metadata, StoreToDms: r.StoreToDms,
 		HasCallback: r.HasCallback, CallbackURL: r.CallbackURL,
-		ExpiredAt: r.ExpiredAt, CreatedBy: r.CreatedBy,
+		ExpiredAt: r.ExpiredAt, CreatedBy: r.CreatedBy, Watermark: r.Watermark,
+	}
+	if r.Encryption != nil {
+		in.Encryption = &pdfsecurity.Spec{
+			PasswordPath: r.Encryption.PasswordPath,
+			NoPrint:      r.Encryption.NoPrint, NoCopy: r.Encryption.NoCopy, NoModify: r.Encryption.NoModify,
+		}
+		in.EncryptionPassword = r.Encryption.Password
 	}
+	return in
 }
 
 func RenderLogFromEntity(l logEntity.RenderLog) RenderLogResponse {
@@ -225,21 +259,35 @@ type BulkCreateDocumentResponse struct {
 // --- Preview ---
 
 type PreviewDocumentRequest struct {
-	Payload map[string]any `json:"payload"`
+	Payload   map[string]any  `json:"payload"`
+	Watermark *watermark.Spec `json:"watermark"` // kosong: DRAFT (HTML saja); {}: tanpa watermark
 }
 
-// --- Zip / Merge ---
-
-type ZipDocumentsRequest struct {
-	IDs   []int64 `json:"ids"`
-	Label string  `json:"label,omitempty"`
+// DocumentStatusEventResponse data event SSE status dokumen.
+type DocumentStatusEventResponse struct {
+	DocumentID     int64                `json:"document_id"`
+	RequestID      string               `json:"request_id"`
+	TenantID       *string              `json:"tenant_id,omitempty"`
+	Status         enums.DocumentStatus `json:"status"`
+	PreviousStatus enums.DocumentStatus `json:"previous_status,omitempty"`
+	ErrorMessage   *string              `json:"error_message,omitempty"`
+	At             time.Time            `json:"at"`
 }
 
-type MergeDocumentsRequest struct {
-	IDs   []int64 `json:"ids"`
-	Label string  `json:"label,omitempty"`
+func DocumentStatusEventFrom(e ucDoc.StatusEvent) DocumentStatusEventResponse {
+	return DocumentStatusEventResponse{
+		DocumentID:     e.DocumentID,
+		RequestID:      e.RequestID,
+		TenantID:       e.TenantID,
+		Status:         e.Status,
+		PreviousStatus: e.PreviousStatus,
+		ErrorMessage:   e.ErrorMessage,
+		At:             e.At,
+	}
 }
 
-type DocumentURLResponse struct {
-	URL string `json:"url"`
+// SyncDocumentResponse hasil POST /documents?mode=sync&response=url.
+type SyncDocumentResponse struct {
+	GeneratedDocumentResponse
+	DownloadURL string `json:"download_url"`
 }
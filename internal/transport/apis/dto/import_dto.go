@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"time"
+
+	importEntity "go-document-generator/internal/entity/documentimports"
+	"go-document-generator/internal/shared/mailmerge"
+	ucImport "go-document-generator/internal/usecase/documentimports"
+)
+
+// CreateImportMappingRequest mapping kolom file import; fields kosong memetakan setiap kolom ke path bernama sama.
+type CreateImportMappingRequest struct {
+	Name            string            `json:"name"`
+	Description     *string           `json:"description"`
+	TemplateCode    *string           `json:"template_code"`
+	Fields          []mailmerge.Field `json:"fields"`
+	GroupBy         *string           `json:"group_by"`
+	RequestIDColumn *string           `json:"request_id_column"`
+	RequestIDPrefix *string           `json:"request_id_prefix"`
+	CreatedBy       *string           `json:"created_by"`
+}
+
+type PatchImportMappingRequest struct {
+	Name            *string           `json:"name"`
+	Description     *string           `json:"description"`
+	TemplateCode    *string           `json:"template_code"`
+	Fields          []mailmerge.Field `json:"fields"`
+	GroupBy         *string           `json:"group_by"`
+	RequestIDColumn *string           `json:"request_id_column"`
+	RequestIDPrefix *string           `json:"request_id_prefix"`
+}
+
+type ImportMappingResponse struct {
+	ID              int64             `json:"id"`
+	TenantID        *string           `json:"tenant_id"`
+	Name            string            `json:"name"`
+	Description     *string           `json:"description"`
+	TemplateCode    *string           `json:"template_code"`
+	Fields          []mailmerge.Field `json:"fields"`
+	GroupBy         *string           `json:"group_by"`
+	RequestIDColumn *string           `json:"request_id_column"`
+	RequestIDPrefix *string           `json:"request_id_prefix"`
+	CreatedBy       *string           `json:"created_by"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+type ImportMappingListResponse struct {
+	Data []ImportMappingResponse `json:"data"`
+	Meta PaginationMeta          `json:"meta"`
+}
+
+// ImportResponse hasil import; batch null untuk dry run atau bila tidak ada baris valid.
+type ImportResponse struct {
+	Format          string               `json:"format"`
+	TemplateCode    string               `json:"template_code"`
+	TemplateVersion int                  `json:"template_version"`
+	Rows            int                  `json:"rows"`
+	Documents       int                  `json:"documents"`
+	InvalidRows     int                  `json:"invalid_rows"`
+	Errors          []mailmerge.RowError `json:"errors"`
+	ErrorsTruncated bool                 `json:"errors_truncated"`
+	Batch           *BatchResponse       `json:"batch"`
+}
+
+func (r CreateImportMappingRequest) ToEntity(tenantID *string) importEntity.Mapping {
+	return importEntity.Mapping{
+		TenantID: tenantID, Name: r.Name, Description: r.Description, TemplateCode: r.TemplateCode,
+		Fields: r.Fields, GroupBy: r.GroupBy, RequestIDColumn: r.RequestIDColumn,
+		RequestIDPrefix: r.RequestIDPrefix, CreatedBy: r.CreatedBy,
+	}
+}
+
+func (r PatchImportMappingRequest) ToPatch() ucImport.Patch {
+	return ucImport.Patch{
+		Name: r.Name, Description: r.Description, TemplateCode: r.TemplateCode, Fields: r.Fields,
+		GroupBy: r.GroupBy, RequestIDColumn: r.RequestIDColumn, RequestIDPrefix: r.RequestIDPrefix,
+	}
+}
+
+func ImportMappingFromEntity(m importEntity.Mapping) ImportMappingResponse {
+	return ImportMappingResponse{
+		ID: m.ID, TenantID: m.TenantID, Name: m.Name, Description: m.Description,
+		TemplateCode: m.TemplateCode, Fields: m.Fields, GroupBy: m.GroupBy,
+		RequestIDColumn: m.RequestIDColumn, RequestIDPrefix: m.RequestIDPrefix,
+		CreatedBy: m.CreatedBy, CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ImportFromResult(r ucImport.ImportResult) ImportResponse {
+	out := ImportResponse{
+		Format: r.Format, TemplateCode: r.TemplateCode, TemplateVersion: r.TemplateVersion,
+		Rows: r.Rows, Documents: r.Documents, InvalidRows: r.InvalidRows,
+		Errors: r.Errors, ErrorsTruncated: r.ErrorsTruncated,
+	}
+	if out.Errors == nil {
+		out.Errors = []mailmerge.RowError{}
+	}
+	if r.Batch != nil {
+		b := BatchFromEntity(*r.Batch)
+		out.Batch = &b
+	}
+	return out
+}
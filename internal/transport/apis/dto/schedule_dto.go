@@ -0,0 +1,143 @@
+package dto
+
+import (
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+	ucSched "go-document-generator/internal/usecase/documentschedules"
+)
+
+// CreateScheduleRequest jadwal generation: isi cron_expression (berulang) atau run_at (sekali).
+type CreateScheduleRequest struct {
+	Name            string                      `json:"name"`
+	Description     *string                     `json:"description"`
+	TemplateCode    string                      `json:"template_code"`
+	TemplateVersion *int                        `json:"template_version"`
+	OutputFormat    enums.OutputFormat          `json:"output_format"`
+	Locale          *string                     `json:"locale"`
+	Priority        enums.DocumentPriority      `json:"priority"`
+	CronExpression  *string                     `json:"cron_expression"` // mis. "0 6 * * *", "@monthly"
+	RunAt           *time.Time                  `json:"run_at"`
+	Timezone        string                      `json:"timezone"` // IANA; default UTC
+	Payload         map[string]any              `json:"payload"`
+	PayloadSource   *schedEntity.PayloadSource  `json:"payload_source"`
+	Metadata        map[string]any              `json:"metadata"`
+	StoreToDms      bool                        `json:"store_to_dms"`
+	HasCallback     bool                        `json:"has_callback"`
+	CallbackURL     *string                     `json:"callback_url"`
+	MisfirePolicy   enums.ScheduleMisfirePolicy `json:"misfire_policy"` // FIRE_ONCE | FIRE_ALL | SKIP
+	CreatedBy       *string                     `json:"created_by"`
+}
+
+type PatchScheduleRequest struct {
+	Name            *string                      `json:"name"`
+	Description     *string                      `json:"description"`
+	TemplateCode    *string                      `json:"template_code"`
+	TemplateVersion *int                         `json:"template_version"`
+	OutputFormat    *enums.OutputFormat          `json:"output_format"`
+	Locale          *string                      `json:"locale"`
+	Priority        *enums.DocumentPriority      `json:"priority"`
+	CronExpression  *string                      `json:"cron_expression"`
+	RunAt           *time.Time                   `json:"run_at"`
+	Timezone        *string                      `json:"timezone"`
+	Payload         map[string]any               `json:"payload"`
+	PayloadSource   *schedEntity.PayloadSource   `json:"payload_source"`
+	Metadata        map[string]any               `json:"metadata"`
+	StoreToDms      *bool                        `json:"store_to_dms"`
+	HasCallback     *bool                        `json:"has_callback"`
+	CallbackURL     *string                      `json:"callback_url"`
+	MisfirePolicy   *enums.ScheduleMisfirePolicy `json:"misfire_policy"`
+}
+
+type ScheduleResponse struct {
+	ID              int64                       `json:"id"`
+	TenantID        *string                     `json:"tenant_id"`
+	Name            string                      `json:"name"`
+	Description     *string                     `json:"description"`
+	TemplateCode    string                      `json:"template_code"`
+	TemplateVersion *int                        `json:"template_version"`
+	OutputFormat    enums.OutputFormat          `json:"output_format,omitempty"`
+	Locale          *string                     `json:"locale"`
+	Priority        enums.DocumentPriority      `json:"priority,omitempty"`
+	CronExpression  *string                     `json:"cron_expression"`
+	RunAt           *time.Time                  `json:"run_at"`
+	Timezone        string                      `json:"timezone"`
+	Payload         map[string]any              `json:"payload"`
+	PayloadSource   *schedEntity.PayloadSource  `json:"payload_source"`
+	Metadata        map[string]any              `json:"metadata"`
+	StoreToDms      bool                        `json:"store_to_dms"`
+	HasCallback     bool                        `json:"has_callback"`
+	CallbackURL     *string                     `json:"callback_url"`
+	MisfirePolicy   enums.ScheduleMisfirePolicy `json:"misfire_policy"`
+	Status          enums.ScheduleStatus        `json:"status"`
+	NextRunAt       *time.Time                  `json:"next_run_at"`
+	LastRunAt       *time.Time                  `json:"last_run_at"`
+	CreatedBy       *string                     `json:"created_by"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+type ScheduleListResponse struct {
+	Data []ScheduleResponse `json:"data"`
+	Meta PaginationMeta     `json:"meta"`
+}
+
+type ScheduleRunResponse struct {
+	ID           int64                   `json:"id"`
+	ScheduleID   int64                   `json:"schedule_id"`
+	ScheduledFor time.Time               `json:"scheduled_for"`
+	Status       enums.ScheduleRunStatus `json:"status"`
+	RequestID    *string                 `json:"request_id"`
+	DocumentID   *int64                  `json:"document_id"`
+	ErrorMessage *string                 `json:"error_message"`
+	CreatedAt    time.Time               `json:"created_at"`
+}
+
+type ScheduleRunListResponse struct {
+	Data []ScheduleRunResponse `json:"data"`
+	Meta PaginationMeta        `json:"meta"`
+}
+
+func (r CreateScheduleRequest) ToEntity(tenantID *string) schedEntity.Schedule {
+	return schedEntity.Schedule{
+		TenantID: tenantID, Name: r.Name, Description: r.Description,
+		TemplateCode: r.TemplateCode, TemplateVersion: r.TemplateVersion,
+		OutputFormat: r.OutputFormat, Locale: r.Locale, Priority: r.Priority,
+		CronExpression: r.CronExpression, RunAt: r.RunAt, Timezone: r.Timezone,
+		Payload: r.Payload, PayloadSource: r.PayloadSource, Metadata: r.Metadata,
+		StoreToDms: r.StoreToDms, HasCallback: r.HasCallback, CallbackURL: r.CallbackURL,
+		MisfirePolicy: r.MisfirePolicy, CreatedBy: r.CreatedBy,
+	}
+}
+
+func (r PatchScheduleRequest) ToPatch() ucSched.Patch {
+	return ucSched.Patch{
+		Name: r.Name, Description: r.Description, TemplateCode: r.TemplateCode, TemplateVersion: r.TemplateVersion,
+		OutputFormat: r.OutputFormat, Locale: r.Locale, Priority: r.Priority,
+		CronExpression: r.CronExpression, RunAt: r.RunAt, Timezone: r.Timezone,
+		Payload: r.Payload, PayloadSource: r.PayloadSource, Metadata: r.Metadata,
+		StoreToDms: r.StoreToDms, HasCallback: r.HasCallback, CallbackURL: r.CallbackURL,
+		MisfirePolicy: r.MisfirePolicy,
+	}
+}
+
+func ScheduleFromEntity(s schedEntity.Schedule) ScheduleResponse {
+	return ScheduleResponse{
+		ID: s.ID, TenantID: s.TenantID, Name: s.Name, Description: s.Description,
+		TemplateCode: s.TemplateCode, TemplateVersion: s.TemplateVersion,
+		OutputFormat: s.OutputFormat, Locale: s.Locale, Priority: s.Priority,
+		CronExpression: s.CronExpression, RunAt: s.RunAt, Timezone: s.Timezone,
+		Payload: s.Payload, PayloadSource: s.PayloadSource, Metadata: s.Metadata,
+		StoreToDms: s.StoreToDms, HasCallback: s.HasCallback, CallbackURL: s.CallbackURL,
+		MisfirePolicy: s.MisfirePolicy, Status: s.Status, NextRunAt: s.NextRunAt, LastRunAt: s.LastRunAt,
+		CreatedBy: s.CreatedBy, CreatedAt: s.CreatedAt, UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func ScheduleRunFromEntity(r schedEntity.Run) ScheduleRunResponse {
+	return ScheduleRunResponse{
+		ID: r.ID, ScheduleID: r.ScheduleID, ScheduledFor: r.ScheduledFor, Status: r.Status,
+		RequestID: r.RequestID, DocumentID: r.DocumentID, ErrorMessage: r.ErrorMessage, CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	assetEntity "go-document-generator/internal/entity/documenttemplateassets"
+)
+
+type TemplateAssetResponse struct {
+	ID          int64     `json:"id"`
+	TenantID    *string   `json:"tenant_id"`
+	TemplateID  *int64    `json:"template_id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	CreatedBy   *string   `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TemplateAssetListResponse struct {
+	Data []TemplateAssetResponse `json:"data"`
+}
+
+func AssetFromEntity(a assetEntity.Asset) TemplateAssetResponse {
+	return TemplateAssetResponse{
+		ID: a.ID, TenantID: a.TenantID, TemplateID: a.TemplateID, Name: a.Name,
+		ContentType: a.ContentType, Size: a.Size, Checksum: a.Checksum,
+		CreatedBy: a.CreatedBy, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func AssetListFromEntities(items []assetEntity.Asset) TemplateAssetListResponse {
+	data := make([]TemplateAssetResponse, len(items))
+	for i := range items {
+		data[i] = AssetFromEntity(items[i])
+	}
+	return TemplateAssetListResponse{Data: data}
+}
@@ -6,44 +6,56 @@ import (
 	tplEntity "go-document-generator/internal/entity/documenttemplates"
 	"go-document-generator/internal/entity/enums"
 	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type DocumentTemplateResponse struct {
-	ID            int64              `json:"id"`
-	TenantID      *string            `json:"tenant_id"`
-	Code          string             `json:"code"`
-	Name          string             `json:"name"`
-	Description   *string            `json:"description"`
-	Engine        enums.TemplateEngine `json:"engine"`
-	DefaultFormat enums.OutputFormat `json:"default_format"`
-	Category      *string            `json:"category"`
-	IsActive      bool               `json:"is_active"`
-	CreatedBy     *string            `json:"created_by"`
-	UpdatedBy     *string            `json:"updated_by"`
-	CreatedAt     time.Time          `json:"created_at"`
-	UpdatedAt     time.Time          `json:"updated_at"`
+	ID                 int64                    `json:"id"`
+	TenantID           *string                  `json:"tenant_id"`
+	Code               string                   `json:"code"`
+	Name               string                   `json:"name"`
+	Description        *string                  `json:"description"`
+	Engine             enums.TemplateEngine     `json:"engine"`
+	DefaultFormat      enums.OutputFormat       `json:"default_format"`
+	Category           *string                  `json:"category"`
+	IsActive           bool                     `json:"is_active"`
+	RequiredApprovals  int                      `json:"required_approvals"`
+	PendingApprovals   *int                     `json:"pending_approvals"`
+	PendingApprovalsBy *string                  `json:"pending_approvals_by"`
+	SchemaCompatPolicy enums.SchemaCompatPolicy `json:"schema_compat_policy"`
+	Watermark          *watermark.Spec          `json:"watermark"`
+	CreatedBy          *string                  `json:"created_by"`
+	UpdatedBy          *string                  `json:"updated_by"`
+	CreatedAt          time.Time                `json:"created_at"`
+	UpdatedAt          time.Time                `json:"updated_at"`
 }
 
 type CreateTemplateRequest struct {
-	TenantID      *string            `json:"tenant_id"`
-	Code          string             `json:"code"`
-	Name          string             `json:"name"`
-	Description   *string            `json:"description"`
-	Engine        enums.TemplateEngine `json:"engine"`
-	DefaultFormat enums.OutputFormat `json:"default_format"`
-	Category      *string            `json:"category"`
-	IsActive      *bool              `json:"is_active"`
-	CreatedBy     *string            `json:"created_by"`
+	TenantID           *string                  `json:"tenant_id"`
+	Code               string                   `json:"code"`
+	Name               string                   `json:"name"`
+	Description        *string                  `json:"description"`
+	Engine             enums.TemplateEngine     `json:"engine"`
+	DefaultFormat      enums.OutputFormat       `json:"default_format"`
+	Category           *string                  `json:"category"`
+	IsActive           *bool                    `json:"is_active"`
+	RequiredApprovals  int                      `json:"required_approvals"`
+	SchemaCompatPolicy enums.SchemaCompatPolicy `json:"schema_compat_policy"`
+	Watermark          *watermark.Spec          `json:"watermark"`
+	CreatedBy          *string                  `json:"created_by"`
 }
 
 type PatchTemplateRequest struct {
-	Name          *string             `json:"name"`
-	Description   *string             `json:"description"`
-	Engine        *enums.TemplateEngine `json:"engine"`
-	DefaultFormat *enums.OutputFormat `json:"default_format"`
-	Category      *string             `json:"category"`
-	IsActive      *bool               `json:"is_active"`
-	UpdatedBy     *string             `json:"updated_by"`
+	Name               *string                   `json:"name"`
+	Description        *string                   `json:"description"`
+	Engine             *enums.TemplateEngine     `json:"engine"`
+	DefaultFormat      *enums.OutputFormat       `json:"default_format"`
+	Category           *string                   `json:"category"`
+	IsActive           *bool                     `json:"is_active"`
+	RequiredApprovals  *int                      `json:"required_approvals"` // penurunan menunggu persetujuan actor lain
+	SchemaCompatPolicy *enums.SchemaCompatPolicy `json:"schema_compat_policy"`
+	Watermark          *watermark.Spec           `json:"watermark"` // objek kosong {} menghapus watermark
+	UpdatedBy          *string                   `json:"updated_by"`
 }
 
 type TemplateListResponse struct {
@@ -55,6 +67,8 @@ func TemplateFromEntity(t tplEntity.Template) DocumentTemplateResponse {
 	return DocumentTemplateResponse{
 		ID: t.ID, TenantID: t.TenantID, Code: t.Code, Name: t.Name, Description: t.Description,
 		Engine: t.Engine, DefaultFormat: t.DefaultFormat, Category: t.Category, IsActive: t.IsActive,
+		RequiredApprovals: t.RequiredApprovals, PendingApprovals: t.PendingApprovals, PendingApprovalsBy: t.PendingApprovalsBy,
+		SchemaCompatPolicy: t.SchemaCompatPolicy, Watermark: t.Watermark,
 		CreatedBy: t.CreatedBy, UpdatedBy: t.UpdatedBy, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt,
 	}
 }
@@ -71,7 +85,8 @@ func (r CreateTemplateRequest) ToEntity(headerTenant *string) tplEntity.Template
 	return tplEntity.Template{
 		TenantID: tid, Code: r.Code, Name: r.Name, Description: r.Description,
 		Engine: r.Engine, DefaultFormat: r.DefaultFormat, Category: r.Category,
-		IsActive: active, CreatedBy: r.CreatedBy,
+		IsActive: active, RequiredApprovals: r.RequiredApprovals,
+		SchemaCompatPolicy: r.SchemaCompatPolicy, Watermark: r.Watermark, CreatedBy: r.CreatedBy,
 	}
 }
 
@@ -94,6 +109,15 @@ func ApplyPatchTemplate(existing tplEntity.Template, req PatchTemplateRequest) t
 	if req.IsActive != nil {
 		existing.IsActive = *req.IsActive
 	}
+	if req.RequiredApprovals != nil {
+		existing.RequiredApprovals = *req.RequiredApprovals
+	}
+	if req.SchemaCompatPolicy != nil {
+		existing.SchemaCompatPolicy = *req.SchemaCompatPolicy
+	}
+	if req.Watermark != nil {
+		existing.Watermark = req.Watermark
+	}
 	if req.UpdatedBy != nil {
 		existing.UpdatedBy = req.UpdatedBy
 	}
@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"time"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	"go-document-generator/internal/entity/enums"
+	ucPartial "go-document-generator/internal/usecase/documenttemplatepartials"
+)
+
+type CreateTemplatePartialRequest struct {
+	Name        string                    `json:"name"`
+	Kind        enums.TemplatePartialKind `json:"kind"`
+	Description *string                   `json:"description"`
+	CreatedBy   *string                   `json:"created_by"`
+}
+
+type PatchTemplatePartialRequest struct {
+	Description *string `json:"description"`
+}
+
+type CreateTemplatePartialVersionRequest struct {
+	Content   string  `json:"content"`
+	CreatedBy *string `json:"created_by"`
+}
+
+type TemplatePartialResponse struct {
+	ID             int64                     `json:"id"`
+	TenantID       *string                   `json:"tenant_id"`
+	Name           string                    `json:"name"`
+	Kind           enums.TemplatePartialKind `json:"kind"`
+	Description    *string                   `json:"description"`
+	CurrentVersion *int                      `json:"current_version"`
+	CreatedBy      *string                   `json:"created_by"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+type TemplatePartialListResponse struct {
+	Data []TemplatePartialResponse `json:"data"`
+}
+
+type TemplatePartialVersionResponse struct {
+	ID          int64      `json:"id"`
+	PartialID   int64      `json:"partial_id"`
+	Version     int        `json:"version"`
+	Content     string     `json:"content"`
+	Checksum    *string    `json:"checksum"`
+	PublishedAt *time.Time `json:"published_at"`
+	CreatedBy   *string    `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type TemplatePartialVersionListResponse struct {
+	Data []TemplatePartialVersionResponse `json:"data"`
+}
+
+type TemplatePartialDependencyResponse struct {
+	TemplateID        int64     `json:"template_id"`
+	TemplateVersionID int64     `json:"template_version_id"`
+	PartialVersion    int       `json:"partial_version"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type TemplatePartialDependencyListResponse struct {
+	Data []TemplatePartialDependencyResponse `json:"data"`
+}
+
+type TemplatePartialImpactResponse struct {
+	TemplateID            int64  `json:"template_id"`
+	TemplateCode          string `json:"template_code"`
+	TemplateVersionID     int64  `json:"template_version_id"`
+	TemplateVersion       int    `json:"template_version"`
+	CurrentPartialVersion int    `json:"current_partial_version"`
+	Changed               bool   `json:"changed"`
+	Diff                  string `json:"diff,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// TemplatePartialImpactListResponse dampak versi kandidat terhadap versi template published.
+type TemplatePartialImpactListResponse struct {
+	Total   int                             `json:"total"`
+	Changed int                             `json:"changed"`
+	Data    []TemplatePartialImpactResponse `json:"data"`
+}
+
+func (r CreateTemplatePartialRequest) ToEntity() partialEntity.Partial {
+	return partialEntity.Partial{Name: r.Name, Kind: r.Kind, Description: r.Description, CreatedBy: r.CreatedBy}
+}
+
+func (r CreateTemplatePartialVersionRequest) ToEntity() partialEntity.PartialVersion {
+	return partialEntity.PartialVersion{Content: r.Content, CreatedBy: r.CreatedBy}
+}
+
+func PartialFromEntity(p partialEntity.Partial) TemplatePartialResponse {
+	return TemplatePartialResponse{
+		ID: p.ID, TenantID: p.TenantID, Name: p.Name, Kind: p.Kind, Description: p.Description,
+		CurrentVersion: p.CurrentVersion, CreatedBy: p.CreatedBy, CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func PartialVersionFromEntity(v partialEntity.PartialVersion) TemplatePartialVersionResponse {
+	return TemplatePartialVersionResponse{
+		ID: v.ID, PartialID: v.PartialID, Version: v.Version, Content: v.Content, Checksum: v.Checksum,
+		PublishedAt: v.PublishedAt, CreatedBy: v.CreatedBy, CreatedAt: v.CreatedAt,
+	}
+}
+
+func PartialDependenciesFromEntities(deps []partialEntity.Dependency) TemplatePartialDependencyListResponse {
+	data := make([]TemplatePartialDependencyResponse, len(deps))
+	for i, d := range deps {
+		data[i] = TemplatePartialDependencyResponse{
+			TemplateID: d.TemplateID, TemplateVersionID: d.TemplateVersionID,
+			PartialVersion: d.PartialVersion, CreatedAt: d.CreatedAt,
+		}
+	}
+	return TemplatePartialDependencyListResponse{Data: data}
+}
+
+func PartialImpactFromEntries(entries []ucPartial.ImpactEntry) TemplatePartialImpactListResponse {
+	resp := TemplatePartialImpactListResponse{Total: len(entries), Data: make([]TemplatePartialImpactResponse, len(entries))}
+	for i, e := range entries {
+		resp.Data[i] = TemplatePartialImpactResponse{
+			TemplateID: e.TemplateID, TemplateCode: e.TemplateCode, TemplateVersionID: e.TemplateVersionID,
+			TemplateVersion: e.TemplateVersion, CurrentPartialVersion: e.CurrentPartialVersion,
+			Changed: e.Changed, Diff: e.Diff, Error: e.Error,
+		}
+		if e.Changed {
+			resp.Changed++
+		}
+	}
+	return resp
+}
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+	"go-document-generator/internal/entity/enums"
+)
+
+// CreateTemplateTestCaseRequest golden test case; isi expected_output (+ output_format) atau
+// snapshot_version_id untuk merekam output versi tersebut sebagai snapshot.
+type CreateTemplateTestCaseRequest struct {
+	Name              string             `json:"name"`
+	Description       *string            `json:"description"`
+	Payload           map[string]any     `json:"payload"`
+	OutputFormat      enums.OutputFormat `json:"output_format"`
+	ExpectedOutput    string             `json:"expected_output"`
+	ExpectedPages     *int               `json:"expected_pages"`
+	SnapshotVersionID *int64             `json:"snapshot_version_id"`
+	CreatedBy         *string            `json:"created_by"`
+}
+
+type PatchTemplateTestCaseRequest struct {
+	Name              *string             `json:"name"`
+	Description       *string             `json:"description"`
+	Payload           map[string]any      `json:"payload"`
+	OutputFormat      *enums.OutputFormat `json:"output_format"`
+	ExpectedOutput    *string             `json:"expected_output"`
+	ExpectedPages     *int                `json:"expected_pages"`
+	SnapshotVersionID *int64              `json:"snapshot_version_id"`
+}
+
+type TemplateTestCaseResponse struct {
+	ID             int64              `json:"id"`
+	TenantID       *string            `json:"tenant_id"`
+	TemplateID     int64              `json:"template_id"`
+	Name           string             `json:"name"`
+	Description    *string            `json:"description"`
+	Payload        map[string]any     `json:"payload"`
+	OutputFormat   enums.OutputFormat `json:"output_format"`
+	ExpectedOutput string             `json:"expected_output"`
+	ExpectedPages  *int               `json:"expected_pages"`
+	CreatedBy      *string            `json:"created_by"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+type TemplateTestCaseListResponse struct {
+	Data []TemplateTestCaseResponse `json:"data"`
+}
+
+type TemplateTestResultResponse struct {
+	ID           int64     `json:"id"`
+	VersionID    int64     `json:"version_id"`
+	TestCaseID   int64     `json:"test_case_id"`
+	CaseName     string    `json:"case_name"`
+	Passed       bool      `json:"passed"`
+	Checksum     *string   `json:"checksum"`
+	ActualOutput string    `json:"actual_output,omitempty"`
+	ActualPages  *int      `json:"actual_pages"`
+	Diff         *string   `json:"diff"`
+	Error        *string   `json:"error"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TemplateTestRunResponse ringkasan hasil golden test sebuah versi.
+type TemplateTestRunResponse struct {
+	Total  int                          `json:"total"`
+	Failed int                          `json:"failed"`
+	Data   []TemplateTestResultResponse `json:"data"`
+}
+
+func (r CreateTemplateTestCaseRequest) ToEntity() testEntity.TestCase {
+	return testEntity.TestCase{
+		Name: r.Name, Description: r.Description, Payload: r.Payload,
+		OutputFormat: r.OutputFormat, ExpectedOutput: r.ExpectedOutput, ExpectedPages: r.ExpectedPages,
+		CreatedBy: r.CreatedBy,
+	}
+}
+
+func (r PatchTemplateTestCaseRequest) ToEntity() testEntity.TestCase {
+	c := testEntity.TestCase{Description: r.Description, Payload: r.Payload, ExpectedPages: r.ExpectedPages}
+	if r.Name != nil {
+		c.Name = *r.Name
+	}
+	if r.OutputFormat != nil {
+		c.OutputFormat = *r.OutputFormat
+	}
+	if r.ExpectedOutput != nil {
+		c.ExpectedOutput = *r.ExpectedOutput
+	}
+	return c
+}
+
+func TestCaseFromEntity(c testEntity.TestCase) TemplateTestCaseResponse {
+	return TemplateTestCaseResponse{
+		ID: c.ID, TenantID: c.TenantID, TemplateID: c.TemplateID, Name: c.Name, Description: c.Description,
+		Payload: c.Payload, OutputFormat: c.OutputFormat, ExpectedOutput: c.ExpectedOutput,
+		ExpectedPages: c.ExpectedPages, CreatedBy: c.CreatedBy, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// TestRunFromResults: actual_output hanya disertakan untuk test yang gagal agar response tetap kecil.
+func TestRunFromResults(results []testEntity.TestResult) TemplateTestRunResponse {
+	resp := TemplateTestRunResponse{Total: len(results), Data: make([]TemplateTestResultResponse, len(results))}
+	for i, r := range results {
+		item := TemplateTestResultResponse{
+			ID: r.ID, VersionID: r.VersionID, TestCaseID: r.TestCaseID, CaseName: r.CaseName,
+			Passed: r.Passed, Checksum: r.Checksum, ActualPages: r.ActualPages,
+			Diff: r.Diff, Error: r.Error, CreatedAt: r.CreatedAt,
+		}
+		if !r.Passed {
+			item.ActualOutput = r.ActualOutput
+			resp.Failed++
+		}
+		resp.Data[i] = item
+	}
+	return resp
+}
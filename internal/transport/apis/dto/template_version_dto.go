@@ -3,34 +3,104 @@ package dto
 import (
 	"time"
 
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/diff"
+	"go-document-generator/internal/shared/einvoice"
+	ucVer "go-document-generator/internal/usecase/documenttemplateversions"
 )
 
 type TemplateVersionResponse struct {
-	ID            int64              `json:"id"`
-	TenantID      *string            `json:"tenant_id"`
-	TemplateID    int64              `json:"template_id"`
-	Version       int                `json:"version"`
-	Content       string             `json:"content,omitempty"`
-	Schema        map[string]any     `json:"schema"`
-	Variables     []any              `json:"variables"`
-	SamplePayload map[string]any     `json:"sample_payload"`
-	OutputFormat  enums.OutputFormat `json:"output_format"`
-	Checksum      *string            `json:"checksum"`
-	IsPublished   bool               `json:"is_published"`
-	PublishedAt   *time.Time         `json:"published_at"`
-	CreatedBy     *string            `json:"created_by"`
-	CreatedAt     time.Time          `json:"created_at"`
+	ID                 int64                             `json:"id"`
+	TenantID           *string                           `json:"tenant_id"`
+	TemplateID         int64                             `json:"template_id"`
+	Version            int                               `json:"version"`
+	Content            string                            `json:"content,omitempty"`
+	Schema             map[string]any                    `json:"schema"`
+	Variables          []any                             `json:"variables"`
+	SamplePayload      map[string]any                    `json:"sample_payload"`
+	OutputFormat       enums.OutputFormat                `json:"output_format"`
+	Layout             *string                           `json:"layout"`
+	Translations       map[string]map[string]string      `json:"translations"`
+	DefaultLocale      *string                           `json:"default_locale"`
+	PDFAConformance    *string                           `json:"pdfa_conformance"`
+	EInvoice           *einvoice.Spec                    `json:"e_invoice"`
+	Checksum           *string                           `json:"checksum"`
+	Status             enums.TemplateVersionStatus       `json:"status"`
+	IsPublished        bool                              `json:"is_published"`
+	PublishedAt        *time.Time                        `json:"published_at"`
+	ScheduledPublishAt *time.Time                        `json:"scheduled_publish_at"`
+	DeprecatedAt       *time.Time                        `json:"deprecated_at"`
+	ArchivedAt         *time.Time                        `json:"archived_at"`
+	ReviewStatus       enums.TemplateVersionReviewStatus `json:"review_status"`
+	SubmittedBy        *string                           `json:"submitted_by"`
+	SubmittedAt        *time.Time                        `json:"submitted_at"`
+	CreatedBy          *string                           `json:"created_by"`
+	CreatedAt          time.Time                         `json:"created_at"`
+	UpdatedAt          time.Time                         `json:"updated_at"`
 }
 
 type CreateTemplateVersionRequest struct {
-	Content       string             `json:"content"`
-	Schema        map[string]any     `json:"schema"`
-	Variables     []any              `json:"variables"`
-	SamplePayload map[string]any     `json:"sample_payload"`
-	OutputFormat  enums.OutputFormat `json:"output_format"`
-	CreatedBy     *string            `json:"created_by"`
+	Content         string                       `json:"content"`
+	Schema          map[string]any               `json:"schema"`
+	Variables       []any                        `json:"variables"`
+	SamplePayload   map[string]any               `json:"sample_payload"`
+	OutputFormat    enums.OutputFormat           `json:"output_format"`
+	Layout          *string                      `json:"layout"`
+	Translations    map[string]map[string]string `json:"translations"` // locale -> key -> pesan
+	DefaultLocale   *string                      `json:"default_locale"`
+	PDFAConformance *string                      `json:"pdfa_conformance"` // mis. "PDF/A-2b"; hanya output PDF
+	EInvoice        *einvoice.Spec               `json:"e_invoice"`        // output XML, atau PDF/A-3b hybrid Factur-X
+	CreatedBy       *string                      `json:"created_by"`       // diganti actor API key bila ada
+}
+
+// PatchTemplateVersionRequest hanya berlaku untuk versi DRAFT.
+type PatchTemplateVersionRequest struct {
+	Content         *string                      `json:"content"`
+	Schema          map[string]any               `json:"schema"`
+	Variables       []any                        `json:"variables"`
+	SamplePayload   map[string]any               `json:"sample_payload"`
+	OutputFormat    *enums.OutputFormat          `json:"output_format"`
+	Layout          *string                      `json:"layout"`           // "" melepas layout
+	Translations    map[string]map[string]string `json:"translations"`     // mengganti seluruh bundle
+	DefaultLocale   *string                      `json:"default_locale"`   // "" melepas default
+	PDFAConformance *string                      `json:"pdfa_conformance"` // "" kembali ke PDF biasa
+	EInvoice        *einvoice.Spec               `json:"e_invoice"`        // {} melepas e-invoice
+}
+
+// PublishTemplateVersionRequest body opsional; override_failing_tests mempublish walau golden test gagal.
+type PublishTemplateVersionRequest struct {
+	OverrideFailingTests bool `json:"override_failing_tests"`
+}
+
+type ScheduleTemplateVersionRequest struct {
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+// SubmitTemplateVersionRequest mengajukan versi DRAFT untuk direview; submitter = actor API key.
+type SubmitTemplateVersionRequest struct {
+	Comment *string `json:"comment"`
+}
+
+// ReviewTemplateVersionRequest dipakai untuk approve maupun reject; reviewer = actor API key,
+// comment wajib saat reject.
+type ReviewTemplateVersionRequest struct {
+	Comment *string `json:"comment"`
+}
+
+type TemplateVersionReviewResponse struct {
+	ID         int64                             `json:"id"`
+	TemplateID int64                             `json:"template_id"`
+	VersionID  int64                             `json:"version_id"`
+	Action     enums.TemplateVersionReviewAction `json:"action"`
+	Actor      string                            `json:"actor"`
+	Comment    *string                           `json:"comment"`
+	CreatedAt  time.Time                         `json:"created_at"`
+}
+
+type TemplateVersionReviewListResponse struct {
+	Data []TemplateVersionReviewResponse `json:"data"`
 }
 
 type TemplateVersionListResponse struct {
@@ -41,11 +111,21 @@ func VersionFromEntity(v verEntity.TemplateVersion, includeContent bool) Templat
 	resp := TemplateVersionResponse{
 		ID: v.ID, TenantID: v.TenantID, TemplateID: v.TemplateID, Version: v.Version,
 		Schema: v.Schema, Variables: v.Variables, SamplePayload: v.SamplePayload,
-		OutputFormat: v.OutputFormat, Checksum: v.Checksum, IsPublished: v.IsPublished,
-		PublishedAt: v.PublishedAt, CreatedBy: v.CreatedBy, CreatedAt: v.CreatedAt,
+		OutputFormat: v.OutputFormat, Layout: v.Layout, Translations: v.Translations, DefaultLocale: v.DefaultLocale,
+		PDFAConformance: v.PDFAConformance, Checksum: v.Checksum, Status: v.Status, IsPublished: v.IsPublished,
+		PublishedAt: v.PublishedAt, ScheduledPublishAt: v.ScheduledPublishAt,
+		DeprecatedAt: v.DeprecatedAt, ArchivedAt: v.ArchivedAt,
+		ReviewStatus: v.ReviewStatus, SubmittedBy: v.SubmittedBy, SubmittedAt: v.SubmittedAt,
+		CreatedBy: v.CreatedBy, CreatedAt: v.CreatedAt, UpdatedAt: v.UpdatedAt,
+	}
+	if v.EInvoice != nil {
+		spec := *v.EInvoice
+		resp.EInvoice = &spec
 	}
 	if includeContent {
 		resp.Content = v.Content
+	} else if resp.EInvoice != nil {
+		resp.EInvoice.Content = ""
 	}
 	return resp
 }
@@ -54,6 +134,145 @@ func (r CreateTemplateVersionRequest) ToEntity(tenantID *string, templateID int6
 	return verEntity.TemplateVersion{
 		TenantID: tenantID, TemplateID: templateID, Content: r.Content,
 		Schema: r.Schema, Variables: r.Variables, SamplePayload: r.SamplePayload,
-		OutputFormat: r.OutputFormat, CreatedBy: r.CreatedBy,
+		OutputFormat: r.OutputFormat, Layout: r.Layout, Translations: r.Translations, DefaultLocale: r.DefaultLocale,
+		PDFAConformance: r.PDFAConformance, EInvoice: r.EInvoice, CreatedBy: r.CreatedBy,
 	}
 }
+
+func (r PatchTemplateVersionRequest) ToEntity() verEntity.TemplateVersion {
+	v := verEntity.TemplateVersion{
+		Schema: r.Schema, Variables: r.Variables, SamplePayload: r.SamplePayload, Layout: r.Layout,
+		Translations: r.Translations, DefaultLocale: r.DefaultLocale, PDFAConformance: r.PDFAConformance,
+		EInvoice: r.EInvoice,
+	}
+	if r.Content != nil {
+		v.Content = *r.Content
+	}
+	if r.OutputFormat != nil {
+		v.OutputFormat = *r.OutputFormat
+	}
+	return v
+}
+
+func ReviewFromEntity(r reviewEntity.Review) TemplateVersionReviewResponse {
+	return TemplateVersionReviewResponse{
+		ID: r.ID, TemplateID: r.TemplateID, VersionID: r.VersionID,
+		Action: r.Action, Actor: r.Actor, Comment: r.Comment, CreatedAt: r.CreatedAt,
+	}
+}
+
+type ContentDiffResponse struct {
+	Unified      string `json:"unified"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+}
+
+type ChangeResponse struct {
+	Path string          `json:"path"`
+	Kind diff.ChangeKind `json:"kind"`
+	From any             `json:"from,omitempty"`
+	To   any             `json:"to,omitempty"`
+}
+
+type TemplateVersionDiffResponse struct {
+	From          TemplateVersionResponse `json:"from"`
+	To            TemplateVersionResponse `json:"to"`
+	Content       ContentDiffResponse     `json:"content"`
+	Schema        []ChangeResponse        `json:"schema"`
+	SamplePayload []ChangeResponse        `json:"sample_payload"`
+	Translations  []ChangeResponse        `json:"translations"`
+}
+
+func VersionDiffFromResult(d ucVer.VersionDiff) TemplateVersionDiffResponse {
+	return TemplateVersionDiffResponse{
+		From: VersionFromEntity(d.From, false),
+		To:   VersionFromEntity(d.To, false),
+		Content: ContentDiffResponse{
+			Unified: d.Content, LinesAdded: d.LinesAdded, LinesRemoved: d.LinesRemoved,
+		},
+		Schema:        changesFrom(d.Schema),
+		SamplePayload: changesFrom(d.SamplePayload),
+		Translations:  changesFrom(d.Translations),
+	}
+}
+
+func changesFrom(changes []diff.Change) []ChangeResponse {
+	out := make([]ChangeResponse, len(changes))
+	for i, c := range changes {
+		out[i] = ChangeResponse{Path: c.Path, Kind: c.Kind, From: c.From, To: c.To}
+	}
+	return out
+}
+
+type SchemaCompatChangeResponse struct {
+	ChangeResponse
+	Breaking bool   `json:"breaking"`
+	Reason   string `json:"reason"`
+}
+
+type FailingPayloadResponse struct {
+	DocumentID      int64  `json:"document_id"`
+	RequestID       string `json:"request_id"`
+	TemplateVersion int    `json:"template_version"`
+	Error           string `json:"error"`
+}
+
+type CompatReportResponse struct {
+	Policy          enums.SchemaCompatPolicy     `json:"policy"`
+	BaselineVersion *int                         `json:"baseline_version"`
+	Breaking        bool                         `json:"breaking"`
+	Changes         []SchemaCompatChangeResponse `json:"changes"`
+	SampledPayloads int                          `json:"sampled_payloads"`
+	FailingPayloads []FailingPayloadResponse     `json:"failing_payloads"`
+}
+
+// PublishTemplateVersionResponse versi yang dipublish beserta hasil cek kompatibilitas schema.
+type PublishTemplateVersionResponse struct {
+	TemplateVersionResponse
+	Compatibility CompatReportResponse `json:"compatibility"`
+}
+
+func CompatReportFromResult(r ucVer.CompatReport) CompatReportResponse {
+	resp := CompatReportResponse{
+		Policy: r.Policy, BaselineVersion: r.BaselineVersion, Breaking: r.Breaking(),
+		Changes:         make([]SchemaCompatChangeResponse, len(r.Changes)),
+		SampledPayloads: r.SampledPayloads,
+		FailingPayloads: make([]FailingPayloadResponse, len(r.FailingPayloads)),
+	}
+	for i, c := range r.Changes {
+		resp.Changes[i] = SchemaCompatChangeResponse{
+			ChangeResponse: ChangeResponse{Path: c.Path, Kind: c.Kind, From: c.From, To: c.To},
+			Breaking:       c.Breaking, Reason: c.Reason,
+		}
+	}
+	for i, f := range r.FailingPayloads {
+		resp.FailingPayloads[i] = FailingPayloadResponse{
+			DocumentID: f.DocumentID, RequestID: f.RequestID, TemplateVersion: f.Version, Error: f.Error,
+		}
+	}
+	return resp
+}
+
+type DiagnosticResponse struct {
+	Severity ucVer.LintSeverity `json:"severity"`
+	Code     string             `json:"code"`
+	Message  string             `json:"message"`
+	Path     string             `json:"path,omitempty"`
+	Line     int                `json:"line,omitempty"`
+	Col      int                `json:"col,omitempty"`
+}
+
+type LintReportResponse struct {
+	Valid       bool                 `json:"valid"`
+	Diagnostics []DiagnosticResponse `json:"diagnostics"`
+}
+
+func LintReportFromResult(r ucVer.LintReport) LintReportResponse {
+	resp := LintReportResponse{Valid: !r.HasErrors(), Diagnostics: make([]DiagnosticResponse, len(r.Diagnostics))}
+	for i, d := range r.Diagnostics {
+		resp.Diagnostics[i] = DiagnosticResponse{
+			Severity: d.Severity, Code: d.Code, Message: d.Message, Path: d.Path, Line: d.Line, Col: d.Col,
+		}
+	}
+	return resp
+}
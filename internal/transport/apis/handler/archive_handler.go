@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	archiverepo "go-document-generator/internal/repository/documentarchives"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucArchive "go-document-generator/internal/usecase/documentarchives"
+)
+
+type ArchiveHandler struct {
+	svc ucArchive.Service
+}
+
+func NewArchiveHandler(svc ucArchive.Service) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc}
+}
+
+// Zip POST /documents/zip — membuat job ZIP; response 202 berisi id job untuk polling.
+func (h *ArchiveHandler) Zip(c echo.Context) error {
+	return h.create(c, enums.ArchiveJobKindZip)
+}
+
+// Merge POST /documents/merge — membuat job merge; response 202 berisi id job untuk polling.
+func (h *ArchiveHandler) Merge(c echo.Context) error {
+	return h.create(c, enums.ArchiveJobKindMerge)
+}
+
+func (h *ArchiveHandler) create(c echo.Context, kind enums.ArchiveJobKind) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.ArchiveJobRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	j, err := h.svc.Create(c.Request().Context(), req.ToInput(headerTenant, kind))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, dto.ArchiveJobFromEntity(j))
+}
+
+func (h *ArchiveHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.List(c.Request().Context(), archiverepo.ListFilter{
+		TenantID: headerTenant,
+		Kind:     enums.ArchiveJobKind(c.QueryParam("kind")),
+		Status:   enums.ArchiveJobStatus(c.QueryParam("status")),
+		Page:     pagination.Params{Page: page, Limit: limit},
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.ArchiveJobResponse, len(items))
+	for i := range items {
+		data[i] = dto.ArchiveJobFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.ArchiveJobListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
+
+func (h *ArchiveHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("job_id"), 10, 64)
+	j, err := h.svc.GetByID(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ArchiveJobFromEntity(j))
+}
+
+// Download GET /documents/archive-jobs/:job_id/download — output job yang COMPLETED.
+func (h *ArchiveHandler) Download(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("job_id"), 10, 64)
+	fileURL, err := h.svc.DownloadURL(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	// Local provider mengembalikan path filesystem, bukan HTTP URL.
+	if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
+		return c.File(fileURL)
+	}
+	return c.Redirect(http.StatusFound, fileURL)
+}
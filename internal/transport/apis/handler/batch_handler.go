@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	batchrepo "go-document-generator/internal/repository/documentbatches"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucBatch "go-document-generator/internal/usecase/documentbatches"
+)
+
+type BatchHandler struct {
+	svc ucBatch.Service
+}
+
+func NewBatchHandler(svc ucBatch.Service) *BatchHandler {
+	return &BatchHandler{svc: svc}
+}
+
+func (h *BatchHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.List(c.Request().Context(), batchrepo.ListFilter{
+		TenantID: headerTenant,
+		Status:   enums.BatchStatus(c.QueryParam("status")),
+		Page:     pagination.Params{Page: page, Limit: limit},
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.BatchResponse, len(items))
+	for i := range items {
+		data[i] = dto.BatchFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.BatchListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
+
+// Create POST /documents/batches — item diproses async; response 202 berisi id batch untuk polling.
+func (h *BatchHandler) Create(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.CreateBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	b, err := h.svc.Create(c.Request().Context(), req.ToInput(headerTenant))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, dto.BatchFromEntity(b))
+}
+
+func (h *BatchHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("batch_id"), 10, 64)
+	b, err := h.svc.GetByID(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.BatchFromEntity(b))
+}
+
+// Items GET /documents/batches/:batch_id/items?status= — item urut seq beserta status dokumennya.
+func (h *BatchHandler) Items(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("batch_id"), 10, 64)
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.ListItems(c.Request().Context(), id, headerTenant, batchrepo.ItemFilter{
+		Status: enums.BatchItemStatus(c.QueryParam("status")),
+		Page:   pagination.Params{Page: page, Limit: limit},
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.BatchItemResponse, len(items))
+	for i := range items {
+		data[i] = dto.BatchItemFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.BatchItemListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
+
+// Cancel POST /documents/batches/:batch_id/cancel
+func (h *BatchHandler) Cancel(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("batch_id"), 10, 64)
+	b, err := h.svc.Cancel(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.BatchFromEntity(b))
+}
+
+// Download GET /documents/batches/:batch_id/download — output ZIP / merge batch.
+func (h *BatchHandler) Download(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("batch_id"), 10, 64)
+	fileURL, err := h.svc.DownloadURL(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	// Local provider mengembalikan path filesystem, bukan HTTP URL.
+	if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
+		return c.File(fileURL)
+	}
+	return c.Redirect(http.StatusFound, fileURL)
+}
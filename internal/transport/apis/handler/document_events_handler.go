@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucDoc "go-document-generator/internal/usecase/documents"
+)
+
+const sseHeartbeat = 15 * time.Second
+
+// Events GET /documents/:document_id/events — snapshot status lalu transisi berikutnya (SSE).
+// Stream ditutup server setelah status final (GENERATED / FAILED / CANCELLED).
+func (h *DocumentHandler) Events(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("document_id"), 10, 64)
+	ch, err := h.docs.WatchDocument(c.Request().Context(), id, headerTenant, lastEventID(c))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return streamStatus(c, ch)
+}
+
+// TenantEvents GET /documents/events — transisi status semua dokumen tenant (SSE).
+func (h *DocumentHandler) TenantEvents(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	ch, err := h.docs.WatchTenant(c.Request().Context(), headerTenant, lastEventID(c))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return streamStatus(c, ch)
+}
+
+// lastEventID dari header Last-Event-ID (reconnect EventSource) atau query last_event_id.
+func lastEventID(c echo.Context) string {
+	if v := c.Request().Header.Get("Last-Event-ID"); v != "" {
+		return v
+	}
+	return c.QueryParam("last_event_id")
+}
+
+// streamStatus menulis event "status" (id = id entry stream, dipakai resume) sampai channel
+// ditutup atau client putus, dengan komentar heartbeat agar proxy tidak memutus koneksi idle.
+func streamStatus(c echo.Context, ch <-chan ucDoc.StatusEvent) error {
+	w := c.Response()
+	clearWriteDeadline(c)
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			w.Flush()
+		case evt, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(dto.DocumentStatusEventFrom(evt))
+			if err != nil {
+				return err
+			}
+			if evt.ID != "" {
+				fmt.Fprintf(w, "id: %s\n", evt.ID)
+			}
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+				return nil
+			}
+			w.Flush()
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"go-document-generator/internal/shared/apperror"
 	"go-document-generator/internal/shared/pagination"
 	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/shared/watermark"
 	"go-document-generator/internal/transport/apis/dto"
 	ucCb "go-document-generator/internal/usecase/documentcallbackattempts"
 	ucDoc "go-document-generator/internal/usecase/documents"
@@ -39,6 +41,7 @@ func (h *DocumentHandler) List(c echo.Context) error {
 		TenantID:     headerTenant,
 		RequestID:    c.QueryParam("request_id"),
 		Status:       enums.DocumentStatus(c.QueryParam("status")),
+		Priority:     enums.DocumentPriority(c.QueryParam("priority")),
 		TemplateCode: c.QueryParam("template_code"),
 		DmsStatus:    enums.DmsStatus(c.QueryParam("dms_status")),
 		CallbackStatus: enums.CallbackStatus(c.QueryParam("callback_status")),
@@ -74,6 +77,9 @@ func (h *DocumentHandler) Create(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return writeError(c, err)
 	}
+	if syncMode(c) {
+		return h.createSync(c, req.ToInput(headerTenant))
+	}
 	doc, replay, err := h.docs.Create(c.Request().Context(), req.ToInput(headerTenant))
 	if err != nil {
 		return writeError(c, err)
@@ -85,6 +91,71 @@ func (h *DocumentHandler) Create(c echo.Context) error {
 	return c.JSON(status, dto.DocumentFromEntity(doc))
 }
 
+// syncMode: ?mode=sync atau header X-Generation-Mode: sync.
+func syncMode(c echo.Context) bool {
+	mode := c.QueryParam("mode")
+	if mode == "" {
+		mode = c.Request().Header.Get("X-Generation-Mode")
+	}
+	return strings.EqualFold(mode, "sync")
+}
+
+// clearWriteDeadline melepas WriteTimeout server untuk response yang memang lama (SSE, render sync).
+func clearWriteDeadline(c echo.Context) {
+	_ = http.NewResponseController(c.Response()).SetWriteDeadline(time.Time{})
+}
+
+// createSync merender dokumen dalam request. Selesai: file (default) atau JSON + download_url
+// (?response=url); render gagal: 422 dengan dokumen FAILED; lewat batas waktu: 202 seperti async.
+func (h *DocumentHandler) createSync(c echo.Context, in ucDoc.CreateInput) error {
+	ctx := c.Request().Context()
+	// Batas tunggu ditentukan SyncOptions.Timeout, bukan WriteTimeout server.
+	clearWriteDeadline(c)
+	res, err := h.docs.CreateSync(ctx, in)
+	if err != nil {
+		return writeError(c, err)
+	}
+	doc := res.Doc
+	switch {
+	case !res.Done && res.Replay:
+		return c.JSON(http.StatusOK, dto.DocumentFromEntity(doc))
+	case !res.Done:
+		return c.JSON(http.StatusAccepted, dto.DocumentFromEntity(doc))
+	case doc.Status == enums.DocumentStatusFailed:
+		return c.JSON(http.StatusUnprocessableEntity, dto.DocumentFromEntity(doc))
+	}
+
+	status := http.StatusCreated
+	if res.Replay {
+		status = http.StatusOK
+	}
+	if c.QueryParam("response") == "url" {
+		fileURL, err := h.docs.DownloadURL(ctx, doc.ID, doc.TenantID)
+		if err != nil {
+			return writeError(c, err)
+		}
+		// Local provider mengembalikan path filesystem; arahkan ke endpoint download.
+		if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
+			fileURL = fmt.Sprintf("/documents/%d/download", doc.ID)
+		}
+		return c.JSON(status, dto.SyncDocumentResponse{GeneratedDocumentResponse: dto.DocumentFromEntity(doc), DownloadURL: fileURL})
+	}
+
+	data, err := h.docs.DownloadFile(ctx, doc.ID, doc.TenantID)
+	if err != nil {
+		return writeError(c, err)
+	}
+	contentType := echo.MIMEOctetStream
+	if doc.ContentType != nil && *doc.ContentType != "" {
+		contentType = *doc.ContentType
+	}
+	if doc.FileName != nil {
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", *doc.FileName))
+	}
+	c.Response().Header().Set("X-Document-Id", strconv.FormatInt(doc.ID, 10))
+	return c.Blob(status, contentType, data)
+}
+
 func (h *DocumentHandler) Get(c echo.Context) error {
 	headerTenant, err := tenant.FromEcho(c)
 	if err != nil {
@@ -172,62 +243,35 @@ func (h *DocumentHandler) Retry(c echo.Context) error {
 	return c.JSON(http.StatusAccepted, dto.DocumentFromEntity(doc))
 }
 
-func (h *DocumentHandler) Zip(c echo.Context) error {
-	headerTenant, err := tenant.FromEcho(c)
-	if err != nil {
-		return writeError(c, err)
-	}
-	var req dto.ZipDocumentsRequest
-	if err := c.Bind(&req); err != nil {
-		return writeError(c, err)
-	}
-	if len(req.IDs) == 0 {
-		return writeError(c, apperror.ErrInvalidInput)
-	}
-	fileURL, err := h.docs.ZipDocuments(c.Request().Context(), req.IDs, headerTenant, req.Label)
-	if err != nil {
-		return writeError(c, err)
-	}
-	if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
-		return c.File(fileURL)
-	}
-	return c.JSON(http.StatusOK, dto.DocumentURLResponse{URL: fileURL})
-}
-
-func (h *DocumentHandler) Merge(c echo.Context) error {
+func (h *DocumentHandler) Download(c echo.Context) error {
 	headerTenant, err := tenant.FromEcho(c)
 	if err != nil {
 		return writeError(c, err)
 	}
-	var req dto.MergeDocumentsRequest
-	if err := c.Bind(&req); err != nil {
-		return writeError(c, err)
-	}
-	if len(req.IDs) < 2 {
-		return writeError(c, apperror.ErrInvalidInput)
-	}
-	fileURL, err := h.docs.MergeDocuments(c.Request().Context(), req.IDs, headerTenant, req.Label)
+	id, _ := strconv.ParseInt(c.Param("document_id"), 10, 64)
+	fileURL, err := h.docs.DownloadURL(c.Request().Context(), id, headerTenant)
 	if err != nil {
 		return writeError(c, err)
 	}
+	// Local provider mengembalikan path filesystem, bukan HTTP URL.
+	// Serve file langsung agar client tidak perlu akses filesystem server.
 	if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
 		return c.File(fileURL)
 	}
-	return c.JSON(http.StatusOK, dto.DocumentURLResponse{URL: fileURL})
+	return c.Redirect(http.StatusFound, fileURL)
 }
 
-func (h *DocumentHandler) Download(c echo.Context) error {
+// DownloadAttachment GET /documents/:document_id/attachments/:name/download — mis. factur-x.xml.
+func (h *DocumentHandler) DownloadAttachment(c echo.Context) error {
 	headerTenant, err := tenant.FromEcho(c)
 	if err != nil {
 		return writeError(c, err)
 	}
 	id, _ := strconv.ParseInt(c.Param("document_id"), 10, 64)
-	fileURL, err := h.docs.DownloadURL(c.Request().Context(), id, headerTenant)
+	fileURL, err := h.docs.AttachmentURL(c.Request().Context(), id, headerTenant, c.Param("name"))
 	if err != nil {
 		return writeError(c, err)
 	}
-	// Local provider mengembalikan path filesystem, bukan HTTP URL.
-	// Serve file langsung agar client tidak perlu akses filesystem server.
 	if !strings.HasPrefix(fileURL, "http://") && !strings.HasPrefix(fileURL, "https://") {
 		return c.File(fileURL)
 	}
@@ -278,13 +322,44 @@ func (h *DocumentHandler) Preview(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return writeError(c, err)
 	}
-	data, contentType, err := h.docs.Preview(c.Request().Context(), templateID, versionID, headerTenant, req.Payload)
+	// Preview HTML diberi stempel DRAFT kecuali request membawa watermark sendiri ({} = tanpa watermark);
+	// preview PDF hanya diberi watermark bila diminta karena overlay PDF butuh qpdf.
+	wm := watermark.Draft()
+	if req.Watermark != nil {
+		wm = *req.Watermark
+		wm.Source = watermark.SourceRequest
+	}
+	data, contentType, err := h.docs.Preview(c.Request().Context(), templateID, versionID, headerTenant, req.Payload, &wm)
 	if err != nil {
 		return writeError(c, err)
 	}
 	return c.Blob(http.StatusOK, contentType, data)
 }
 
+// PreviewCompare menampilkan render dua versi berdampingan; body payload opsional (default sample_payload).
+func (h *DocumentHandler) PreviewCompare(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	leftID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	rightID, err := strconv.ParseInt(c.Param("other_version_id"), 10, 64)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	var req dto.PreviewDocumentRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	page, err := h.docs.PreviewCompare(c.Request().Context(), templateID, leftID, rightID, headerTenant, req.Payload)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.HTMLBlob(http.StatusOK, page)
+}
+
 func (h *DocumentHandler) BulkCreate(c echo.Context) error {
 	headerTenant, err := tenant.FromEcho(c)
 	if err != nil {
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	importrepo "go-document-generator/internal/repository/documentimports"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucImport "go-document-generator/internal/usecase/documentimports"
+)
+
+type ImportHandler struct {
+	svc ucImport.Service
+}
+
+func NewImportHandler(svc ucImport.Service) *ImportHandler {
+	return &ImportHandler{svc: svc}
+}
+
+func (h *ImportHandler) ListMappings(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.ListMappings(c.Request().Context(), importrepo.ListFilter{
+		TenantID:     headerTenant,
+		TemplateCode: c.QueryParam("template_code"),
+		Page:         pagination.Params{Page: page, Limit: limit},
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.ImportMappingResponse, len(items))
+	for i := range items {
+		data[i] = dto.ImportMappingFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.ImportMappingListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
+
+func (h *ImportHandler) CreateMapping(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.CreateImportMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	m, err := h.svc.CreateMapping(c.Request().Context(), req.ToEntity(headerTenant))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.ImportMappingFromEntity(m))
+}
+
+func (h *ImportHandler) GetMapping(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("mapping_id"), 10, 64)
+	m, err := h.svc.GetMapping(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ImportMappingFromEntity(m))
+}
+
+func (h *ImportHandler) PatchMapping(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("mapping_id"), 10, 64)
+	var req dto.PatchImportMappingRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	m, err := h.svc.UpdateMapping(c.Request().Context(), id, headerTenant, req.ToPatch())
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ImportMappingFromEntity(m))
+}
+
+func (h *ImportHandler) DeleteMapping(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("mapping_id"), 10, 64)
+	if err := h.svc.DeleteMapping(c.Request().Context(), id, headerTenant); err != nil {
+		return writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Import POST /documents/imports — multipart form: file (CSV / JSONL, wajib), template_code atau
+// mapping_id, dan opsi batch. Response 202 bila batch dibuat, 200 untuk dry_run atau tanpa baris valid.
+func (h *ImportHandler) Import(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return writeError(c, fmt.Errorf("%w: file is required", apperror.ErrInvalidInput))
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return writeError(c, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, ucImport.MaxFileSize+1))
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	in := ucImport.ImportInput{
+		TenantID:        headerTenant,
+		FileName:        fh.Filename,
+		Data:            data,
+		Format:          c.FormValue("format"),
+		TemplateCode:    c.FormValue("template_code"),
+		GroupBy:         c.FormValue("group_by"),
+		RequestIDColumn: c.FormValue("request_id_column"),
+		RequestIDPrefix: c.FormValue("request_id_prefix"),
+		OutputFormat:    enums.OutputFormat(c.FormValue("output_format")),
+		Priority:        enums.DocumentPriority(c.FormValue("priority")),
+		PostAction:      enums.BatchPostAction(strings.ToUpper(c.FormValue("post_action"))),
+	}
+	if v := c.FormValue("mapping_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return writeError(c, fmt.Errorf("%w: invalid mapping_id", apperror.ErrInvalidInput))
+		}
+		in.MappingID = &id
+	}
+	if v := c.FormValue("template_version"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return writeError(c, fmt.Errorf("%w: invalid template_version", apperror.ErrInvalidInput))
+		}
+		in.TemplateVersion = &n
+	}
+	for name, dst := range map[string]*bool{"dry_run": &in.DryRun, "store_to_dms": &in.StoreToDms, "has_callback": &in.HasCallback} {
+		if v := c.FormValue(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return writeError(c, fmt.Errorf("%w: invalid %s", apperror.ErrInvalidInput, name))
+			}
+			*dst = b
+		}
+	}
+	for name, dst := range map[string]**string{"name": &in.Name, "locale": &in.Locale, "callback_url": &in.CallbackURL, "created_by": &in.CreatedBy} {
+		if v := c.FormValue(name); v != "" {
+			*dst = &v
+		}
+	}
+
+	res, err := h.svc.Import(c.Request().Context(), in)
+	if err != nil {
+		return writeError(c, err)
+	}
+	status := http.StatusOK
+	if res.Batch != nil {
+		status = http.StatusAccepted
+	}
+	return c.JSON(status, dto.ImportFromResult(res))
+}
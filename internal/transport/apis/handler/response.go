@@ -17,6 +17,10 @@ func writeError(c echo.Context, err error) error {
 		return c.JSON(http.StatusConflict, apperror.New("CONFLICT", err.Error()))
 	case errors.Is(err, apperror.ErrInvalidState):
 		return c.JSON(http.StatusConflict, apperror.New("INVALID_STATE", err.Error()))
+	case errors.Is(err, apperror.ErrUnauthorized):
+		return c.JSON(http.StatusUnauthorized, apperror.New("UNAUTHORIZED", err.Error()))
+	case errors.Is(err, apperror.ErrForbidden):
+		return c.JSON(http.StatusForbidden, apperror.New("FORBIDDEN", err.Error()))
 	case errors.Is(err, apperror.ErrInvalidInput):
 		return c.JSON(http.StatusBadRequest, apperror.New("BAD_REQUEST", err.Error()))
 	default:
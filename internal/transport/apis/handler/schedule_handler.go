@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	schedrepo "go-document-generator/internal/repository/documentschedules"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucSched "go-document-generator/internal/usecase/documentschedules"
+)
+
+type ScheduleHandler struct {
+	svc ucSched.Service
+}
+
+func NewScheduleHandler(svc ucSched.Service) *ScheduleHandler {
+	return &ScheduleHandler{svc: svc}
+}
+
+func (h *ScheduleHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.List(c.Request().Context(), schedrepo.ListFilter{
+		TenantID:     headerTenant,
+		Status:       enums.ScheduleStatus(c.QueryParam("status")),
+		TemplateCode: c.QueryParam("template_code"),
+		Page:         pagination.Params{Page: page, Limit: limit},
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.ScheduleResponse, len(items))
+	for i := range items {
+		data[i] = dto.ScheduleFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
+
+func (h *ScheduleHandler) Create(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.CreateScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	created, err := h.svc.Create(c.Request().Context(), req.ToEntity(headerTenant))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.ScheduleFromEntity(created))
+}
+
+func (h *ScheduleHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	s, err := h.svc.GetByID(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleFromEntity(s))
+}
+
+func (h *ScheduleHandler) Patch(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	var req dto.PatchScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	s, err := h.svc.Update(c.Request().Context(), id, headerTenant, req.ToPatch())
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleFromEntity(s))
+}
+
+func (h *ScheduleHandler) Delete(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	if err := h.svc.Delete(c.Request().Context(), id, headerTenant); err != nil {
+		return writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Pause POST /schedules/:schedule_id/pause
+func (h *ScheduleHandler) Pause(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	s, err := h.svc.Pause(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleFromEntity(s))
+}
+
+// Resume POST /schedules/:schedule_id/resume
+func (h *ScheduleHandler) Resume(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	s, err := h.svc.Resume(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleFromEntity(s))
+}
+
+// Runs GET /schedules/:schedule_id/runs — riwayat waktu jadwal, terbaru dulu.
+func (h *ScheduleHandler) Runs(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	items, meta, err := h.svc.ListRuns(c.Request().Context(), id, headerTenant, pagination.Params{Page: page, Limit: limit})
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.ScheduleRunResponse, len(items))
+	for i := range items {
+		data[i] = dto.ScheduleRunFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.ScheduleRunListResponse{Data: data, Meta: dto.MetaFrom(meta)})
+}
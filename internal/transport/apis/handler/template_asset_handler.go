@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucAsset "go-document-generator/internal/usecase/documenttemplateassets"
+)
+
+type TemplateAssetHandler struct {
+	svc ucAsset.Service
+}
+
+func NewTemplateAssetHandler(svc ucAsset.Service) *TemplateAssetHandler {
+	return &TemplateAssetHandler{svc: svc}
+}
+
+// List mengembalikan asset template (route /templates/:template_id/assets) atau asset tenant-wide (/assets).
+func (h *TemplateAssetHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	items, err := h.svc.List(c.Request().Context(), headerTenant, templateScope(c))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.AssetListFromEntities(items))
+}
+
+// Upload menerima multipart form: file (wajib), name (default nama file), created_by.
+// Nama yang sudah ada pada scope yang sama diganti isinya.
+func (h *TemplateAssetHandler) Upload(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return writeError(c, fmt.Errorf("%w: file is required", apperror.ErrInvalidInput))
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return writeError(c, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, ucAsset.MaxAssetSize+1))
+	if err != nil {
+		return writeError(c, err)
+	}
+	name := strings.TrimSpace(c.FormValue("name"))
+	if name == "" {
+		name = fh.Filename
+	}
+	in := ucAsset.UploadInput{
+		TenantID:    headerTenant,
+		TemplateID:  templateScope(c),
+		Name:        name,
+		ContentType: fh.Header.Get("Content-Type"),
+		Data:        data,
+	}
+	if by := c.FormValue("created_by"); by != "" {
+		in.CreatedBy = &by
+	}
+	a, created, err := h.svc.Upload(c.Request().Context(), in)
+	if err != nil {
+		return writeError(c, err)
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	return c.JSON(status, dto.AssetFromEntity(a))
+}
+
+func (h *TemplateAssetHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("asset_id"), 10, 64)
+	a, err := h.svc.GetByID(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.AssetFromEntity(a))
+}
+
+// Content mengirim isi file asset.
+func (h *TemplateAssetHandler) Content(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("asset_id"), 10, 64)
+	a, data, err := h.svc.Content(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	// Asset bisa berisi SVG/CSS dari tenant: jangan pernah dieksekusi di origin API.
+	hdr := c.Response().Header()
+	hdr.Set("ETag", `"`+a.Checksum+`"`)
+	hdr.Set("X-Content-Type-Options", "nosniff")
+	hdr.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	if a.ContentType == "image/svg+xml" {
+		hdr.Set("Content-Disposition", `attachment; filename="`+path.Base(a.Name)+`"`)
+	}
+	return c.Blob(http.StatusOK, a.ContentType, data)
+}
+
+func (h *TemplateAssetHandler) Delete(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("asset_id"), 10, 64)
+	if err := h.svc.Delete(c.Request().Context(), id, headerTenant); err != nil {
+		return writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// templateScope: route template mengisi template_id; route /assets berarti asset tenant-wide.
+func templateScope(c echo.Context) *int64 {
+	raw := c.Param("template_id")
+	if raw == "" {
+		return nil
+	}
+	id, _ := strconv.ParseInt(raw, 10, 64)
+	return &id
+}
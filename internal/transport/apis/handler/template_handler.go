@@ -108,6 +108,24 @@ func (h *TemplateHandler) Patch(c echo.Context) error {
 	return c.JSON(http.StatusOK, dto.TemplateFromEntity(updated))
 }
 
+// ApprovePendingApprovals POST /templates/:template_id/required-approvals/approve — menerapkan
+// penurunan required_approvals yang diminta actor lain.
+func (h *TemplateHandler) ApprovePendingApprovals(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, err := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	if err != nil {
+		return writeError(c, err)
+	}
+	updated, err := h.svc.ApprovePendingApprovals(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.TemplateFromEntity(updated))
+}
+
 func (h *TemplateHandler) Delete(c echo.Context) error {
 	headerTenant, err := tenant.FromEcho(c)
 	if err != nil {
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucPartial "go-document-generator/internal/usecase/documenttemplatepartials"
+)
+
+type TemplatePartialHandler struct {
+	svc ucPartial.Service
+}
+
+func NewTemplatePartialHandler(svc ucPartial.Service) *TemplatePartialHandler {
+	return &TemplatePartialHandler{svc: svc}
+}
+
+func (h *TemplatePartialHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	items, err := h.svc.List(c.Request().Context(), headerTenant, enums.TemplatePartialKind(c.QueryParam("kind")))
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.TemplatePartialResponse, len(items))
+	for i := range items {
+		data[i] = dto.PartialFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.TemplatePartialListResponse{Data: data})
+}
+
+func (h *TemplatePartialHandler) Create(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.CreateTemplatePartialRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	p := req.ToEntity()
+	p.TenantID = headerTenant
+	created, err := h.svc.Create(c.Request().Context(), p)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.PartialFromEntity(created))
+}
+
+func (h *TemplatePartialHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	p, err := h.svc.GetByID(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialFromEntity(p))
+}
+
+func (h *TemplatePartialHandler) Patch(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	var req dto.PatchTemplatePartialRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	p, err := h.svc.UpdateDescription(c.Request().Context(), id, headerTenant, req.Description)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialFromEntity(p))
+}
+
+func (h *TemplatePartialHandler) ListVersions(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	items, err := h.svc.ListVersions(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.TemplatePartialVersionResponse, len(items))
+	for i := range items {
+		data[i] = dto.PartialVersionFromEntity(items[i])
+	}
+	return c.JSON(http.StatusOK, dto.TemplatePartialVersionListResponse{Data: data})
+}
+
+func (h *TemplatePartialHandler) CreateVersion(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	var req dto.CreateTemplatePartialVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.CreateVersion(c.Request().Context(), id, headerTenant, req.ToEntity())
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.PartialVersionFromEntity(v))
+}
+
+func (h *TemplatePartialHandler) GetVersion(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	v, err := h.svc.GetVersion(c.Request().Context(), id, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialVersionFromEntity(v))
+}
+
+// PublishVersion menjadikan versi sebagai versi yang dipakai render semua template.
+func (h *TemplatePartialHandler) PublishVersion(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	p, err := h.svc.PublishVersion(c.Request().Context(), id, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialFromEntity(p))
+}
+
+// Impact membandingkan output versi template published dengan versi partial kandidat.
+func (h *TemplatePartialHandler) Impact(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	entries, err := h.svc.Impact(c.Request().Context(), id, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialImpactFromEntries(entries))
+}
+
+func (h *TemplatePartialHandler) Dependents(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	id, _ := strconv.ParseInt(c.Param("partial_id"), 10, 64)
+	deps, err := h.svc.Dependents(c.Request().Context(), id, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PartialDependenciesFromEntities(deps))
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/shared/tenant"
+	"go-document-generator/internal/transport/apis/dto"
+	ucTest "go-document-generator/internal/usecase/documenttemplatetests"
+)
+
+type TemplateTestHandler struct {
+	svc ucTest.Service
+}
+
+func NewTemplateTestHandler(svc ucTest.Service) *TemplateTestHandler {
+	return &TemplateTestHandler{svc: svc}
+}
+
+func (h *TemplateTestHandler) List(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	cases, err := h.svc.ListCases(c.Request().Context(), templateID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.TemplateTestCaseResponse, len(cases))
+	for i := range cases {
+		data[i] = dto.TestCaseFromEntity(cases[i])
+	}
+	return c.JSON(http.StatusOK, dto.TemplateTestCaseListResponse{Data: data})
+}
+
+func (h *TemplateTestHandler) Create(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	var req dto.CreateTemplateTestCaseRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	created, err := h.svc.CreateCase(c.Request().Context(), templateID, headerTenant, req.ToEntity(), req.SnapshotVersionID)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dto.TestCaseFromEntity(created))
+}
+
+func (h *TemplateTestHandler) Get(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	caseID, _ := strconv.ParseInt(c.Param("test_id"), 10, 64)
+	tc, err := h.svc.GetCase(c.Request().Context(), templateID, caseID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.TestCaseFromEntity(tc))
+}
+
+func (h *TemplateTestHandler) Patch(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	caseID, _ := strconv.ParseInt(c.Param("test_id"), 10, 64)
+	var req dto.PatchTemplateTestCaseRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	tc, err := h.svc.UpdateCase(c.Request().Context(), templateID, caseID, headerTenant, req.ToEntity(), req.SnapshotVersionID)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.TestCaseFromEntity(tc))
+}
+
+func (h *TemplateTestHandler) Delete(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	caseID, _ := strconv.ParseInt(c.Param("test_id"), 10, 64)
+	if err := h.svc.DeleteCase(c.Request().Context(), templateID, caseID, headerTenant); err != nil {
+		return writeError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Run menjalankan semua golden test terhadap versi dan mengembalikan hasilnya.
+func (h *TemplateTestHandler) Run(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	results, err := h.svc.Run(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.TestRunFromResults(results))
+}
+
+// Results mengembalikan hasil terakhir tiap golden test untuk versi.
+func (h *TemplateTestHandler) Results(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	results, err := h.svc.LatestResults(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.TestRunFromResults(results))
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/entity/enums"
+	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/apperror"
 	"go-document-generator/internal/shared/tenant"
 	"go-document-generator/internal/transport/apis/dto"
 	ucVer "go-document-generator/internal/usecase/documenttemplateversions"
@@ -27,12 +31,15 @@ func (h *TemplateVersionHandler) List(c echo.Context) error {
 	if err != nil {
 		return writeError(c, err)
 	}
-	var isPublished *bool
+	f := verrepo.ListFilter{
+		TenantID: headerTenant,
+		Status:   enums.TemplateVersionStatus(c.QueryParam("status")),
+	}
 	if v := c.QueryParam("is_published"); v != "" {
 		b := v == "true"
-		isPublished = &b
+		f.IsPublished = &b
 	}
-	items, err := h.svc.List(c.Request().Context(), templateID, headerTenant, isPublished)
+	items, err := h.svc.List(c.Request().Context(), templateID, f)
 	if err != nil {
 		return writeError(c, err)
 	}
@@ -58,7 +65,7 @@ func (h *TemplateVersionHandler) Create(c echo.Context) error {
 	}
 	created, err := h.svc.Create(c.Request().Context(), templateID, headerTenant, req.ToEntity(headerTenant, templateID))
 	if err != nil {
-		return writeError(c, err)
+		return writeVersionError(c, err)
 	}
 	return c.JSON(http.StatusCreated, dto.VersionFromEntity(created, true))
 }
@@ -84,9 +91,261 @@ func (h *TemplateVersionHandler) Publish(c echo.Context) error {
 	}
 	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
 	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
-	v, err := h.svc.Publish(c.Request().Context(), templateID, versionID, headerTenant)
+	var req dto.PublishTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, report, err := h.svc.Publish(c.Request().Context(), templateID, versionID, headerTenant, req.OverrideFailingTests)
+	if err != nil {
+		var testsErr *ucVer.GoldenTestsFailedError
+		if !errors.As(err, &testsErr) && errors.Is(err, apperror.ErrInvalidState) && report.Breaking() {
+			apiErr := apperror.New("INVALID_STATE", err.Error())
+			apiErr.Details = map[string]any{"compatibility": dto.CompatReportFromResult(report)}
+			return c.JSON(http.StatusConflict, apiErr)
+		}
+		return writeVersionError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.PublishTemplateVersionResponse{
+		TemplateVersionResponse: dto.VersionFromEntity(v, true),
+		Compatibility:           dto.CompatReportFromResult(report),
+	})
+}
+
+func (h *TemplateVersionHandler) Patch(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	var req dto.PatchTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.UpdateDraft(c.Request().Context(), templateID, versionID, headerTenant, req.ToEntity())
+	if err != nil {
+		return writeVersionError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Unpublish(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	v, err := h.svc.Unpublish(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Archive(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	v, err := h.svc.Archive(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Rollback(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, err := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	if err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.Rollback(c.Request().Context(), templateID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Schedule(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	var req dto.ScheduleTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	if req.PublishAt == nil {
+		return writeError(c, apperror.ErrInvalidInput)
+	}
+	v, err := h.svc.SchedulePublish(c.Request().Context(), templateID, versionID, headerTenant, req.PublishAt)
+	if err != nil {
+		return writeVersionError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) CancelSchedule(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	v, err := h.svc.SchedulePublish(c.Request().Context(), templateID, versionID, headerTenant, nil)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Submit(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	var req dto.SubmitTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.Submit(c.Request().Context(), templateID, versionID, headerTenant, req.Comment)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Approve(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	var req dto.ReviewTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.Approve(c.Request().Context(), templateID, versionID, headerTenant, req.Comment)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
+}
+
+func (h *TemplateVersionHandler) Reject(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	var req dto.ReviewTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	v, err := h.svc.Reject(c.Request().Context(), templateID, versionID, headerTenant, req.Comment)
 	if err != nil {
 		return writeError(c, err)
 	}
 	return c.JSON(http.StatusOK, dto.VersionFromEntity(v, true))
 }
+
+func (h *TemplateVersionHandler) ListReviews(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	reviews, err := h.svc.ListReviews(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	data := make([]dto.TemplateVersionReviewResponse, len(reviews))
+	for i := range reviews {
+		data[i] = dto.ReviewFromEntity(reviews[i])
+	}
+	return c.JSON(http.StatusOK, dto.TemplateVersionReviewListResponse{Data: data})
+}
+
+func (h *TemplateVersionHandler) Diff(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	fromID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	toID, err := strconv.ParseInt(c.Param("other_version_id"), 10, 64)
+	if err != nil {
+		return writeError(c, err)
+	}
+	d, err := h.svc.Diff(c.Request().Context(), templateID, fromID, toID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.VersionDiffFromResult(d))
+}
+
+func (h *TemplateVersionHandler) Compatibility(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, _ := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	versionID, _ := strconv.ParseInt(c.Param("version_id"), 10, 64)
+	report, err := h.svc.CheckCompatibility(c.Request().Context(), templateID, versionID, headerTenant)
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.CompatReportFromResult(report))
+}
+
+// Lint menjalankan lint + render-check pada calon versi tanpa menyimpannya.
+func (h *TemplateVersionHandler) Lint(c echo.Context) error {
+	headerTenant, err := tenant.FromEcho(c)
+	if err != nil {
+		return writeError(c, err)
+	}
+	templateID, err := strconv.ParseInt(c.Param("template_id"), 10, 64)
+	if err != nil {
+		return writeError(c, err)
+	}
+	var req dto.CreateTemplateVersionRequest
+	if err := c.Bind(&req); err != nil {
+		return writeError(c, err)
+	}
+	report, err := h.svc.Lint(c.Request().Context(), templateID, headerTenant, req.ToEntity(headerTenant, templateID))
+	if err != nil {
+		return writeError(c, err)
+	}
+	return c.JSON(http.StatusOK, dto.LintReportFromResult(report))
+}
+
+// writeVersionError menyertakan diagnostics lint pada response 400.
+func writeVersionError(c echo.Context, err error) error {
+	var lintErr *ucVer.LintFailedError
+	if errors.As(err, &lintErr) {
+		apiErr := apperror.New("LINT_FAILED", err.Error())
+		apiErr.Details = map[string]any{"lint": dto.LintReportFromResult(lintErr.Report)}
+		return c.JSON(http.StatusBadRequest, apiErr)
+	}
+	var testsErr *ucVer.GoldenTestsFailedError
+	if errors.As(err, &testsErr) {
+		apiErr := apperror.New("GOLDEN_TESTS_FAILED", err.Error())
+		apiErr.Details = map[string]any{"golden_tests": dto.TestRunFromResults(testsErr.Results)}
+		return c.JSON(http.StatusConflict, apiErr)
+	}
+	return writeError(c, err)
+}
@@ -4,10 +4,17 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"go-document-generator/internal/transport/apis/handler"
+	ucArchive "go-document-generator/internal/usecase/documentarchives"
+	ucBatch "go-document-generator/internal/usecase/documentbatches"
 	ucCb "go-document-generator/internal/usecase/documentcallbackattempts"
+	ucImport "go-document-generator/internal/usecase/documentimports"
 	ucDoc "go-document-generator/internal/usecase/documents"
 	ucLog "go-document-generator/internal/usecase/documentrenderlogs"
+	ucSched "go-document-generator/internal/usecase/documentschedules"
+	ucAsset "go-document-generator/internal/usecase/documenttemplateassets"
+	ucPartial "go-document-generator/internal/usecase/documenttemplatepartials"
 	ucTpl "go-document-generator/internal/usecase/documenttemplates"
+	ucTest "go-document-generator/internal/usecase/documenttemplatetests"
 	ucVer "go-document-generator/internal/usecase/documenttemplateversions"
 	usecaseusers "go-document-generator/internal/usecase/users"
 )
@@ -16,9 +23,16 @@ type Services struct {
 	Users            usecaseusers.UserService
 	Templates        ucTpl.Service
 	TemplateVersions ucVer.Service
+	TemplateTests    ucTest.Service
+	Partials         ucPartial.Service
+	Assets           ucAsset.Service
 	Documents        ucDoc.Service
 	RenderLogs       ucLog.Service
 	Callbacks        ucCb.Service
+	Schedules        ucSched.Service
+	Batches          ucBatch.Service
+	Imports          ucImport.Service
+	Archives         ucArchive.Service
 }
 
 func RegisterRoutes(e *echo.Echo, svc Services) {
@@ -34,8 +48,15 @@ func RegisterRoutes(e *echo.Echo, svc Services) {
 
 	tplHandler := handler.NewTemplateHandler(svc.Templates)
 	verHandler := handler.NewTemplateVersionHandler(svc.TemplateVersions)
+	testHandler := handler.NewTemplateTestHandler(svc.TemplateTests)
+	partialHandler := handler.NewTemplatePartialHandler(svc.Partials)
+	assetHandler := handler.NewTemplateAssetHandler(svc.Assets)
 	docHandler := handler.NewDocumentHandler(svc.Documents, svc.RenderLogs, svc.Callbacks)
 	cbHandler := handler.NewCallbackHandler(svc.Callbacks)
+	schedHandler := handler.NewScheduleHandler(svc.Schedules)
+	batchHandler := handler.NewBatchHandler(svc.Batches)
+	importHandler := handler.NewImportHandler(svc.Imports)
+	archiveHandler := handler.NewArchiveHandler(svc.Archives)
 
 	templates := e.Group("/templates")
 	templates.GET("", tplHandler.List)
@@ -43,19 +64,76 @@ func RegisterRoutes(e *echo.Echo, svc Services) {
 	templates.GET("/:template_id", tplHandler.Get)
 	templates.PATCH("/:template_id", tplHandler.Patch)
 	templates.DELETE("/:template_id", tplHandler.Delete)
+	templates.POST("/:template_id/required-approvals/approve", tplHandler.ApprovePendingApprovals)
 
 	templates.GET("/:template_id/versions", verHandler.List)
 	templates.POST("/:template_id/versions", verHandler.Create)
+	templates.POST("/:template_id/versions/rollback", verHandler.Rollback)
+	templates.POST("/:template_id/versions/lint", verHandler.Lint)
 	templates.GET("/:template_id/versions/:version_id", verHandler.Get)
+	templates.PATCH("/:template_id/versions/:version_id", verHandler.Patch)
 	templates.POST("/:template_id/versions/:version_id/publish", verHandler.Publish)
+	templates.POST("/:template_id/versions/:version_id/unpublish", verHandler.Unpublish)
+	templates.POST("/:template_id/versions/:version_id/archive", verHandler.Archive)
+	templates.POST("/:template_id/versions/:version_id/schedule", verHandler.Schedule)
+	templates.DELETE("/:template_id/versions/:version_id/schedule", verHandler.CancelSchedule)
+	templates.POST("/:template_id/versions/:version_id/submit", verHandler.Submit)
+	templates.POST("/:template_id/versions/:version_id/approve", verHandler.Approve)
+	templates.POST("/:template_id/versions/:version_id/reject", verHandler.Reject)
+	templates.GET("/:template_id/versions/:version_id/reviews", verHandler.ListReviews)
+	templates.GET("/:template_id/versions/:version_id/diff/:other_version_id", verHandler.Diff)
+	templates.GET("/:template_id/versions/:version_id/compatibility", verHandler.Compatibility)
+	templates.GET("/:template_id/versions/:version_id/tests", testHandler.Results)
+	templates.POST("/:template_id/versions/:version_id/tests/run", testHandler.Run)
 	templates.POST("/:template_id/versions/:version_id/preview", docHandler.Preview)
+	templates.GET("/:template_id/versions/:version_id/diff/:other_version_id/preview", docHandler.PreviewCompare)
+	templates.POST("/:template_id/versions/:version_id/diff/:other_version_id/preview", docHandler.PreviewCompare)
+
+	templates.GET("/:template_id/tests", testHandler.List)
+	templates.POST("/:template_id/tests", testHandler.Create)
+	templates.GET("/:template_id/tests/:test_id", testHandler.Get)
+	templates.PATCH("/:template_id/tests/:test_id", testHandler.Patch)
+	templates.DELETE("/:template_id/tests/:test_id", testHandler.Delete)
+
+	templates.GET("/:template_id/assets", assetHandler.List)
+	templates.POST("/:template_id/assets", assetHandler.Upload)
+
+	partials := e.Group("/partials")
+	partials.GET("", partialHandler.List)
+	partials.POST("", partialHandler.Create)
+	partials.GET("/:partial_id", partialHandler.Get)
+	partials.PATCH("/:partial_id", partialHandler.Patch)
+	partials.GET("/:partial_id/dependents", partialHandler.Dependents)
+	partials.GET("/:partial_id/versions", partialHandler.ListVersions)
+	partials.POST("/:partial_id/versions", partialHandler.CreateVersion)
+	partials.GET("/:partial_id/versions/:version_id", partialHandler.GetVersion)
+	partials.POST("/:partial_id/versions/:version_id/publish", partialHandler.PublishVersion)
+	partials.GET("/:partial_id/versions/:version_id/impact", partialHandler.Impact)
+
+	assets := e.Group("/assets")
+	assets.GET("", assetHandler.List)
+	assets.POST("", assetHandler.Upload)
+	assets.GET("/:asset_id", assetHandler.Get)
+	assets.GET("/:asset_id/content", assetHandler.Content)
+	assets.DELETE("/:asset_id", assetHandler.Delete)
 
 	docs := e.Group("/documents")
 	docs.GET("", docHandler.List)
 	docs.POST("", docHandler.Create)
 	docs.POST("/bulk", docHandler.BulkCreate)
-	docs.POST("/zip", docHandler.Zip)
-	docs.POST("/merge", docHandler.Merge)
+	docs.POST("/zip", archiveHandler.Zip)
+	docs.POST("/merge", archiveHandler.Merge)
+	docs.GET("/archive-jobs", archiveHandler.List)
+	docs.GET("/archive-jobs/:job_id", archiveHandler.Get)
+	docs.GET("/archive-jobs/:job_id/download", archiveHandler.Download)
+	docs.GET("/batches", batchHandler.List)
+	docs.POST("/batches", batchHandler.Create)
+	docs.GET("/batches/:batch_id", batchHandler.Get)
+	docs.GET("/batches/:batch_id/items", batchHandler.Items)
+	docs.POST("/batches/:batch_id/cancel", batchHandler.Cancel)
+	docs.GET("/batches/:batch_id/download", batchHandler.Download)
+	docs.POST("/imports", importHandler.Import)
+	docs.GET("/events", docHandler.TenantEvents)
 	docs.GET("/by-request/:request_id", docHandler.GetByRequestID)
 	docs.GET("/:document_id", docHandler.Get)
 	docs.PATCH("/:document_id", docHandler.Patch)
@@ -63,8 +141,27 @@ func RegisterRoutes(e *echo.Echo, svc Services) {
 	docs.POST("/:document_id/cancel", docHandler.Cancel)
 	docs.POST("/:document_id/retry", docHandler.Retry)
 	docs.GET("/:document_id/download", docHandler.Download)
+	docs.GET("/:document_id/attachments/:name/download", docHandler.DownloadAttachment)
+	docs.GET("/:document_id/events", docHandler.Events)
 	docs.GET("/:document_id/render-logs", docHandler.ListRenderLogs)
 	docs.GET("/:document_id/callback-attempts", docHandler.ListCallbackAttempts)
 
+	schedules := e.Group("/schedules")
+	schedules.GET("", schedHandler.List)
+	schedules.POST("", schedHandler.Create)
+	schedules.GET("/:schedule_id", schedHandler.Get)
+	schedules.PATCH("/:schedule_id", schedHandler.Patch)
+	schedules.DELETE("/:schedule_id", schedHandler.Delete)
+	schedules.POST("/:schedule_id/pause", schedHandler.Pause)
+	schedules.POST("/:schedule_id/resume", schedHandler.Resume)
+	schedules.GET("/:schedule_id/runs", schedHandler.Runs)
+
+	mappings := e.Group("/import-mappings")
+	mappings.GET("", importHandler.ListMappings)
+	mappings.POST("", importHandler.CreateMapping)
+	mappings.GET("/:mapping_id", importHandler.GetMapping)
+	mappings.PATCH("/:mapping_id", importHandler.PatchMapping)
+	mappings.DELETE("/:mapping_id", importHandler.DeleteMapping)
+
 	e.POST("/callbacks/test", cbHandler.Test)
 }
@@ -22,6 +22,7 @@ type DocumentState struct {
 	TemplateVersion int        `json:"template_version"`
 	OutputFormat    string     `json:"output_format"`
 	Status          string     `json:"status"`
+	Priority        string     `json:"priority,omitempty"`
 	ErrorMessage    *string    `json:"error_message,omitempty"`
 	RetryCount      int        `json:"retry_count"`
 	FilePath        *string    `json:"file_path,omitempty"`
@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 // TemplateCreatedEvent payload Kafka untuk template baru.
 type TemplateCreatedEvent struct {
 	ID   int64  `json:"id"`
@@ -12,11 +14,19 @@ type TemplateUpdatedEvent struct {
 	Code string `json:"code"`
 }
 
-// TemplateVersionCreatedEvent payload Kafka untuk versi template baru.
+// TemplateVersionCreatedEvent payload Kafka untuk lifecycle versi template.
+// Action: CREATED | UPDATED | PUBLISHED | DEPRECATED | ARCHIVED | SCHEDULED |
+// REVIEW_SUBMITTED | REVIEW_APPROVED | REVIEW_REJECTED (dengan Actor/Comment).
 type TemplateVersionCreatedEvent struct {
-	ID         int64 `json:"id"`
-	TemplateID int64 `json:"template_id"`
-	Version    int   `json:"version"`
+	ID                 int64      `json:"id"`
+	TemplateID         int64      `json:"template_id"`
+	Version            int        `json:"version"`
+	Action             string     `json:"action,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	ScheduledPublishAt *time.Time `json:"scheduled_publish_at,omitempty"`
+	ReviewStatus       string     `json:"review_status,omitempty"`
+	Actor              string     `json:"actor,omitempty"`
+	Comment            *string    `json:"comment,omitempty"`
 }
 
 // TemplateVersionPublishedEvent payload Kafka saat versi dipublish.
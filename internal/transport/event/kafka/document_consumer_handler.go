@@ -3,24 +3,31 @@ package kafka
 import (
 	"context"
 	"log"
+	"strings"
 
+	"go-document-generator/internal/entity/enums"
 	"go-document-generator/internal/transport/event/events"
 	ucDoc "go-document-generator/internal/usecase/documents"
 
 	libkafka "github.com/viantonugroho11/go-lib/kafka"
 )
 
-// DocumentProcessHandler consume DocumentEvent dari topic document-process
+// DocumentProcessHandler consume DocumentEvent dari topic document-process satu lane prioritas
 // dan menjalankan pipeline generation: QUEUED → PROCESSING → GENERATED/FAILED.
+// Slot render dibagi dengan lane lain lewat PriorityLanes.
 type DocumentProcessHandler struct {
-	docs ucDoc.Service
+	docs  ucDoc.Service
+	lanes *PriorityLanes
+	lane  enums.DocumentPriority
 }
 
-func NewDocumentProcessHandler(docs ucDoc.Service) *DocumentProcessHandler {
-	return &DocumentProcessHandler{docs: docs}
+func NewDocumentProcessHandler(docs ucDoc.Service, lanes *PriorityLanes, lane enums.DocumentPriority) *DocumentProcessHandler {
+	return &DocumentProcessHandler{docs: docs, lanes: lanes, lane: lane}
 }
 
-func (h *DocumentProcessHandler) Name() string { return "document-process" }
+func (h *DocumentProcessHandler) Name() string {
+	return "document-process-" + strings.ToLower(string(h.lane))
+}
 
 func (h *DocumentProcessHandler) Handle(ctx context.Context, evt events.DocumentEvent, _ ...libkafka.Header) libkafka.Progress {
 	if evt.After == nil || evt.After.ID == 0 {
@@ -31,6 +38,12 @@ func (h *DocumentProcessHandler) Handle(ctx context.Context, evt events.Document
 	requestID := evt.After.RequestID
 	tenantID := evt.After.TenantID
 
+	release, err := h.lanes.Acquire(ctx, h.lane)
+	if err != nil {
+		return libkafka.Progress{Status: libkafka.ProgressError, Result: err.Error()}
+	}
+	defer release()
+
 	if err := h.docs.Process(ctx, id, tenantID); err != nil {
 		log.Printf("document_consumer: Process id=%d request_id=%s: %v", id, requestID, err)
 		return libkafka.Progress{Status: libkafka.ProgressError, Result: err.Error()}
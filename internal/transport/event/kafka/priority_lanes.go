@@ -0,0 +1,130 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+const (
+	defaultWorkerSlots  = 4
+	defaultWeightHigh   = 6
+	defaultWeightNormal = 3
+	defaultWeightLow    = 1
+)
+
+// LaneWeights bobot slot per lane; 0 memakai default 6 / 3 / 1.
+type LaneWeights struct {
+	High, Normal, Low int
+}
+
+// PriorityLanes membagi slot render worker ke consumer lane HIGH / NORMAL / LOW.
+// Selama ada slot kosong semua lane jalan; saat lane berebut, slot yang lepas diberikan dengan
+// smooth weighted round-robin sehingga lane HIGH didahulukan tanpa membuat lane LOW kelaparan.
+type PriorityLanes struct {
+	mu      sync.Mutex
+	free    int
+	lanes   []enums.DocumentPriority
+	weight  map[enums.DocumentPriority]int
+	current map[enums.DocumentPriority]int
+	waiting map[enums.DocumentPriority][]chan struct{}
+}
+
+func NewPriorityLanes(slots int, w LaneWeights) *PriorityLanes {
+	if slots <= 0 {
+		slots = defaultWorkerSlots
+	}
+	if w.High <= 0 {
+		w.High = defaultWeightHigh
+	}
+	if w.Normal <= 0 {
+		w.Normal = defaultWeightNormal
+	}
+	if w.Low <= 0 {
+		w.Low = defaultWeightLow
+	}
+	return &PriorityLanes{
+		free:  slots,
+		lanes: []enums.DocumentPriority{enums.DocumentPriorityHigh, enums.DocumentPriorityNormal, enums.DocumentPriorityLow},
+		weight: map[enums.DocumentPriority]int{
+			enums.DocumentPriorityHigh:   w.High,
+			enums.DocumentPriorityNormal: w.Normal,
+			enums.DocumentPriorityLow:    w.Low,
+		},
+		current: map[enums.DocumentPriority]int{},
+		waiting: map[enums.DocumentPriority][]chan struct{}{},
+	}
+}
+
+// Acquire menunggu slot untuk lane; release wajib dipanggil setelah render selesai.
+func (l *PriorityLanes) Acquire(ctx context.Context, lane enums.DocumentPriority) (release func(), err error) {
+	l.mu.Lock()
+	if l.free > 0 && l.queued() == 0 {
+		l.free--
+		l.mu.Unlock()
+		return l.release, nil
+	}
+	ready := make(chan struct{})
+	l.waiting[lane] = append(l.waiting[lane], ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return l.release, nil
+	case <-ctx.Done():
+	}
+	l.mu.Lock()
+	for i, ch := range l.waiting[lane] {
+		if ch == ready {
+			l.waiting[lane] = append(l.waiting[lane][:i], l.waiting[lane][i+1:]...)
+			l.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+	l.mu.Unlock()
+	// Slot sudah terlanjur diberikan bersamaan dengan cancel: teruskan ke antrean berikutnya.
+	l.release()
+	return nil, ctx.Err()
+}
+
+func (l *PriorityLanes) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lane, ok := l.next()
+	if !ok {
+		l.free++
+		return
+	}
+	ready := l.waiting[lane][0]
+	l.waiting[lane] = l.waiting[lane][1:]
+	close(ready)
+}
+
+// next memilih lane berikutnya di antara lane yang punya antrean (smooth weighted round-robin).
+func (l *PriorityLanes) next() (enums.DocumentPriority, bool) {
+	var best enums.DocumentPriority
+	total, found := 0, false
+	for _, lane := range l.lanes {
+		if len(l.waiting[lane]) == 0 {
+			continue
+		}
+		l.current[lane] += l.weight[lane]
+		total += l.weight[lane]
+		if !found || l.current[lane] > l.current[best] {
+			best, found = lane, true
+		}
+	}
+	if found {
+		l.current[best] -= total
+	}
+	return best, found
+}
+
+func (l *PriorityLanes) queued() int {
+	n := 0
+	for _, q := range l.waiting {
+		n += len(q)
+	}
+	return n
+}
@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+func TestPriorityLanesWeightedHandoff(t *testing.T) {
+	l := NewPriorityLanes(1, LaneWeights{High: 2, Normal: 1, Low: 1})
+	release, err := l.Acquire(context.Background(), enums.DocumentPriorityLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	granted := make(chan string, 8)
+	held := make(chan func(), 8)
+	queue := func(lane enums.DocumentPriority, n int) {
+		for i := 0; i < n; i++ {
+			go func() {
+				rel, err := l.Acquire(context.Background(), lane)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				granted <- string(lane)[:1]
+				// Slot dilepas oleh test agar urutan serah terima deterministik.
+				held <- rel
+			}()
+		}
+	}
+	queue(enums.DocumentPriorityHigh, 4)
+	queue(enums.DocumentPriorityNormal, 2)
+	queue(enums.DocumentPriorityLow, 2)
+	waitQueued(t, l, 8)
+
+	var order []string
+	release()
+	for i := 0; i < 8; i++ {
+		order = append(order, <-granted)
+		(<-held)()
+	}
+	if got := strings.Join(order, ""); got != "HNLHHNLH" {
+		t.Fatalf("unexpected grant order %s", got)
+	}
+}
+
+func TestPriorityLanesCancelWhileWaiting(t *testing.T) {
+	l := NewPriorityLanes(1, LaneWeights{})
+	release, _ := l.Acquire(context.Background(), enums.DocumentPriorityNormal)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.Acquire(ctx, enums.DocumentPriorityHigh)
+		done <- err
+	}()
+	waitQueued(t, l, 1)
+	cancel()
+	if err := <-done; err == nil {
+		t.Fatal("expected context error")
+	}
+
+	// Slot kembali ke pool setelah pemegang pertama selesai.
+	release()
+	rel, err := l.Acquire(context.Background(), enums.DocumentPriorityLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel()
+}
+
+func waitQueued(t *testing.T, l *PriorityLanes, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		q := l.queued()
+		l.mu.Unlock()
+		if q == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected %d waiters", n)
+}
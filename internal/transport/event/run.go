@@ -2,8 +2,10 @@ package event
 
 import (
 	"context"
+	"errors"
 
 	"go-document-generator/internal/config"
+	"go-document-generator/internal/entity/enums"
 	infrakafka "go-document-generator/internal/infrastructure/broker/kafka"
 	transportkafka "go-document-generator/internal/transport/event/kafka"
 	ucDoc "go-document-generator/internal/usecase/documents"
@@ -33,17 +35,48 @@ func RunOrder(ctx context.Context, cfg *config.Configuration) (interface{ Close(
 	return infrakafka.RunWithConfig(ctx, cfg, cfg.Kafka.GroupIDOrders, cfg.Kafka.TopicOrders, h)
 }
 
-// RunDocument menjalankan consumer Kafka untuk document-process topic.
+// RunDocument menjalankan consumer Kafka untuk topic document-process tiap lane prioritas.
 // Consumer ini memicu pipeline generation: QUEUED → PROCESSING → GENERATED/FAILED.
+// Ketiga lane berbagi slot render worker (generation.worker_slots) dengan bobot per lane.
 func RunDocument(ctx context.Context, cfg *config.Configuration, docs ucDoc.Service) (interface{ Close() error }, error) {
-	topic := cfg.Kafka.TopicDocumentProcess
-	if topic == "" {
-		topic = "document-process"
-	}
+	high, normal, low := cfg.Kafka.DocumentProcessTopics()
 	groupID := cfg.Kafka.GroupIDDocumentWorker
 	if groupID == "" {
 		groupID = "document-generator-worker"
 	}
-	h := transportkafka.NewDocumentProcessHandler(docs)
-	return infrakafka.RunWithConfig(ctx, cfg, groupID, topic, h)
+	g := cfg.Generation
+	lanes := transportkafka.NewPriorityLanes(g.WorkerSlots, transportkafka.LaneWeights{
+		High: g.PriorityWeightHigh, Normal: g.PriorityWeightNormal, Low: g.PriorityWeightLow,
+	})
+	// Lane NORMAL memakai group lama agar offset document-process yang sudah ada tetap berlaku.
+	subs := []struct {
+		lane         enums.DocumentPriority
+		group, topic string
+	}{
+		{enums.DocumentPriorityHigh, groupID + "-high", high},
+		{enums.DocumentPriorityNormal, groupID, normal},
+		{enums.DocumentPriorityLow, groupID + "-low", low},
+	}
+	var consumers multiCloser
+	for _, s := range subs {
+		h := transportkafka.NewDocumentProcessHandler(docs, lanes, s.lane)
+		c, err := infrakafka.RunWithConfig(ctx, cfg, s.group, s.topic, h)
+		if err != nil {
+			_ = consumers.Close()
+			return nil, err
+		}
+		consumers = append(consumers, c)
+	}
+	return consumers, nil
+}
+
+// multiCloser menutup beberapa consumer sekaligus.
+type multiCloser []interface{ Close() error }
+
+func (m multiCloser) Close() error {
+	var errs []error
+	for _, c := range m {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
 }
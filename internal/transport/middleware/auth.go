@@ -5,21 +5,30 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"go-document-generator/internal/shared/actor"
 	"go-document-generator/internal/shared/apperror"
 )
 
 // APIKeyAuth middleware validasi API key dari header Authorization atau X-API-Key.
 // Jika validKeys kosong, auth dinonaktifkan (dev mode).
+// Entri "nama=key" memberi nama actor; actor dari key yang cocok disimpan di context request.
+// Dev mode memakai header X-Actor sebagai actor (tidak terautentikasi).
 func APIKeyAuth(validKeys []string) echo.MiddlewareFunc {
 	if len(validKeys) == 0 {
 		return func(next echo.HandlerFunc) echo.HandlerFunc {
-			return next
+			return func(c echo.Context) error {
+				if name := c.Request().Header.Get(actor.HeaderDevActor); strings.TrimSpace(name) != "" {
+					setActor(c, name)
+				}
+				return next(c)
+			}
 		}
 	}
 
-	keySet := make(map[string]struct{}, len(validKeys))
+	keySet := make(map[string]string, len(validKeys))
 	for _, k := range validKeys {
-		keySet[strings.TrimSpace(k)] = struct{}{}
+		name, key := actor.ParseKey(k)
+		keySet[key] = name
 	}
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -28,14 +37,21 @@ func APIKeyAuth(validKeys []string) echo.MiddlewareFunc {
 			if key == "" {
 				return c.JSON(http.StatusUnauthorized, apperror.New("UNAUTHORIZED", "missing api key"))
 			}
-			if _, ok := keySet[key]; !ok {
+			name, ok := keySet[key]
+			if !ok {
 				return c.JSON(http.StatusUnauthorized, apperror.New("UNAUTHORIZED", "invalid api key"))
 			}
+			setActor(c, name)
 			return next(c)
 		}
 	}
 }
 
+func setActor(c echo.Context, name string) {
+	r := c.Request()
+	c.SetRequest(r.WithContext(actor.With(r.Context(), name)))
+}
+
 func extractKey(r *http.Request) string {
 	if v := r.Header.Get("X-API-Key"); v != "" {
 		return strings.TrimSpace(v)
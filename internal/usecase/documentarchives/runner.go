@@ -0,0 +1,211 @@
+package documentarchives
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	docrepo "go-document-generator/internal/repository/documents"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/archive"
+)
+
+const (
+	// jobsPerTick batas job yang diproses per tick.
+	jobsPerTick = 5
+	// staleAfter job PROCESSING tanpa heartbeat selama ini dianggap ditinggal worker dan diklaim ulang.
+	staleAfter = 10 * time.Minute
+	// heartbeatInterval jarak penyimpanan progress/heartbeat selama job berjalan.
+	heartbeatInterval = 5 * time.Second
+	// maxAttempts klaim maksimum sebelum job dianggap gagal (worker mati berulang kali).
+	maxAttempts = 3
+)
+
+func (s *service) RunDue(ctx context.Context, now time.Time) (int, error) {
+	done := 0
+	for i := 0; i < jobsPerTick; i++ {
+		job, ok, err := s.claim(ctx, now.UTC())
+		if err != nil || !ok {
+			return done, err
+		}
+		if err := s.process(ctx, job); err != nil {
+			return done, err
+		}
+		done++
+		now = time.Now()
+	}
+	return done, nil
+}
+
+// claim mengunci satu job (SKIP LOCKED, aman lintas replika) dan menandainya PROCESSING.
+// Job yang sudah diklaim maxAttempts kali langsung difinalisasi FAILED.
+func (s *service) claim(ctx context.Context, now time.Time) (_ archiveEntity.Job, ok bool, err error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return archiveEntity.Job{}, false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	for {
+		var job archiveEntity.Job
+		job, err = s.jobs.ClaimNext(ctx, tx, now.Add(-staleAfter))
+		if errors.Is(err, apperror.ErrNotFound) {
+			return archiveEntity.Job{}, false, s.txManager.Commit(ctx, tx)
+		}
+		if err != nil {
+			return archiveEntity.Job{}, false, err
+		}
+		if job.Attempts >= maxAttempts {
+			if err = s.jobs.Finish(ctx, tx, failed(job, "worker stopped while processing", now)); err != nil {
+				return archiveEntity.Job{}, false, err
+			}
+			continue
+		}
+		if err = s.jobs.Start(ctx, tx, job.ID, now); err != nil {
+			return archiveEntity.Job{}, false, err
+		}
+		return job, true, s.txManager.Commit(ctx, tx)
+	}
+}
+
+// process me-resolve dokumen job lalu membuat output. Kegagalan output dicatat pada job (FAILED);
+// error yang dikembalikan hanya kegagalan menyimpan status job.
+func (s *service) process(ctx context.Context, job archiveEntity.Job) error {
+	ids := job.DocumentIDs
+	if ids == nil {
+		var err error
+		if ids, err = s.resolve(ctx, job); err != nil {
+			return s.jobs.Finish(ctx, nil, failed(job, err.Error(), time.Now().UTC()))
+		}
+		if err := s.jobs.SetDocuments(ctx, nil, job.ID, ids); err != nil {
+			return err
+		}
+	}
+
+	var done atomic.Int64
+	progress := func(n int) { done.Store(int64(n)) }
+	stop := s.heartbeat(ctx, job.ID, &done)
+	label := fmt.Sprintf("archive-%d", job.ID)
+	if job.Label != nil {
+		label = *job.Label
+	}
+
+	var path, format string
+	var err error
+	if job.Kind == enums.ArchiveJobKindMerge {
+		path, err = s.archiver.MergeToStorage(ctx, ids, job.TenantID, label, progress)
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	} else {
+		var opts archive.Options
+		if opts, err = s.openOptions(job.Options); err == nil {
+			path, err = s.archiver.ZipToStorage(ctx, ids, job.TenantID, label, opts, progress)
+		}
+		format = job.Options.Extension()
+	}
+	stop()
+	now := time.Now().UTC()
+	if err != nil {
+		log.Printf("documentarchives: job %d: %s: %v", job.ID, job.Kind, err)
+		return s.jobs.Finish(ctx, nil, failed(job, err.Error(), now))
+	}
+	job.Status = enums.ArchiveJobStatusCompleted
+	job.Processed = len(ids)
+	job.Options.Password = ""
+	job.OutputPath, job.OutputFormat = &path, &format
+	job.CompletedAt = &now
+	return s.jobs.Finish(ctx, nil, job)
+}
+
+// openOptions membuka password ZIP yang disegel saat job dibuat.
+func (s *service) openOptions(opts archive.Options) (archive.Options, error) {
+	if opts.Password == "" {
+		return opts, nil
+	}
+	if s.sealer == nil {
+		return opts, errors.New("zip password cannot be opened: no password secret configured")
+	}
+	pw, err := s.sealer.Open(opts.Password)
+	if err != nil {
+		return opts, err
+	}
+	opts.Password = pw
+	return opts, nil
+}
+
+// heartbeat memperbarui heartbeat job setiap heartbeatInterval selama download, pembuatan arsip
+// dan upload berjalan, agar job tidak dianggap basi (staleAfter) dan diklaim worker lain.
+// Fungsi yang dikembalikan menghentikan heartbeat dan menunggu goroutine selesai.
+func (s *service) heartbeat(ctx context.Context, jobID int64, done *atomic.Int64) func() {
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(heartbeatInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-quit:
+				return
+			case <-ctx.Done():
+				return
+			case now := <-t.C:
+				if err := s.jobs.Heartbeat(ctx, nil, jobID, int(done.Load()), now.UTC()); err != nil {
+					log.Printf("documentarchives: job %d: heartbeat: %v", jobID, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(quit)
+		wg.Wait()
+	}
+}
+
+// resolve memilih dokumen GENERATED milik tenant job yang cocok dengan filter.
+func (s *service) resolve(ctx context.Context, job archiveEntity.Job) ([]int64, error) {
+	f := job.Filter
+	if f == nil {
+		return nil, errors.New("job has neither ids nor filter")
+	}
+	ids, err := s.docs.ListIDs(ctx, nil, docrepo.ListFilter{
+		TenantID:     job.TenantID,
+		Status:       enums.DocumentStatusGenerated,
+		TemplateCode: f.TemplateCode,
+		Priority:     f.Priority,
+		CreatedFrom:  f.CreatedFrom,
+		CreatedTo:    f.CreatedTo,
+	}, MaxDocuments+1)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(ids) == 0:
+		return nil, errors.New("no generated documents match the filter")
+	case len(ids) > MaxDocuments:
+		return nil, fmt.Errorf("more than %d documents match the filter; narrow it down", MaxDocuments)
+	case job.Kind == enums.ArchiveJobKindMerge && len(ids) < 2:
+		return nil, errors.New("merge needs at least 2 documents; only 1 matches the filter")
+	}
+	return ids, nil
+}
+
+func failed(job archiveEntity.Job, msg string, now time.Time) archiveEntity.Job {
+	job.Status = enums.ArchiveJobStatusFailed
+	job.Options.Password = ""
+	job.ErrorMessage = &msg
+	job.CompletedAt = &now
+	return job
+}
@@ -0,0 +1,200 @@
+package documentarchives
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	archiveEntity "go-document-generator/internal/entity/documentarchives"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/repository/begin"
+	archiverepo "go-document-generator/internal/repository/documentarchives"
+	docrepo "go-document-generator/internal/repository/documents"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/archive"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/usecase/documents"
+)
+
+// MaxDocuments jumlah dokumen maksimum per job; arsip ditulis ke file sementara worker.
+const MaxDocuments = 10000
+
+// Archiver bagian documents.Service yang membuat output job.
+type Archiver interface {
+	ZipToStorage(ctx context.Context, ids []int64, tenantID *string, label string, opts archive.Options, progress documents.ProgressFunc) (string, error)
+	MergeToStorage(ctx context.Context, ids []int64, tenantID *string, label string, progress documents.ProgressFunc) (string, error)
+}
+
+// Presigner membuat URL download output job.
+type Presigner interface {
+	PresignedURL(ctx context.Context, path string, ttl time.Duration) (string, error)
+}
+
+// CreateInput job baru: isi DocumentIDs atau Filter, tidak keduanya. Options hanya untuk ZIP.
+type CreateInput struct {
+	TenantID    *string
+	Kind        enums.ArchiveJobKind
+	DocumentIDs []int64
+	Filter      *archiveEntity.Filter
+	Options     archive.Options
+	Label       *string
+	CreatedBy   *string
+}
+
+type Service interface {
+	// Create menyimpan job QUEUED dan mengembalikan segera; output dibuat oleh RunDue.
+	Create(ctx context.Context, in CreateInput) (archiveEntity.Job, error)
+	GetByID(ctx context.Context, id int64, tenantID *string) (archiveEntity.Job, error)
+	List(ctx context.Context, f archiverepo.ListFilter) ([]archiveEntity.Job, pagination.Meta, error)
+	// DownloadURL URL presigned output job yang COMPLETED.
+	DownloadURL(ctx context.Context, id int64, tenantID *string) (string, error)
+	// RunDue dipanggil scheduler: memproses job QUEUED satu per satu, mengembalikan jumlah job selesai.
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type service struct {
+	jobs      archiverepo.DocumentArchivesRepository
+	docs      docrepo.DocumentsRepository
+	txManager begin.BeginRepository
+	archiver  Archiver
+	storage   Presigner
+	// sealer menyegel password ZIP sebelum options disimpan; nil berarti password ZIP tidak didukung.
+	sealer *pdfsecurity.Sealer
+}
+
+func NewService(
+	jobs archiverepo.DocumentArchivesRepository,
+	docs docrepo.DocumentsRepository,
+	tx begin.BeginRepository,
+	archiver Archiver,
+	storage Presigner,
+	sealer *pdfsecurity.Sealer,
+) Service {
+	return &service{
+		jobs:      jobs,
+		docs:      docs,
+		txManager: tx,
+		archiver:  archiver,
+		storage:   storage,
+		sealer:    sealer,
+	}
+}
+
+func (s *service) Create(ctx context.Context, in CreateInput) (archiveEntity.Job, error) {
+	switch in.Kind {
+	case enums.ArchiveJobKindZip, enums.ArchiveJobKindMerge:
+	default:
+		return archiveEntity.Job{}, fmt.Errorf("%w: invalid kind %q", apperror.ErrInvalidInput, in.Kind)
+	}
+	if (len(in.DocumentIDs) == 0) == (in.Filter == nil) {
+		return archiveEntity.Job{}, fmt.Errorf("%w: exactly one of ids or filter is required", apperror.ErrInvalidInput)
+	}
+	ids := dedupe(in.DocumentIDs)
+	if len(ids) > MaxDocuments {
+		return archiveEntity.Job{}, fmt.Errorf("%w: at most %d documents per job", apperror.ErrInvalidInput, MaxDocuments)
+	}
+	if in.Kind == enums.ArchiveJobKindMerge && ids != nil && len(ids) < 2 {
+		return archiveEntity.Job{}, fmt.Errorf("%w: merge needs at least 2 documents", apperror.ErrInvalidInput)
+	}
+	if in.Kind == enums.ArchiveJobKindMerge && in.Options != (archive.Options{}) {
+		return archiveEntity.Job{}, fmt.Errorf("%w: options are only supported for zip", apperror.ErrInvalidInput)
+	}
+	if err := in.Options.Validate(); err != nil {
+		return archiveEntity.Job{}, fmt.Errorf("%w: options: %v", apperror.ErrInvalidInput, err)
+	}
+	if in.Options.Password != "" {
+		if s.sealer == nil {
+			return archiveEntity.Job{}, fmt.Errorf("%w: zip password is not enabled", apperror.ErrInvalidInput)
+		}
+		sealed, err := s.sealer.Seal(in.Options.Password)
+		if err != nil {
+			return archiveEntity.Job{}, err
+		}
+		in.Options.Password = sealed
+	}
+	if f := in.Filter; f != nil {
+		if f.Priority != "" && !documents.ValidPriority(f.Priority) {
+			return archiveEntity.Job{}, fmt.Errorf("%w: invalid priority %q", apperror.ErrInvalidInput, f.Priority)
+		}
+		if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedTo.Before(*f.CreatedFrom) {
+			return archiveEntity.Job{}, fmt.Errorf("%w: created_to is before created_from", apperror.ErrInvalidInput)
+		}
+		f.TemplateCode = strings.TrimSpace(f.TemplateCode)
+	}
+	if in.Label != nil {
+		label := strings.TrimSpace(*in.Label)
+		if len(label) > 150 {
+			return archiveEntity.Job{}, fmt.Errorf("%w: label exceeds 150 characters", apperror.ErrInvalidInput)
+		}
+		in.Label = &label
+		if label == "" {
+			in.Label = nil
+		}
+	}
+	created, err := s.jobs.Create(ctx, nil, archiveEntity.Job{
+		TenantID:    in.TenantID,
+		Kind:        in.Kind,
+		Status:      enums.ArchiveJobStatusQueued,
+		Label:       in.Label,
+		DocumentIDs: ids,
+		Filter:      in.Filter,
+		Options:     in.Options,
+		Total:       len(ids),
+		CreatedBy:   in.CreatedBy,
+	})
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetByID(ctx context.Context, id int64, tenantID *string) (archiveEntity.Job, error) {
+	j, err := s.jobs.GetByID(ctx, nil, id, tenantID)
+	return j, mapRepoErr(err)
+}
+
+func (s *service) List(ctx context.Context, f archiverepo.ListFilter) ([]archiveEntity.Job, pagination.Meta, error) {
+	f.Page = pagination.Normalize(f.Page.Page, f.Page.Limit)
+	items, total, err := s.jobs.List(ctx, nil, f)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return items, pagination.Meta{Page: f.Page.Page, Limit: f.Page.Limit, Total: total}, nil
+}
+
+func (s *service) DownloadURL(ctx context.Context, id int64, tenantID *string) (string, error) {
+	j, err := s.jobs.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return "", mapRepoErr(err)
+	}
+	if j.Status != enums.ArchiveJobStatusCompleted || j.OutputPath == nil {
+		return "", fmt.Errorf("%w: archive job is %s", apperror.ErrInvalidState, j.Status)
+	}
+	return s.storage.PresignedURL(ctx, *j.OutputPath, 15*time.Minute)
+}
+
+// dedupe membuang id ganda dengan mempertahankan urutan; nil untuk input kosong.
+func dedupe(ids []int64) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+	seen := make(map[int64]bool, len(ids))
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package documentbatches
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/usecase/documentcallbackattempts"
+)
+
+const (
+	callbackBatch       = 20
+	maxCallbackAttempts = 5
+	callbackBackoff     = time.Minute
+	// callbackOutputTTL masa berlaku output_url di body callback.
+	callbackOutputTTL = time.Hour
+	// callbackLease jeda sebelum callback yang sedang dikirim boleh diklaim ulang (proses mati di tengah kirim).
+	callbackLease = 5 * time.Minute
+)
+
+// CallbackPayload body callback batch.
+type CallbackPayload struct {
+	BatchID     int64                 `json:"batch_id"`
+	TenantID    *string               `json:"tenant_id,omitempty"`
+	Name        *string               `json:"name,omitempty"`
+	Status      enums.BatchStatus     `json:"status"`
+	Progress    batchEntity.Progress  `json:"progress"`
+	PostAction  enums.BatchPostAction `json:"post_action"`
+	OutputURL   string                `json:"output_url,omitempty"`
+	OutputError *string               `json:"output_error,omitempty"`
+	Metadata    map[string]any        `json:"metadata,omitempty"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
+
+// deliverCallbacks mengirim satu callback per batch final. Gagal dicoba ulang dengan backoff
+// eksponensial sampai maxCallbackAttempts, lalu FAILED. Request HTTP dikirim di luar transaksi.
+func (s *service) deliverCallbacks(ctx context.Context, now time.Time) error {
+	due, err := s.claimCallbacks(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, b := range due {
+		attempts := b.CallbackAttempts
+		status, next := enums.CallbackStatusSuccess, (*time.Time)(nil)
+		if sendErr := s.sendCallback(ctx, b); sendErr != nil {
+			log.Printf("documentbatches: batch %d: callback attempt %d: %v", b.ID, attempts, sendErr)
+			status = enums.CallbackStatusFailed
+			if attempts < maxCallbackAttempts {
+				at := now.Add(callbackBackoff << (attempts - 1))
+				status, next = enums.CallbackStatusRetrying, &at
+			}
+		}
+		if err := s.batches.UpdateCallback(ctx, nil, b.ID, status, attempts, next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claimCallbacks mengunci callback jatuh tempo, mencatat percobaan dan menggeser next_callback_at
+// sejauh callbackLease, lalu commit; replika lain tidak mengirim ulang selama lease berlaku.
+func (s *service) claimCallbacks(ctx context.Context, now time.Time) (due []batchEntity.Batch, err error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	due, err = s.batches.ClaimCallbacks(ctx, tx, now, callbackBatch)
+	if err != nil {
+		return nil, err
+	}
+	lease := now.Add(callbackLease)
+	for i := range due {
+		due[i].CallbackAttempts++
+		if err = s.batches.UpdateCallback(ctx, tx, due[i].ID, due[i].CallbackStatus, due[i].CallbackAttempts, &lease); err != nil {
+			return nil, err
+		}
+	}
+	return due, s.txManager.Commit(ctx, tx)
+}
+
+func (s *service) sendCallback(ctx context.Context, b batchEntity.Batch) error {
+	progress, err := s.batches.Progress(ctx, nil, []int64{b.ID})
+	if err != nil {
+		return err
+	}
+	payload := CallbackPayload{
+		BatchID: b.ID, TenantID: b.TenantID, Name: b.Name, Status: b.Status,
+		Progress: progress[b.ID], PostAction: b.PostAction, OutputError: b.OutputError,
+		Metadata: b.Metadata, CompletedAt: b.CompletedAt,
+	}
+	if b.OutputPath != nil {
+		if payload.OutputURL, err = s.storage.PresignedURL(ctx, *b.OutputPath, callbackOutputTTL); err != nil {
+			return err
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *b.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.hmacSecret != "" {
+		req.Header.Set(documentcallbackattempts.SignatureHeader, documentcallbackattempts.ComputeHMAC(body, s.hmacSecret))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", *b.CallbackURL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package documentbatches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/archive"
+	"go-document-generator/internal/usecase/documents"
+)
+
+const (
+	// submitChunk item yang diklaim dan dibuat per transaksi.
+	submitChunk = 100
+	// submitChunksPerTick batas potongan per tick agar finalisasi dan callback tetap berjalan.
+	submitChunksPerTick = 10
+	// settleBatch batch terbuka yang diperiksa per tick.
+	settleBatch = 50
+	// cancelChunk dokumen QUEUED yang dibatalkan per batch per tick.
+	cancelChunk = 500
+	// maxArchiveItems batas dokumen untuk ZIP / merge otomatis (ZIP ditulis ke file sementara worker).
+	maxArchiveItems = 10000
+	// settleLease lama batch ditahan selama pembatalan / pembuatan output di luar transaksi;
+	// bila proses mati, batch diklaim ulang setelahnya.
+	settleLease = 15 * time.Minute
+	// submitLease lama item ditahan selama dokumennya dibuat di luar transaksi klaim; bila proses
+	// mati, item diklaim ulang setelahnya dan request_id yang sama membuat Create menjadi replay.
+	submitLease = 5 * time.Minute
+)
+
+func (s *service) RunDue(ctx context.Context, now time.Time) (int, error) {
+	now = now.UTC()
+	submitted := 0
+	for i := 0; i < submitChunksPerTick; i++ {
+		n, err := s.submitNext(ctx, now)
+		submitted += n
+		if err != nil {
+			return submitted, err
+		}
+		if n < submitChunk {
+			break
+		}
+	}
+	if err := s.settle(ctx, now); err != nil {
+		return submitted, err
+	}
+	return submitted, s.deliverCallbacks(ctx, now)
+}
+
+// submitNext mengklaim satu potongan item PENDING (SKIP LOCKED, aman lintas replika) dan memasang
+// lease dalam transaksi singkat, lalu membuat dokumennya lewat BulkCreate di luar transaksi.
+func (s *service) submitNext(ctx context.Context, now time.Time) (int, error) {
+	items, tenants, err := s.claimItems(ctx, now)
+	if err != nil || len(items) == 0 {
+		return 0, err
+	}
+
+	inputs := make([]documents.CreateInput, len(items))
+	for i, it := range items {
+		metadata := make(map[string]any, len(it.Input.Metadata)+1)
+		for k, v := range it.Input.Metadata {
+			metadata[k] = v
+		}
+		metadata["batch_id"] = it.BatchID
+		inputs[i] = documents.CreateInput{
+			TenantID:        tenants[it.BatchID],
+			RequestID:       it.RequestID,
+			TemplateCode:    it.Input.TemplateCode,
+			TemplateVersion: it.Input.TemplateVersion,
+			OutputFormat:    it.Input.OutputFormat,
+			Locale:          it.Input.Locale,
+			Priority:        it.Input.Priority,
+			Payload:         it.Input.Payload,
+			Metadata:        metadata,
+			StoreToDms:      it.Input.StoreToDms,
+			ExpiredAt:       it.Input.ExpiredAt,
+		}
+	}
+
+	results := s.docs.BulkCreate(ctx, inputs)
+	for i, r := range results {
+		it := items[i]
+		if r.Err != nil {
+			msg := r.Err.Error()
+			it.Status, it.ErrorMessage = enums.BatchItemStatusRejected, &msg
+		} else {
+			id := r.Doc.ID
+			it.Status, it.DocumentID = enums.BatchItemStatusSubmitted, &id
+		}
+		if err := s.batches.UpdateItem(ctx, nil, it); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// claimItems mengunci item PENDING, memasang lease submitLease dan membaca tenant batch-nya.
+func (s *service) claimItems(ctx context.Context, now time.Time) (items []batchEntity.Item, tenants map[int64]*string, err error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	items, err = s.batches.ClaimPendingItems(ctx, tx, now, submitChunk)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil, s.txManager.Commit(ctx, tx)
+	}
+	ids := make([]int64, len(items))
+	tenants = make(map[int64]*string)
+	for i, it := range items {
+		ids[i] = it.ID
+		if _, ok := tenants[it.BatchID]; ok {
+			continue
+		}
+		b, err := s.batches.GetByID(ctx, tx, it.BatchID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		tenants[it.BatchID] = b.TenantID
+	}
+	if err = s.batches.LeaseItems(ctx, tx, ids, now.Add(submitLease)); err != nil {
+		return nil, nil, err
+	}
+	return items, tenants, s.txManager.Commit(ctx, tx)
+}
+
+// settle memeriksa batch RUNNING / CANCELLING: batch CANCELLING membatalkan dokumen QUEUED-nya,
+// dan batch tanpa item terbuka difinalisasi (output ZIP / merge lalu status final).
+// Klaim hanya memasang lease; pembatalan dan pembuatan output berjalan setelah commit.
+func (s *service) settle(ctx context.Context, now time.Time) error {
+	claimed, err := s.claimSettle(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, b := range claimed {
+		if b.Status == enums.BatchStatusCancelling {
+			// Item yang lease submit-nya habis (proses mati) tidak akan diklaim lagi dari batch CANCELLING.
+			if _, err := s.batches.CancelPendingItems(ctx, nil, b.ID, now); err != nil {
+				return err
+			}
+			if err := s.cancelQueued(ctx, b); err != nil {
+				return err
+			}
+			progress, err := s.batches.Progress(ctx, nil, []int64{b.ID})
+			if err != nil {
+				return err
+			}
+			if progress[b.ID].Open() > 0 {
+				// Masih ada dokumen yang sedang diproses; diperiksa lagi tick berikutnya.
+				if err := s.batches.Lease(ctx, nil, b.ID, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			b.Progress = progress[b.ID]
+		}
+		if err := s.batches.Complete(ctx, nil, s.finalize(ctx, b, now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claimSettle mengunci batch terbuka dan memasang lease pada batch yang perlu dikerjakan:
+// CANCELLING, atau tanpa item terbuka (siap difinalisasi).
+func (s *service) claimSettle(ctx context.Context, now time.Time) (claimed []batchEntity.Batch, err error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	open, err := s.batches.ClaimOpen(ctx, tx, now, settleBatch)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) == 0 {
+		return nil, s.txManager.Commit(ctx, tx)
+	}
+	ids := make([]int64, len(open))
+	for i, b := range open {
+		ids[i] = b.ID
+	}
+	progress, err := s.batches.Progress(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	lease := now.Add(settleLease)
+	for _, b := range open {
+		if b.Status != enums.BatchStatusCancelling && progress[b.ID].Open() > 0 {
+			continue
+		}
+		if err = s.batches.Lease(ctx, tx, b.ID, &lease); err != nil {
+			return nil, err
+		}
+		b.Progress = progress[b.ID]
+		claimed = append(claimed, b)
+	}
+	return claimed, s.txManager.Commit(ctx, tx)
+}
+
+func (s *service) cancelQueued(ctx context.Context, b batchEntity.Batch) error {
+	ids, err := s.batches.DocumentIDsByStatus(ctx, nil, b.ID, enums.DocumentStatusQueued, cancelChunk)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		// Dokumen bisa sudah diambil worker; kegagalan transisi cukup dicatat.
+		if _, err := s.docs.Cancel(ctx, id, b.TenantID); err != nil {
+			log.Printf("documentbatches: batch %d: cancel document %d: %v", b.ID, id, err)
+		}
+	}
+	return nil
+}
+
+// finalize menentukan status final batch dan membuat output ZIP / merge dari dokumen GENERATED.
+// Kegagalan output dicatat di output_error; batch tetap selesai.
+func (s *service) finalize(ctx context.Context, b batchEntity.Batch, now time.Time) batchEntity.Batch {
+	b.CompletedAt = &now
+	if b.Status == enums.BatchStatusCancelling {
+		b.Status = enums.BatchStatusCancelled
+		return b
+	}
+	b.Status = enums.BatchStatusCompleted
+	if b.PostAction == enums.BatchPostActionNone || b.PostAction == "" {
+		return b
+	}
+	path, err := s.buildOutput(ctx, b)
+	if err != nil {
+		log.Printf("documentbatches: batch %d: %s output: %v", b.ID, b.PostAction, err)
+		msg := err.Error()
+		b.OutputError = &msg
+		return b
+	}
+	b.OutputPath = &path
+	return b
+}
+
+func (s *service) buildOutput(ctx context.Context, b batchEntity.Batch) (string, error) {
+	ids, err := s.batches.DocumentIDsByStatus(ctx, nil, b.ID, enums.DocumentStatusGenerated, maxArchiveItems+1)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case len(ids) == 0:
+		return "", errors.New("no generated documents")
+	case len(ids) > maxArchiveItems:
+		return "", fmt.Errorf("more than %d generated documents; download them individually", maxArchiveItems)
+	}
+	label := fmt.Sprintf("batch-%d", b.ID)
+	if b.PostAction == enums.BatchPostActionMerge {
+		return s.docs.MergeToStorage(ctx, ids, b.TenantID, label, nil)
+	}
+	return s.docs.ZipToStorage(ctx, ids, b.TenantID, label, archive.Options{}, nil)
+}
@@ -0,0 +1,334 @@
+package documentbatches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/repository/begin"
+	batchrepo "go-document-generator/internal/repository/documentbatches"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/archive"
+	"go-document-generator/internal/shared/netguard"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/usecase/documents"
+)
+
+// MaxItems jumlah item maksimum per batch.
+const MaxItems = 500000
+
+// Documents bagian documents.Service yang dipakai batch.
+type Documents interface {
+	BulkCreate(ctx context.Context, inputs []documents.CreateInput) []documents.BulkCreateItem
+	Cancel(ctx context.Context, id int64, tenantID *string) (docEntity.Document, error)
+	ZipToStorage(ctx context.Context, ids []int64, tenantID *string, label string, opts archive.Options, progress documents.ProgressFunc) (string, error)
+	MergeToStorage(ctx context.Context, ids []int64, tenantID *string, label string, progress documents.ProgressFunc) (string, error)
+}
+
+// Presigner membuat URL download untuk output batch.
+type Presigner interface {
+	PresignedURL(ctx context.Context, path string, ttl time.Duration) (string, error)
+}
+
+// CreateInput batch baru. Field kosong pada item diisi dari Defaults (kecuali Payload dan Metadata).
+type CreateInput struct {
+	TenantID    *string
+	Name        *string
+	Defaults    batchEntity.ItemInput
+	Items       []CreateItem
+	PostAction  enums.BatchPostAction
+	Metadata    map[string]any
+	HasCallback bool
+	CallbackURL *string
+	CreatedBy   *string
+}
+
+// CreateItem satu item; RequestID kosong menjadi batch-<batch_id>-<seq>.
+type CreateItem struct {
+	RequestID string
+	Input     batchEntity.ItemInput
+}
+
+type Service interface {
+	// Create menyimpan batch dan itemnya lalu mengembalikan segera; item dibuat async oleh RunDue.
+	Create(ctx context.Context, in CreateInput) (batchEntity.Batch, error)
+	GetByID(ctx context.Context, id int64, tenantID *string) (batchEntity.Batch, error)
+	List(ctx context.Context, f batchrepo.ListFilter) ([]batchEntity.Batch, pagination.Meta, error)
+	ListItems(ctx context.Context, id int64, tenantID *string, f batchrepo.ItemFilter) ([]batchEntity.Item, pagination.Meta, error)
+	// Cancel membatalkan item yang belum dibuat; dokumen yang masih QUEUED dibatalkan oleh RunDue.
+	Cancel(ctx context.Context, id int64, tenantID *string) (batchEntity.Batch, error)
+	// DownloadURL URL presigned output ZIP / merge batch yang sudah selesai.
+	DownloadURL(ctx context.Context, id int64, tenantID *string) (string, error)
+	// RunDue dipanggil scheduler: membuat dokumen item PENDING, menyelesaikan batch yang semua
+	// itemnya final, dan mengirim callback. Mengembalikan jumlah item yang dibuat.
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type service struct {
+	batches    batchrepo.DocumentBatchesRepository
+	txManager  begin.BeginRepository
+	docs       Documents
+	storage    Presigner
+	client     *http.Client
+	guard      netguard.Guard
+	hmacSecret string
+}
+
+func NewService(
+	batches batchrepo.DocumentBatchesRepository,
+	tx begin.BeginRepository,
+	docs Documents,
+	storage Presigner,
+	hmacSecret string,
+	callbackHosts []string,
+) Service {
+	// Callback URL berasal dari user: hanya alamat publik kecuali host di callbackHosts.
+	guard := netguard.Guard{Allowed: callbackHosts}
+	return &service{
+		batches:    batches,
+		txManager:  tx,
+		docs:       docs,
+		storage:    storage,
+		client:     guard.Client(15 * time.Second),
+		guard:      guard,
+		hmacSecret: hmacSecret,
+	}
+}
+
+func (s *service) Create(ctx context.Context, in CreateInput) (_ batchEntity.Batch, err error) {
+	if len(in.Items) == 0 || len(in.Items) > MaxItems {
+		return batchEntity.Batch{}, fmt.Errorf("%w: items must contain 1..%d entries", apperror.ErrInvalidInput, MaxItems)
+	}
+	if in.PostAction == "" {
+		in.PostAction = enums.BatchPostActionNone
+	}
+	switch in.PostAction {
+	case enums.BatchPostActionNone, enums.BatchPostActionZip, enums.BatchPostActionMerge:
+	default:
+		return batchEntity.Batch{}, fmt.Errorf("%w: invalid post_action %q", apperror.ErrInvalidInput, in.PostAction)
+	}
+	if in.HasCallback {
+		if in.CallbackURL == nil {
+			return batchEntity.Batch{}, fmt.Errorf("%w: callback_url is required when has_callback is true", apperror.ErrInvalidInput)
+		}
+		u, err := url.Parse(*in.CallbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return batchEntity.Batch{}, fmt.Errorf("%w: callback_url must be an absolute http(s) URL", apperror.ErrInvalidInput)
+		}
+		if err := s.guard.CheckHost(u.Hostname()); err != nil {
+			return batchEntity.Batch{}, fmt.Errorf("%w: callback_url: %v", apperror.ErrInvalidInput, err)
+		}
+	}
+	items := make([]batchEntity.Item, len(in.Items))
+	for i, it := range in.Items {
+		input := withDefaults(it.Input, in.Defaults)
+		if err := validateItem(input); err != nil {
+			return batchEntity.Batch{}, fmt.Errorf("item %d: %w", i, err)
+		}
+		requestID := strings.TrimSpace(it.RequestID)
+		if len(requestID) > 100 {
+			return batchEntity.Batch{}, fmt.Errorf("item %d: %w: request_id exceeds 100 characters", i, apperror.ErrInvalidInput)
+		}
+		items[i] = batchEntity.Item{
+			Seq:       i,
+			RequestID: requestID,
+			Input:     input,
+			Status:    enums.BatchItemStatusPending,
+		}
+	}
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return batchEntity.Batch{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+	created, err := s.batches.Create(ctx, tx, batchEntity.Batch{
+		TenantID:       in.TenantID,
+		Name:           in.Name,
+		Status:         enums.BatchStatusRunning,
+		TotalItems:     len(items),
+		PostAction:     in.PostAction,
+		Metadata:       in.Metadata,
+		HasCallback:    in.HasCallback,
+		CallbackURL:    in.CallbackURL,
+		CallbackStatus: enums.CallbackStatusPending,
+		CreatedBy:      in.CreatedBy,
+	})
+	if err != nil {
+		return batchEntity.Batch{}, err
+	}
+	for i := range items {
+		items[i].BatchID = created.ID
+		if items[i].RequestID == "" {
+			items[i].RequestID = ItemRequestID(created.ID, items[i].Seq)
+		}
+	}
+	if err = s.batches.CreateItems(ctx, tx, items); err != nil {
+		return batchEntity.Batch{}, err
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return batchEntity.Batch{}, err
+	}
+	created.Progress = batchEntity.Progress{Total: int64(len(items)), Pending: int64(len(items))}
+	return created, nil
+}
+
+// withDefaults mengisi field item yang kosong dari default batch.
+func withDefaults(in, def batchEntity.ItemInput) batchEntity.ItemInput {
+	if strings.TrimSpace(in.TemplateCode) == "" {
+		in.TemplateCode = def.TemplateCode
+	}
+	in.TemplateCode = strings.TrimSpace(in.TemplateCode)
+	if in.TemplateVersion == nil {
+		in.TemplateVersion = def.TemplateVersion
+	}
+	if in.OutputFormat == "" {
+		in.OutputFormat = def.OutputFormat
+	}
+	if in.Locale == nil {
+		in.Locale = def.Locale
+	}
+	if in.Priority == "" {
+		in.Priority = def.Priority
+	}
+	if in.ExpiredAt == nil {
+		in.ExpiredAt = def.ExpiredAt
+	}
+	in.StoreToDms = in.StoreToDms || def.StoreToDms
+	return in
+}
+
+// validateItem memeriksa hal yang bisa diperiksa tanpa DB; validasi template dan schema terjadi
+// saat item dibuat dan kegagalannya tercatat di item (REJECTED).
+func validateItem(in batchEntity.ItemInput) error {
+	if in.TemplateCode == "" {
+		return fmt.Errorf("%w: template_code is required", apperror.ErrInvalidInput)
+	}
+	if in.Payload == nil {
+		return fmt.Errorf("%w: payload is required", apperror.ErrInvalidInput)
+	}
+	if in.Priority != "" && !documents.ValidPriority(in.Priority) {
+		return fmt.Errorf("%w: invalid priority %q", apperror.ErrInvalidInput, in.Priority)
+	}
+	return nil
+}
+
+// ItemRequestID request_id default item: stabil sehingga pembuatan ulang setelah crash menjadi replay.
+func ItemRequestID(batchID int64, seq int) string {
+	return fmt.Sprintf("batch-%d-%d", batchID, seq)
+}
+
+func (s *service) GetByID(ctx context.Context, id int64, tenantID *string) (batchEntity.Batch, error) {
+	b, err := s.batches.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return batchEntity.Batch{}, mapRepoErr(err)
+	}
+	progress, err := s.batches.Progress(ctx, nil, []int64{b.ID})
+	if err != nil {
+		return batchEntity.Batch{}, err
+	}
+	b.Progress = progress[b.ID]
+	return b, nil
+}
+
+func (s *service) List(ctx context.Context, f batchrepo.ListFilter) ([]batchEntity.Batch, pagination.Meta, error) {
+	f.Page = pagination.Normalize(f.Page.Page, f.Page.Limit)
+	items, total, err := s.batches.List(ctx, nil, f)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	ids := make([]int64, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+	progress, err := s.batches.Progress(ctx, nil, ids)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	for i := range items {
+		items[i].Progress = progress[items[i].ID]
+	}
+	return items, pagination.Meta{Page: f.Page.Page, Limit: f.Page.Limit, Total: total}, nil
+}
+
+func (s *service) ListItems(ctx context.Context, id int64, tenantID *string, f batchrepo.ItemFilter) ([]batchEntity.Item, pagination.Meta, error) {
+	if _, err := s.batches.GetByID(ctx, nil, id, tenantID); err != nil {
+		return nil, pagination.Meta{}, mapRepoErr(err)
+	}
+	f.Page = pagination.Normalize(f.Page.Page, f.Page.Limit)
+	items, total, err := s.batches.ListItems(ctx, nil, id, f)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return items, pagination.Meta{Page: f.Page.Page, Limit: f.Page.Limit, Total: total}, nil
+}
+
+func (s *service) Cancel(ctx context.Context, id int64, tenantID *string) (_ batchEntity.Batch, err error) {
+	b, err := s.batches.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return batchEntity.Batch{}, mapRepoErr(err)
+	}
+	if b.Status != enums.BatchStatusRunning {
+		return batchEntity.Batch{}, fmt.Errorf("%w: batch is %s", apperror.ErrInvalidState, b.Status)
+	}
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return batchEntity.Batch{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+	if err = s.batches.SetStatus(ctx, tx, id, tenantID, enums.BatchStatusRunning, enums.BatchStatusCancelling); err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			// Selesai atau dibatalkan bersamaan.
+			return batchEntity.Batch{}, fmt.Errorf("%w: batch is no longer running", apperror.ErrInvalidState)
+		}
+		return batchEntity.Batch{}, err
+	}
+	// Item yang sedang di-lease submitNext tetap disubmit; dokumennya dibatalkan oleh settle.
+	if _, err = s.batches.CancelPendingItems(ctx, tx, id, time.Now().UTC()); err != nil {
+		return batchEntity.Batch{}, err
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return batchEntity.Batch{}, err
+	}
+	return s.GetByID(ctx, id, tenantID)
+}
+
+func (s *service) DownloadURL(ctx context.Context, id int64, tenantID *string) (string, error) {
+	b, err := s.batches.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return "", mapRepoErr(err)
+	}
+	if !b.Terminal() {
+		return "", fmt.Errorf("%w: batch is %s", apperror.ErrInvalidState, b.Status)
+	}
+	if b.OutputPath == nil {
+		return "", apperror.ErrNotFound
+	}
+	return s.storage.PresignedURL(ctx, *b.OutputPath, 15*time.Minute)
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
@@ -73,7 +73,7 @@ func (s *service) TestCallback(ctx context.Context, in TestCallbackInput) (TestC
 	}
 	req.Header.Set("Content-Type", "application/json")
 	if s.hmacSecret != "" {
-		req.Header.Set("X-Document-Signature", computeHMAC(body, s.hmacSecret))
+		req.Header.Set(SignatureHeader, ComputeHMAC(body, s.hmacSecret))
 	}
 
 	resp, err := s.client.Do(req)
@@ -94,7 +94,12 @@ func (s *service) TestCallback(ctx context.Context, in TestCallbackInput) (TestC
 	return result, nil
 }
 
-func computeHMAC(body []byte, secret string) string {
+// SignatureHeader header tanda tangan body callback.
+const SignatureHeader = "X-Document-Signature"
+
+// ComputeHMAC nilai SignatureHeader untuk body callback: "hmac-sha256=<hex>".
+// Dipakai semua pengirim callback (dokumen, batch) agar penerima cukup satu cara verifikasi.
+func ComputeHMAC(body []byte, secret string) string {
 	mac := hmac.New(sha256.New, []byte(secret))
 	mac.Write(body)
 	return "hmac-sha256=" + hex.EncodeToString(mac.Sum(nil))
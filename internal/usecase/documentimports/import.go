@@ -0,0 +1,247 @@
+package documentimports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/mailmerge"
+	"go-document-generator/internal/shared/validators"
+	"go-document-generator/internal/usecase/documentbatches"
+)
+
+const (
+	// MaxFileSize ukuran file import maksimum.
+	MaxFileSize = 50 << 20
+	// maxReportedErrors batas kesalahan baris yang dikembalikan; jumlah totalnya tetap dihitung.
+	maxReportedErrors  = 1000
+	maxRequestIDLength = 100
+)
+
+// ImportInput file import beserta opsi pembuatan dokumen. TemplateCode kosong memakai template
+// mapping; GroupBy / RequestIDColumn / RequestIDPrefix yang diisi menimpa nilai mapping.
+type ImportInput struct {
+	TenantID        *string
+	FileName        string
+	Data            []byte
+	Format          string // csv | jsonl; kosong dideteksi dari nama file dan isi
+	MappingID       *int64
+	TemplateCode    string
+	TemplateVersion *int // nil: versi published terbaru, lalu di-pin untuk seluruh baris
+	GroupBy         string
+	RequestIDColumn string
+	RequestIDPrefix string
+	DryRun          bool
+	Name            *string
+	OutputFormat    enums.OutputFormat
+	Locale          *string
+	Priority        enums.DocumentPriority
+	StoreToDms      bool
+	PostAction      enums.BatchPostAction
+	HasCallback     bool
+	CallbackURL     *string
+	CreatedBy       *string
+}
+
+// ImportResult ringkasan import. Rows jumlah baris file, Documents jumlah dokumen valid (satu dokumen
+// bisa berasal dari beberapa baris bila GroupBy diisi), InvalidRows jumlah baris yang gagal.
+type ImportResult struct {
+	Format          string
+	TemplateCode    string
+	TemplateVersion int
+	Rows            int
+	Documents       int
+	InvalidRows     int
+	Errors          []mailmerge.RowError
+	ErrorsTruncated bool
+	Batch           *batchEntity.Batch
+}
+
+func (s *service) Import(ctx context.Context, in ImportInput) (ImportResult, error) {
+	if len(in.Data) == 0 {
+		return ImportResult{}, fmt.Errorf("%w: file is empty", apperror.ErrInvalidInput)
+	}
+	if len(in.Data) > MaxFileSize {
+		return ImportResult{}, fmt.Errorf("%w: file exceeds %d bytes", apperror.ErrInvalidInput, MaxFileSize)
+	}
+
+	rules, templateCode, err := s.resolveRules(ctx, in)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	schema, version, err := s.resolveSchema(ctx, templateCode, in.TemplateVersion, in.TenantID)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	format := strings.ToLower(strings.TrimSpace(in.Format))
+	if format == "" {
+		format = mailmerge.DetectFormat(in.FileName, in.Data)
+	}
+	records, err := mailmerge.Read(in.Data, format, documentbatches.MaxItems)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+	}
+	if len(records) == 0 {
+		return ImportResult{}, fmt.Errorf("%w: file has no data rows", apperror.ErrInvalidInput)
+	}
+
+	rows, rowErrs := mailmerge.Build(records, rules)
+	valid := rows[:0]
+	for _, row := range rows {
+		if len(row.RequestID) > maxRequestIDLength {
+			rowErrs = append(rowErrs, mailmerge.RowError{Line: row.Lines[0], Column: rules.RequestIDColumn,
+				Message: fmt.Sprintf("request id exceeds %d characters", maxRequestIDLength)})
+			continue
+		}
+		if len(schema) > 0 {
+			if err := validators.ValidateSchema(schema, row.Payload); err != nil {
+				rowErrs = append(rowErrs, mailmerge.RowError{Line: row.Lines[0], Message: err.Error()})
+				continue
+			}
+		}
+		valid = append(valid, row)
+	}
+
+	sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Line < rowErrs[j].Line })
+	res := ImportResult{
+		Format:          format,
+		TemplateCode:    templateCode,
+		TemplateVersion: version,
+		Rows:            len(records),
+		Documents:       len(valid),
+		InvalidRows:     countLines(rowErrs),
+		Errors:          rowErrs,
+	}
+	if len(res.Errors) > maxReportedErrors {
+		res.Errors, res.ErrorsTruncated = res.Errors[:maxReportedErrors], true
+	}
+	if in.DryRun || len(valid) == 0 {
+		return res, nil
+	}
+
+	name := in.Name
+	if name == nil && in.FileName != "" {
+		name = &in.FileName
+	}
+	metadata := map[string]any{"import_file": in.FileName}
+	if in.MappingID != nil {
+		metadata["import_mapping_id"] = *in.MappingID
+	}
+	items := make([]documentbatches.CreateItem, len(valid))
+	for i, row := range valid {
+		items[i] = documentbatches.CreateItem{
+			RequestID: row.RequestID,
+			Input: batchEntity.ItemInput{
+				Payload:  row.Payload,
+				Metadata: map[string]any{"import_line": row.Lines[0]},
+			},
+		}
+	}
+	batch, err := s.batches.Create(ctx, documentbatches.CreateInput{
+		TenantID: in.TenantID,
+		Name:     name,
+		Defaults: batchEntity.ItemInput{
+			TemplateCode:    templateCode,
+			TemplateVersion: &version,
+			OutputFormat:    in.OutputFormat,
+			Locale:          in.Locale,
+			Priority:        in.Priority,
+			StoreToDms:      in.StoreToDms,
+		},
+		Items:       items,
+		PostAction:  in.PostAction,
+		Metadata:    metadata,
+		HasCallback: in.HasCallback,
+		CallbackURL: in.CallbackURL,
+		CreatedBy:   in.CreatedBy,
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	res.Batch = &batch
+	return res, nil
+}
+
+// resolveRules menggabungkan mapping tersimpan dengan override dari request.
+func (s *service) resolveRules(ctx context.Context, in ImportInput) (mailmerge.Mapping, string, error) {
+	var rules mailmerge.Mapping
+	templateCode := strings.TrimSpace(in.TemplateCode)
+	if in.MappingID != nil {
+		m, err := s.imports.GetMapping(ctx, nil, *in.MappingID, in.TenantID)
+		if err != nil {
+			if errors.Is(err, apperror.ErrNotFound) {
+				return rules, "", fmt.Errorf("%w: mapping %d not found", apperror.ErrInvalidInput, *in.MappingID)
+			}
+			return rules, "", err
+		}
+		rules = m.Rules()
+		if templateCode == "" && m.TemplateCode != nil {
+			templateCode = *m.TemplateCode
+		}
+	}
+	if v := strings.TrimSpace(in.GroupBy); v != "" {
+		rules.GroupBy = v
+	}
+	if v := strings.TrimSpace(in.RequestIDColumn); v != "" {
+		rules.RequestIDColumn = v
+	}
+	if v := strings.TrimSpace(in.RequestIDPrefix); v != "" {
+		rules.RequestIDPrefix = v
+	}
+	if templateCode == "" {
+		return rules, "", fmt.Errorf("%w: template_code is required", apperror.ErrInvalidInput)
+	}
+	if err := rules.Validate(); err != nil {
+		return rules, "", fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+	}
+	return rules, templateCode, nil
+}
+
+// resolveSchema memilih versi template seperti pembuatan dokumen: versi yang di-pin (boleh
+// DEPRECATED) atau published terbaru.
+func (s *service) resolveSchema(ctx context.Context, code string, version *int, tenantID *string) (map[string]any, int, error) {
+	tpl, err := s.templates.GetByCode(ctx, nil, code, tenantID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return nil, 0, fmt.Errorf("%w: template %q not found", apperror.ErrInvalidInput, code)
+		}
+		return nil, 0, err
+	}
+	if !tpl.IsActive {
+		return nil, 0, fmt.Errorf("%w: template %q is inactive", apperror.ErrInvalidState, code)
+	}
+	ver, err := s.latestOrPinned(ctx, tpl.ID, version, tenantID)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return nil, 0, fmt.Errorf("%w: template %q has no usable version", apperror.ErrInvalidInput, code)
+		}
+		return nil, 0, err
+	}
+	if !ver.IsRenderable() {
+		return nil, 0, fmt.Errorf("%w: template version %d is %s", apperror.ErrInvalidState, ver.Version, ver.Status)
+	}
+	return ver.EnforcedSchema(), ver.Version, nil
+}
+
+func (s *service) latestOrPinned(ctx context.Context, templateID int64, version *int, tenantID *string) (verEntity.TemplateVersion, error) {
+	if version != nil {
+		return s.versions.GetByTemplateAndVersion(ctx, nil, templateID, *version, tenantID)
+	}
+	return s.versions.GetLatestPublished(ctx, nil, templateID, tenantID)
+}
+
+// countLines jumlah baris berbeda yang memiliki kesalahan.
+func countLines(errs []mailmerge.RowError) int {
+	seen := make(map[int]bool, len(errs))
+	for _, e := range errs {
+		seen[e.Line] = true
+	}
+	return len(seen)
+}
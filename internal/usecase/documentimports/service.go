@@ -0,0 +1,165 @@
+package documentimports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	batchEntity "go-document-generator/internal/entity/documentbatches"
+	importEntity "go-document-generator/internal/entity/documentimports"
+	importrepo "go-document-generator/internal/repository/documentimports"
+	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/mailmerge"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/usecase/documentbatches"
+)
+
+// BatchCreator membuat batch dari baris valid (dipenuhi documentbatches.Service).
+type BatchCreator interface {
+	Create(ctx context.Context, in documentbatches.CreateInput) (batchEntity.Batch, error)
+}
+
+// Patch perubahan mapping; field nil tidak diubah. String kosong menghapus field opsional.
+type Patch struct {
+	Name            *string
+	Description     *string
+	TemplateCode    *string
+	Fields          []mailmerge.Field
+	GroupBy         *string
+	RequestIDColumn *string
+	RequestIDPrefix *string
+}
+
+type Service interface {
+	CreateMapping(ctx context.Context, m importEntity.Mapping) (importEntity.Mapping, error)
+	GetMapping(ctx context.Context, id int64, tenantID *string) (importEntity.Mapping, error)
+	ListMappings(ctx context.Context, f importrepo.ListFilter) ([]importEntity.Mapping, pagination.Meta, error)
+	UpdateMapping(ctx context.Context, id int64, tenantID *string, p Patch) (importEntity.Mapping, error)
+	DeleteMapping(ctx context.Context, id int64, tenantID *string) error
+	// Import memetakan dan memvalidasi setiap baris file terhadap schema versi template; baris valid
+	// dibuat sebagai batch (kecuali DryRun), baris gagal dilaporkan per nomor baris.
+	Import(ctx context.Context, in ImportInput) (ImportResult, error)
+}
+
+type service struct {
+	imports   importrepo.DocumentImportsRepository
+	templates tplrepo.DocumentTemplatesRepository
+	versions  verrepo.DocumentTemplateVersionsRepository
+	batches   BatchCreator
+}
+
+func NewService(
+	imports importrepo.DocumentImportsRepository,
+	templates tplrepo.DocumentTemplatesRepository,
+	versions verrepo.DocumentTemplateVersionsRepository,
+	batches BatchCreator,
+) Service {
+	return &service{
+		imports:   imports,
+		templates: templates,
+		versions:  versions,
+		batches:   batches,
+	}
+}
+
+func (s *service) CreateMapping(ctx context.Context, m importEntity.Mapping) (importEntity.Mapping, error) {
+	if err := validateMapping(&m); err != nil {
+		return importEntity.Mapping{}, err
+	}
+	created, err := s.imports.CreateMapping(ctx, nil, m)
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetMapping(ctx context.Context, id int64, tenantID *string) (importEntity.Mapping, error) {
+	m, err := s.imports.GetMapping(ctx, nil, id, tenantID)
+	return m, mapRepoErr(err)
+}
+
+func (s *service) ListMappings(ctx context.Context, f importrepo.ListFilter) ([]importEntity.Mapping, pagination.Meta, error) {
+	f.Page = pagination.Normalize(f.Page.Page, f.Page.Limit)
+	items, total, err := s.imports.ListMappings(ctx, nil, f)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return items, pagination.Meta{Page: f.Page.Page, Limit: f.Page.Limit, Total: total}, nil
+}
+
+func (s *service) UpdateMapping(ctx context.Context, id int64, tenantID *string, p Patch) (importEntity.Mapping, error) {
+	m, err := s.imports.GetMapping(ctx, nil, id, tenantID)
+	if err != nil {
+		return importEntity.Mapping{}, mapRepoErr(err)
+	}
+	if p.Name != nil {
+		m.Name = *p.Name
+	}
+	if p.Description != nil {
+		m.Description = p.Description
+	}
+	if p.TemplateCode != nil {
+		m.TemplateCode = p.TemplateCode
+	}
+	if p.Fields != nil {
+		m.Fields = p.Fields
+	}
+	if p.GroupBy != nil {
+		m.GroupBy = p.GroupBy
+	}
+	if p.RequestIDColumn != nil {
+		m.RequestIDColumn = p.RequestIDColumn
+	}
+	if p.RequestIDPrefix != nil {
+		m.RequestIDPrefix = p.RequestIDPrefix
+	}
+	if err := validateMapping(&m); err != nil {
+		return importEntity.Mapping{}, err
+	}
+	saved, err := s.imports.UpdateMapping(ctx, nil, m)
+	return saved, mapRepoErr(err)
+}
+
+func (s *service) DeleteMapping(ctx context.Context, id int64, tenantID *string) error {
+	return mapRepoErr(s.imports.DeleteMapping(ctx, nil, id, tenantID))
+}
+
+// validateMapping menormalkan field opsional (string kosong menjadi nil) lalu memeriksa aturan.
+func validateMapping(m *importEntity.Mapping) error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return fmt.Errorf("%w: name is required", apperror.ErrInvalidInput)
+	}
+	m.TemplateCode = nilIfBlank(m.TemplateCode)
+	m.GroupBy = nilIfBlank(m.GroupBy)
+	m.RequestIDColumn = nilIfBlank(m.RequestIDColumn)
+	m.RequestIDPrefix = nilIfBlank(m.RequestIDPrefix)
+	if m.Fields == nil {
+		m.Fields = []mailmerge.Field{}
+	}
+	if err := m.Rules().Validate(); err != nil {
+		return fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+func nilIfBlank(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*s)
+	if t == "" {
+		return nil
+	}
+	return &t
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package documents
+
+import (
+	"fmt"
+
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pdfsecurity"
+)
+
+// requestEncryption memvalidasi enkripsi dari request create. Password langsung disegel dengan
+// secret config sebelum disimpan; password_path harus sudah bisa dibaca dari payload saat create.
+func (s *service) requestEncryption(in CreateInput, format enums.OutputFormat) (*pdfsecurity.Spec, *string, error) {
+	if in.Encryption == nil {
+		if in.EncryptionPassword != "" {
+			return nil, nil, fmt.Errorf("%w: encryption password requires an encryption spec", apperror.ErrInvalidInput)
+		}
+		return nil, nil, nil
+	}
+	if format != enums.OutputFormatPDF {
+		return nil, nil, fmt.Errorf("%w: encryption is not supported for %s output", apperror.ErrInvalidInput, format)
+	}
+	spec := *in.Encryption
+	if err := spec.Validate(in.EncryptionPassword != ""); err != nil {
+		return nil, nil, fmt.Errorf("%w: encryption: %v", apperror.ErrInvalidInput, err)
+	}
+	spec.Algorithm = pdfsecurity.AlgorithmAES256
+	if spec.PasswordPath != "" {
+		if _, err := pdfsecurity.Lookup(in.Payload, spec.PasswordPath); err != nil {
+			return nil, nil, fmt.Errorf("%w: encryption: %v", apperror.ErrInvalidInput, err)
+		}
+		return &spec, nil, nil
+	}
+	if err := pdfsecurity.CheckPassword(in.EncryptionPassword); err != nil {
+		return nil, nil, fmt.Errorf("%w: encryption: %v", apperror.ErrInvalidInput, err)
+	}
+	if s.postRender == nil || s.postRender.Sealer == nil {
+		return nil, nil, fmt.Errorf("%w: encryption password is not enabled; use password_path", apperror.ErrInvalidInput)
+	}
+	sealed, err := s.postRender.Sealer.Seal(in.EncryptionPassword)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &spec, &sealed, nil
+}
@@ -0,0 +1,48 @@
+package documents
+
+import (
+	"fmt"
+	"strings"
+
+	"go-document-generator/internal/shared/i18n"
+)
+
+// TenantLocales locale default per tenant (implementasi: config.Localization).
+type TenantLocales interface {
+	LocaleFor(tenantID *string) string
+}
+
+// resolveLocale memilih locale dokumen: input eksplisit, metadata["locale"], lalu default tenant.
+// Hasilnya disimpan di dokumen agar retry merender dengan bahasa yang sama.
+func (s *service) resolveLocale(in CreateInput) (*string, error) {
+	var l string
+	switch {
+	case in.Locale != nil && strings.TrimSpace(*in.Locale) != "":
+		l = *in.Locale
+	case metadataLocale(in.Metadata) != "":
+		l = metadataLocale(in.Metadata)
+	case s.locales != nil:
+		l = s.locales.LocaleFor(in.TenantID)
+	}
+	l = strings.TrimSpace(l)
+	if l == "" {
+		return nil, nil
+	}
+	if !i18n.ValidLocale(l) {
+		return nil, fmt.Errorf("invalid locale %q", l)
+	}
+	return &l, nil
+}
+
+func metadataLocale(md map[string]any) string {
+	l, _ := md["locale"].(string)
+	return strings.TrimSpace(l)
+}
+
+// tenantLocale default tenant untuk preview (tanpa dokumen).
+func (s *service) tenantLocale(tenantID *string) string {
+	if s.locales == nil {
+		return ""
+	}
+	return s.locales.LocaleFor(tenantID)
+}
@@ -0,0 +1,146 @@
+// Package postrender berisi tahap pemrosesan output setelah Generator.Generate dan sebelum file
+// disimpan (watermark, PDF/A, enkripsi, validasi e-invoice). Dipakai transisi GENERATED dan Preview.
+package postrender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/pdfa"
+	"go-document-generator/internal/shared/pdfsecurity"
+	"go-document-generator/internal/shared/templating"
+	"go-document-generator/internal/shared/watermark"
+)
+
+// PDFProcessor memproses file PDF hasil render (implementasi: qpdf).
+type PDFProcessor interface {
+	Watermark(ctx context.Context, data []byte, spec watermark.Spec, img *watermark.Image) ([]byte, error)
+	Encrypt(ctx context.Context, data []byte, userPassword, ownerPassword string, spec pdfsecurity.Spec) ([]byte, error)
+}
+
+// PDFAConverter mengonversi PDF ke PDF/A (implementasi: Ghostscript).
+type PDFAConverter interface {
+	ConvertPDFA(ctx context.Context, data []byte, level string, info pdfa.Info) ([]byte, error)
+}
+
+// PDFAValidator memvalidasi konformansi PDF/A (implementasi: veraPDF).
+type PDFAValidator interface {
+	ValidatePDFA(ctx context.Context, data []byte, level string) (pdfa.Report, error)
+}
+
+// SchemaValidator memvalidasi XML e-invoice terhadap XSD syntax-nya (implementasi: xmllint).
+type SchemaValidator interface {
+	ValidateXML(ctx context.Context, data []byte, syntax string) error
+}
+
+// AssetLoader memuat satu asset template, mis. logo untuk watermark gambar
+// (dipenuhi usecase documenttemplateassets).
+type AssetLoader interface {
+	Load(ctx context.Context, tenantID *string, templateID int64, name string) (templating.Asset, error)
+}
+
+// Pipeline tahap pasca-render. PDF nil: watermark/enkripsi PDF gagal; Assets nil: watermark gambar gagal.
+// OwnerPassword kosong: setiap dokumen memakai owner password acak. Sealer nil: password langsung
+// dari request ditolak (hanya password_path yang bisa dipakai). PDFAValidator nil: preflight bawaan
+// pdfa.Check (report tidak Verified). PDFAFlagOnly: output PDF/A yang tidak konform tetap disimpan dan hanya ditandai di report.
+// XML nil: e-invoice gagal divalidasi.
+type Pipeline struct {
+	PDF           PDFProcessor
+	Assets        AssetLoader
+	OwnerPassword string
+	Sealer        *pdfsecurity.Sealer
+	PDFA          PDFAConverter
+	PDFAValidator PDFAValidator
+	PDFAFlagOnly  bool
+	XML           SchemaValidator
+}
+
+// SupportsWatermark true untuk format output yang bisa diberi watermark.
+func SupportsWatermark(f enums.OutputFormat) bool {
+	return f == enums.OutputFormatPDF || f == enums.OutputFormatHTML
+}
+
+// Watermark menerapkan spec ke output: overlay CSS untuk HTML, halaman overlay untuk PDF.
+func (p *Pipeline) Watermark(ctx context.Context, tenantID *string, templateID int64, spec watermark.Spec, format enums.OutputFormat, data []byte) ([]byte, error) {
+	spec = spec.WithDefaults()
+	var img *watermark.Image
+	if spec.Image != "" {
+		if p.Assets == nil {
+			return nil, errors.New("asset store not configured")
+		}
+		a, err := p.Assets.Load(ctx, tenantID, templateID, spec.Image)
+		if err != nil {
+			return nil, fmt.Errorf("watermark image %s: %w", spec.Image, err)
+		}
+		img = &watermark.Image{ContentType: a.ContentType, Data: a.Data}
+	}
+	switch format {
+	case enums.OutputFormatHTML:
+		return watermark.HTML(data, spec, img), nil
+	case enums.OutputFormatPDF:
+		if p.PDF == nil {
+			return nil, errors.New("pdf processor not configured")
+		}
+		return p.PDF.Watermark(ctx, data, spec, img)
+	}
+	return nil, fmt.Errorf("watermark is not supported for %s output", format)
+}
+
+// Encrypt mengenkripsi PDF dengan password user dan pembatasan izin spec.
+func (p *Pipeline) Encrypt(ctx context.Context, data []byte, userPassword string, spec pdfsecurity.Spec) ([]byte, error) {
+	if p.PDF == nil {
+		return nil, errors.New("pdf processor not configured")
+	}
+	owner := p.OwnerPassword
+	if owner == "" {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		owner = hex.EncodeToString(b)
+	}
+	return p.PDF.Encrypt(ctx, data, userPassword, owner, spec)
+}
+
+// Archive mengonversi PDF ke level PDF/A lalu memvalidasinya. Output yang tidak konform ditolak
+// kecuali PDFAFlagOnly; report selalu dikembalikan bila validasi sempat berjalan.
+func (p *Pipeline) Archive(ctx context.Context, data []byte, level string, info pdfa.Info) ([]byte, *pdfa.Report, error) {
+	if p.PDFA == nil {
+		return nil, nil, errors.New("pdf/a converter not configured")
+	}
+	out, err := p.PDFA.ConvertPDFA(ctx, data, level, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	var report pdfa.Report
+	if p.PDFAValidator != nil {
+		if report, err = p.PDFAValidator.ValidatePDFA(ctx, out, level); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		issues := pdfa.Check(out, level)
+		report = pdfa.Report{Level: level, Compliant: len(issues) == 0, Validator: pdfa.ValidatorBuiltin,
+			Issues: issues, CheckedAt: time.Now().UTC()}
+	}
+	if !report.Compliant && !p.PDFAFlagOnly {
+		return nil, &report, fmt.Errorf("output is not %s conformant: %s", level, report.Summary(5))
+	}
+	return out, &report, nil
+}
+
+// ValidateEInvoice memeriksa root XML sesuai syntax lalu memvalidasinya terhadap XSD.
+func (p *Pipeline) ValidateEInvoice(ctx context.Context, data []byte, syntax string) error {
+	if err := einvoice.CheckRoot(data, syntax); err != nil {
+		return err
+	}
+	if p.XML == nil {
+		return errors.New("xml schema validator not configured")
+	}
+	return p.XML.ValidateXML(ctx, data, syntax)
+}
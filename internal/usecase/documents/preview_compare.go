@@ -0,0 +1,103 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/validators"
+)
+
+var compareTemplate = template.Must(template.New("compare").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Template {{.TemplateCode}}: v{{.Left.Version}} vs v{{.Right.Version}}</title>
+<style>
+body { margin: 0; font-family: sans-serif; }
+.panes { display: flex; height: 100vh; }
+.pane { flex: 1; display: flex; flex-direction: column; border-right: 1px solid #ccc; }
+.pane h2 { margin: 0; padding: 8px 12px; font-size: 14px; background: #f4f4f4; border-bottom: 1px solid #ccc; }
+.pane iframe { flex: 1; border: 0; width: 100%; }
+.pane pre { margin: 12px; color: #b00020; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<div class="panes">
+{{range .Panes}}<div class="pane">
+<h2>v{{.Version}} ({{.Status}})</h2>
+{{if .Error}}<pre>{{.Error}}</pre>{{else}}<iframe sandbox srcdoc="{{.HTML}}"></iframe>{{end}}
+</div>
+{{end}}</div>
+</body>
+</html>
+`))
+
+type comparePane struct {
+	Version int
+	Status  enums.TemplateVersionStatus
+	HTML    string
+	Error   string
+}
+
+// PreviewCompare merender dua versi template dengan payload yang sama dan menampilkannya berdampingan (HTML).
+// Payload kosong memakai sample_payload versi kanan (fallback versi kiri). Error render/validasi
+// ditampilkan di panel versi terkait agar perbedaan tetap terlihat.
+func (s *service) PreviewCompare(ctx context.Context, templateID, leftID, rightID int64, tenantID *string, payload map[string]any) ([]byte, error) {
+	tpl, err := s.templates.GetByID(ctx, nil, templateID, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	left, err := s.versions.GetByID(ctx, nil, templateID, leftID, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	right, err := s.versions.GetByID(ctx, nil, templateID, rightID, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	if len(payload) == 0 {
+		payload = right.SamplePayload
+		if len(payload) == 0 {
+			payload = left.SamplePayload
+		}
+	}
+
+	gen := s.selector.Select(string(enums.OutputFormatHTML), string(tpl.Engine))
+	render := func(v verEntity.TemplateVersion) comparePane {
+		pane := comparePane{Version: v.Version, Status: v.Status}
+		if schema := v.EnforcedSchema(); schema != nil {
+			if err := validators.ValidateSchema(schema, payload); err != nil {
+				pane.Error = fmt.Sprintf("payload does not match schema: %v", err)
+				return pane
+			}
+		}
+		src, err := s.source(ctx, v)
+		if err != nil {
+			pane.Error = err.Error()
+			return pane
+		}
+		out, _, err := gen.Generate(ctx, src, payload)
+		if err != nil {
+			pane.Error = err.Error()
+			return pane
+		}
+		pane.HTML = string(out)
+		return pane
+	}
+
+	var buf bytes.Buffer
+	err = compareTemplate.Execute(&buf, map[string]any{
+		"TemplateCode": tpl.Code,
+		"Left":         left,
+		"Right":        right,
+		"Panes":        []comparePane{render(left), render(right)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
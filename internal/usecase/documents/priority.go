@@ -0,0 +1,42 @@
+package documents
+
+import (
+	"fmt"
+	"strings"
+
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+)
+
+// TenantPriorities prioritas default per tenant (implementasi: config.Generation).
+type TenantPriorities interface {
+	PriorityFor(tenantID *string) string
+}
+
+// ValidPriority true untuk HIGH, NORMAL, dan LOW.
+func ValidPriority(p enums.DocumentPriority) bool {
+	switch p {
+	case enums.DocumentPriorityHigh, enums.DocumentPriorityNormal, enums.DocumentPriorityLow:
+		return true
+	}
+	return false
+}
+
+// resolvePriority memilih lane dokumen: input eksplisit, lalu default tenant, lalu NORMAL.
+// Disimpan di dokumen agar retry masuk lane yang sama.
+func (s *service) resolvePriority(in CreateInput) (enums.DocumentPriority, error) {
+	if in.Priority != "" {
+		p := enums.DocumentPriority(strings.ToUpper(strings.TrimSpace(string(in.Priority))))
+		if !ValidPriority(p) {
+			return "", fmt.Errorf("%w: invalid priority %q", apperror.ErrInvalidInput, in.Priority)
+		}
+		return p, nil
+	}
+	if s.priorities != nil {
+		p := enums.DocumentPriority(strings.ToUpper(strings.TrimSpace(s.priorities.PriorityFor(in.TenantID))))
+		if ValidPriority(p) {
+			return p, nil
+		}
+	}
+	return enums.DocumentPriorityNormal, nil
+}
@@ -1,10 +1,26 @@
 package documents
 
-import "context"
+import (
+	"context"
 
-// Generator merender dokumen dari template + data payload.
+	"go-document-generator/internal/shared/templating"
+)
+
+// Generator merender dokumen dari template (content + partial/layout yang sudah di-resolve) + data payload.
 type Generator interface {
-	Generate(ctx context.Context, templateSource string, data any) ([]byte, string, error)
+	Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error)
+}
+
+// PartialResolver me-resolve partial/layout yang dipakai content versi template
+// (dipenuhi usecase documenttemplatepartials).
+type PartialResolver interface {
+	Resolve(ctx context.Context, tenantID *string, content string, layout *string) (templating.Source, error)
+}
+
+// AssetResolver memuat asset yang dipanggil template ({{asset "nama"}}) ke source
+// (dipenuhi usecase documenttemplateassets).
+type AssetResolver interface {
+	Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error
 }
 
 // GeneratorSelector memilih engine render berdasarkan format output dan template engine.
@@ -5,9 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"mime"
+	"os"
+	"path"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	docEntity "go-document-generator/internal/entity/documents"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
 	"go-document-generator/internal/entity/enums"
@@ -16,9 +20,14 @@ import (
 	tplrepo "go-document-generator/internal/repository/documenttemplates"
 	verrepo "go-document-generator/internal/repository/documenttemplateversions"
 	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/archive"
 	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/pdfsecurity"
 	sharedStorage "go-document-generator/internal/shared/storage"
+	"go-document-generator/internal/shared/templating"
 	"go-document-generator/internal/shared/validators"
+	"go-document-generator/internal/shared/watermark"
+	"go-document-generator/internal/usecase/documents/postrender"
 	"go-document-generator/internal/usecase/documents/states"
 	"go-document-generator/internal/usecase/documents/transitions"
 )
@@ -29,6 +38,8 @@ type CreateInput struct {
 	TemplateCode    string
 	TemplateVersion *int
 	OutputFormat    enums.OutputFormat
+	Locale          *string                // kosong: metadata["locale"], lalu default tenant
+	Priority        enums.DocumentPriority // kosong: default tenant, lalu NORMAL (BulkCreate: LOW)
 	Payload         map[string]any
 	Metadata        map[string]any
 	StoreToDms      bool
@@ -36,6 +47,12 @@ type CreateInput struct {
 	CallbackURL     *string
 	ExpiredAt       *time.Time
 	CreatedBy       *string
+	// Watermark stempel/logo untuk output PDF/HTML; nil: default template (bila ada).
+	Watermark *watermark.Spec
+	// Encryption password + pembatasan izin untuk output PDF. EncryptionPassword (alternatif
+	// Encryption.PasswordPath) hanya disimpan tersegel.
+	Encryption         *pdfsecurity.Spec
+	EncryptionPassword string
 }
 
 type BulkCreateItem struct {
@@ -47,6 +64,13 @@ type BulkCreateItem struct {
 
 type Service interface {
 	Create(ctx context.Context, in CreateInput) (docEntity.Document, bool, error)
+	// CreateSync membuat dokumen lalu merendernya in-process dalam batas SyncOptions.Timeout;
+	// bila lewat batas (atau slot sinkron penuh) dokumen dilanjutkan async dan Done false.
+	CreateSync(ctx context.Context, in CreateInput) (SyncResult, error)
+	// WatchDocument mengirim snapshot status dokumen lalu tiap transisi berikutnya sampai status final.
+	WatchDocument(ctx context.Context, id int64, tenantID *string, lastEventID string) (<-chan StatusEvent, error)
+	// WatchTenant mengalirkan transisi status semua dokumen tenant.
+	WatchTenant(ctx context.Context, tenantID *string, lastEventID string) (<-chan StatusEvent, error)
 	BulkCreate(ctx context.Context, inputs []CreateInput) []BulkCreateItem
 	GetByID(ctx context.Context, id int64, tenantID *string) (docEntity.Document, error)
 	Patch(ctx context.Context, d docEntity.Document) (docEntity.Document, error)
@@ -56,11 +80,20 @@ type Service interface {
 	Retry(ctx context.Context, id int64, tenantID *string) (docEntity.Document, error)
 	SoftDelete(ctx context.Context, id int64, tenantID *string) error
 	DownloadURL(ctx context.Context, id int64, tenantID *string) (string, error)
-	Preview(ctx context.Context, templateID, versionID int64, tenantID *string, payload map[string]any) ([]byte, string, error)
-	// ZipDocuments mengambil file dari banyak dokumen, membuat arsip ZIP, mengembalikan URL download.
-	ZipDocuments(ctx context.Context, ids []int64, tenantID *string, label string) (string, error)
-	// MergeDocuments menggabungkan banyak dokumen format sama menjadi satu file, mengembalikan URL download.
-	MergeDocuments(ctx context.Context, ids []int64, tenantID *string, label string) (string, error)
+	// DownloadFile membaca isi file dokumen GENERATED.
+	DownloadFile(ctx context.Context, id int64, tenantID *string) ([]byte, error)
+	// AttachmentURL URL/path file pendamping dokumen GENERATED, mis. "factur-x.xml".
+	AttachmentURL(ctx context.Context, id int64, tenantID *string, name string) (string, error)
+	// wm diterapkan ke output PDF/HTML; nil atau spec kosong: tanpa watermark. Stempel otomatis
+	// (Source PREVIEW, mis. watermark.Draft) hanya untuk HTML: PDF butuh watermark eksplisit.
+	Preview(ctx context.Context, templateID, versionID int64, tenantID *string, payload map[string]any, wm *watermark.Spec) ([]byte, string, error)
+	// PreviewCompare merender dua versi dengan payload yang sama sebagai halaman HTML side-by-side.
+	PreviewCompare(ctx context.Context, templateID, leftID, rightID int64, tenantID *string, payload map[string]any) ([]byte, error)
+	// ZipToStorage mengambil file dari banyak dokumen, membuat arsip (ZIP / tar.gz sesuai opts) dan
+	// mengembalikan path storage. MergeToStorage menggabungkan dokumen format sama menjadi satu file.
+	// Keduanya dipanggil job background (archive job, batch); progress boleh nil.
+	ZipToStorage(ctx context.Context, ids []int64, tenantID *string, label string, opts archive.Options, progress ProgressFunc) (string, error)
+	MergeToStorage(ctx context.Context, ids []int64, tenantID *string, label string, progress ProgressFunc) (string, error)
 	// Process dipanggil oleh Kafka consumer untuk menjalankan generation pipeline:
 	// QUEUED → PROCESSING → GENERATED (atau FAILED bila error).
 	Process(ctx context.Context, id int64, tenantID *string) error
@@ -71,8 +104,9 @@ type StorageProvider interface {
 	PresignedURL(ctx context.Context, path string, ttl time.Duration) (string, error)
 	ProviderName() enums.StorageProvider
 	Download(ctx context.Context, path string) ([]byte, error)
-	Zip(ctx context.Context, documentID int64, requestID string, entries []sharedStorage.ZipEntry) (path, fileName string, err error)
 	Compose(ctx context.Context, documentID int64, requestID string, srcPaths []string, ext string) (path, fileName string, err error)
+	Put(ctx context.Context, key, contentType string, data []byte) (path string, err error)
+	PutFile(ctx context.Context, key, contentType, srcPath string) (path string, err error)
 }
 
 type service struct {
@@ -84,6 +118,14 @@ type service struct {
 	selector     GeneratorSelector
 	stateMachine states.IDocumentStateMachineFactory
 	storage      StorageProvider
+	partials     PartialResolver
+	assets       AssetResolver
+	postRender   *postrender.Pipeline
+	locales      TenantLocales
+	priorities   TenantPriorities
+	sync         SyncOptions
+	syncSlots    chan struct{}
+	status       StatusStream
 }
 
 func NewService(
@@ -94,12 +136,20 @@ func NewService(
 	publisher DocumentEventPublisher,
 	selector GeneratorSelector,
 	storageProv StorageProvider,
+	partials PartialResolver,
+	assets AssetResolver,
+	postRender *postrender.Pipeline,
+	locales TenantLocales,
+	priorities TenantPriorities,
+	sync SyncOptions,
+	status StatusStream,
 ) Service {
 	if publisher == nil {
 		publisher = NoopDocumentPublisher()
 	}
-	deps := transitions.Deps{Templates: templates, Versions: versions, Selector: adaptSelector(selector)}
+	deps := transitions.Deps{Templates: templates, Versions: versions, Selector: adaptSelector(selector), Partials: partials, Assets: assets, PostRender: postRender}
 	smFactory := states.NewDocumentStateMachineFactory(BuildStateHandlers(deps))
+	sync = sync.withDefaults()
 
 	return &service{
 		docs:         docs,
@@ -110,10 +160,23 @@ func NewService(
 		selector:     selector,
 		stateMachine: smFactory,
 		storage:      storageProv,
+		partials:     partials,
+		assets:       assets,
+		postRender:   postRender,
+		locales:      locales,
+		priorities:   priorities,
+		sync:         sync,
+		syncSlots:    make(chan struct{}, sync.MaxInFlight),
+		status:       status,
 	}
 }
 
 func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Document, bool, error) {
+	return s.create(ctx, in, true)
+}
+
+// create menyimpan dokumen QUEUED; enqueue false dipakai mode sync yang merender sendiri.
+func (s *service) create(ctx context.Context, in CreateInput, enqueue bool) (docEntity.Document, bool, error) {
 	if strings.TrimSpace(in.RequestID) == "" || strings.TrimSpace(in.TemplateCode) == "" {
 		return docEntity.Document{}, false, apperror.ErrInvalidInput
 	}
@@ -146,16 +209,29 @@ func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Documen
 	if err != nil {
 		return docEntity.Document{}, false, mapRepoErr(err)
 	}
-	if !ver.IsPublished {
+	// Versi yang di-pin eksplisit boleh DEPRECATED; DRAFT dan ARCHIVED tidak boleh dipakai.
+	if !ver.IsRenderable() {
+		if ver.Status == enums.TemplateVersionStatusArchived {
+			return docEntity.Document{}, false, fmt.Errorf("%w: template version %d is archived", apperror.ErrInvalidState, ver.Version)
+		}
 		return docEntity.Document{}, false, apperror.ErrNotFound
 	}
 
-	if len(ver.Schema) > 0 {
-		if err := validators.ValidateSchema(ver.Schema, in.Payload); err != nil {
+	if schema := ver.EnforcedSchema(); schema != nil {
+		if err := validators.ValidateSchema(schema, in.Payload); err != nil {
 			return docEntity.Document{}, false, err
 		}
 	}
 
+	locale, err := s.resolveLocale(in)
+	if err != nil {
+		return docEntity.Document{}, false, err
+	}
+	priority, err := s.resolvePriority(in)
+	if err != nil {
+		return docEntity.Document{}, false, err
+	}
+
 	outFmt := in.OutputFormat
 	if outFmt == "" {
 		outFmt = ver.OutputFormat
@@ -163,6 +239,19 @@ func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Documen
 	if outFmt == "" {
 		outFmt = tpl.DefaultFormat
 	}
+	wm, err := requestWatermark(in.Watermark, outFmt)
+	if err != nil {
+		return docEntity.Document{}, false, err
+	}
+	enc, encSecret, err := s.requestEncryption(in, outFmt)
+	if err != nil {
+		return docEntity.Document{}, false, err
+	}
+	// PDF/A melarang enkripsi.
+	if enc != nil && ver.PDFAConformance != nil {
+		return docEntity.Document{}, false, fmt.Errorf("%w: template version %d produces %s, which cannot be encrypted",
+			apperror.ErrInvalidInput, ver.Version, *ver.PDFAConformance)
+	}
 
 	tplID := tpl.ID
 	verID := ver.ID
@@ -176,7 +265,9 @@ func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Documen
 		Payload:           in.Payload,
 		Metadata:          in.Metadata,
 		Status:            enums.DocumentStatusQueued,
+		Priority:          priority,
 		OutputFormat:      outFmt,
+		Locale:            locale,
 		StoreToDms:        in.StoreToDms,
 		DmsStatus:         enums.DmsStatusNotSent,
 		HasCallback:       in.HasCallback,
@@ -184,6 +275,9 @@ func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Documen
 		CallbackStatus:    enums.CallbackStatusPending,
 		ExpiredAt:         in.ExpiredAt,
 		CreatedBy:         in.CreatedBy,
+		Watermark:         wm,
+		Encryption:        enc,
+		EncryptionSecret:  encSecret,
 	}
 
 	tx, err := s.txManager.Begin(ctx)
@@ -206,16 +300,24 @@ func (s *service) Create(ctx context.Context, in CreateInput) (docEntity.Documen
 	if pubErr := s.publisher.PublishDocumentEvent(ctx, "CREATE", nil, &created); pubErr != nil {
 		log.Printf("documents: PublishDocumentEvent CREATE: %v", pubErr)
 	}
-	if pubErr := s.publisher.PublishDocumentProcess(ctx, created); pubErr != nil {
-		log.Printf("documents: PublishDocumentProcess: %v", pubErr)
+	if enqueue {
+		s.enqueue(ctx, created)
 	}
 	return created, false, nil
 }
 
+// enqueue memicu generation worker via Kafka.
+func (s *service) enqueue(ctx context.Context, d docEntity.Document) {
+	if pubErr := s.publisher.PublishDocumentProcess(ctx, d); pubErr != nil {
+		log.Printf("documents: PublishDocumentProcess: %v", pubErr)
+	}
+}
+
 const bulkWorkers = 10
 
 // BulkCreate membuat banyak dokumen secara konkuren (maks bulkWorkers goroutine).
 // Tiap item diproses independen; error satu item tidak menghentikan item lain.
+// Item tanpa priority eksplisit masuk lane LOW agar tidak menahan request interaktif.
 func (s *service) BulkCreate(ctx context.Context, inputs []CreateInput) []BulkCreateItem {
 	type indexed struct {
 		i   int
@@ -227,6 +329,9 @@ func (s *service) BulkCreate(ctx context.Context, inputs []CreateInput) []BulkCr
 
 	for i, in := range inputs {
 		i, in := i, in
+		if in.Priority == "" {
+			in.Priority = enums.DocumentPriorityLow
+		}
 		sem <- struct{}{}
 		go func() {
 			defer func() { <-sem }()
@@ -387,8 +492,45 @@ func (s *service) DownloadURL(ctx context.Context, id int64, tenantID *string) (
 	return *d.FilePath, nil
 }
 
+func (s *service) DownloadFile(ctx context.Context, id int64, tenantID *string) ([]byte, error) {
+	d, err := s.docs.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	if d.Status != enums.DocumentStatusGenerated {
+		return nil, apperror.ErrInvalidState
+	}
+	if d.FilePath == nil || strings.TrimSpace(*d.FilePath) == "" {
+		return nil, apperror.ErrNotFound
+	}
+	if s.storage != nil {
+		return s.storage.Download(ctx, *d.FilePath)
+	}
+	return os.ReadFile(*d.FilePath)
+}
+
+func (s *service) AttachmentURL(ctx context.Context, id int64, tenantID *string, name string) (string, error) {
+	d, err := s.docs.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return "", mapRepoErr(err)
+	}
+	if d.Status != enums.DocumentStatusGenerated {
+		return "", apperror.ErrInvalidState
+	}
+	for _, a := range d.Attachments {
+		if a.Name != name {
+			continue
+		}
+		if s.storage != nil {
+			return s.storage.PresignedURL(ctx, a.FilePath, 15*time.Minute)
+		}
+		return a.FilePath, nil
+	}
+	return "", apperror.ErrNotFound
+}
+
 // Preview merender template version dengan payload yang diberikan tanpa menyimpan ke DB.
-func (s *service) Preview(ctx context.Context, templateID, versionID int64, tenantID *string, payload map[string]any) ([]byte, string, error) {
+func (s *service) Preview(ctx context.Context, templateID, versionID int64, tenantID *string, payload map[string]any, wm *watermark.Spec) ([]byte, string, error) {
 	tpl, err := s.templates.GetByID(ctx, nil, templateID, tenantID)
 	if err != nil {
 		return nil, "", mapRepoErr(err)
@@ -397,19 +539,55 @@ func (s *service) Preview(ctx context.Context, templateID, versionID int64, tena
 	if err != nil {
 		return nil, "", mapRepoErr(err)
 	}
-	if len(ver.Schema) > 0 {
-		if err := validators.ValidateSchema(ver.Schema, payload); err != nil {
+	if schema := ver.EnforcedSchema(); schema != nil {
+		if err := validators.ValidateSchema(schema, payload); err != nil {
 			return nil, "", err
 		}
 	}
+	if wm != nil && !wm.IsZero() {
+		if err := wm.Validate(); err != nil {
+			return nil, "", fmt.Errorf("%w: watermark: %v", apperror.ErrInvalidInput, err)
+		}
+	}
+	src, err := s.source(ctx, ver)
+	if err != nil {
+		return nil, "", err
+	}
 	gen := s.selector.Select(string(ver.OutputFormat), string(tpl.Engine))
-	data, contentType, err := gen.Generate(ctx, ver.Content, payload)
+	data, contentType, err := gen.Generate(ctx, src, payload)
 	if err != nil {
 		return nil, "", err
 	}
+	if wm != nil && wm.Source == watermark.SourcePreview && ver.OutputFormat == enums.OutputFormatPDF {
+		wm = nil
+	}
+	if wm != nil && !wm.IsZero() && s.postRender != nil && postrender.SupportsWatermark(ver.OutputFormat) {
+		if data, err = s.postRender.Watermark(ctx, tenantID, tpl.ID, *wm, ver.OutputFormat, data); err != nil {
+			return nil, "", fmt.Errorf("apply watermark: %w", err)
+		}
+	}
 	return data, contentType, nil
 }
 
+// source me-resolve partial/layout lalu asset versi; tanpa resolver content dirender apa adanya.
+// Preview memakai locale default tenant (lalu default_locale versi) untuk {{t}}.
+func (s *service) source(ctx context.Context, ver verEntity.TemplateVersion) (templating.Source, error) {
+	src := templating.Inline(ver.Content)
+	if s.partials != nil {
+		var err error
+		if src, err = s.partials.Resolve(ctx, ver.TenantID, ver.Content, ver.Layout); err != nil {
+			return templating.Source{}, err
+		}
+	}
+	if s.assets != nil {
+		if err := s.assets.Attach(ctx, ver.TenantID, ver.TemplateID, &src); err != nil {
+			return templating.Source{}, err
+		}
+	}
+	src.Translator = ver.Translator(s.tenantLocale(ver.TenantID))
+	return src, nil
+}
+
 // Process dijalankan oleh Kafka consumer: QUEUED → PROCESSING → GENERATED (atau FAILED).
 func (s *service) Process(ctx context.Context, id int64, tenantID *string) error {
 	doc, err := s.docs.GetByID(ctx, nil, id, tenantID)
@@ -420,42 +598,74 @@ func (s *service) Process(ctx context.Context, id int64, tenantID *string) error
 		// Sudah diproses oleh consumer lain (at-least-once delivery) — skip.
 		return nil
 	}
+	_, err = s.process(ctx, doc)
+	return err
+}
 
+// process menjalankan QUEUED → PROCESSING → GENERATED untuk dokumen QUEUED.
+// Bila render gagal, dokumen FAILED yang sudah tersimpan dikembalikan bersama error-nya.
+func (s *service) process(ctx context.Context, doc docEntity.Document) (docEntity.Document, error) {
 	// Transisi QUEUED → PROCESSING
 	processing, err := s.transitionDocument(ctx, doc, enums.DocumentStatusProcessing)
 	if err != nil {
-		return err
+		return docEntity.Document{}, err
 	}
-	if _, err := s.docs.Update(ctx, nil, processing); err != nil {
-		return err
+	processing, err = s.docs.Update(ctx, nil, processing)
+	if err != nil {
+		return docEntity.Document{}, err
 	}
+	s.publishStatusEvent(ctx, doc, processing)
 
 	// Transisi PROCESSING → GENERATED (toGenerated handler melakukan render file)
 	generated, err := s.transitionDocument(ctx, processing, enums.DocumentStatusGenerated)
 	if err != nil {
 		// applyStateMachine sudah simpan status FAILED dan publish event Failed
-		return err
+		return generated, err
 	}
 	saved, err := s.docs.Update(ctx, nil, generated)
 	if err != nil {
-		return err
+		return docEntity.Document{}, err
 	}
-	if pubErr := s.publisher.PublishDocumentEvent(ctx, "UPDATE", &doc, &saved); pubErr != nil {
+	if pubErr := s.publisher.PublishDocumentEvent(ctx, "UPDATE", &processing, &saved); pubErr != nil {
 		log.Printf("documents: Process: publish generated: %v", pubErr)
 	}
-	return nil
+	return saved, nil
 }
 
-// ZipDocuments mengunduh file tiap dokumen, membuat ZIP, dan mengembalikan URL download.
-func (s *service) ZipDocuments(ctx context.Context, ids []int64, tenantID *string, label string) (string, error) {
+// ProgressFunc dipanggil setelah setiap dokumen diambil / diperiksa dengan jumlah yang sudah selesai.
+type ProgressFunc func(done int)
+
+// ZipToStorage menyimpan arsip dengan key unik per pemanggilan (archives/<tenant>/<tanggal>/<uuid>/),
+// sehingga arsip berlabel sama tidak saling menimpa.
+func (s *service) ZipToStorage(ctx context.Context, ids []int64, tenantID *string, label string, opts archive.Options, progress ProgressFunc) (string, error) {
 	if s.storage == nil {
 		return "", errors.New("storage provider not configured")
 	}
 	if len(ids) == 0 {
 		return "", apperror.ErrInvalidInput
 	}
-	entries := make([]sharedStorage.ZipEntry, 0, len(ids))
-	for _, id := range ids {
+	if err := opts.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+	}
+	reqID := label
+	if reqID == "" {
+		reqID = fmt.Sprintf("zip-%d-docs", len(ids))
+	}
+	now := time.Now().UTC()
+
+	// Arsip ditulis ke file sementara satu dokumen demi satu dokumen lalu diunggah dari file,
+	// sehingga memori tidak tumbuh sebanding jumlah dokumen.
+	tmp, err := os.CreateTemp("", "archive-*."+opts.Extension())
+	if err != nil {
+		return "", fmt.Errorf("archive temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	aw, err := archive.NewWriter(tmp, opts, now)
+	if err != nil {
+		return "", err
+	}
+	for i, id := range ids {
 		d, err := s.docs.GetByID(ctx, nil, id, tenantID)
 		if err != nil {
 			return "", mapRepoErr(err)
@@ -467,33 +677,52 @@ func (s *service) ZipDocuments(ctx context.Context, ids []int64, tenantID *strin
 		if err != nil {
 			return "", fmt.Errorf("download document %d: %w", id, err)
 		}
-		name := fmt.Sprintf("%d", d.ID)
+		e := archive.Entry{
+			DocumentID:      d.ID,
+			RequestID:       d.RequestID,
+			TemplateCode:    d.TemplateCode,
+			TemplateVersion: d.TemplateVersion,
+			CreatedAt:       d.CreatedAt,
+			Metadata:        d.Metadata,
+			Data:            data,
+			Encrypted:       d.IsEncrypted,
+		}
 		if d.FileName != nil {
-			name = *d.FileName
+			e.FileName = *d.FileName
+		}
+		if err := aw.Add(e); err != nil {
+			return "", err
+		}
+		if progress != nil {
+			progress(i + 1)
 		}
-		entries = append(entries, sharedStorage.ZipEntry{Name: name, Data: data})
-	}
-	reqID := label
-	if reqID == "" {
-		reqID = fmt.Sprintf("zip-%d-docs", len(ids))
 	}
-	path, _, err := s.storage.Zip(ctx, 0, reqID, entries)
-	if err != nil {
+	if err := aw.Close(); err != nil {
 		return "", err
 	}
-	url, err := s.storage.PresignedURL(ctx, path, 15*time.Minute)
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("archive temp file: %w", err)
+	}
+	path, err := s.storage.PutFile(ctx, archiveKey(tenantID, reqID, opts.Extension(), now), opts.ContentType(), tmp.Name())
 	if err != nil {
 		return "", err
 	}
-	if pubErr := s.publisher.PublishDocumentBulkEvent(ctx, "DocumentZip", ids, tenantID, path, "zip"); pubErr != nil {
+	if pubErr := s.publisher.PublishDocumentBulkEvent(ctx, "DocumentZip", ids, tenantID, path, opts.Extension()); pubErr != nil {
 		log.Printf("documents: PublishDocumentBulkEvent zip: %v", pubErr)
 	}
-	return url, nil
+	return path, nil
 }
 
-// MergeDocuments menggabungkan file dokumen berformat sama menjadi satu, mengembalikan URL download.
-// Untuk format teks (HTML, CSV): byte concat. Untuk PDF: butuh library pdfcpu — saat ini byte concat.
-func (s *service) MergeDocuments(ctx context.Context, ids []int64, tenantID *string, label string) (string, error) {
+func archiveKey(tenantID *string, label, ext string, now time.Time) string {
+	scope := "global"
+	if tenantID != nil {
+		scope = *tenantID
+	}
+	return path.Join("archives", scope, now.Format("2006/01/02"), uuid.NewString(), archive.SafeName(label)+"."+ext)
+}
+
+// MergeToStorage: untuk format teks (HTML, CSV) byte concat. Untuk PDF: butuh library pdfcpu — saat ini byte concat.
+func (s *service) MergeToStorage(ctx context.Context, ids []int64, tenantID *string, label string, progress ProgressFunc) (string, error) {
 	if s.storage == nil {
 		return "", errors.New("storage provider not configured")
 	}
@@ -510,30 +739,55 @@ func (s *service) MergeDocuments(ctx context.Context, ids []int64, tenantID *str
 		if d.Status != enums.DocumentStatusGenerated || d.FilePath == nil {
 			return "", fmt.Errorf("document %d belum generated", id)
 		}
+		// Isi PDF terenkripsi tidak bisa digabung tanpa password user masing-masing dokumen.
+		if d.IsEncrypted {
+			return "", fmt.Errorf("%w: document %d is password-protected and cannot be merged; use zip", apperror.ErrInvalidState, id)
+		}
 		if i == 0 {
 			format = d.OutputFormat
 		} else if d.OutputFormat != format {
 			return "", fmt.Errorf("document %d format %s tidak cocok dengan %s", id, d.OutputFormat, format)
 		}
 		srcPaths = append(srcPaths, *d.FilePath)
+		if progress != nil {
+			progress(len(srcPaths))
+		}
 	}
 	ext := sharedStorage.ExtensionForFormat(string(format))
 	reqID := label
 	if reqID == "" {
 		reqID = fmt.Sprintf("merge-%d-docs", len(ids))
 	}
-	path, _, err := s.storage.Compose(ctx, 0, reqID, srcPaths, ext)
+	now := time.Now().UTC()
+
+	// Hasil merge ditulis ke file sementara lalu diunggah dengan key unik per tenant dan per pemanggilan,
+	// sama seperti ZipToStorage, sehingga merge berlabel sama tidak saling menimpa antar tenant.
+	tmp, err := os.CreateTemp("", "merge-*."+ext)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("merge temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	for i, src := range srcPaths {
+		data, err := s.storage.Download(ctx, src)
+		if err != nil {
+			return "", fmt.Errorf("download document %d: %w", ids[i], err)
+		}
+		if _, err := tmp.Write(data); err != nil {
+			return "", fmt.Errorf("merge temp file: %w", err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("merge temp file: %w", err)
 	}
-	url, err := s.storage.PresignedURL(ctx, path, 15*time.Minute)
+	path, err := s.storage.PutFile(ctx, archiveKey(tenantID, reqID, ext, now), mime.TypeByExtension("."+ext), tmp.Name())
 	if err != nil {
 		return "", err
 	}
 	if pubErr := s.publisher.PublishDocumentBulkEvent(ctx, "DocumentMerge", ids, tenantID, path, string(format)); pubErr != nil {
 		log.Printf("documents: PublishDocumentBulkEvent merge: %v", pubErr)
 	}
-	return url, nil
+	return path, nil
 }
 
 func mapRepoErr(err error) error {
@@ -0,0 +1,105 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/entity/enums"
+)
+
+// StatusEvent perubahan status dokumen untuk stream SSE.
+type StatusEvent struct {
+	ID             string // id entry stream; kosong untuk snapshot awal (dipakai sebagai Last-Event-ID)
+	DocumentID     int64
+	RequestID      string
+	TenantID       *string
+	Status         enums.DocumentStatus
+	PreviousStatus enums.DocumentStatus
+	ErrorMessage   *string
+	At             time.Time
+}
+
+// StatusStream sumber perubahan status lintas replica (dipenuhi Redis stream document-status).
+type StatusStream interface {
+	// Subscribe mengalirkan event dokumen milik tenantID (nil: dokumen tanpa tenant) setelah
+	// lastEventID (kosong: mulai dari sekarang).
+	// Channel ditutup saat ctx selesai atau subscriber tertinggal terlalu jauh.
+	Subscribe(ctx context.Context, tenantID *string, lastEventID string) (<-chan StatusEvent, error)
+}
+
+// StatusEventFrom snapshot status dokumen saat ini.
+func StatusEventFrom(d docEntity.Document) StatusEvent {
+	return StatusEvent{
+		DocumentID:   d.ID,
+		RequestID:    d.RequestID,
+		TenantID:     d.TenantID,
+		Status:       d.Status,
+		ErrorMessage: d.ErrorMessage,
+		At:           d.UpdatedAt,
+	}
+}
+
+// finalStatus status yang tidak berubah lagi tanpa aksi client (retry / patch).
+func finalStatus(s enums.DocumentStatus) bool {
+	return s == enums.DocumentStatusGenerated || s == enums.DocumentStatusFailed || s == enums.DocumentStatusCancelled
+}
+
+func (s *service) WatchDocument(ctx context.Context, id int64, tenantID *string, lastEventID string) (<-chan StatusEvent, error) {
+	if s.status == nil {
+		return nil, errors.New("status streaming not configured")
+	}
+	// Event di stream dikunci tenant pemilik dokumen, bukan tenant request: tanpa X-Tenant-Id
+	// GetByID tetap menemukan dokumen milik tenant, jadi subscribe memakai d.TenantID.
+	owner, err := s.docs.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	// Subscribe sebelum membaca snapshot agar transisi di antaranya tidak hilang.
+	ctx, cancel := context.WithCancel(ctx)
+	in, err := s.status.Subscribe(ctx, owner.TenantID, lastEventID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	d, err := s.docs.GetByID(ctx, nil, id, owner.TenantID)
+	if err != nil {
+		cancel()
+		return nil, mapRepoErr(err)
+	}
+
+	out := make(chan StatusEvent, 1)
+	go func() {
+		defer cancel()
+		defer close(out)
+		// Resume cukup dari backlog stream; dokumen yang sudah final langsung ditutup dengan snapshot.
+		if lastEventID == "" || finalStatus(d.Status) {
+			out <- StatusEventFrom(d)
+			if finalStatus(d.Status) {
+				return
+			}
+		}
+		for evt := range in {
+			if evt.DocumentID != id {
+				continue
+			}
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return
+			}
+			if finalStatus(evt.Status) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *service) WatchTenant(ctx context.Context, tenantID *string, lastEventID string) (<-chan StatusEvent, error) {
+	if s.status == nil {
+		return nil, errors.New("status streaming not configured")
+	}
+	return s.status.Subscribe(ctx, tenantID, lastEventID)
+}
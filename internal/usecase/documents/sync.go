@@ -0,0 +1,98 @@
+package documents
+
+import (
+	"context"
+	"log"
+	"time"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/entity/enums"
+)
+
+const (
+	defaultSyncTimeout     = 10 * time.Second
+	defaultSyncMaxInFlight = 8
+)
+
+// SyncOptions batas generation sinkron (POST /documents?mode=sync).
+type SyncOptions struct {
+	Timeout     time.Duration // 0 = 10 detik
+	MaxInFlight int           // render sinkron paralel per proses; 0 = 8
+}
+
+func (o SyncOptions) withDefaults() SyncOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = defaultSyncTimeout
+	}
+	if o.MaxInFlight <= 0 {
+		o.MaxInFlight = defaultSyncMaxInFlight
+	}
+	return o
+}
+
+// SyncResult hasil CreateSync. Done true berarti dokumen sudah GENERATED atau FAILED;
+// Done false berarti dokumen dilanjutkan async dan client kembali ke polling / callback.
+type SyncResult struct {
+	Doc    docEntity.Document
+	Replay bool
+	Done   bool
+}
+
+type syncOutcome struct {
+	doc docEntity.Document
+	err error
+}
+
+func (s *service) CreateSync(ctx context.Context, in CreateInput) (SyncResult, error) {
+	doc, replay, err := s.create(ctx, in, false)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if replay {
+		done := doc.Status == enums.DocumentStatusGenerated || doc.Status == enums.DocumentStatusFailed
+		return SyncResult{Doc: doc, Replay: true, Done: done}, nil
+	}
+
+	select {
+	case s.syncSlots <- struct{}{}:
+	default:
+		// Slot sinkron penuh: serahkan ke worker seperti Create biasa.
+		s.enqueue(ctx, doc)
+		return SyncResult{Doc: doc}, nil
+	}
+
+	// Render tidak ikut batal saat timeout atau client putus; dokumen tetap diselesaikan di background.
+	bg := context.WithoutCancel(ctx)
+	out := make(chan syncOutcome, 1)
+	go func() {
+		defer func() { <-s.syncSlots }()
+		d, err := s.process(bg, doc)
+		if err != nil && d.Status != enums.DocumentStatusFailed {
+			// Gagal sebelum status final (mis. DB): serahkan ke worker; Process melewati dokumen
+			// yang sudah tidak QUEUED.
+			log.Printf("documents: sync process %d: %v", doc.ID, err)
+			s.enqueue(bg, doc)
+		}
+		out <- syncOutcome{doc: d, err: err}
+	}()
+
+	timer := time.NewTimer(s.sync.Timeout)
+	defer timer.Stop()
+	select {
+	case o := <-out:
+		if o.err != nil && o.doc.Status != enums.DocumentStatusFailed {
+			return SyncResult{}, o.err
+		}
+		return SyncResult{Doc: o.doc, Done: true}, nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	// Render tetap berjalan di goroutine di atas. Pesan process tetap dikirim sebagai cadangan: bila
+	// proses ini mati sebelum render dimulai (dokumen masih QUEUED), worker Kafka mengambilnya; bila
+	// render sudah berjalan atau selesai, worker melewati pesan karena dokumen tidak lagi QUEUED.
+	s.enqueue(bg, doc)
+	if cur, err := s.docs.GetByID(bg, nil, doc.ID, doc.TenantID); err == nil {
+		doc = cur
+	}
+	return SyncResult{Doc: doc}, nil
+}
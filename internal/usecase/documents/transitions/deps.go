@@ -7,11 +7,18 @@ import (
 	"go-document-generator/internal/entity/enums"
 	tplrepo "go-document-generator/internal/repository/documenttemplates"
 	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/templating"
+	"go-document-generator/internal/usecase/documents/postrender"
 )
 
 // Generator merender dokumen (kontrak sama dengan documents.Generator).
 type Generator interface {
-	Generate(ctx context.Context, templateSource string, data any) ([]byte, string, error)
+	Generate(ctx context.Context, src templating.Source, data any) ([]byte, string, error)
+}
+
+// PartialResolver me-resolve partial/layout yang dipakai content versi template.
+type PartialResolver interface {
+	Resolve(ctx context.Context, tenantID *string, content string, layout *string) (templating.Source, error)
 }
 
 // GeneratorSelector memilih engine render.
@@ -19,6 +26,11 @@ type GeneratorSelector interface {
 	Select(outputFormat string, engine string) Generator
 }
 
+// AssetResolver memuat asset template ke source sebelum render.
+type AssetResolver interface {
+	Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error
+}
+
 // StorageProvider abstraksi penyimpanan file (sama signature dengan shared/storage.Provider).
 type StorageProvider interface {
 	Save(ctx context.Context, documentID int64, requestID, ext string, data []byte) (path, fileName string, err error)
@@ -32,4 +44,8 @@ type Deps struct {
 	Versions  verrepo.DocumentTemplateVersionsRepository
 	Selector  GeneratorSelector
 	Storage   StorageProvider
+	Partials  PartialResolver // nil: content dirender tanpa partial
+	Assets    AssetResolver   // nil: {{asset}} gagal saat render
+	// PostRender tahap setelah render (watermark); nil: dokumen ber-watermark gagal.
+	PostRender *postrender.Pipeline
 }
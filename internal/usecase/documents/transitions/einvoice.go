@@ -0,0 +1,46 @@
+package transitions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/templating"
+)
+
+// applyEInvoice memvalidasi e-invoice versi template. Output XML: file utama divalidasi terhadap XSD.
+// Output PDF (hybrid): template XML CII dirender dengan payload dan translator yang sama, divalidasi,
+// lalu dikembalikan untuk di-embed ke PDF/A-3 dan disimpan sebagai attachment.
+func applyEInvoice(ctx context.Context, deps Deps, d *docEntity.Document, ver verEntity.TemplateVersion, engine string, src templating.Source, data []byte) ([]byte, error) {
+	if ver.EInvoice == nil {
+		return nil, nil
+	}
+	if deps.PostRender == nil {
+		return nil, errors.New("post-render pipeline not configured")
+	}
+	switch d.OutputFormat {
+	case enums.OutputFormatXML:
+		if ver.EInvoice.Hybrid() {
+			return nil, nil
+		}
+		return nil, deps.PostRender.ValidateEInvoice(ctx, data, ver.EInvoice.Syntax)
+	case enums.OutputFormatPDF:
+		if !ver.EInvoice.Hybrid() {
+			return nil, nil
+		}
+		xsrc := templating.Inline(ver.EInvoice.Content)
+		xsrc.Translator = src.Translator
+		out, _, err := deps.Selector.Select(string(enums.OutputFormatXML), engine).Generate(ctx, xsrc, d.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("render xml: %w", err)
+		}
+		if err := deps.PostRender.ValidateEInvoice(ctx, out, ver.EInvoice.Syntax); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package transitions
+
+import (
+	"context"
+	"errors"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	"go-document-generator/internal/shared/pdfsecurity"
+)
+
+// applyEncryption mengenkripsi PDF bila dokumen meminta enkripsi. Password diambil dari payload
+// (password_path) atau dari password request yang tersegel. Segel tidak dihapus di sini: bila simpan
+// file gagal, retry masih membutuhkannya; toGenerated menghapusnya setelah status GENERATED.
+func applyEncryption(ctx context.Context, deps Deps, d *docEntity.Document, data []byte) ([]byte, error) {
+	d.IsEncrypted = false
+	if d.Encryption == nil {
+		return data, nil
+	}
+	if deps.PostRender == nil {
+		return nil, errors.New("post-render pipeline not configured")
+	}
+	var password string
+	switch {
+	case d.Encryption.PasswordPath != "":
+		pw, err := pdfsecurity.Lookup(d.Payload, d.Encryption.PasswordPath)
+		if err != nil {
+			return nil, err
+		}
+		password = pw
+	case d.EncryptionSecret != nil:
+		if deps.PostRender.Sealer == nil {
+			return nil, errors.New("pdf password secret not configured")
+		}
+		pw, err := deps.PostRender.Sealer.Open(*d.EncryptionSecret)
+		if err != nil {
+			return nil, err
+		}
+		password = pw
+	default:
+		return nil, errors.New("encryption password is no longer available")
+	}
+	out, err := deps.PostRender.Encrypt(ctx, data, password, *d.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	d.IsEncrypted = true
+	return out, nil
+}
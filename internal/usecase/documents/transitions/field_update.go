@@ -22,8 +22,8 @@ func (h *fieldUpdate) OnStateTransition(ctx context.Context, update docEntity.Do
 		if err != nil {
 			return docEntity.Document{}, err
 		}
-		if len(ver.Schema) > 0 {
-			if err := validators.ValidateSchema(ver.Schema, update.Payload); err != nil {
+		if schema := ver.EnforcedSchema(); schema != nil {
+			if err := validators.ValidateSchema(schema, update.Payload); err != nil {
 				return docEntity.Document{}, err
 			}
 		}
@@ -0,0 +1,45 @@
+package transitions
+
+import (
+	"context"
+	"errors"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/pdfa"
+)
+
+// applyPDFA mengonversi output PDF ke level PDF/A versi template lalu memvalidasinya. Report
+// disimpan di dokumen (juga saat ditolak); level hanya dicatat bila output konform. invoice (XML Factur-X)
+// di-embed sebagai associated file beserta metadata XMP Factur-X.
+func applyPDFA(ctx context.Context, deps Deps, d *docEntity.Document, ver verEntity.TemplateVersion, data, invoice []byte) ([]byte, error) {
+	d.PDFAConformance, d.PDFAReport = nil, nil
+	if ver.PDFAConformance == nil || d.OutputFormat != enums.OutputFormatPDF {
+		return data, nil
+	}
+	if deps.PostRender == nil {
+		return nil, errors.New("post-render pipeline not configured")
+	}
+	info := pdfa.Info{TemplateCode: d.TemplateCode, RequestID: d.RequestID, DocumentID: d.ID, CreatedAt: d.CreatedAt}
+	if invoice != nil && ver.EInvoice != nil {
+		spec := ver.EInvoice.WithDefaults()
+		info.Attachments = []pdfa.Attachment{{
+			Name: einvoice.FacturXFileName, ContentType: "text/xml", Relationship: "Data",
+			Description: "Factur-X invoice " + spec.Profile, Data: invoice,
+		}}
+		info.XMPExtension = einvoice.FacturXMetadata(spec.Profile)
+	}
+	out, report, err := deps.PostRender.Archive(ctx, data, *ver.PDFAConformance, info)
+	d.PDFAReport = report
+	if err != nil {
+		return nil, err
+	}
+	// Level hanya dicatat bila validator penuh mengonfirmasi; hasil preflight bawaan tetap di report.
+	if report.Compliant && report.Verified {
+		level := report.Level
+		d.PDFAConformance = &level
+	}
+	return out, nil
+}
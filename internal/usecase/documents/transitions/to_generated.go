@@ -10,7 +10,9 @@ import (
 
 	docEntity "go-document-generator/internal/entity/documents"
 	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
 	"go-document-generator/internal/shared/storage"
+	"go-document-generator/internal/shared/templating"
 )
 
 type toGenerated struct {
@@ -49,32 +51,61 @@ func generateAndFinalize(ctx context.Context, deps Deps, d *docEntity.Document)
 		return err
 	}
 
+	src := templating.Inline(ver.Content)
+	if deps.Partials != nil {
+		if src, err = deps.Partials.Resolve(ctx, d.TenantID, ver.Content, ver.Layout); err != nil {
+			return fmt.Errorf("resolve partials: %w", err)
+		}
+	}
+	if deps.Assets != nil {
+		if err := deps.Assets.Attach(ctx, d.TenantID, ver.TemplateID, &src); err != nil {
+			return fmt.Errorf("resolve assets: %w", err)
+		}
+	}
+	var locale string
+	if d.Locale != nil {
+		locale = *d.Locale
+	}
+	src.Translator = ver.Translator(locale)
+
 	gen := deps.Selector.Select(string(d.OutputFormat), string(tpl.Engine))
-	data, contentType, err := gen.Generate(ctx, ver.Content, d.Payload)
+	data, contentType, err := gen.Generate(ctx, src, d.Payload)
 	if err != nil {
 		return fmt.Errorf("generate document: %w", err)
 	}
+	invoice, err := applyEInvoice(ctx, deps, d, ver, string(tpl.Engine), src, data)
+	if err != nil {
+		return fmt.Errorf("e-invoice: %w", err)
+	}
+	if data, err = applyWatermark(ctx, deps, d, tpl, data); err != nil {
+		return fmt.Errorf("apply watermark: %w", err)
+	}
+	if data, err = applyPDFA(ctx, deps, d, ver, data, invoice); err != nil {
+		return fmt.Errorf("pdf/a: %w", err)
+	}
+	if data, err = applyEncryption(ctx, deps, d, data); err != nil {
+		return fmt.Errorf("encrypt document: %w", err)
+	}
 
 	ext := storage.ExtensionForFormat(string(d.OutputFormat))
-
-	var path, fileName string
-	var storageProvider enums.StorageProvider
-	if deps.Storage != nil {
-		path, fileName, err = deps.Storage.Save(ctx, d.ID, d.RequestID, ext, data)
-		if err != nil {
-			return fmt.Errorf("save document file: %w", err)
-		}
-		storageProvider = deps.Storage.ProviderName()
-	} else {
-		path, fileName, err = storage.SaveDocument("", d.ID, d.RequestID, ext, data)
+	path, fileName, storageProvider, err := saveFile(ctx, deps, d, ext, data)
+	if err != nil {
+		return fmt.Errorf("save document file: %w", err)
+	}
+	d.Attachments = nil
+	if invoice != nil {
+		xpath, xname, xprovider, err := saveFile(ctx, deps, d, storage.ExtensionForFormat(string(enums.OutputFormatXML)), invoice)
 		if err != nil {
-			return fmt.Errorf("save document file: %w", err)
+			return fmt.Errorf("save e-invoice file: %w", err)
 		}
-		storageProvider = enums.StorageProviderLocal
+		d.Attachments = []docEntity.Attachment{{
+			Name: einvoice.FacturXFileName, Kind: docEntity.AttachmentKindEInvoiceXML,
+			FileName: xname, FilePath: xpath, StorageProvider: xprovider, ContentType: "application/xml",
+			FileSize: int64(len(invoice)), Checksum: checksum(invoice), Syntax: ver.EInvoice.Syntax,
+		}}
 	}
 
-	sum := sha256.Sum256(data)
-	chk := hex.EncodeToString(sum[:])
+	chk := checksum(data)
 	size := int64(len(data))
 	now := time.Now().UTC()
 	provider := storageProvider
@@ -88,6 +119,23 @@ func generateAndFinalize(ctx context.Context, deps Deps, d *docEntity.Document)
 	d.StorageProvider = &provider
 	d.ProcessedAt = &now
 	d.ErrorMessage = nil
+	// File terenkripsi sudah tersimpan; password tersegel tidak dibutuhkan lagi.
+	d.EncryptionSecret = nil
 
 	return nil
 }
+
+// saveFile menyimpan file dokumen ke storage provider (default: disk lokal).
+func saveFile(ctx context.Context, deps Deps, d *docEntity.Document, ext string, data []byte) (string, string, enums.StorageProvider, error) {
+	if deps.Storage != nil {
+		path, fileName, err := deps.Storage.Save(ctx, d.ID, d.RequestID, ext, data)
+		return path, fileName, deps.Storage.ProviderName(), err
+	}
+	path, fileName, err := storage.SaveDocument("", d.ID, d.RequestID, ext, data)
+	return path, fileName, enums.StorageProviderLocal, err
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
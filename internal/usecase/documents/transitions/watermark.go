@@ -0,0 +1,40 @@
+package transitions
+
+import (
+	"context"
+	"errors"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	tplEntity "go-document-generator/internal/entity/documenttemplates"
+	"go-document-generator/internal/shared/watermark"
+	"go-document-generator/internal/usecase/documents/postrender"
+)
+
+// applyWatermark menerapkan watermark request, atau default template bila request tidak membawa
+// watermark, lalu mencatat spec yang diterapkan (dengan nilai default) di dokumen.
+func applyWatermark(ctx context.Context, deps Deps, d *docEntity.Document, tpl tplEntity.Template, data []byte) ([]byte, error) {
+	spec := d.Watermark
+	// Watermark dari template (tercatat pada percobaan sebelumnya) di-resolve ulang saat retry.
+	if spec == nil || spec.Source == watermark.SourceTemplate {
+		spec = nil
+		if tpl.Watermark != nil && postrender.SupportsWatermark(d.OutputFormat) {
+			w := *tpl.Watermark
+			w.Source = watermark.SourceTemplate
+			spec = &w
+		}
+	}
+	if spec == nil {
+		d.Watermark = nil
+		return data, nil
+	}
+	if deps.PostRender == nil {
+		return nil, errors.New("post-render pipeline not configured")
+	}
+	out, err := deps.PostRender.Watermark(ctx, d.TenantID, tpl.ID, *spec, d.OutputFormat, data)
+	if err != nil {
+		return nil, err
+	}
+	applied := spec.WithDefaults()
+	d.Watermark = &applied
+	return out, nil
+}
@@ -0,0 +1,27 @@
+package documents
+
+import (
+	"fmt"
+
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/watermark"
+	"go-document-generator/internal/usecase/documents/postrender"
+)
+
+// requestWatermark memvalidasi watermark dari request create dan menandainya source REQUEST.
+// Watermark hanya didukung untuk output PDF dan HTML.
+func requestWatermark(w *watermark.Spec, format enums.OutputFormat) (*watermark.Spec, error) {
+	if w == nil {
+		return nil, nil
+	}
+	if err := w.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: watermark: %v", apperror.ErrInvalidInput, err)
+	}
+	if !postrender.SupportsWatermark(format) {
+		return nil, fmt.Errorf("%w: watermark is not supported for %s output", apperror.ErrInvalidInput, format)
+	}
+	spec := *w
+	spec.Source = watermark.SourceRequest
+	return &spec, nil
+}
@@ -0,0 +1,77 @@
+package documentschedules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/shared/netguard"
+)
+
+const (
+	payloadSourceHTTP     = "HTTP"
+	payloadSourceMaxBytes = 5 << 20
+	payloadSourceTimeout  = 15 * time.Second
+)
+
+// payloadFetcher mengambil payload dari payload source saat jadwal jalan.
+type payloadFetcher interface {
+	Fetch(ctx context.Context, src schedEntity.PayloadSource, scheduleID int64, at time.Time) (map[string]any, error)
+}
+
+type httpPayloadFetcher struct {
+	client *http.Client
+}
+
+// newHTTPPayloadFetcher client hanya tersambung ke alamat publik, kecuali host di guard.Allowed.
+func newHTTPPayloadFetcher(guard netguard.Guard) payloadFetcher {
+	return &httpPayloadFetcher{client: guard.Client(payloadSourceTimeout)}
+}
+
+// Fetch GET url dengan query schedule_id dan scheduled_for (RFC3339) agar source bisa mengembalikan
+// data periode tersebut; response wajib JSON object.
+func (f *httpPayloadFetcher) Fetch(ctx context.Context, src schedEntity.PayloadSource, scheduleID int64, at time.Time) (map[string]any, error) {
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("schedule_id", strconv.FormatInt(scheduleID, 10))
+	q.Set("scheduled_for", at.UTC().Format(time.RFC3339))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range src.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GET %s: %s", src.URL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, payloadSourceMaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > payloadSourceMaxBytes {
+		return nil, fmt.Errorf("GET %s: response exceeds %d bytes", src.URL, payloadSourceMaxBytes)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("GET %s: response is not a JSON object: %w", src.URL, err)
+	}
+	return payload, nil
+}
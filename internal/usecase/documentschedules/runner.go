@@ -0,0 +1,232 @@
+package documentschedules
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/usecase/documents"
+)
+
+const (
+	// runDueBatch jadwal maksimum yang diproses per tick scheduler.
+	runDueBatch = 20
+	// maxCatchUp waktu terlewat yang dijalankan per klaim untuk FIRE_ALL; sisanya di tick berikutnya.
+	maxCatchUp = 50
+	// maxMisfireScan batas enumerasi waktu terlewat; di atasnya jadwal langsung lompat ke waktu berikutnya.
+	maxMisfireScan = 10000
+	// claimLease lama jadwal ditahan selama fetch payload dan create dokumen; bila proses mati,
+	// jadwal diklaim ulang setelahnya.
+	claimLease = 10 * time.Minute
+)
+
+// RunDue mengklaim jadwal jatuh tempo satu per satu. Klaim (SKIP LOCKED) hanya memasang lease
+// claimed_until lalu commit, sehingga lock baris tidak ditahan selama I/O jaringan; replika lain
+// melewati jadwal yang di-lease. Bila proses mati sebelum Advance, jadwal diklaim ulang setelah
+// lease habis dan request_id deterministik membuat Create menjadi replay.
+func (s *service) RunDue(ctx context.Context, now time.Time) (int, error) {
+	now = now.UTC()
+	created := 0
+	for i := 0; i < runDueBatch; i++ {
+		n, ok, err := s.runNext(ctx, now)
+		created += n
+		if err != nil {
+			return created, err
+		}
+		if !ok {
+			break
+		}
+	}
+	return created, nil
+}
+
+func (s *service) runNext(ctx context.Context, now time.Time) (created int, claimed bool, err error) {
+	sc, ok, err := s.claim(ctx, now)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+
+	fire, skipped, next, err := s.plan(sc, now)
+	if err != nil {
+		// Definisi rusak (mis. timezone dihapus dari tzdata): jadwal dihentikan agar tidak diklaim terus.
+		log.Printf("documentschedules: schedule %d: %v", sc.ID, err)
+		msg := err.Error()
+		if err = s.schedules.CreateRun(ctx, nil, schedEntity.Run{
+			TenantID: sc.TenantID, ScheduleID: sc.ID, ScheduledFor: sc.NextRunAt.UTC(),
+			Status: enums.ScheduleRunStatusFailed, ErrorMessage: &msg,
+		}); err != nil {
+			return 0, true, err
+		}
+		return 0, true, s.schedules.Advance(ctx, nil, sc.ID, nil, &now, enums.ScheduleStatusPaused)
+	}
+
+	if len(skipped) > 0 {
+		msg := fmt.Sprintf("misfire (%s): skipped %d run(s) scheduled from %s to %s",
+			sc.MisfirePolicy, len(skipped), skipped[0].Format(time.RFC3339), skipped[len(skipped)-1].Format(time.RFC3339))
+		if err = s.schedules.CreateRun(ctx, nil, schedEntity.Run{
+			TenantID: sc.TenantID, ScheduleID: sc.ID, ScheduledFor: skipped[0],
+			Status: enums.ScheduleRunStatusSkipped, ErrorMessage: &msg,
+		}); err != nil {
+			return 0, true, err
+		}
+	}
+	for _, at := range fire {
+		run := s.fire(ctx, sc, at)
+		if run.Status == enums.ScheduleRunStatusSucceeded {
+			created++
+		}
+		if err = s.schedules.CreateRun(ctx, nil, run); err != nil {
+			return created, true, err
+		}
+	}
+
+	status := enums.ScheduleStatusActive
+	if next == nil {
+		status = enums.ScheduleStatusCompleted
+	}
+	return created, true, s.schedules.Advance(ctx, nil, sc.ID, next, &now, status)
+}
+
+// claim mengambil satu jadwal jatuh tempo dan memasang lease dalam transaksi singkat.
+func (s *service) claim(ctx context.Context, now time.Time) (sc schedEntity.Schedule, ok bool, err error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return sc, false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	due, err := s.schedules.ClaimDue(ctx, tx, now, 1)
+	if err != nil {
+		return sc, false, err
+	}
+	if len(due) == 0 {
+		return sc, false, s.txManager.Commit(ctx, tx)
+	}
+	if err = s.schedules.Lease(ctx, tx, due[0].ID, now.Add(claimLease)); err != nil {
+		return sc, false, err
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return sc, false, err
+	}
+	return due[0], true, nil
+}
+
+// plan membagi waktu jadwal yang sudah lewat (next_run_at .. now) menjadi yang dijalankan dan
+// yang dilewati sesuai misfire policy, serta menghitung next_run_at baru (nil = selesai).
+// Waktu yang terlambat tidak lebih dari misfireGrace dianggap tepat waktu.
+func (s *service) plan(sc schedEntity.Schedule, now time.Time) (fire, skipped []time.Time, next *time.Time, err error) {
+	trig, err := newTrigger(sc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var due []time.Time
+	t := sc.NextRunAt.UTC()
+	for {
+		if t.After(now) || (sc.MisfirePolicy == enums.ScheduleMisfireFireAll && len(due) == maxCatchUp) {
+			next = &t
+			break
+		}
+		if len(due) == maxMisfireScan {
+			next = trig.next(now)
+			break
+		}
+		due = append(due, t)
+		n := trig.next(t)
+		if n == nil {
+			break
+		}
+		t = *n
+	}
+
+	onTime := func(at time.Time) bool { return now.Sub(at) <= s.misfireGrace }
+	switch sc.MisfirePolicy {
+	case enums.ScheduleMisfireFireAll:
+		fire = due
+	case enums.ScheduleMisfireSkip:
+		for _, at := range due {
+			if onTime(at) {
+				fire = append(fire, at)
+			} else {
+				skipped = append(skipped, at)
+			}
+		}
+	default:
+		// FIRE_ONCE: hanya waktu terakhir; yang lebih lama dilewati.
+		if len(due) > 0 {
+			fire = due[len(due)-1:]
+			skipped = due[:len(due)-1]
+		}
+	}
+	return fire, skipped, next, nil
+}
+
+// fire membuat dokumen untuk satu waktu jadwal. Error dicatat di run, bukan dikembalikan,
+// agar jadwal tetap maju dan kegagalan terlihat di riwayat.
+func (s *service) fire(ctx context.Context, sc schedEntity.Schedule, at time.Time) schedEntity.Run {
+	requestID := RequestID(sc.ID, at)
+	run := schedEntity.Run{
+		TenantID: sc.TenantID, ScheduleID: sc.ID, ScheduledFor: at,
+		Status: enums.ScheduleRunStatusFailed, RequestID: &requestID,
+	}
+	fail := func(err error) schedEntity.Run {
+		log.Printf("documentschedules: schedule %d at %s: %v", sc.ID, at.Format(time.RFC3339), err)
+		msg := err.Error()
+		run.ErrorMessage = &msg
+		return run
+	}
+
+	payload := make(map[string]any, len(sc.Payload))
+	for k, v := range sc.Payload {
+		payload[k] = v
+	}
+	if sc.PayloadSource != nil {
+		fetched, err := s.fetcher.Fetch(ctx, *sc.PayloadSource, sc.ID, at)
+		if err != nil {
+			return fail(fmt.Errorf("payload source: %w", err))
+		}
+		for k, v := range fetched {
+			payload[k] = v
+		}
+	}
+	metadata := make(map[string]any, len(sc.Metadata)+2)
+	for k, v := range sc.Metadata {
+		metadata[k] = v
+	}
+	metadata["schedule_id"] = sc.ID
+	metadata["scheduled_for"] = at.Format(time.RFC3339)
+
+	doc, _, err := s.docs.Create(ctx, documents.CreateInput{
+		TenantID:        sc.TenantID,
+		RequestID:       requestID,
+		TemplateCode:    sc.TemplateCode,
+		TemplateVersion: sc.TemplateVersion,
+		OutputFormat:    sc.OutputFormat,
+		Locale:          sc.Locale,
+		Priority:        sc.Priority,
+		Payload:         payload,
+		Metadata:        metadata,
+		StoreToDms:      sc.StoreToDms,
+		HasCallback:     sc.HasCallback,
+		CallbackURL:     sc.CallbackURL,
+		CreatedBy:       sc.CreatedBy,
+	})
+	if err != nil {
+		return fail(err)
+	}
+	run.Status = enums.ScheduleRunStatusSucceeded
+	run.DocumentID = &doc.ID
+	return run
+}
+
+// RequestID request_id dokumen untuk satu waktu jadwal: sama untuk jadwal dan waktu yang sama
+// sehingga klaim ulang setelah crash tidak membuat dokumen ganda.
+func RequestID(scheduleID int64, at time.Time) string {
+	return fmt.Sprintf("schedule-%d-%s", scheduleID, at.UTC().Format("20060102T1504Z"))
+}
@@ -0,0 +1,381 @@
+package documentschedules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	docEntity "go-document-generator/internal/entity/documents"
+	schedEntity "go-document-generator/internal/entity/documentschedules"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/repository/begin"
+	schedrepo "go-document-generator/internal/repository/documentschedules"
+	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/cron"
+	"go-document-generator/internal/shared/netguard"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/usecase/documents"
+)
+
+// DocumentCreator membuat dokumen saat jadwal jalan (dipenuhi documents.Service).
+type DocumentCreator interface {
+	Create(ctx context.Context, in documents.CreateInput) (docEntity.Document, bool, error)
+}
+
+// Patch perubahan jadwal; field nil tidak diubah. Mengisi CronExpression menghapus RunAt dan sebaliknya.
+type Patch struct {
+	Name            *string
+	Description     *string
+	TemplateCode    *string
+	TemplateVersion *int
+	OutputFormat    *enums.OutputFormat
+	Locale          *string
+	Priority        *enums.DocumentPriority
+	CronExpression  *string
+	RunAt           *time.Time
+	Timezone        *string
+	Payload         map[string]any
+	PayloadSource   *schedEntity.PayloadSource // Type kosong menghapus payload source
+	Metadata        map[string]any
+	StoreToDms      *bool
+	HasCallback     *bool
+	CallbackURL     *string
+	MisfirePolicy   *enums.ScheduleMisfirePolicy
+}
+
+type Service interface {
+	Create(ctx context.Context, s schedEntity.Schedule) (schedEntity.Schedule, error)
+	GetByID(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error)
+	List(ctx context.Context, f schedrepo.ListFilter) ([]schedEntity.Schedule, pagination.Meta, error)
+	// Update mengubah definisi jadwal; perubahan trigger menghitung ulang next_run_at.
+	Update(ctx context.Context, id int64, tenantID *string, p Patch) (schedEntity.Schedule, error)
+	Delete(ctx context.Context, id int64, tenantID *string) error
+	// Pause menghentikan jadwal ACTIVE; waktu jadwal selama pause tidak dijalankan.
+	Pause(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error)
+	// Resume mengaktifkan kembali jadwal PAUSED mulai waktu jadwal berikutnya setelah sekarang.
+	Resume(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error)
+	ListRuns(ctx context.Context, id int64, tenantID *string, page pagination.Params) ([]schedEntity.Run, pagination.Meta, error)
+	// RunDue dipanggil scheduler: menjalankan jadwal yang sudah jatuh tempo, mengembalikan jumlah dokumen dibuat.
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type service struct {
+	schedules    schedrepo.DocumentSchedulesRepository
+	templates    tplrepo.DocumentTemplatesRepository
+	txManager    begin.BeginRepository
+	docs         DocumentCreator
+	misfireGrace time.Duration
+	fetcher      payloadFetcher
+	guard        netguard.Guard
+}
+
+const defaultMisfireGrace = 5 * time.Minute
+
+// NewService: misfireGrace batas keterlambatan yang masih dianggap tepat waktu; 0 = 5 menit.
+// payloadHosts host payload source yang boleh berada di jaringan internal; selain itu hanya alamat publik.
+func NewService(
+	schedules schedrepo.DocumentSchedulesRepository,
+	templates tplrepo.DocumentTemplatesRepository,
+	tx begin.BeginRepository,
+	docs DocumentCreator,
+	misfireGrace time.Duration,
+	payloadHosts []string,
+) Service {
+	if misfireGrace <= 0 {
+		misfireGrace = defaultMisfireGrace
+	}
+	guard := netguard.Guard{Allowed: payloadHosts}
+	return &service{
+		schedules:    schedules,
+		templates:    templates,
+		txManager:    tx,
+		docs:         docs,
+		misfireGrace: misfireGrace,
+		fetcher:      newHTTPPayloadFetcher(guard),
+		guard:        guard,
+	}
+}
+
+func (s *service) Create(ctx context.Context, sc schedEntity.Schedule) (schedEntity.Schedule, error) {
+	sc.Status = enums.ScheduleStatusActive
+	if err := s.validate(ctx, &sc); err != nil {
+		return schedEntity.Schedule{}, err
+	}
+	now := time.Now().UTC()
+	if sc.RunAt != nil && !sc.RunAt.After(now) {
+		return schedEntity.Schedule{}, fmt.Errorf("%w: run_at must be in the future", apperror.ErrInvalidInput)
+	}
+	next, err := firstRun(sc, now)
+	if err != nil {
+		return schedEntity.Schedule{}, err
+	}
+	sc.NextRunAt = next
+	created, err := s.schedules.Create(ctx, nil, sc)
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetByID(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error) {
+	sc, err := s.schedules.GetByID(ctx, nil, id, tenantID)
+	return sc, mapRepoErr(err)
+}
+
+func (s *service) List(ctx context.Context, f schedrepo.ListFilter) ([]schedEntity.Schedule, pagination.Meta, error) {
+	f.Page = pagination.Normalize(f.Page.Page, f.Page.Limit)
+	items, total, err := s.schedules.List(ctx, nil, f)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return items, pagination.Meta{Page: f.Page.Page, Limit: f.Page.Limit, Total: total}, nil
+}
+
+func (s *service) Update(ctx context.Context, id int64, tenantID *string, p Patch) (schedEntity.Schedule, error) {
+	sc, err := s.schedules.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return schedEntity.Schedule{}, mapRepoErr(err)
+	}
+	triggerChanged := p.CronExpression != nil || p.RunAt != nil || p.Timezone != nil
+	applyPatch(&sc, p)
+	if err := s.validate(ctx, &sc); err != nil {
+		return schedEntity.Schedule{}, err
+	}
+	if triggerChanged {
+		now := time.Now().UTC()
+		if p.RunAt != nil && !p.RunAt.After(now) {
+			return schedEntity.Schedule{}, fmt.Errorf("%w: run_at must be in the future", apperror.ErrInvalidInput)
+		}
+		// Trigger baru mengaktifkan kembali jadwal one-off yang sudah selesai; jadwal PAUSED tetap PAUSED.
+		if sc.Status == enums.ScheduleStatusCompleted {
+			sc.Status = enums.ScheduleStatusActive
+		}
+		if sc.Status == enums.ScheduleStatusActive {
+			if sc.NextRunAt, err = firstRun(sc, now); err != nil {
+				return schedEntity.Schedule{}, err
+			}
+		}
+	}
+	saved, err := s.schedules.Update(ctx, nil, sc)
+	return saved, mapRepoErr(err)
+}
+
+func (s *service) Delete(ctx context.Context, id int64, tenantID *string) error {
+	return mapRepoErr(s.schedules.Delete(ctx, nil, id, tenantID))
+}
+
+func (s *service) Pause(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error) {
+	sc, err := s.schedules.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return schedEntity.Schedule{}, mapRepoErr(err)
+	}
+	if sc.Status != enums.ScheduleStatusActive {
+		return schedEntity.Schedule{}, fmt.Errorf("%w: schedule is %s", apperror.ErrInvalidState, sc.Status)
+	}
+	sc.Status = enums.ScheduleStatusPaused
+	sc.NextRunAt = nil
+	saved, err := s.schedules.Update(ctx, nil, sc)
+	return saved, mapRepoErr(err)
+}
+
+func (s *service) Resume(ctx context.Context, id int64, tenantID *string) (schedEntity.Schedule, error) {
+	sc, err := s.schedules.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return schedEntity.Schedule{}, mapRepoErr(err)
+	}
+	if sc.Status != enums.ScheduleStatusPaused {
+		return schedEntity.Schedule{}, fmt.Errorf("%w: schedule is %s", apperror.ErrInvalidState, sc.Status)
+	}
+	// Jadwal one-off yang lewat selama pause diperlakukan sebagai misfire.
+	next, err := firstRun(sc, time.Now().UTC())
+	if err != nil {
+		return schedEntity.Schedule{}, err
+	}
+	sc.Status = enums.ScheduleStatusActive
+	sc.NextRunAt = next
+	saved, err := s.schedules.Update(ctx, nil, sc)
+	return saved, mapRepoErr(err)
+}
+
+func (s *service) ListRuns(ctx context.Context, id int64, tenantID *string, page pagination.Params) ([]schedEntity.Run, pagination.Meta, error) {
+	if _, err := s.schedules.GetByID(ctx, nil, id, tenantID); err != nil {
+		return nil, pagination.Meta{}, mapRepoErr(err)
+	}
+	page = pagination.Normalize(page.Page, page.Limit)
+	items, total, err := s.schedules.ListRuns(ctx, nil, id, page)
+	if err != nil {
+		return nil, pagination.Meta{}, err
+	}
+	return items, pagination.Meta{Page: page.Page, Limit: page.Limit, Total: total}, nil
+}
+
+func applyPatch(sc *schedEntity.Schedule, p Patch) {
+	if p.Name != nil {
+		sc.Name = *p.Name
+	}
+	if p.Description != nil {
+		sc.Description = p.Description
+	}
+	if p.TemplateCode != nil {
+		sc.TemplateCode = *p.TemplateCode
+	}
+	if p.TemplateVersion != nil {
+		sc.TemplateVersion = p.TemplateVersion
+	}
+	if p.OutputFormat != nil {
+		sc.OutputFormat = *p.OutputFormat
+	}
+	if p.Locale != nil {
+		sc.Locale = p.Locale
+	}
+	if p.Priority != nil {
+		sc.Priority = *p.Priority
+	}
+	if p.CronExpression != nil {
+		sc.CronExpression, sc.RunAt = p.CronExpression, nil
+	}
+	if p.RunAt != nil {
+		sc.RunAt, sc.CronExpression = p.RunAt, nil
+	}
+	if p.Timezone != nil {
+		sc.Timezone = *p.Timezone
+	}
+	if p.Payload != nil {
+		sc.Payload = p.Payload
+	}
+	if p.PayloadSource != nil {
+		sc.PayloadSource = p.PayloadSource
+		if p.PayloadSource.Type == "" {
+			sc.PayloadSource = nil
+		}
+	}
+	if p.Metadata != nil {
+		sc.Metadata = p.Metadata
+	}
+	if p.StoreToDms != nil {
+		sc.StoreToDms = *p.StoreToDms
+	}
+	if p.HasCallback != nil {
+		sc.HasCallback = *p.HasCallback
+	}
+	if p.CallbackURL != nil {
+		sc.CallbackURL = p.CallbackURL
+	}
+	if p.MisfirePolicy != nil {
+		sc.MisfirePolicy = *p.MisfirePolicy
+	}
+}
+
+// validate menormalkan default (timezone UTC, misfire FIRE_ONCE) lalu memeriksa definisi jadwal.
+func (s *service) validate(ctx context.Context, sc *schedEntity.Schedule) error {
+	sc.Name = strings.TrimSpace(sc.Name)
+	sc.TemplateCode = strings.TrimSpace(sc.TemplateCode)
+	if sc.Name == "" || sc.TemplateCode == "" {
+		return fmt.Errorf("%w: name and template_code are required", apperror.ErrInvalidInput)
+	}
+	if (sc.CronExpression == nil) == (sc.RunAt == nil) {
+		return fmt.Errorf("%w: exactly one of cron_expression or run_at is required", apperror.ErrInvalidInput)
+	}
+	if sc.Timezone == "" {
+		sc.Timezone = "UTC"
+	}
+	if sc.MisfirePolicy == "" {
+		sc.MisfirePolicy = enums.ScheduleMisfireFireOnce
+	}
+	switch sc.MisfirePolicy {
+	case enums.ScheduleMisfireFireOnce, enums.ScheduleMisfireFireAll, enums.ScheduleMisfireSkip:
+	default:
+		return fmt.Errorf("%w: invalid misfire_policy %q", apperror.ErrInvalidInput, sc.MisfirePolicy)
+	}
+	if sc.Priority != "" && !documents.ValidPriority(sc.Priority) {
+		return fmt.Errorf("%w: invalid priority %q", apperror.ErrInvalidInput, sc.Priority)
+	}
+	if _, err := newTrigger(*sc); err != nil {
+		return err
+	}
+	if src := sc.PayloadSource; src != nil {
+		if !strings.EqualFold(src.Type, payloadSourceHTTP) {
+			return fmt.Errorf("%w: unsupported payload_source type %q", apperror.ErrInvalidInput, src.Type)
+		}
+		src.Type = payloadSourceHTTP
+		u, err := url.Parse(src.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: payload_source url must be an absolute http(s) URL", apperror.ErrInvalidInput)
+		}
+		if err := s.guard.CheckHost(u.Hostname()); err != nil {
+			return fmt.Errorf("%w: payload_source url: %v", apperror.ErrInvalidInput, err)
+		}
+	}
+	if sc.Payload == nil {
+		sc.Payload = map[string]any{}
+	}
+	if _, err := s.templates.GetByCode(ctx, nil, sc.TemplateCode, sc.TenantID); err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return fmt.Errorf("%w: template %q not found", apperror.ErrInvalidInput, sc.TemplateCode)
+		}
+		return err
+	}
+	return nil
+}
+
+// trigger menghitung waktu jadwal berikutnya; cron nil berarti jadwal one-off.
+type trigger struct {
+	cron *cron.Schedule
+	loc  *time.Location
+}
+
+func newTrigger(sc schedEntity.Schedule) (trigger, error) {
+	loc, err := time.LoadLocation(sc.Timezone)
+	if err != nil {
+		return trigger{}, fmt.Errorf("%w: invalid timezone %q", apperror.ErrInvalidInput, sc.Timezone)
+	}
+	if sc.CronExpression == nil {
+		return trigger{loc: loc}, nil
+	}
+	c, err := cron.Parse(*sc.CronExpression)
+	if err != nil {
+		return trigger{}, fmt.Errorf("%w: %v", apperror.ErrInvalidInput, err)
+	}
+	return trigger{cron: &c, loc: loc}, nil
+}
+
+// next waktu jadwal setelah t (UTC); nil bila tidak ada lagi.
+func (t trigger) next(after time.Time) *time.Time {
+	if t.cron == nil {
+		return nil
+	}
+	n := t.cron.Next(after.In(t.loc))
+	if n.IsZero() {
+		return nil
+	}
+	n = n.UTC()
+	return &n
+}
+
+// firstRun waktu jadwal pertama dihitung dari now: run_at untuk one-off, cron berikutnya untuk berulang.
+func firstRun(sc schedEntity.Schedule, now time.Time) (*time.Time, error) {
+	if sc.RunAt != nil {
+		at := sc.RunAt.UTC()
+		return &at, nil
+	}
+	trig, err := newTrigger(sc)
+	if err != nil {
+		return nil, err
+	}
+	next := trig.next(now)
+	if next == nil {
+		return nil, fmt.Errorf("%w: cron_expression never fires", apperror.ErrInvalidInput)
+	}
+	return next, nil
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
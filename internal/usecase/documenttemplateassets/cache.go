@@ -0,0 +1,42 @@
+package documenttemplateassets
+
+import "sync"
+
+const defaultCacheBytes = 64 << 20
+
+// blobCache cache isi asset per checksum. Isi content-addressed tidak pernah berubah, jadi tidak
+// perlu invalidasi; bila melewati batas ukuran cache dikosongkan.
+type blobCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+	size  int
+	max   int
+}
+
+func newBlobCache(max int) *blobCache {
+	return &blobCache{items: map[string][]byte{}, max: max}
+}
+
+func (c *blobCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.items[key]
+	return data, ok
+}
+
+func (c *blobCache) put(key string, data []byte) {
+	if len(data) > c.max {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; ok {
+		return
+	}
+	if c.size+len(data) > c.max {
+		c.items = map[string][]byte{}
+		c.size = 0
+	}
+	c.items[key] = data
+	c.size += len(data)
+}
@@ -0,0 +1,267 @@
+package documenttemplateassets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	assetEntity "go-document-generator/internal/entity/documenttemplateassets"
+	"go-document-generator/internal/entity/enums"
+	assetrepo "go-document-generator/internal/repository/documenttemplateassets"
+	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/templating"
+)
+
+// MaxAssetSize batas ukuran satu asset; asset di-embed ke setiap dokumen yang dirender.
+const MaxAssetSize = 5 << 20
+
+// StorageProvider subset storage provider yang dipakai asset store.
+type StorageProvider interface {
+	Put(ctx context.Context, key, contentType string, data []byte) (path string, err error)
+	Download(ctx context.Context, path string) ([]byte, error)
+	ProviderName() enums.StorageProvider
+}
+
+type UploadInput struct {
+	TenantID    *string
+	TemplateID  *int64 // nil: asset tenant-wide
+	Name        string
+	ContentType string // kosong: dideteksi dari ekstensi/isi file
+	Data        []byte
+	CreatedBy   *string
+}
+
+type Service interface {
+	// Upload menyimpan asset baru atau mengganti isi asset dengan nama yang sama pada scope yang sama.
+	// Bool bernilai true bila asset baru dibuat.
+	Upload(ctx context.Context, in UploadInput) (assetEntity.Asset, bool, error)
+	GetByID(ctx context.Context, id int64, tenantID *string) (assetEntity.Asset, error)
+	// Content mengembalikan metadata dan isi file asset.
+	Content(ctx context.Context, id int64, tenantID *string) (assetEntity.Asset, []byte, error)
+	List(ctx context.Context, tenantID *string, templateID *int64) ([]assetEntity.Asset, error)
+	Delete(ctx context.Context, id int64, tenantID *string) error
+	// Attach memuat asset yang dipanggil src ({{asset "nama"}}) untuk template tersebut.
+	Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error
+	// Load memuat satu asset berdasarkan nama dengan prioritas yang sama seperti Attach
+	// (dipakai watermark gambar).
+	Load(ctx context.Context, tenantID *string, templateID int64, name string) (templating.Asset, error)
+}
+
+type service struct {
+	assets    assetrepo.DocumentTemplateAssetsRepository
+	templates tplrepo.DocumentTemplatesRepository
+	storage   StorageProvider
+	cache     *blobCache
+}
+
+func NewService(
+	assets assetrepo.DocumentTemplateAssetsRepository,
+	templates tplrepo.DocumentTemplatesRepository,
+	storageProv StorageProvider,
+) Service {
+	return &service{assets: assets, templates: templates, storage: storageProv, cache: newBlobCache(defaultCacheBytes)}
+}
+
+var assetName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*(/[A-Za-z0-9_][A-Za-z0-9_.-]*)*$`)
+
+// Tipe di luar tabel mime bawaan Go.
+var extContentTypes = map[string]string{
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".ttf":   "font/ttf",
+	".otf":   "font/otf",
+	".svg":   "image/svg+xml",
+	".css":   "text/css",
+}
+
+func (s *service) Upload(ctx context.Context, in UploadInput) (assetEntity.Asset, bool, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return assetEntity.Asset{}, false, fmt.Errorf("%w: name is required", apperror.ErrInvalidInput)
+	}
+	if len(name) > 200 || !assetName.MatchString(name) || strings.Contains(name, "..") {
+		return assetEntity.Asset{}, false, fmt.Errorf("%w: invalid asset name %q", apperror.ErrInvalidInput, name)
+	}
+	if len(in.Data) == 0 {
+		return assetEntity.Asset{}, false, fmt.Errorf("%w: file is required", apperror.ErrInvalidInput)
+	}
+	if len(in.Data) > MaxAssetSize {
+		return assetEntity.Asset{}, false, fmt.Errorf("%w: asset exceeds %d bytes", apperror.ErrInvalidInput, MaxAssetSize)
+	}
+	contentType := detectContentType(name, in.ContentType, in.Data)
+	if !allowedContentType(contentType) {
+		return assetEntity.Asset{}, false, fmt.Errorf("%w: content type %s is not an image, font or stylesheet", apperror.ErrInvalidInput, contentType)
+	}
+	if in.TemplateID != nil {
+		if _, err := s.templates.GetByID(ctx, nil, *in.TemplateID, in.TenantID); err != nil {
+			return assetEntity.Asset{}, false, mapRepoErr(err)
+		}
+	}
+	if s.storage == nil {
+		return assetEntity.Asset{}, false, errors.New("asset storage not configured")
+	}
+
+	sum := sha256.Sum256(in.Data)
+	checksum := hex.EncodeToString(sum[:])
+	storedPath, err := s.storage.Put(ctx, storageKey(in.TenantID, checksum), contentType, in.Data)
+	if err != nil {
+		return assetEntity.Asset{}, false, fmt.Errorf("save asset file: %w", err)
+	}
+	a := assetEntity.Asset{
+		TenantID: in.TenantID, TemplateID: in.TemplateID, Name: name,
+		ContentType: contentType, Size: int64(len(in.Data)), Checksum: checksum,
+		StoragePath: storedPath, StorageProvider: s.storage.ProviderName(), CreatedBy: in.CreatedBy,
+	}
+	s.cache.put(checksum, in.Data)
+
+	existing, err := s.assets.GetByName(ctx, nil, in.TenantID, in.TemplateID, name)
+	switch {
+	case err == nil:
+		a.ID = existing.ID
+		updated, err := s.assets.UpdateContent(ctx, nil, a)
+		return updated, false, mapRepoErr(err)
+	case errors.Is(err, apperror.ErrNotFound):
+		created, err := s.assets.Create(ctx, nil, a)
+		return created, true, mapRepoErr(err)
+	default:
+		return assetEntity.Asset{}, false, err
+	}
+}
+
+func (s *service) GetByID(ctx context.Context, id int64, tenantID *string) (assetEntity.Asset, error) {
+	a, err := s.assets.GetByID(ctx, nil, id, tenantID)
+	return a, mapRepoErr(err)
+}
+
+func (s *service) Content(ctx context.Context, id int64, tenantID *string) (assetEntity.Asset, []byte, error) {
+	a, err := s.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return assetEntity.Asset{}, nil, err
+	}
+	data, err := s.load(ctx, a)
+	return a, data, err
+}
+
+func (s *service) List(ctx context.Context, tenantID *string, templateID *int64) ([]assetEntity.Asset, error) {
+	if templateID != nil {
+		if _, err := s.templates.GetByID(ctx, nil, *templateID, tenantID); err != nil {
+			return nil, mapRepoErr(err)
+		}
+	}
+	return s.assets.List(ctx, nil, tenantID, templateID)
+}
+
+// Delete hanya menghapus metadata; file content-addressed bisa dipakai asset lain dengan isi sama.
+func (s *service) Delete(ctx context.Context, id int64, tenantID *string) error {
+	return mapRepoErr(s.assets.Delete(ctx, nil, id, tenantID))
+}
+
+func (s *service) Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error {
+	names := src.AssetNames()
+	if len(names) == 0 {
+		return nil
+	}
+	found, err := s.assets.ResolveNames(ctx, nil, tenantID, templateID, names)
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]assetEntity.Asset, len(found))
+	for _, a := range found {
+		byName[a.Name] = a
+	}
+	var missing []string
+	assets := make(map[string]templating.Asset, len(names))
+	for _, n := range names {
+		a, ok := byName[n]
+		if !ok {
+			missing = append(missing, n)
+			continue
+		}
+		data, err := s.load(ctx, a)
+		if err != nil {
+			return fmt.Errorf("load asset %s: %w", n, err)
+		}
+		assets[n] = templating.Asset{ContentType: a.ContentType, Data: data}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return &templating.MissingAssetsError{Names: missing}
+	}
+	src.Assets = assets
+	return nil
+}
+
+func (s *service) Load(ctx context.Context, tenantID *string, templateID int64, name string) (templating.Asset, error) {
+	found, err := s.assets.ResolveNames(ctx, nil, tenantID, templateID, []string{name})
+	if err != nil {
+		return templating.Asset{}, err
+	}
+	if len(found) == 0 {
+		return templating.Asset{}, &templating.MissingAssetsError{Names: []string{name}}
+	}
+	data, err := s.load(ctx, found[0])
+	if err != nil {
+		return templating.Asset{}, fmt.Errorf("load asset %s: %w", name, err)
+	}
+	return templating.Asset{ContentType: found[0].ContentType, Data: data}, nil
+}
+
+func (s *service) load(ctx context.Context, a assetEntity.Asset) ([]byte, error) {
+	if data, ok := s.cache.get(a.Checksum); ok {
+		return data, nil
+	}
+	if s.storage == nil {
+		return nil, errors.New("asset storage not configured")
+	}
+	data, err := s.storage.Download(ctx, a.StoragePath)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.put(a.Checksum, data)
+	return data, nil
+}
+
+// storageKey content-addressed per tenant: upload ulang isi yang sama tidak menambah file.
+func storageKey(tenantID *string, checksum string) string {
+	scope := "global"
+	if tenantID != nil {
+		scope = *tenantID
+	}
+	return path.Join("assets", scope, checksum[:2], checksum)
+}
+
+func detectContentType(name, declared string, data []byte) string {
+	if ct, ok := extContentTypes[strings.ToLower(path.Ext(name))]; ok {
+		return ct
+	}
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		return strings.TrimSpace(strings.Split(ct, ";")[0])
+	}
+	if declared != "" && declared != "application/octet-stream" {
+		return strings.TrimSpace(strings.Split(declared, ";")[0])
+	}
+	return strings.Split(http.DetectContentType(data), ";")[0]
+}
+
+func allowedContentType(ct string) bool {
+	return strings.HasPrefix(ct, "image/") || strings.HasPrefix(ct, "font/") || ct == "text/css"
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package documenttemplatepartials
+
+import (
+	"context"
+	"errors"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/diff"
+)
+
+const impactDiffContext = 3
+
+// ImpactEntry hasil render ulang satu versi template published dengan versi partial kandidat.
+type ImpactEntry struct {
+	TemplateID            int64
+	TemplateCode          string
+	TemplateVersionID     int64
+	TemplateVersion       int
+	CurrentPartialVersion int // versi partial yang tercatat saat template dipublish
+	Changed               bool
+	Diff                  string // unified diff HTML current → candidate; kosong bila tidak berubah
+	Error                 string
+}
+
+// Impact merender tahap HTML (juga untuk template PDF) dengan sample_payload versi template,
+// sehingga perbedaan bisa dibaca tanpa membandingkan byte PDF.
+func (s *service) Impact(ctx context.Context, id, versionID int64, tenantID *string) ([]ImpactEntry, error) {
+	if s.selector == nil {
+		return nil, errors.New("document generator not configured")
+	}
+	p, err := s.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := s.partials.GetVersion(ctx, nil, p.ID, versionID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	deps, err := s.Dependents(ctx, id, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []ImpactEntry
+	for _, d := range deps {
+		v, err := s.versions.GetByID(ctx, nil, d.TemplateID, d.TemplateVersionID, d.TenantID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v.Status != enums.TemplateVersionStatusPublished {
+			continue
+		}
+		tpl, err := s.templates.GetByID(ctx, nil, v.TemplateID, v.TenantID)
+		if err != nil {
+			return nil, mapRepoErr(err)
+		}
+		entry := ImpactEntry{
+			TemplateID: v.TemplateID, TemplateCode: tpl.Code, TemplateVersionID: v.ID,
+			TemplateVersion: v.Version, CurrentPartialVersion: d.PartialVersion,
+		}
+		current, err := s.renderHTML(ctx, v, string(tpl.Engine), nil)
+		if err != nil {
+			entry.Error = "current: " + err.Error()
+			out = append(out, entry)
+			continue
+		}
+		next, err := s.renderHTML(ctx, v, string(tpl.Engine), &candidate)
+		if err != nil {
+			entry.Changed = true
+			entry.Error = "candidate: " + err.Error()
+			out = append(out, entry)
+			continue
+		}
+		if current != next {
+			entry.Changed = true
+			ops := diff.Lines(diff.SplitLines(current), diff.SplitLines(next))
+			entry.Diff = diff.Unified("current", "candidate", ops, impactDiffContext)
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func (s *service) renderHTML(ctx context.Context, v verEntity.TemplateVersion, engine string, override *partialEntity.PartialVersion) (string, error) {
+	src, _, err := s.resolve(ctx, v.TenantID, v.Content, v.Layout, override)
+	if err != nil {
+		return "", err
+	}
+	if s.assets != nil {
+		if err := s.assets.Attach(ctx, v.TenantID, v.TemplateID, &src); err != nil {
+			return "", err
+		}
+	}
+	src.Translator = v.Translator("")
+	payload := v.SamplePayload
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	data, _, err := s.selector.Select(string(enums.OutputFormatHTML), engine).Generate(ctx, src, payload)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,142 @@
+package documenttemplatepartials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/templating"
+)
+
+// errNotPublished partial ada tetapi belum punya versi published; diperlakukan sebagai missing.
+var errNotPublished = errors.New("partial has no published version")
+
+func (s *service) Resolve(ctx context.Context, tenantID *string, content string, layout *string) (templating.Source, error) {
+	src, _, err := s.resolve(ctx, tenantID, content, layout, nil)
+	return src, err
+}
+
+func (s *service) RecordDependencies(ctx context.Context, v verEntity.TemplateVersion) error {
+	_, deps, err := s.resolve(ctx, v.TenantID, v.Content, v.Layout, nil)
+	if err != nil {
+		return err
+	}
+	for i := range deps {
+		deps[i].TenantID = v.TenantID
+		deps[i].TemplateID = v.TemplateID
+	}
+	return s.partials.ReplaceDependencies(ctx, nil, v.ID, deps)
+}
+
+// resolve mengumpulkan layout dan partial secara rekursif. override (opsional) menggantikan versi
+// published sebuah partial dengan versi kandidat, dipakai analisis dampak.
+func (s *service) resolve(ctx context.Context, tenantID *string, content string, layout *string, override *partialEntity.PartialVersion) (templating.Source, []partialEntity.Dependency, error) {
+	src := templating.Source{Content: content, Partials: map[string]string{}, Versions: map[string]int{}}
+	var deps []partialEntity.Dependency
+	defined := map[string]bool{}
+	markDefined := func(body string) {
+		for _, n := range templating.DefinedNames(templating.NormalizePartialSyntax(body)) {
+			defined[n] = true
+		}
+	}
+	markDefined(content)
+	pending := templating.PartialNames(content)
+	var missing []string
+
+	if layout != nil && *layout != "" {
+		found, err := s.partials.GetByNames(ctx, nil, []string{*layout}, tenantID)
+		if err != nil {
+			return templating.Source{}, nil, err
+		}
+		if len(found) == 0 {
+			return templating.Source{}, nil, &templating.MissingPartialsError{Names: []string{*layout}}
+		}
+		l := found[0]
+		if l.Kind != enums.TemplatePartialKindLayout {
+			return templating.Source{}, nil, fmt.Errorf("%w: %q is not a layout", apperror.ErrInvalidInput, l.Name)
+		}
+		v, err := s.currentVersion(ctx, l, override)
+		if errors.Is(err, errNotPublished) {
+			return templating.Source{}, nil, &templating.MissingPartialsError{Names: []string{l.Name}}
+		}
+		if err != nil {
+			return templating.Source{}, nil, err
+		}
+		src.Layout = v.Content
+		src.Versions[l.Name] = v.Version
+		deps = append(deps, partialEntity.Dependency{PartialID: l.ID, PartialVersion: v.Version})
+		markDefined(v.Content)
+		pending = append(pending, templating.PartialNames(v.Content)...)
+	}
+
+	seen := map[string]bool{}
+	for len(pending) > 0 {
+		var names []string
+		for _, n := range pending {
+			if !seen[n] && !defined[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+		pending = nil
+		if len(names) == 0 {
+			break
+		}
+		found, err := s.partials.GetByNames(ctx, nil, names, tenantID)
+		if err != nil {
+			return templating.Source{}, nil, err
+		}
+		byName := make(map[string]partialEntity.Partial, len(found))
+		for _, p := range found {
+			byName[p.Name] = p
+		}
+		for _, n := range names {
+			p, ok := byName[n]
+			if !ok {
+				missing = append(missing, n)
+				continue
+			}
+			v, err := s.currentVersion(ctx, p, override)
+			if errors.Is(err, errNotPublished) {
+				missing = append(missing, n)
+				continue
+			}
+			if err != nil {
+				return templating.Source{}, nil, err
+			}
+			src.Partials[n] = v.Content
+			src.Versions[n] = v.Version
+			deps = append(deps, partialEntity.Dependency{PartialID: p.ID, PartialVersion: v.Version})
+			markDefined(v.Content)
+			pending = append(pending, templating.PartialNames(v.Content)...)
+		}
+	}
+
+	// Nama yang tidak ditemukan bisa saja didefinisikan partial lain yang di-resolve belakangan.
+	var unresolved []string
+	for _, n := range missing {
+		if !defined[n] {
+			unresolved = append(unresolved, n)
+		}
+	}
+	if len(unresolved) > 0 {
+		sort.Strings(unresolved)
+		return templating.Source{}, nil, &templating.MissingPartialsError{Names: unresolved}
+	}
+	return src, deps, nil
+}
+
+func (s *service) currentVersion(ctx context.Context, p partialEntity.Partial, override *partialEntity.PartialVersion) (partialEntity.PartialVersion, error) {
+	if override != nil && override.PartialID == p.ID {
+		return *override, nil
+	}
+	if p.CurrentVersion == nil {
+		return partialEntity.PartialVersion{}, errNotPublished
+	}
+	return s.partials.GetVersionByNumber(ctx, nil, p.ID, *p.CurrentVersion)
+}
@@ -0,0 +1,253 @@
+package documenttemplatepartials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	partialEntity "go-document-generator/internal/entity/documenttemplatepartials"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	begin "go-document-generator/internal/repository/begin"
+	partialrepo "go-document-generator/internal/repository/documenttemplatepartials"
+	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/templating"
+	"go-document-generator/internal/usecase/documents"
+)
+
+type Service interface {
+	Create(ctx context.Context, p partialEntity.Partial) (partialEntity.Partial, error)
+	GetByID(ctx context.Context, id int64, tenantID *string) (partialEntity.Partial, error)
+	// List mengembalikan partial milik tenant dan partial global; kind kosong untuk semua jenis.
+	List(ctx context.Context, tenantID *string, kind enums.TemplatePartialKind) ([]partialEntity.Partial, error)
+	UpdateDescription(ctx context.Context, id int64, tenantID *string, description *string) (partialEntity.Partial, error)
+	// CreateVersion menyimpan versi baru (belum dipakai render sampai dipublish).
+	CreateVersion(ctx context.Context, id int64, tenantID *string, v partialEntity.PartialVersion) (partialEntity.PartialVersion, error)
+	GetVersion(ctx context.Context, id, versionID int64, tenantID *string) (partialEntity.PartialVersion, error)
+	ListVersions(ctx context.Context, id int64, tenantID *string) ([]partialEntity.PartialVersion, error)
+	// PublishVersion menjadikan versi sebagai versi yang dipakai render; versi lama bisa dipublish ulang (rollback).
+	PublishVersion(ctx context.Context, id, versionID int64, tenantID *string) (partialEntity.Partial, error)
+	// Dependents mengembalikan versi template yang memakai partial (dicatat saat template dipublish).
+	Dependents(ctx context.Context, id int64, tenantID *string) ([]partialEntity.Dependency, error)
+	// Impact merender ulang versi template published yang bergantung pada partial dengan versi kandidat
+	// dan membandingkannya dengan output saat ini.
+	Impact(ctx context.Context, id, versionID int64, tenantID *string) ([]ImpactEntry, error)
+
+	// Resolve mengumpulkan layout dan partial (rekursif, versi published saat ini) yang dipakai content.
+	Resolve(ctx context.Context, tenantID *string, content string, layout *string) (templating.Source, error)
+	// RecordDependencies mencatat versi partial yang dipakai versi template; dipanggil saat publish.
+	RecordDependencies(ctx context.Context, v verEntity.TemplateVersion) error
+}
+
+// AssetResolver memuat asset template ke source (dipenuhi usecase documenttemplateassets).
+type AssetResolver interface {
+	Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error
+}
+
+type service struct {
+	partials  partialrepo.DocumentTemplatePartialsRepository
+	templates tplrepo.DocumentTemplatesRepository
+	versions  verrepo.DocumentTemplateVersionsRepository
+	selector  documents.GeneratorSelector
+	assets    AssetResolver
+	txManager begin.BeginRepository
+}
+
+func NewService(
+	partials partialrepo.DocumentTemplatePartialsRepository,
+	templates tplrepo.DocumentTemplatesRepository,
+	versions verrepo.DocumentTemplateVersionsRepository,
+	selector documents.GeneratorSelector,
+	assets AssetResolver,
+	tx begin.BeginRepository,
+) Service {
+	return &service{partials: partials, templates: templates, versions: versions, selector: selector, assets: assets, txManager: tx}
+}
+
+// Nama partial dipakai langsung di {{template "name"}} dan {{> name}}.
+var partialName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_./-]{0,99}$`)
+
+func (s *service) Create(ctx context.Context, p partialEntity.Partial) (partialEntity.Partial, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return partialEntity.Partial{}, errors.New("name is required")
+	}
+	if !partialName.MatchString(p.Name) || p.Name == templating.ContentBlock || p.Name == templating.TreeName {
+		return partialEntity.Partial{}, fmt.Errorf("%w: invalid partial name %q", apperror.ErrInvalidInput, p.Name)
+	}
+	if p.Kind == "" {
+		p.Kind = enums.TemplatePartialKindPartial
+	}
+	if p.Kind != enums.TemplatePartialKindPartial && p.Kind != enums.TemplatePartialKindLayout {
+		return partialEntity.Partial{}, fmt.Errorf("%w: unknown kind %q", apperror.ErrInvalidInput, p.Kind)
+	}
+	existing, err := s.partials.GetByNames(ctx, nil, []string{p.Name}, p.TenantID)
+	if err != nil {
+		return partialEntity.Partial{}, err
+	}
+	// Tenant boleh menimpa partial global dengan nama yang sama, tetapi tidak menduplikasi miliknya sendiri.
+	for _, e := range existing {
+		if sameTenant(e.TenantID, p.TenantID) {
+			return partialEntity.Partial{}, fmt.Errorf("%w: partial %q already exists", apperror.ErrConflict, p.Name)
+		}
+	}
+	p.CurrentVersion = nil
+	created, err := s.partials.Create(ctx, nil, p)
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetByID(ctx context.Context, id int64, tenantID *string) (partialEntity.Partial, error) {
+	p, err := s.partials.GetByID(ctx, nil, id, tenantID)
+	return p, mapRepoErr(err)
+}
+
+func (s *service) List(ctx context.Context, tenantID *string, kind enums.TemplatePartialKind) ([]partialEntity.Partial, error) {
+	return s.partials.List(ctx, nil, tenantID, kind)
+}
+
+func (s *service) UpdateDescription(ctx context.Context, id int64, tenantID *string, description *string) (partialEntity.Partial, error) {
+	if _, err := s.owned(ctx, id, tenantID); err != nil {
+		return partialEntity.Partial{}, err
+	}
+	p, err := s.partials.UpdateDescription(ctx, nil, id, tenantID, description)
+	return p, mapRepoErr(err)
+}
+
+func (s *service) CreateVersion(ctx context.Context, id int64, tenantID *string, v partialEntity.PartialVersion) (partialEntity.PartialVersion, error) {
+	p, err := s.owned(ctx, id, tenantID)
+	if err != nil {
+		return partialEntity.PartialVersion{}, err
+	}
+	if err := validatePartialContent(p, v.Content); err != nil {
+		return partialEntity.PartialVersion{}, err
+	}
+	next, err := s.partials.NextVersionNumber(ctx, nil, p.ID)
+	if err != nil {
+		return partialEntity.PartialVersion{}, err
+	}
+	v.PartialID = p.ID
+	v.TenantID = p.TenantID
+	v.Version = next
+	v.PublishedAt = nil
+	v.Checksum = contentChecksum(v.Content)
+	created, err := s.partials.CreateVersion(ctx, nil, v)
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetVersion(ctx context.Context, id, versionID int64, tenantID *string) (partialEntity.PartialVersion, error) {
+	if _, err := s.GetByID(ctx, id, tenantID); err != nil {
+		return partialEntity.PartialVersion{}, err
+	}
+	v, err := s.partials.GetVersion(ctx, nil, id, versionID)
+	return v, mapRepoErr(err)
+}
+
+func (s *service) ListVersions(ctx context.Context, id int64, tenantID *string) ([]partialEntity.PartialVersion, error) {
+	if _, err := s.GetByID(ctx, id, tenantID); err != nil {
+		return nil, err
+	}
+	return s.partials.ListVersions(ctx, nil, id)
+}
+
+func (s *service) PublishVersion(ctx context.Context, id, versionID int64, tenantID *string) (partialEntity.Partial, error) {
+	if _, err := s.owned(ctx, id, tenantID); err != nil {
+		return partialEntity.Partial{}, err
+	}
+	// published_at dan current_version harus berubah bersama agar render tidak melihat state setengah jadi.
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return partialEntity.Partial{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+	v, err := s.partials.MarkVersionPublished(ctx, tx, id, versionID)
+	if err != nil {
+		return partialEntity.Partial{}, mapRepoErr(err)
+	}
+	if err = s.partials.SetCurrentVersion(ctx, tx, id, v.Version); err != nil {
+		return partialEntity.Partial{}, mapRepoErr(err)
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return partialEntity.Partial{}, err
+	}
+	return s.GetByID(ctx, id, tenantID)
+}
+
+func (s *service) Dependents(ctx context.Context, id int64, tenantID *string) ([]partialEntity.Dependency, error) {
+	if _, err := s.GetByID(ctx, id, tenantID); err != nil {
+		return nil, err
+	}
+	deps, err := s.partials.ListDependents(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	// Partial global dipakai banyak tenant; tenant hanya melihat template miliknya.
+	if tenantID == nil {
+		return deps, nil
+	}
+	out := deps[:0]
+	for _, d := range deps {
+		if sameTenant(d.TenantID, tenantID) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// owned memastikan partial bisa diubah oleh tenant: partial global hanya dikelola tanpa header tenant.
+func (s *service) owned(ctx context.Context, id int64, tenantID *string) (partialEntity.Partial, error) {
+	p, err := s.GetByID(ctx, id, tenantID)
+	if err != nil {
+		return partialEntity.Partial{}, err
+	}
+	if tenantID != nil && p.TenantID == nil {
+		return partialEntity.Partial{}, fmt.Errorf("%w: global partial %q is read-only for tenants", apperror.ErrForbidden, p.Name)
+	}
+	return p, nil
+}
+
+// validatePartialContent mem-parse content; layout wajib merender block "content".
+func validatePartialContent(p partialEntity.Partial, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return errors.New("content is required")
+	}
+	if _, err := templating.Inline(content).HTML(p.Name, nil); err != nil {
+		return fmt.Errorf("%w: %s", apperror.ErrInvalidInput, templating.Message(err))
+	}
+	if p.Kind == enums.TemplatePartialKindLayout && !templating.UsesContentBlock(content) {
+		return fmt.Errorf("%w: layout must render the %q block", apperror.ErrInvalidInput, templating.ContentBlock)
+	}
+	return nil
+}
+
+func sameTenant(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func contentChecksum(content string) *string {
+	sum := sha256.Sum256([]byte(content))
+	chk := hex.EncodeToString(sum[:])
+	return &chk
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
@@ -3,6 +3,7 @@ package documenttemplates
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -10,15 +11,20 @@ import (
 	"go-document-generator/internal/entity/enums"
 	begin "go-document-generator/internal/repository/begin"
 	repo "go-document-generator/internal/repository/documenttemplates"
+	"go-document-generator/internal/shared/actor"
 	"go-document-generator/internal/shared/apperror"
 	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/watermark"
 )
 
 type Service interface {
 	Create(ctx context.Context, t tplEntity.Template) (tplEntity.Template, error)
 	GetByID(ctx context.Context, id int64, tenantID *string) (tplEntity.Template, error)
 	List(ctx context.Context, f repo.ListFilter) ([]tplEntity.Template, pagination.Meta, error)
+	// Patch menerapkan perubahan; penurunan required_approvals hanya dicatat sebagai pending_approvals
+	// dan baru berlaku setelah ApprovePendingApprovals oleh actor lain.
 	Patch(ctx context.Context, t tplEntity.Template) (tplEntity.Template, error)
+	ApprovePendingApprovals(ctx context.Context, id int64, tenantID *string) (tplEntity.Template, error)
 	Deactivate(ctx context.Context, id int64, tenantID *string, updatedBy *string) error
 }
 
@@ -39,6 +45,10 @@ func (s *service) Create(ctx context.Context, t tplEntity.Template) (tplEntity.T
 	if err := validateTemplate(t, true); err != nil {
 		return tplEntity.Template{}, err
 	}
+	t.Watermark = normalizeWatermark(t.Watermark)
+	if t.SchemaCompatPolicy == "" {
+		t.SchemaCompatPolicy = enums.SchemaCompatPolicyWarn
+	}
 	tx, err := s.txManager.Begin(ctx)
 	if err != nil {
 		return tplEntity.Template{}, err
@@ -84,6 +94,59 @@ func (s *service) Patch(ctx context.Context, t tplEntity.Template) (tplEntity.Te
 	if t.ID <= 0 {
 		return tplEntity.Template{}, apperror.ErrInvalidInput
 	}
+	if t.RequiredApprovals < 0 {
+		return tplEntity.Template{}, errors.New("required_approvals must not be negative")
+	}
+	if err := validateCompatPolicy(t.SchemaCompatPolicy); err != nil {
+		return tplEntity.Template{}, err
+	}
+	if err := validateWatermark(t.Watermark); err != nil {
+		return tplEntity.Template{}, err
+	}
+	t.Watermark = normalizeWatermark(t.Watermark)
+	current, err := s.repo.GetByID(ctx, nil, t.ID, t.TenantID)
+	if err != nil {
+		return tplEntity.Template{}, mapRepoErr(err)
+	}
+	t.PendingApprovals, t.PendingApprovalsBy = current.PendingApprovals, current.PendingApprovalsBy
+	switch {
+	case t.RequiredApprovals < current.RequiredApprovals:
+		// Melonggarkan four-eyes tidak boleh dilakukan sendiri: tunggu persetujuan actor lain.
+		requestedBy := actor.From(ctx)
+		if requestedBy == "" {
+			return tplEntity.Template{}, fmt.Errorf("%w: lowering required_approvals requires an authenticated actor", apperror.ErrUnauthorized)
+		}
+		pending := t.RequiredApprovals
+		t.RequiredApprovals = current.RequiredApprovals
+		t.PendingApprovals, t.PendingApprovalsBy = &pending, &requestedBy
+	case t.RequiredApprovals > current.RequiredApprovals:
+		t.PendingApprovals, t.PendingApprovalsBy = nil, nil
+	}
+	return s.update(ctx, t)
+}
+
+func (s *service) ApprovePendingApprovals(ctx context.Context, id int64, tenantID *string) (tplEntity.Template, error) {
+	approver := actor.From(ctx)
+	if approver == "" {
+		return tplEntity.Template{}, fmt.Errorf("%w: approval requires an authenticated actor", apperror.ErrUnauthorized)
+	}
+	t, err := s.repo.GetByID(ctx, nil, id, tenantID)
+	if err != nil {
+		return tplEntity.Template{}, mapRepoErr(err)
+	}
+	if t.PendingApprovals == nil {
+		return tplEntity.Template{}, fmt.Errorf("%w: template %d has no pending required_approvals change", apperror.ErrInvalidState, id)
+	}
+	if t.PendingApprovalsBy != nil && strings.EqualFold(*t.PendingApprovalsBy, approver) {
+		return tplEntity.Template{}, fmt.Errorf("%w: the requester cannot approve their own required_approvals change", apperror.ErrForbidden)
+	}
+	t.RequiredApprovals = *t.PendingApprovals
+	t.PendingApprovals, t.PendingApprovalsBy = nil, nil
+	t.UpdatedBy = &approver
+	return s.update(ctx, t)
+}
+
+func (s *service) update(ctx context.Context, t tplEntity.Template) (tplEntity.Template, error) {
 	updated, err := s.repo.Update(ctx, nil, t)
 	if err != nil {
 		return tplEntity.Template{}, mapRepoErr(err)
@@ -108,6 +171,15 @@ func validateTemplate(t tplEntity.Template, creating bool) error {
 	if strings.TrimSpace(t.Name) == "" {
 		return errors.New("name is required")
 	}
+	if t.RequiredApprovals < 0 {
+		return errors.New("required_approvals must not be negative")
+	}
+	if err := validateCompatPolicy(t.SchemaCompatPolicy); err != nil {
+		return err
+	}
+	if err := validateWatermark(t.Watermark); err != nil {
+		return err
+	}
 	if creating {
 		switch t.Engine {
 		case enums.TemplateEngineHandlebars, enums.TemplateEngineMustache, enums.TemplateEngineHTML:
@@ -115,7 +187,7 @@ func validateTemplate(t tplEntity.Template, creating bool) error {
 			return errors.New("invalid engine")
 		}
 		switch t.DefaultFormat {
-		case enums.OutputFormatPDF, enums.OutputFormatHTML, enums.OutputFormatDOCX:
+		case enums.OutputFormatPDF, enums.OutputFormatHTML, enums.OutputFormatDOCX, enums.OutputFormatXML:
 		default:
 			return errors.New("invalid default_format")
 		}
@@ -123,6 +195,34 @@ func validateTemplate(t tplEntity.Template, creating bool) error {
 	return nil
 }
 
+func validateCompatPolicy(p enums.SchemaCompatPolicy) error {
+	switch p {
+	case "", enums.SchemaCompatPolicyNone, enums.SchemaCompatPolicyWarn, enums.SchemaCompatPolicyBlock:
+		return nil
+	}
+	return errors.New("invalid schema_compat_policy")
+}
+
+// validateWatermark memeriksa watermark default; objek kosong berarti hapus watermark.
+func validateWatermark(w *watermark.Spec) error {
+	if w == nil || w.IsZero() {
+		return nil
+	}
+	if err := w.Validate(); err != nil {
+		return fmt.Errorf("%w: watermark: %v", apperror.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+func normalizeWatermark(w *watermark.Spec) *watermark.Spec {
+	if w == nil || w.IsZero() {
+		return nil
+	}
+	n := *w
+	n.Source = ""
+	return &n
+}
+
 func mapRepoErr(err error) error {
 	if err == nil {
 		return nil
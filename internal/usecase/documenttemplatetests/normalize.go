@@ -0,0 +1,43 @@
+package documenttemplatetests
+
+import (
+	"regexp"
+	"strings"
+
+	"go-document-generator/internal/entity/enums"
+)
+
+const diffContextLines = 3
+
+var (
+	tagBoundary = regexp.MustCompile(`>\s*<`)
+	blankRun    = regexp.MustCompile(`[ \t]+`)
+)
+
+// normalizeSnapshot menyamakan bentuk output sebelum dibandingkan:
+// HTML dan XML dipecah per tag dan spasinya dirapatkan; teks PDF juga dirapatkan karena layout
+// pdftotext tidak stabil antar versi font; output teks lain (CSV) hanya dibuang trailing whitespace.
+func normalizeSnapshot(format enums.OutputFormat, s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	collapse := false
+	switch format {
+	case enums.OutputFormatHTML, enums.OutputFormatXML:
+		s = tagBoundary.ReplaceAllString(s, ">\n<")
+		collapse = true
+	case enums.OutputFormatPDF:
+		collapse = true
+	}
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if collapse {
+			line = strings.TrimSpace(blankRun.ReplaceAllString(line, " "))
+		} else {
+			line = strings.TrimRight(line, " \t")
+		}
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,278 @@
+package documenttemplatetests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	testrepo "go-document-generator/internal/repository/documenttemplatetests"
+	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/diff"
+	"go-document-generator/internal/shared/watermark"
+)
+
+// Previewer merender versi template tanpa menyimpan dokumen (dipenuhi documents.Service).
+// Golden test merender tanpa watermark agar snapshot tidak memuat stempel DRAFT.
+type Previewer interface {
+	Preview(ctx context.Context, templateID, versionID int64, tenantID *string, payload map[string]any, wm *watermark.Spec) ([]byte, string, error)
+}
+
+// TextExtractor mengekstrak teks dan jumlah halaman dari file PDF.
+type TextExtractor interface {
+	ExtractText(ctx context.Context, data []byte) (string, int, error)
+}
+
+type Service interface {
+	// CreateCase menyimpan golden test case. Bila snapshotVersionID diisi, expected output
+	// diambil dari render versi tersebut dengan payload test case.
+	CreateCase(ctx context.Context, templateID int64, tenantID *string, c testEntity.TestCase, snapshotVersionID *int64) (testEntity.TestCase, error)
+	GetCase(ctx context.Context, templateID, caseID int64, tenantID *string) (testEntity.TestCase, error)
+	ListCases(ctx context.Context, templateID int64, tenantID *string) ([]testEntity.TestCase, error)
+	// UpdateCase mengubah field yang diisi; snapshotVersionID merekam ulang expected output.
+	UpdateCase(ctx context.Context, templateID, caseID int64, tenantID *string, patch testEntity.TestCase, snapshotVersionID *int64) (testEntity.TestCase, error)
+	DeleteCase(ctx context.Context, templateID, caseID int64, tenantID *string) error
+	// Run menjalankan semua test case template terhadap versi dan menyimpan hasilnya.
+	Run(ctx context.Context, templateID, versionID int64, tenantID *string) ([]testEntity.TestResult, error)
+	// LatestResults mengembalikan hasil terakhir tiap test case untuk versi.
+	LatestResults(ctx context.Context, templateID, versionID int64, tenantID *string) ([]testEntity.TestResult, error)
+	// RunVersion dipakai service versi saat create/publish.
+	RunVersion(ctx context.Context, v verEntity.TemplateVersion) ([]testEntity.TestResult, error)
+}
+
+type service struct {
+	tests     testrepo.DocumentTemplateTestsRepository
+	templates tplrepo.DocumentTemplatesRepository
+	versions  verrepo.DocumentTemplateVersionsRepository
+	previewer Previewer
+	extractor TextExtractor
+}
+
+func NewService(
+	tests testrepo.DocumentTemplateTestsRepository,
+	templates tplrepo.DocumentTemplatesRepository,
+	versions verrepo.DocumentTemplateVersionsRepository,
+	previewer Previewer,
+	extractor TextExtractor,
+) Service {
+	return &service{tests: tests, templates: templates, versions: versions, previewer: previewer, extractor: extractor}
+}
+
+func (s *service) CreateCase(ctx context.Context, templateID int64, tenantID *string, c testEntity.TestCase, snapshotVersionID *int64) (testEntity.TestCase, error) {
+	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
+		return testEntity.TestCase{}, mapRepoErr(err)
+	}
+	c.TemplateID = templateID
+	c.TenantID = tenantID
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return testEntity.TestCase{}, errors.New("name is required")
+	}
+	if err := s.ensureUniqueName(ctx, c); err != nil {
+		return testEntity.TestCase{}, err
+	}
+	if err := s.applyExpected(ctx, &c, snapshotVersionID); err != nil {
+		return testEntity.TestCase{}, err
+	}
+	if c.Payload == nil {
+		c.Payload = map[string]any{}
+	}
+	created, err := s.tests.CreateCase(ctx, nil, c)
+	return created, mapRepoErr(err)
+}
+
+func (s *service) GetCase(ctx context.Context, templateID, caseID int64, tenantID *string) (testEntity.TestCase, error) {
+	c, err := s.tests.GetCaseByID(ctx, nil, templateID, caseID, tenantID)
+	return c, mapRepoErr(err)
+}
+
+func (s *service) ListCases(ctx context.Context, templateID int64, tenantID *string) ([]testEntity.TestCase, error) {
+	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
+		return nil, mapRepoErr(err)
+	}
+	return s.tests.ListCases(ctx, nil, templateID, tenantID)
+}
+
+func (s *service) UpdateCase(ctx context.Context, templateID, caseID int64, tenantID *string, patch testEntity.TestCase, snapshotVersionID *int64) (testEntity.TestCase, error) {
+	existing, err := s.tests.GetCaseByID(ctx, nil, templateID, caseID, tenantID)
+	if err != nil {
+		return testEntity.TestCase{}, mapRepoErr(err)
+	}
+	updated := existing
+	if name := strings.TrimSpace(patch.Name); name != "" && name != existing.Name {
+		updated.Name = name
+		if err := s.ensureUniqueName(ctx, updated); err != nil {
+			return testEntity.TestCase{}, err
+		}
+	}
+	if patch.Description != nil {
+		updated.Description = patch.Description
+	}
+	if patch.Payload != nil {
+		updated.Payload = patch.Payload
+	}
+	if patch.OutputFormat != "" {
+		updated.OutputFormat = patch.OutputFormat
+	}
+	if patch.ExpectedOutput != "" {
+		updated.ExpectedOutput = patch.ExpectedOutput
+	}
+	if patch.ExpectedPages != nil {
+		updated.ExpectedPages = patch.ExpectedPages
+	}
+	if snapshotVersionID != nil || patch.ExpectedOutput != "" {
+		if err := s.applyExpected(ctx, &updated, snapshotVersionID); err != nil {
+			return testEntity.TestCase{}, err
+		}
+	}
+	saved, err := s.tests.UpdateCase(ctx, nil, updated)
+	return saved, mapRepoErr(err)
+}
+
+func (s *service) DeleteCase(ctx context.Context, templateID, caseID int64, tenantID *string) error {
+	return mapRepoErr(s.tests.DeleteCase(ctx, nil, templateID, caseID, tenantID))
+}
+
+func (s *service) Run(ctx context.Context, templateID, versionID int64, tenantID *string) ([]testEntity.TestResult, error) {
+	v, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return nil, mapRepoErr(err)
+	}
+	return s.RunVersion(ctx, v)
+}
+
+func (s *service) LatestResults(ctx context.Context, templateID, versionID int64, tenantID *string) ([]testEntity.TestResult, error) {
+	if _, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID); err != nil {
+		return nil, mapRepoErr(err)
+	}
+	return s.tests.ListLatestResults(ctx, nil, versionID)
+}
+
+func (s *service) RunVersion(ctx context.Context, v verEntity.TemplateVersion) ([]testEntity.TestResult, error) {
+	cases, err := s.tests.ListCases(ctx, nil, v.TemplateID, v.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, nil
+	}
+	results := make([]testEntity.TestResult, 0, len(cases))
+	for _, c := range cases {
+		results = append(results, s.runCase(ctx, v, c))
+	}
+	saved, err := s.tests.CreateResults(ctx, nil, results)
+	if err != nil {
+		// Hasil tetap dikembalikan agar gate publish tidak bergantung pada penyimpanan.
+		log.Printf("documenttemplatetests: CreateResults template=%d version=%d: %v", v.TemplateID, v.Version, err)
+		return results, nil
+	}
+	return saved, nil
+}
+
+func (s *service) runCase(ctx context.Context, v verEntity.TemplateVersion, c testEntity.TestCase) testEntity.TestResult {
+	res := testEntity.TestResult{
+		TenantID: v.TenantID, TemplateID: v.TemplateID, VersionID: v.ID,
+		TestCaseID: c.ID, CaseName: c.Name, Checksum: v.Checksum,
+	}
+	fail := func(msg string) testEntity.TestResult {
+		res.Error = &msg
+		return res
+	}
+	if c.OutputFormat != v.OutputFormat {
+		return fail(fmt.Sprintf("output format changed from %s to %s; record a new snapshot", c.OutputFormat, v.OutputFormat))
+	}
+	actual, pages, err := s.render(ctx, v, c.Payload)
+	if err != nil {
+		return fail(err.Error())
+	}
+	res.ActualOutput, res.ActualPages = actual, pages
+
+	expected := normalizeSnapshot(c.OutputFormat, c.ExpectedOutput)
+	if expected != actual {
+		ops := diff.Lines(diff.SplitLines(expected), diff.SplitLines(actual))
+		unified := diff.Unified("expected", "actual", ops, diffContextLines)
+		res.Diff = &unified
+		return res
+	}
+	if c.ExpectedPages != nil && (pages == nil || *pages != *c.ExpectedPages) {
+		got := 0
+		if pages != nil {
+			got = *pages
+		}
+		return fail(fmt.Sprintf("page count %d, expected %d", got, *c.ExpectedPages))
+	}
+	res.Passed = true
+	return res
+}
+
+// render memakai Preview (validasi schema + generator yang sama dengan produksi) lalu menormalisasi output.
+func (s *service) render(ctx context.Context, v verEntity.TemplateVersion, payload map[string]any) (string, *int, error) {
+	data, _, err := s.previewer.Preview(ctx, v.TemplateID, v.ID, v.TenantID, payload, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	if v.OutputFormat != enums.OutputFormatPDF {
+		return normalizeSnapshot(v.OutputFormat, string(data)), nil, nil
+	}
+	if s.extractor == nil {
+		return "", nil, errors.New("pdf text extractor not configured")
+	}
+	text, pages, err := s.extractor.ExtractText(ctx, data)
+	if err != nil {
+		return "", nil, err
+	}
+	return normalizeSnapshot(v.OutputFormat, text), &pages, nil
+}
+
+// applyExpected merekam snapshot dari versi atau menormalisasi expected output yang dikirim.
+func (s *service) applyExpected(ctx context.Context, c *testEntity.TestCase, snapshotVersionID *int64) error {
+	if snapshotVersionID != nil {
+		v, err := s.versions.GetByID(ctx, nil, c.TemplateID, *snapshotVersionID, c.TenantID)
+		if err != nil {
+			return mapRepoErr(err)
+		}
+		actual, pages, err := s.render(ctx, v, c.Payload)
+		if err != nil {
+			return fmt.Errorf("%w: snapshot render failed: %v", apperror.ErrInvalidInput, err)
+		}
+		c.OutputFormat, c.ExpectedOutput, c.ExpectedPages = v.OutputFormat, actual, pages
+		return nil
+	}
+	if c.OutputFormat == "" {
+		return errors.New("output_format is required when no snapshot_version_id is given")
+	}
+	if strings.TrimSpace(c.ExpectedOutput) == "" {
+		return errors.New("expected_output or snapshot_version_id is required")
+	}
+	c.ExpectedOutput = normalizeSnapshot(c.OutputFormat, c.ExpectedOutput)
+	return nil
+}
+
+func (s *service) ensureUniqueName(ctx context.Context, c testEntity.TestCase) error {
+	cases, err := s.tests.ListCases(ctx, nil, c.TemplateID, c.TenantID)
+	if err != nil {
+		return err
+	}
+	for _, other := range cases {
+		if other.ID != c.ID && strings.EqualFold(other.Name, c.Name) {
+			return fmt.Errorf("%w: test case %q already exists", apperror.ErrConflict, c.Name)
+		}
+	}
+	return nil
+}
+
+func mapRepoErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ErrNotFound
+	}
+	return err
+}
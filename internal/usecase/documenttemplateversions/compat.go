@@ -0,0 +1,201 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	tplEntity "go-document-generator/internal/entity/documenttemplates"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	docrepo "go-document-generator/internal/repository/documents"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/diff"
+	"go-document-generator/internal/shared/pagination"
+	"go-document-generator/internal/shared/validators"
+)
+
+// compatPayloadSample jumlah payload dokumen terbaru yang divalidasi ulang terhadap schema baru.
+const compatPayloadSample = pagination.MaxLimit
+
+// SchemaCompatChange perubahan schema beserta klasifikasinya.
+type SchemaCompatChange struct {
+	diff.Change
+	Breaking bool
+	Reason   string
+}
+
+// FailingPayload payload dokumen produksi yang tidak lolos schema baru.
+type FailingPayload struct {
+	DocumentID int64
+	RequestID  string
+	Version    int
+	Error      string
+}
+
+// CompatReport hasil pemeriksaan kompatibilitas schema versi terhadap versi published saat ini.
+type CompatReport struct {
+	Policy          enums.SchemaCompatPolicy
+	BaselineVersion *int // nil bila belum ada versi published
+	Changes         []SchemaCompatChange
+	SampledPayloads int
+	FailingPayloads []FailingPayload
+}
+
+// Breaking true bila ada perubahan breaking atau payload produksi yang gagal validasi.
+func (r CompatReport) Breaking() bool {
+	if len(r.FailingPayloads) > 0 {
+		return true
+	}
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func (r CompatReport) summary() string {
+	var reasons []string
+	for _, c := range r.Changes {
+		if c.Breaking {
+			reasons = append(reasons, c.Path+": "+c.Reason)
+		}
+	}
+	if n := len(r.FailingPayloads); n > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d of %d recent payloads fail the new schema", n, r.SampledPayloads))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (s *service) CheckCompatibility(ctx context.Context, templateID, versionID int64, tenantID *string) (CompatReport, error) {
+	tpl, err := s.templates.GetByID(ctx, nil, templateID, tenantID)
+	if err != nil {
+		return CompatReport{}, mapRepoErr(err)
+	}
+	v, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return CompatReport{}, mapRepoErr(err)
+	}
+	return s.checkCompatibility(ctx, tpl, v)
+}
+
+func (s *service) checkCompatibility(ctx context.Context, tpl tplEntity.Template, v verEntity.TemplateVersion) (CompatReport, error) {
+	report := CompatReport{Policy: tpl.SchemaCompatPolicy}
+	if report.Policy == "" {
+		report.Policy = enums.SchemaCompatPolicyWarn
+	}
+
+	baseline, err := s.versions.GetLatestPublished(ctx, nil, tpl.ID, v.TenantID)
+	switch {
+	case errors.Is(err, apperror.ErrNotFound):
+		// Belum ada versi published: tidak ada pemanggil yang bisa rusak.
+		return report, nil
+	case err != nil:
+		return CompatReport{}, err
+	}
+	if baseline.ID == v.ID {
+		return report, nil
+	}
+	report.BaselineVersion = &baseline.Version
+	report.Changes = classifySchemaChanges(baseline.Schema, v.Schema)
+
+	if v.EnforcedSchema() == nil || s.docs == nil {
+		return report, nil
+	}
+	docs, _, err := s.docs.List(ctx, nil, docrepo.ListFilter{
+		TenantID:     v.TenantID,
+		TemplateCode: tpl.Code,
+		Page:         pagination.Params{Page: 1, Limit: compatPayloadSample},
+	})
+	if err != nil {
+		return CompatReport{}, err
+	}
+	report.SampledPayloads = len(docs)
+	for _, d := range docs {
+		if verr := validators.ValidateSchema(v.Schema, d.Payload); verr != nil {
+			report.FailingPayloads = append(report.FailingPayloads, FailingPayload{
+				DocumentID: d.ID, RequestID: d.RequestID, Version: d.TemplateVersion, Error: verr.Error(),
+			})
+		}
+	}
+	return report, nil
+}
+
+// enforceCompatibility menerapkan policy template terhadap hasil pemeriksaan sebelum publish.
+func (s *service) enforceCompatibility(ctx context.Context, v verEntity.TemplateVersion) (CompatReport, error) {
+	tpl, err := s.templates.GetByID(ctx, nil, v.TemplateID, v.TenantID)
+	if err != nil {
+		return CompatReport{}, mapRepoErr(err)
+	}
+	if tpl.SchemaCompatPolicy == enums.SchemaCompatPolicyNone {
+		return CompatReport{Policy: tpl.SchemaCompatPolicy}, nil
+	}
+	report, err := s.checkCompatibility(ctx, tpl, v)
+	if err != nil {
+		return CompatReport{}, err
+	}
+	if !report.Breaking() {
+		return report, nil
+	}
+	if report.Policy == enums.SchemaCompatPolicyBlock {
+		return report, fmt.Errorf("%w: version %d has breaking schema changes: %s", apperror.ErrInvalidState, v.Version, report.summary())
+	}
+	log.Printf("documenttemplateversions: template=%d version=%d breaking schema changes: %s", v.TemplateID, v.Version, report.summary())
+	return report, nil
+}
+
+// classifySchemaChanges menandai perubahan yang bisa membuat payload lama gagal validasi.
+func classifySchemaChanges(from, to map[string]any) []SchemaCompatChange {
+	changes := diff.Schema(from, to)
+	out := make([]SchemaCompatChange, 0, len(changes))
+	for _, c := range changes {
+		cc := SchemaCompatChange{Change: c}
+		switch c.Kind {
+		case diff.ChangeRequiredAdded:
+			cc.Breaking, cc.Reason = true, "field became required"
+		case diff.ChangeTypeChanged:
+			if !typeWidened(fmt.Sprint(c.From), fmt.Sprint(c.To)) {
+				cc.Breaking, cc.Reason = true, fmt.Sprintf("type changed from %v to %v", c.From, c.To)
+			} else {
+				cc.Reason = "type widened"
+			}
+		case diff.ChangeRemoved:
+			if parent := diff.SchemaAt(to, parentPath(c.Path)); parent != nil && parent["additionalProperties"] == false {
+				cc.Breaking, cc.Reason = true, "property removed and additionalProperties is false"
+			} else {
+				cc.Reason = "property removed"
+			}
+		case diff.ChangeAdded:
+			cc.Reason = "optional property added"
+		case diff.ChangeRequiredRemoved:
+			cc.Reason = "field no longer required"
+		}
+		out = append(out, cc)
+	}
+	return out
+}
+
+// typeWidened true bila semua type lama masih diterima type baru (integer ⊂ number).
+func typeWidened(from, to string) bool {
+	accepted := map[string]bool{}
+	for _, t := range strings.Split(to, "|") {
+		accepted[t] = true
+	}
+	for _, t := range strings.Split(from, "|") {
+		if accepted[t] || (t == "integer" && accepted["number"]) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func parentPath(p string) string {
+	if i := strings.LastIndex(p, "."); i >= 0 {
+		return p[:i]
+	}
+	return ""
+}
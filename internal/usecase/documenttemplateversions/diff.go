@@ -0,0 +1,69 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"fmt"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/shared/diff"
+)
+
+// diffContextLines jumlah baris konteks di sekitar perubahan pada unified diff.
+const diffContextLines = 3
+
+// VersionDiff perbedaan dua versi template yang sama.
+type VersionDiff struct {
+	From          verEntity.TemplateVersion
+	To            verEntity.TemplateVersion
+	Content       string // unified diff; kosong bila content identik
+	LinesAdded    int
+	LinesRemoved  int
+	Schema        []diff.Change
+	SamplePayload []diff.Change
+	Translations  []diff.Change
+}
+
+func (s *service) Diff(ctx context.Context, templateID, fromID, toID int64, tenantID *string) (VersionDiff, error) {
+	from, err := s.versions.GetByID(ctx, nil, templateID, fromID, tenantID)
+	if err != nil {
+		return VersionDiff{}, mapRepoErr(err)
+	}
+	to, err := s.versions.GetByID(ctx, nil, templateID, toID, tenantID)
+	if err != nil {
+		return VersionDiff{}, mapRepoErr(err)
+	}
+
+	ops := diff.Lines(diff.SplitLines(from.Content), diff.SplitLines(to.Content))
+	added, removed := diff.Stats(ops)
+	return VersionDiff{
+		From:         from,
+		To:           to,
+		Content:      diff.Unified(fmt.Sprintf("v%d", from.Version), fmt.Sprintf("v%d", to.Version), ops, diffContextLines),
+		LinesAdded:   added,
+		LinesRemoved: removed,
+		Schema:       diff.Schema(from.Schema, to.Schema),
+		// map nil vs kosong dianggap sama.
+		SamplePayload: diff.Values(nonNilMap(from.SamplePayload), nonNilMap(to.SamplePayload)),
+		Translations:  diff.Values(bundleMap(from.Translations), bundleMap(to.Translations)),
+	}, nil
+}
+
+// bundleMap mengubah Translations ke map[string]any agar bisa dibandingkan diff.Values (path "locale.key").
+func bundleMap(b map[string]map[string]string) map[string]any {
+	out := make(map[string]any, len(b))
+	for l, msgs := range b {
+		m := make(map[string]any, len(msgs))
+		for k, v := range msgs {
+			m[k] = v
+		}
+		out[l] = m
+	}
+	return out
+}
+
+func nonNilMap(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
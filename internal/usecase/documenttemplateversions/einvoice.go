@@ -0,0 +1,43 @@
+package documenttemplateversions
+
+import (
+	"errors"
+	"text/template"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/einvoice"
+	"go-document-generator/internal/shared/pdfa"
+	"go-document-generator/internal/shared/templating"
+)
+
+// validateEInvoice: output XML memilih XSD lewat syntax; output PDF (hybrid Factur-X) membawa
+// template XML CII sendiri dan harus PDF/A-3b agar XML boleh di-embed.
+func validateEInvoice(v verEntity.TemplateVersion) error {
+	if v.EInvoice == nil {
+		if v.OutputFormat == enums.OutputFormatXML {
+			return errors.New("required for XML output_format (syntax selects the XSD)")
+		}
+		return nil
+	}
+	switch v.OutputFormat {
+	case enums.OutputFormatXML, enums.OutputFormatPDF:
+	default:
+		return errors.New("requires XML or PDF output_format")
+	}
+	hybrid := v.OutputFormat == enums.OutputFormatPDF
+	if err := v.EInvoice.Validate(hybrid); err != nil {
+		return err
+	}
+	if !hybrid {
+		return nil
+	}
+	if v.PDFAConformance == nil || *v.PDFAConformance != pdfa.Level3B {
+		return errors.New("PDF output with an embedded invoice requires pdfa_conformance " + pdfa.Level3B)
+	}
+	_, err := templating.Inline(v.EInvoice.Content).Text(templating.TreeName, xmlFuncs)
+	return err
+}
+
+// xmlFuncs fungsi template generator XML.
+var xmlFuncs = template.FuncMap{"xml": einvoice.Escape}
@@ -3,17 +3,33 @@ package documenttemplateversions
 import (
 	"context"
 
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
 )
 
 type VersionEventPublisher interface {
 	PublishVersionCreated(ctx context.Context, v verEntity.TemplateVersion) error
+	PublishVersionUpdated(ctx context.Context, v verEntity.TemplateVersion) error
 	PublishVersionPublished(ctx context.Context, v verEntity.TemplateVersion) error
+	PublishVersionDeprecated(ctx context.Context, v verEntity.TemplateVersion) error
+	PublishVersionArchived(ctx context.Context, v verEntity.TemplateVersion) error
+	// PublishVersionScheduled dikirim saat jadwal publish dipasang atau dibatalkan (ScheduledPublishAt nil).
+	PublishVersionScheduled(ctx context.Context, v verEntity.TemplateVersion) error
+	// PublishVersionReviewed dikirim untuk setiap langkah review (submit/approve/reject).
+	PublishVersionReviewed(ctx context.Context, v verEntity.TemplateVersion, r reviewEntity.Review) error
 }
 
 type noopVersionPublisher struct{}
 
-func (noopVersionPublisher) PublishVersionCreated(context.Context, verEntity.TemplateVersion) error   { return nil }
-func (noopVersionPublisher) PublishVersionPublished(context.Context, verEntity.TemplateVersion) error { return nil }
+func (noopVersionPublisher) PublishVersionCreated(context.Context, verEntity.TemplateVersion) error    { return nil }
+func (noopVersionPublisher) PublishVersionUpdated(context.Context, verEntity.TemplateVersion) error    { return nil }
+func (noopVersionPublisher) PublishVersionPublished(context.Context, verEntity.TemplateVersion) error  { return nil }
+func (noopVersionPublisher) PublishVersionDeprecated(context.Context, verEntity.TemplateVersion) error { return nil }
+func (noopVersionPublisher) PublishVersionArchived(context.Context, verEntity.TemplateVersion) error   { return nil }
+func (noopVersionPublisher) PublishVersionScheduled(context.Context, verEntity.TemplateVersion) error  { return nil }
+
+func (noopVersionPublisher) PublishVersionReviewed(context.Context, verEntity.TemplateVersion, reviewEntity.Review) error {
+	return nil
+}
 
 func NoopVersionPublisher() VersionEventPublisher { return noopVersionPublisher{} }
@@ -0,0 +1,74 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	testEntity "go-document-generator/internal/entity/documenttemplatetests"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/shared/apperror"
+)
+
+// GoldenTestRunner menjalankan golden test template terhadap versi dan menyimpan hasilnya
+// (implementasi: usecase documenttemplatetests).
+type GoldenTestRunner interface {
+	RunVersion(ctx context.Context, v verEntity.TemplateVersion) ([]testEntity.TestResult, error)
+}
+
+// GoldenTestsFailedError dikembalikan Publish/SchedulePublish bila ada golden test yang gagal.
+type GoldenTestsFailedError struct {
+	Version int
+	Results []testEntity.TestResult
+}
+
+func (e *GoldenTestsFailedError) Failed() []testEntity.TestResult {
+	var out []testEntity.TestResult
+	for _, r := range e.Results {
+		if !r.Passed {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (e *GoldenTestsFailedError) Error() string {
+	return fmt.Sprintf("%v: version %d fails %d of %d golden test(s)", apperror.ErrInvalidState, e.Version, len(e.Failed()), len(e.Results))
+}
+
+func (e *GoldenTestsFailedError) Unwrap() error { return apperror.ErrInvalidState }
+
+// checkGoldenTests menjalankan golden test; kegagalan memblokir publish kecuali override.
+func (s *service) checkGoldenTests(ctx context.Context, v verEntity.TemplateVersion, override bool) error {
+	if s.tests == nil {
+		return nil
+	}
+	results, err := s.tests.RunVersion(ctx, v)
+	if err != nil {
+		return err
+	}
+	failed := &GoldenTestsFailedError{Version: v.Version, Results: results}
+	if len(failed.Failed()) == 0 {
+		return nil
+	}
+	if override {
+		log.Printf("documenttemplateversions: template=%d version=%d published with %d failing golden test(s) (override)",
+			v.TemplateID, v.Version, len(failed.Failed()))
+		return nil
+	}
+	return failed
+}
+
+// runGoldenTestsAsync menjalankan golden test setelah create/update tanpa menahan response;
+// hasilnya bisa dilihat lewat endpoint hasil test.
+func (s *service) runGoldenTestsAsync(ctx context.Context, v verEntity.TemplateVersion) {
+	if s.tests == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if _, err := s.tests.RunVersion(ctx, v); err != nil {
+			log.Printf("documenttemplateversions: golden tests template=%d version=%d: %v", v.TemplateID, v.Version, err)
+		}
+	}()
+}
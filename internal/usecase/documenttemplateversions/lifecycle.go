@@ -0,0 +1,38 @@
+package documenttemplateversions
+
+import (
+	"fmt"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+)
+
+// allowedFrom daftar status asal yang valid untuk tiap status target.
+//
+//	DRAFT ──publish──▶ PUBLISHED ──unpublish──▶ DEPRECATED ──archive──▶ ARCHIVED
+//	  │                    ▲                        │
+//	  └──archive──▶ ARCHIVED└──────publish/rollback─┘
+var allowedFrom = map[enums.TemplateVersionStatus][]enums.TemplateVersionStatus{
+	enums.TemplateVersionStatusPublished: {
+		enums.TemplateVersionStatusDraft,
+		enums.TemplateVersionStatusDeprecated,
+	},
+	enums.TemplateVersionStatusDeprecated: {
+		enums.TemplateVersionStatusPublished,
+	},
+	enums.TemplateVersionStatusArchived: {
+		enums.TemplateVersionStatusDraft,
+		enums.TemplateVersionStatusDeprecated,
+	},
+}
+
+// checkTransition memvalidasi transisi sebelum menyentuh DB agar pesan error lebih jelas.
+func checkTransition(v verEntity.TemplateVersion, to enums.TemplateVersionStatus) error {
+	for _, from := range allowedFrom[to] {
+		if v.Status == from {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: version %d is %s, cannot move to %s", apperror.ErrInvalidState, v.Version, v.Status, to)
+}
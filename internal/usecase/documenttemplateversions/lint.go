@@ -0,0 +1,299 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/diff"
+	"go-document-generator/internal/shared/templating"
+)
+
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// Diagnostic satu temuan lint. Line/Col 1-based; 0 bila tidak relevan (mis. properti schema).
+type Diagnostic struct {
+	Severity LintSeverity
+	Code     string
+	Message  string
+	Path     string
+	Line     int
+	Col      int
+}
+
+type LintReport struct {
+	Diagnostics []Diagnostic
+}
+
+func (r LintReport) HasErrors() bool {
+	for _, d := range r.Diagnostics {
+		if d.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintFailedError dikembalikan Create/UpdateDraft bila lint menemukan error.
+type LintFailedError struct {
+	Report LintReport
+}
+
+func (e *LintFailedError) Error() string {
+	for _, d := range e.Report.Diagnostics {
+		if d.Severity == LintError {
+			if d.Line > 0 {
+				return fmt.Sprintf("template lint failed: line %d: %s", d.Line, d.Message)
+			}
+			return "template lint failed: " + d.Message
+		}
+	}
+	return "template lint failed"
+}
+
+func (e *LintFailedError) Unwrap() error { return apperror.ErrInvalidInput }
+
+// Lint menjalankan lint tanpa menyimpan (dry-run) untuk calon versi template.
+func (s *service) Lint(ctx context.Context, templateID int64, tenantID *string, v verEntity.TemplateVersion) (LintReport, error) {
+	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
+		return LintReport{}, mapRepoErr(err)
+	}
+	if err := validateContent(v); err != nil {
+		return LintReport{}, err
+	}
+	v.TemplateID, v.TenantID = templateID, tenantID
+	return s.lint(ctx, v), nil
+}
+
+// lint me-resolve partial/layout dan asset versi lalu menjalankan lintVersion; partial atau
+// asset yang tidak ada dilaporkan sebagai error.
+func (s *service) lint(ctx context.Context, v verEntity.TemplateVersion) LintReport {
+	src, err := s.source(ctx, v)
+	if err == nil {
+		return lintVersion(v, src)
+	}
+	var report LintReport
+	var missing *templating.MissingPartialsError
+	if errors.As(err, &missing) {
+		for _, n := range missing.Names {
+			report.Diagnostics = append(report.Diagnostics, Diagnostic{
+				Severity: LintError, Code: "unknown_partial", Path: n,
+				Message: fmt.Sprintf("partial %s does not exist or has no published version", n),
+			})
+		}
+		return report
+	}
+	var missingAssets *templating.MissingAssetsError
+	if errors.As(err, &missingAssets) {
+		for _, n := range missingAssets.Names {
+			report.Diagnostics = append(report.Diagnostics, Diagnostic{
+				Severity: LintError, Code: "unknown_asset", Path: n,
+				Message: fmt.Sprintf("asset %s does not exist for this template or tenant", n),
+			})
+		}
+		return report
+	}
+	report.Diagnostics = append(report.Diagnostics, Diagnostic{Severity: LintError, Code: "resolve_error", Message: err.Error()})
+	return report
+}
+
+// lintVersion mem-parse content, test-render dengan SamplePayload (termasuk partial/layout src),
+// lalu mencocokkan referensi template dengan Schema/Variables.
+func lintVersion(v verEntity.TemplateVersion, src templating.Source) LintReport {
+	var report LintReport
+	add := func(d Diagnostic) { report.Diagnostics = append(report.Diagnostics, d) }
+
+	if v.OutputFormat == enums.OutputFormatDOCX {
+		add(Diagnostic{Severity: LintWarning, Code: "unsupported_format", Message: "output_format DOCX is not supported by the renderer yet"})
+	}
+
+	refs, err := templating.Refs(v.Content)
+	if err != nil {
+		var pe *templating.ParseError
+		if errors.As(err, &pe) {
+			add(Diagnostic{Severity: LintError, Code: "parse_error", Message: pe.Msg, Line: pe.Line, Col: pe.Col})
+		} else {
+			add(Diagnostic{Severity: LintError, Code: "parse_error", Message: err.Error()})
+		}
+		return report
+	}
+
+	// Test-render hanya bila ada sample_payload; payload kosong akan gagal di setiap field bertingkat.
+	if len(v.SamplePayload) > 0 {
+		if err := testRender(src, v.OutputFormat, v.SamplePayload); err != nil {
+			line, col := templating.Position(err)
+			add(Diagnostic{Severity: LintError, Code: "render_error", Message: templating.Message(err), Line: line, Col: col})
+		}
+	}
+
+	report.Diagnostics = append(report.Diagnostics, lintTranslations(v, src)...)
+
+	// Variables/schema hasil derive selalu cocok dengan content, jadi tidak dihitung sebagai deklarasi.
+	declared := declaredVariables(v.Variables)
+	schema := v.Schema
+	if isGeneratedSchema(schema) {
+		schema = nil
+	}
+	if len(schema) == 0 && len(declared) == 0 {
+		return report
+	}
+	seen := map[string]bool{}
+	for _, r := range refs {
+		if seen[r.Path] || declared[r.Path] || schemaDeclares(schema, r.Path) {
+			continue
+		}
+		seen[r.Path] = true
+		add(Diagnostic{
+			Severity: LintWarning, Code: "undeclared_variable", Path: r.Path, Line: r.Line, Col: r.Col,
+			Message: fmt.Sprintf("%s is used in the template but not declared in schema or variables", r.Path),
+		})
+	}
+	for _, p := range unusedSchemaProperties(schema, "", refs) {
+		add(Diagnostic{
+			Severity: LintWarning, Code: "unused_schema_property", Path: p,
+			Message: fmt.Sprintf("schema property %s is never used in the template", p),
+		})
+	}
+	return report
+}
+
+// testRender merender dengan html/template seperti generator HTML/PDF; output XML memakai
+// text/template dengan fungsi {{xml}} seperti generator XML.
+func testRender(src templating.Source, format enums.OutputFormat, payload map[string]any) error {
+	if format == enums.OutputFormatXML {
+		tpl, err := src.Text(templating.TreeName, xmlFuncs)
+		if err != nil {
+			return err
+		}
+		return tpl.Execute(io.Discard, payload)
+	}
+	tpl, err := src.HTML(templating.TreeName, nil)
+	if err != nil {
+		return err
+	}
+	return tpl.Execute(io.Discard, payload)
+}
+
+// declaredVariables menerima entri Variables berupa string path atau object {"path"|"name": ...};
+// entri hasil derive dilewati.
+func declaredVariables(vars []any) map[string]bool {
+	out := map[string]bool{}
+	for _, item := range vars {
+		var p string
+		switch v := item.(type) {
+		case string:
+			p = v
+		case map[string]any:
+			if v["source"] == variableSourceDerived {
+				continue
+			}
+			if s, ok := v["path"].(string); ok {
+				p = s
+			} else if s, ok := v["name"].(string); ok {
+				p = s
+			}
+		}
+		if p = strings.TrimPrefix(strings.TrimSpace(p), "."); p != "" {
+			out[p] = true
+		}
+	}
+	return out
+}
+
+// schemaDeclares true bila path ada di schema; object tanpa "properties" dianggap bebas.
+func schemaDeclares(schema map[string]any, path string) bool {
+	if len(schema) == 0 {
+		return false
+	}
+	cur := schema
+	for _, seg := range strings.Split(path, ".") {
+		name := strings.TrimRight(seg, "[]")
+		props, ok := cur["properties"].(map[string]any)
+		if !ok {
+			// Object tanpa properties bebas diisi; skalar tidak punya field.
+			t := diff.SchemaType(cur)
+			return t == "" || strings.Contains(t, "object")
+		}
+		next, ok := props[name].(map[string]any)
+		if !ok {
+			return false
+		}
+		cur = next
+		for i := len(name); i+1 < len(seg); i += 2 {
+			items, ok := cur["items"].(map[string]any)
+			if !ok {
+				return true
+			}
+			cur = items
+		}
+	}
+	return true
+}
+
+// unusedSchemaProperties mengembalikan properti schema teratas yang tidak direferensikan sama sekali.
+func unusedSchemaProperties(schema map[string]any, prefix string, refs []templating.Ref) []string {
+	props, _ := schema["properties"].(map[string]any)
+	var out []string
+	for _, name := range sortedNames(props) {
+		p := name
+		if prefix != "" {
+			p = prefix + "." + name
+		}
+		if !pathUsed(p, refs) {
+			out = append(out, p)
+			continue
+		}
+		if consumedWhole(p, refs) {
+			continue
+		}
+		child, _ := props[name].(map[string]any)
+		if items, ok := child["items"].(map[string]any); ok {
+			child, p = items, p+"[]"
+		}
+		if child != nil {
+			out = append(out, unusedSchemaProperties(child, p, refs)...)
+		}
+	}
+	return out
+}
+
+// pathUsed true bila p atau turunannya direferensikan.
+func pathUsed(p string, refs []templating.Ref) bool {
+	for _, r := range refs {
+		if r.Path == p || strings.HasPrefix(r.Path, p+".") || strings.HasPrefix(r.Path, p+"[]") {
+			return true
+		}
+	}
+	return false
+}
+
+// consumedWhole true bila nilai p dipakai utuh (mis. {{.customer}} atau argumen fungsi),
+// sehingga properti turunannya tidak perlu dilaporkan.
+func consumedWhole(p string, refs []templating.Ref) bool {
+	for _, r := range refs {
+		if r.Path == p && (r.Usage == templating.UsageOutput || r.Usage == templating.UsageArg) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNames(m map[string]any) []string {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
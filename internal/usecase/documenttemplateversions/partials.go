@@ -0,0 +1,49 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"log"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/shared/templating"
+)
+
+// PartialLibrary me-resolve partial/layout dan mencatat dependensinya
+// (implementasi: usecase documenttemplatepartials).
+type PartialLibrary interface {
+	Resolve(ctx context.Context, tenantID *string, content string, layout *string) (templating.Source, error)
+	RecordDependencies(ctx context.Context, v verEntity.TemplateVersion) error
+}
+
+// AssetLibrary memuat asset template ke source (implementasi: usecase documenttemplateassets).
+type AssetLibrary interface {
+	Attach(ctx context.Context, tenantID *string, templateID int64, src *templating.Source) error
+}
+
+// source me-resolve partial/layout, asset dan terjemahan untuk lint; tanpa library content dipakai apa adanya.
+func (s *service) source(ctx context.Context, v verEntity.TemplateVersion) (templating.Source, error) {
+	src := templating.Inline(v.Content)
+	if s.partials != nil {
+		var err error
+		if src, err = s.partials.Resolve(ctx, v.TenantID, v.Content, v.Layout); err != nil {
+			return templating.Source{}, err
+		}
+	}
+	if s.assets != nil {
+		if err := s.assets.Attach(ctx, v.TenantID, v.TemplateID, &src); err != nil {
+			return templating.Source{}, err
+		}
+	}
+	src.Translator = v.Translator("") // preview/lint merender dengan bahasa default
+	return src, nil
+}
+
+// recordDependencies dijalankan setelah publish; kegagalan hanya dicatat agar publish tidak batal.
+func (s *service) recordDependencies(ctx context.Context, v verEntity.TemplateVersion) {
+	if s.partials == nil {
+		return
+	}
+	if err := s.partials.RecordDependencies(ctx, v); err != nil {
+		log.Printf("documenttemplateversions: RecordDependencies template=%d version=%d: %v", v.TemplateID, v.Version, err)
+	}
+}
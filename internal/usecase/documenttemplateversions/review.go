@@ -0,0 +1,191 @@
+package documenttemplateversions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
+	"go-document-generator/internal/shared/actor"
+	"go-document-generator/internal/shared/apperror"
+)
+
+func (s *service) Submit(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error) {
+	submittedBy, err := requireActor(ctx)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	return s.recordReview(ctx, templateID, versionID, tenantID, reviewEntity.Review{
+		Action:  enums.TemplateVersionReviewActionSubmitted,
+		Actor:   submittedBy,
+		Comment: comment,
+	}, func(v verEntity.TemplateVersion, _ []reviewEntity.Review) error {
+		if v.Status != enums.TemplateVersionStatusDraft {
+			return fmt.Errorf("%w: version %d is %s, only DRAFT can be submitted for review", apperror.ErrInvalidState, v.Version, v.Status)
+		}
+		return nil
+	}, func(int) (enums.TemplateVersionReviewStatus, []enums.TemplateVersionReviewStatus) {
+		return enums.TemplateVersionReviewStatusPending, []enums.TemplateVersionReviewStatus{
+			enums.TemplateVersionReviewStatusNone,
+			enums.TemplateVersionReviewStatusRejected,
+		}
+	})
+}
+
+func (s *service) Approve(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error) {
+	reviewer, err := requireActor(ctx)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	tpl, err := s.templates.GetByID(ctx, nil, templateID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	required := tpl.RequiredApprovals
+	if required < 1 {
+		required = 1
+	}
+
+	return s.recordReview(ctx, templateID, versionID, tenantID, reviewEntity.Review{
+		Action:  enums.TemplateVersionReviewActionApproved,
+		Actor:   reviewer,
+		Comment: comment,
+	}, checkReviewer(reviewer), func(approvals int) (enums.TemplateVersionReviewStatus, []enums.TemplateVersionReviewStatus) {
+		if approvals < required {
+			return "", nil
+		}
+		return enums.TemplateVersionReviewStatusApproved, []enums.TemplateVersionReviewStatus{enums.TemplateVersionReviewStatusPending}
+	})
+}
+
+func (s *service) Reject(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error) {
+	if comment == nil || strings.TrimSpace(*comment) == "" {
+		return verEntity.TemplateVersion{}, errors.New("comment is required when rejecting")
+	}
+	reviewer, err := requireActor(ctx)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+
+	return s.recordReview(ctx, templateID, versionID, tenantID, reviewEntity.Review{
+		Action:  enums.TemplateVersionReviewActionRejected,
+		Actor:   reviewer,
+		Comment: comment,
+	}, checkReviewer(reviewer), func(int) (enums.TemplateVersionReviewStatus, []enums.TemplateVersionReviewStatus) {
+		return enums.TemplateVersionReviewStatusRejected, []enums.TemplateVersionReviewStatus{enums.TemplateVersionReviewStatusPending}
+	})
+}
+
+func (s *service) ListReviews(ctx context.Context, templateID, versionID int64, tenantID *string) ([]reviewEntity.Review, error) {
+	if _, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID); err != nil {
+		return nil, mapRepoErr(err)
+	}
+	return s.reviews.ListByVersionID(ctx, nil, versionID)
+}
+
+// requireActor mengambil actor terautentikasi dari context; review tanpa identitas ditolak.
+func requireActor(ctx context.Context) (string, error) {
+	a := actor.From(ctx)
+	if a == "" {
+		return "", fmt.Errorf("%w: review requires an authenticated actor", apperror.ErrUnauthorized)
+	}
+	return a, nil
+}
+
+// checkReviewer memvalidasi bahwa versi sedang direview dan reviewer bukan author/submitter
+// serta belum memberi approval pada putaran review ini.
+func checkReviewer(reviewer string) func(verEntity.TemplateVersion, []reviewEntity.Review) error {
+	return func(v verEntity.TemplateVersion, approvals []reviewEntity.Review) error {
+		if v.ReviewStatus != enums.TemplateVersionReviewStatusPending || v.SubmittedAt == nil {
+			return fmt.Errorf("%w: version %d is not pending review (review status %s)", apperror.ErrInvalidState, v.Version, v.ReviewStatus)
+		}
+		if sameActor(v.CreatedBy, reviewer) || sameActor(v.SubmittedBy, reviewer) {
+			return fmt.Errorf("%w: reviewer must be different from the author and submitter", apperror.ErrForbidden)
+		}
+		for _, a := range approvals {
+			if sameActor(&a.Actor, reviewer) {
+				return fmt.Errorf("%w: %s already approved version %d", apperror.ErrConflict, reviewer, v.Version)
+			}
+		}
+		return nil
+	}
+}
+
+// recordReview mengunci baris versi (SELECT ... FOR UPDATE), menjalankan check atas state terkunci,
+// lalu menyimpan entri audit dan (bila next mengembalikan status) memindahkan review_status dalam
+// satu transaksi. Lock menserialkan approval yang datang bersamaan sehingga hitungannya tidak
+// terlewat. next menerima jumlah approval pada putaran review ini, termasuk entri r.
+func (s *service) recordReview(
+	ctx context.Context,
+	templateID, versionID int64,
+	tenantID *string,
+	r reviewEntity.Review,
+	check func(v verEntity.TemplateVersion, approvals []reviewEntity.Review) error,
+	next func(approvals int) (enums.TemplateVersionReviewStatus, []enums.TemplateVersionReviewStatus),
+) (verEntity.TemplateVersion, error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	v, err := s.versions.GetByIDForUpdate(ctx, tx, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	var prior []reviewEntity.Review
+	if v.SubmittedAt != nil {
+		if prior, err = s.reviews.ListApprovalsSince(ctx, tx, v.ID, *v.SubmittedAt); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+	}
+	if err = check(v, prior); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	approvals := 0
+	if r.Action == enums.TemplateVersionReviewActionApproved {
+		approvals = len(prior) + 1
+	}
+
+	updated := v
+	if to, from := next(approvals); to != "" {
+		var submittedBy *string
+		if to == enums.TemplateVersionReviewStatusPending {
+			submittedBy = &r.Actor
+		}
+		updated, err = s.versions.SetReviewStatus(ctx, tx, v.TemplateID, v.ID, v.TenantID, from, to, submittedBy)
+		if err != nil {
+			if errors.Is(err, apperror.ErrInvalidState) {
+				err = fmt.Errorf("%w: version %d review status changed concurrently", apperror.ErrInvalidState, v.Version)
+			}
+			return verEntity.TemplateVersion{}, mapRepoErr(err)
+		}
+	}
+
+	r.TenantID = v.TenantID
+	r.TemplateID = v.TemplateID
+	r.VersionID = v.ID
+	saved, err := s.reviews.Create(ctx, tx, r)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	if pubErr := s.publisher.PublishVersionReviewed(ctx, updated, saved); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionReviewed: %v", pubErr)
+	}
+	return updated, nil
+}
+
+func sameActor(a *string, b string) bool {
+	return a != nil && strings.EqualFold(strings.TrimSpace(*a), strings.TrimSpace(b))
+}
@@ -5,26 +5,72 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
+	reviewEntity "go-document-generator/internal/entity/documenttemplateversionreviews"
 	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/entity/enums"
 	begin "go-document-generator/internal/repository/begin"
+	docrepo "go-document-generator/internal/repository/documents"
 	tplrepo "go-document-generator/internal/repository/documenttemplates"
+	reviewrepo "go-document-generator/internal/repository/documenttemplateversionreviews"
 	verrepo "go-document-generator/internal/repository/documenttemplateversions"
+	"go-document-generator/internal/shared/actor"
 	"go-document-generator/internal/shared/apperror"
+	"go-document-generator/internal/shared/pdfa"
+	"gorm.io/gorm"
 )
 
 type Service interface {
 	Create(ctx context.Context, templateID int64, tenantID *string, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error)
 	GetByID(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
-	List(ctx context.Context, templateID int64, tenantID *string, isPublished *bool) ([]verEntity.TemplateVersion, error)
-	Publish(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
+	List(ctx context.Context, templateID int64, f verrepo.ListFilter) ([]verEntity.TemplateVersion, error)
+	// UpdateDraft mengubah content/schema/variables/sample_payload/output_format/layout; hanya untuk versi DRAFT.
+	// Versi yang sedang direview tidak bisa diubah; perubahan setelah APPROVED/REJECTED mereset review.
+	UpdateDraft(ctx context.Context, templateID, versionID int64, tenantID *string, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error)
+	// Publish: DRAFT/DEPRECATED → PUBLISHED. Versi PUBLISHED lain menjadi DEPRECATED.
+	// Bila template mensyaratkan approval, versi DRAFT harus berstatus review APPROVED.
+	// Kompatibilitas schema diperiksa sesuai SchemaCompatPolicy template; report dikembalikan untuk WARN.
+	// Golden test yang gagal memblokir publish kecuali overrideTests. Versi partial yang dipakai dicatat setelah publish.
+	Publish(ctx context.Context, templateID, versionID int64, tenantID *string, overrideTests bool) (verEntity.TemplateVersion, CompatReport, error)
+	// Unpublish: PUBLISHED → DEPRECATED tanpa mempublish versi lain.
+	Unpublish(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
+	// Archive: DRAFT/DEPRECATED → ARCHIVED. Versi ARCHIVED tidak bisa dipakai membuat dokumen.
+	Archive(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error)
+	// Rollback mempublish ulang versi DEPRECATED yang terakhir kali published (tanpa cek kompatibilitas schema dan golden test).
+	Rollback(ctx context.Context, templateID int64, tenantID *string) (verEntity.TemplateVersion, error)
+	// SchedulePublish memasang jadwal publish; at nil membatalkan jadwal.
+	SchedulePublish(ctx context.Context, templateID, versionID int64, tenantID *string, at *time.Time) (verEntity.TemplateVersion, error)
+	// PublishDue dipanggil scheduler untuk mempublish versi yang jadwalnya sudah lewat.
+	PublishDue(ctx context.Context, now time.Time) (int, error)
+	// Submit mengajukan versi DRAFT untuk direview (review NONE/REJECTED → PENDING).
+	// Submitter, reviewer dan author diambil dari actor terautentikasi di ctx (shared/actor).
+	Submit(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error)
+	// Approve mencatat approval reviewer; review menjadi APPROVED setelah jumlah approval terpenuhi.
+	Approve(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error)
+	// Reject menolak versi yang sedang direview (PENDING → REJECTED); comment wajib.
+	Reject(ctx context.Context, templateID, versionID int64, tenantID *string, comment *string) (verEntity.TemplateVersion, error)
+	ListReviews(ctx context.Context, templateID, versionID int64, tenantID *string) ([]reviewEntity.Review, error)
+	// Diff membandingkan content (unified diff), schema (struktural) dan sample_payload dua versi.
+	Diff(ctx context.Context, templateID, fromID, toID int64, tenantID *string) (VersionDiff, error)
+	// CheckCompatibility membandingkan schema versi dengan versi published dan memvalidasi ulang payload terbaru.
+	CheckCompatibility(ctx context.Context, templateID, versionID int64, tenantID *string) (CompatReport, error)
+	// Lint mem-parse dan test-render calon versi tanpa menyimpannya (dry-run).
+	Lint(ctx context.Context, templateID int64, tenantID *string, v verEntity.TemplateVersion) (LintReport, error)
 }
 
 type service struct {
 	versions  verrepo.DocumentTemplateVersionsRepository
 	templates tplrepo.DocumentTemplatesRepository
+	reviews   reviewrepo.DocumentTemplateVersionReviewsRepository
+	docs      docrepo.DocumentsRepository
+	tests     GoldenTestRunner
+	partials  PartialLibrary
+	assets    AssetLibrary
 	txManager begin.BeginRepository
 	publisher VersionEventPublisher
 }
@@ -32,25 +78,36 @@ type service struct {
 func NewService(
 	versions verrepo.DocumentTemplateVersionsRepository,
 	templates tplrepo.DocumentTemplatesRepository,
+	reviews reviewrepo.DocumentTemplateVersionReviewsRepository,
+	docs docrepo.DocumentsRepository,
+	tests GoldenTestRunner,
+	partials PartialLibrary,
+	assets AssetLibrary,
 	tx begin.BeginRepository,
 	publisher VersionEventPublisher,
 ) Service {
 	if publisher == nil {
 		publisher = NoopVersionPublisher()
 	}
-	return &service{versions: versions, templates: templates, txManager: tx, publisher: publisher}
+	return &service{versions: versions, templates: templates, reviews: reviews, docs: docs, tests: tests, partials: partials, assets: assets, txManager: tx, publisher: publisher}
 }
 
 func (s *service) Create(ctx context.Context, templateID int64, tenantID *string, v verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
 	if templateID <= 0 {
 		return verEntity.TemplateVersion{}, apperror.ErrInvalidInput
 	}
-	if strings.TrimSpace(v.Content) == "" {
-		return verEntity.TemplateVersion{}, errors.New("content is required")
+	if err := validateContent(v); err != nil {
+		return verEntity.TemplateVersion{}, err
 	}
-	if v.OutputFormat == "" {
-		return verEntity.TemplateVersion{}, errors.New("output_format is required")
+	v.TemplateID, v.TenantID = templateID, tenantID
+	// Author untuk aturan four-eyes harus actor terautentikasi, bukan created_by dari body.
+	if a := actor.From(ctx); a != "" {
+		v.CreatedBy = &a
 	}
+	if report := s.lint(ctx, v); report.HasErrors() {
+		return verEntity.TemplateVersion{}, &LintFailedError{Report: report}
+	}
+	deriveVariables(&v)
 
 	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
 		return verEntity.TemplateVersion{}, mapRepoErr(err)
@@ -73,9 +130,10 @@ func (s *service) Create(ctx context.Context, templateID int64, tenantID *string
 	v.TemplateID = templateID
 	v.TenantID = tenantID
 	v.Version = next
-	sum := sha256.Sum256([]byte(v.Content))
-	chk := hex.EncodeToString(sum[:])
-	v.Checksum = &chk
+	v.Status = enums.TemplateVersionStatusDraft
+	v.IsPublished = false
+	v.PublishedAt = nil
+	v.Checksum = contentChecksum(v.Content)
 
 	created, err := s.versions.Create(ctx, tx, v)
 	if err != nil {
@@ -87,6 +145,7 @@ func (s *service) Create(ctx context.Context, templateID int64, tenantID *string
 	if pubErr := s.publisher.PublishVersionCreated(ctx, created); pubErr != nil {
 		log.Printf("documenttemplateversions: PublishVersionCreated: %v", pubErr)
 	}
+	s.runGoldenTestsAsync(ctx, created)
 	return created, nil
 }
 
@@ -95,14 +154,103 @@ func (s *service) GetByID(ctx context.Context, templateID, versionID int64, tena
 	return v, mapRepoErr(err)
 }
 
-func (s *service) List(ctx context.Context, templateID int64, tenantID *string, isPublished *bool) ([]verEntity.TemplateVersion, error) {
-	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
+func (s *service) List(ctx context.Context, templateID int64, f verrepo.ListFilter) ([]verEntity.TemplateVersion, error) {
+	if _, err := s.templates.GetByID(ctx, nil, templateID, f.TenantID); err != nil {
 		return nil, mapRepoErr(err)
 	}
-	return s.versions.ListByTemplateID(ctx, nil, templateID, tenantID, isPublished)
+	return s.versions.ListByTemplateID(ctx, nil, templateID, f)
+}
+
+func (s *service) UpdateDraft(ctx context.Context, templateID, versionID int64, tenantID *string, patch verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
+	existing, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if existing.Status != enums.TemplateVersionStatusDraft {
+		return verEntity.TemplateVersion{}, fmt.Errorf("%w: version %d is %s, only DRAFT can be edited", apperror.ErrInvalidState, existing.Version, existing.Status)
+	}
+	if existing.ReviewStatus == enums.TemplateVersionReviewStatusPending {
+		return verEntity.TemplateVersion{}, fmt.Errorf("%w: version %d is pending review", apperror.ErrInvalidState, existing.Version)
+	}
+
+	updated := mergeDraftPatch(existing, patch)
+	if err := validateContent(updated); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	if report := s.lint(ctx, updated); report.HasErrors() {
+		return verEntity.TemplateVersion{}, &LintFailedError{Report: report}
+	}
+	deriveVariables(&updated)
+	updated.Checksum = contentChecksum(updated.Content)
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	defer func() {
+		if err != nil {
+			_ = s.txManager.Rollback(ctx, tx)
+		}
+	}()
+
+	// Status review dibaca ulang di bawah lock: submit review / approve bisa terjadi sejak pembacaan awal.
+	current, err := s.versions.GetByIDForUpdate(ctx, tx, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if current.ReviewStatus == enums.TemplateVersionReviewStatusPending {
+		err = fmt.Errorf("%w: version %d is pending review", apperror.ErrInvalidState, current.Version)
+		return verEntity.TemplateVersion{}, err
+	}
+	saved, err := s.versions.Update(ctx, tx, updated)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	// Konten yang sudah direview berubah: approval/penolakan lama tidak berlaku lagi.
+	if current.ReviewStatus == enums.TemplateVersionReviewStatusApproved || current.ReviewStatus == enums.TemplateVersionReviewStatusRejected {
+		saved, err = s.versions.SetReviewStatus(ctx, tx, templateID, versionID, tenantID,
+			[]enums.TemplateVersionReviewStatus{current.ReviewStatus}, enums.TemplateVersionReviewStatusNone, nil)
+		if err != nil {
+			return verEntity.TemplateVersion{}, mapRepoErr(err)
+		}
+	}
+	if err = s.txManager.Commit(ctx, tx); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	if pubErr := s.publisher.PublishVersionUpdated(ctx, saved); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionUpdated: %v", pubErr)
+	}
+	s.runGoldenTestsAsync(ctx, saved)
+	return saved, nil
 }
 
-func (s *service) Publish(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+func (s *service) Publish(ctx context.Context, templateID, versionID int64, tenantID *string, overrideTests bool) (verEntity.TemplateVersion, CompatReport, error) {
+	existing, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, CompatReport{}, mapRepoErr(err)
+	}
+	if err := checkTransition(existing, enums.TemplateVersionStatusPublished); err != nil {
+		return verEntity.TemplateVersion{}, CompatReport{}, err
+	}
+	if err := s.checkApproval(ctx, nil, existing); err != nil {
+		return verEntity.TemplateVersion{}, CompatReport{}, err
+	}
+	report, err := s.enforceCompatibility(ctx, existing)
+	if err != nil {
+		return verEntity.TemplateVersion{}, report, err
+	}
+	if err := s.checkGoldenTests(ctx, existing, overrideTests); err != nil {
+		return verEntity.TemplateVersion{}, report, err
+	}
+	published, err := s.publish(ctx, templateID, versionID, tenantID, &existing)
+	return published, report, err
+}
+
+// publish menjalankan transisi → PUBLISHED dan men-deprecate versi published sebelumnya dalam satu transaksi.
+// gated versi yang sudah lolos gate (approval, kompatibilitas, golden test) di luar transaksi; baris
+// dikunci lalu dibandingkan agar edit draft di antaranya tidak ikut terbit tanpa review. nil untuk
+// rollback ke versi yang pernah terbit.
+func (s *service) publish(ctx context.Context, templateID, versionID int64, tenantID *string, gated *verEntity.TemplateVersion) (verEntity.TemplateVersion, error) {
 	tx, err := s.txManager.Begin(ctx)
 	if err != nil {
 		return verEntity.TemplateVersion{}, err
@@ -113,22 +261,304 @@ func (s *service) Publish(ctx context.Context, templateID, versionID int64, tena
 		}
 	}()
 
-	if err = s.versions.UnpublishOthers(ctx, tx, templateID, versionID); err != nil {
+	locked, err := s.versions.GetByIDForUpdate(ctx, tx, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if gated != nil && (!sameChecksum(locked.Checksum, gated.Checksum) || !locked.UpdatedAt.Equal(gated.UpdatedAt)) {
+		err = fmt.Errorf("%w: version %d changed while publishing, retry", apperror.ErrInvalidState, locked.Version)
+		return verEntity.TemplateVersion{}, err
+	}
+	if err = s.checkApproval(ctx, tx, locked); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+
+	deprecated, err := s.versions.DeprecatePublished(ctx, tx, templateID, versionID)
+	if err != nil {
 		return verEntity.TemplateVersion{}, err
 	}
-	published, err := s.versions.Publish(ctx, tx, templateID, versionID, tenantID)
+	published, err := s.versions.UpdateStatus(ctx, tx, templateID, versionID, tenantID,
+		allowedFrom[enums.TemplateVersionStatusPublished], enums.TemplateVersionStatusPublished)
 	if err != nil {
 		return verEntity.TemplateVersion{}, mapRepoErr(err)
 	}
 	if err = s.txManager.Commit(ctx, tx); err != nil {
 		return verEntity.TemplateVersion{}, err
 	}
+	for _, d := range deprecated {
+		if pubErr := s.publisher.PublishVersionDeprecated(ctx, d); pubErr != nil {
+			log.Printf("documenttemplateversions: PublishVersionDeprecated: %v", pubErr)
+		}
+	}
 	if pubErr := s.publisher.PublishVersionPublished(ctx, published); pubErr != nil {
 		log.Printf("documenttemplateversions: PublishVersionPublished: %v", pubErr)
 	}
+	s.recordDependencies(ctx, published)
 	return published, nil
 }
 
+func (s *service) Unpublish(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+	existing, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if err := checkTransition(existing, enums.TemplateVersionStatusDeprecated); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	deprecated, err := s.versions.UpdateStatus(ctx, nil, templateID, versionID, tenantID,
+		allowedFrom[enums.TemplateVersionStatusDeprecated], enums.TemplateVersionStatusDeprecated)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if pubErr := s.publisher.PublishVersionDeprecated(ctx, deprecated); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionDeprecated: %v", pubErr)
+	}
+	return deprecated, nil
+}
+
+func (s *service) Archive(ctx context.Context, templateID, versionID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+	existing, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if err := checkTransition(existing, enums.TemplateVersionStatusArchived); err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	archived, err := s.versions.UpdateStatus(ctx, nil, templateID, versionID, tenantID,
+		allowedFrom[enums.TemplateVersionStatusArchived], enums.TemplateVersionStatusArchived)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if pubErr := s.publisher.PublishVersionArchived(ctx, archived); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionArchived: %v", pubErr)
+	}
+	return archived, nil
+}
+
+func (s *service) Rollback(ctx context.Context, templateID int64, tenantID *string) (verEntity.TemplateVersion, error) {
+	if _, err := s.templates.GetByID(ctx, nil, templateID, tenantID); err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	candidates, err := s.versions.ListByTemplateID(ctx, nil, templateID, verrepo.ListFilter{
+		TenantID: tenantID,
+		Status:   enums.TemplateVersionStatusDeprecated,
+	})
+	if err != nil {
+		return verEntity.TemplateVersion{}, err
+	}
+	// Hanya versi yang pernah published yang bisa jadi target rollback.
+	prev := candidates[:0]
+	for _, c := range candidates {
+		if c.PublishedAt != nil {
+			prev = append(prev, c)
+		}
+	}
+	if len(prev) == 0 {
+		return verEntity.TemplateVersion{}, fmt.Errorf("%w: no previously published version to roll back to", apperror.ErrInvalidState)
+	}
+	sort.SliceStable(prev, func(i, j int) bool { return prev[i].PublishedAt.After(*prev[j].PublishedAt) })
+	return s.publish(ctx, templateID, prev[0].ID, tenantID, nil)
+}
+
+func (s *service) SchedulePublish(ctx context.Context, templateID, versionID int64, tenantID *string, at *time.Time) (verEntity.TemplateVersion, error) {
+	existing, err := s.versions.GetByID(ctx, nil, templateID, versionID, tenantID)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if at != nil {
+		if err := checkTransition(existing, enums.TemplateVersionStatusPublished); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		if err := s.checkApproval(ctx, nil, existing); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		if _, err := s.enforceCompatibility(ctx, existing); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		if err := s.checkGoldenTests(ctx, existing, false); err != nil {
+			return verEntity.TemplateVersion{}, err
+		}
+		if !at.After(time.Now()) {
+			return verEntity.TemplateVersion{}, errors.New("publish_at must be in the future")
+		}
+		utc := at.UTC()
+		at = &utc
+	}
+	scheduled, err := s.versions.SetSchedule(ctx, nil, templateID, versionID, tenantID, at)
+	if err != nil {
+		return verEntity.TemplateVersion{}, mapRepoErr(err)
+	}
+	if pubErr := s.publisher.PublishVersionScheduled(ctx, scheduled); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionScheduled: %v", pubErr)
+	}
+	return scheduled, nil
+}
+
+const publishDueBatch = 50
+
+func (s *service) PublishDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.versions.ListDueScheduled(ctx, nil, now.UTC(), publishDueBatch)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	for _, v := range due {
+		// Approval bisa direset oleh edit draft setelah jadwal dipasang.
+		if err := s.checkApproval(ctx, nil, v); err != nil {
+			s.failSchedule(ctx, v, err)
+			continue
+		}
+		// Payload produksi bisa berubah sejak jadwal dipasang; policy diterapkan ulang.
+		if _, err := s.enforceCompatibility(ctx, v); err != nil {
+			s.failSchedule(ctx, v, err)
+			continue
+		}
+		// Draft bisa diedit setelah jadwal dipasang; override hanya tersedia untuk publish langsung.
+		if err := s.checkGoldenTests(ctx, v, false); err != nil {
+			s.failSchedule(ctx, v, err)
+			continue
+		}
+		if _, err := s.publish(ctx, v.TemplateID, v.ID, v.TenantID, &v); err != nil {
+			// Replika lain mungkin sudah mempublish versi ini lebih dulu.
+			if errors.Is(err, apperror.ErrInvalidState) {
+				continue
+			}
+			log.Printf("documenttemplateversions: scheduled publish template=%d version=%d: %v", v.TemplateID, v.Version, err)
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+// failSchedule membatalkan jadwal publish yang gagal gate (approval, kompatibilitas, golden test)
+// agar tidak dicoba ulang setiap tick; event scheduled (ScheduledPublishAt nil) menandai pembatalan.
+// Error lain (mis. DB) dianggap sementara dan jadwal dibiarkan untuk tick berikutnya.
+func (s *service) failSchedule(ctx context.Context, v verEntity.TemplateVersion, cause error) {
+	log.Printf("documenttemplateversions: scheduled publish template=%d version=%d: %v", v.TemplateID, v.Version, cause)
+	if !errors.Is(cause, apperror.ErrInvalidState) && !errors.Is(cause, apperror.ErrNotFound) {
+		return
+	}
+	cancelled, err := s.versions.SetSchedule(ctx, nil, v.TemplateID, v.ID, v.TenantID, nil)
+	if err != nil {
+		log.Printf("documenttemplateversions: cancel schedule template=%d version=%d: %v", v.TemplateID, v.Version, err)
+		return
+	}
+	log.Printf("documenttemplateversions: scheduled publish template=%d version=%d cancelled", v.TemplateID, v.Version)
+	if pubErr := s.publisher.PublishVersionScheduled(ctx, cancelled); pubErr != nil {
+		log.Printf("documenttemplateversions: PublishVersionScheduled: %v", pubErr)
+	}
+}
+
+func sameChecksum(a, b *string) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// checkApproval memastikan versi DRAFT sudah APPROVED bila template mensyaratkan review dan jumlah
+// approval pada putaran review terakhir memenuhi required_approvals. tx diisi saat dipanggil dari
+// publish (baris versi sudah dikunci). Versi DEPRECATED sudah pernah dipublish sehingga tidak perlu
+// direview ulang.
+func (s *service) checkApproval(ctx context.Context, tx *gorm.DB, v verEntity.TemplateVersion) error {
+	if v.Status != enums.TemplateVersionStatusDraft {
+		return nil
+	}
+	tpl, err := s.templates.GetByID(ctx, nil, v.TemplateID, v.TenantID)
+	if err != nil {
+		return mapRepoErr(err)
+	}
+	if tpl.RequiredApprovals == 0 {
+		return nil
+	}
+	if v.ReviewStatus != enums.TemplateVersionReviewStatusApproved || v.SubmittedAt == nil {
+		return fmt.Errorf("%w: version %d requires %d approval(s) before publish, review status is %s",
+			apperror.ErrInvalidState, v.Version, tpl.RequiredApprovals, v.ReviewStatus)
+	}
+	approvals, err := s.reviews.ListApprovalsSince(ctx, tx, v.ID, *v.SubmittedAt)
+	if err != nil {
+		return err
+	}
+	if len(approvals) < tpl.RequiredApprovals {
+		return fmt.Errorf("%w: version %d has %d of %d required approval(s)",
+			apperror.ErrInvalidState, v.Version, len(approvals), tpl.RequiredApprovals)
+	}
+	return nil
+}
+
+func validateContent(v verEntity.TemplateVersion) error {
+	if strings.TrimSpace(v.Content) == "" {
+		return errors.New("content is required")
+	}
+	if v.OutputFormat == "" {
+		return errors.New("output_format is required")
+	}
+	if v.PDFAConformance != nil {
+		if !pdfa.Valid(*v.PDFAConformance) {
+			return fmt.Errorf("pdfa_conformance: unsupported level %q", *v.PDFAConformance)
+		}
+		if v.OutputFormat != enums.OutputFormatPDF {
+			return errors.New("pdfa_conformance requires PDF output_format")
+		}
+	}
+	if err := validateEInvoice(v); err != nil {
+		return fmt.Errorf("e_invoice: %w", err)
+	}
+	return validateTranslations(v)
+}
+
+// mergeDraftPatch menggabungkan field patch ke versi draft existing.
+func mergeDraftPatch(existing, patch verEntity.TemplateVersion) verEntity.TemplateVersion {
+	out := existing
+	if patch.Content != "" {
+		out.Content = patch.Content
+	}
+	if patch.Schema != nil {
+		out.Schema = patch.Schema
+	}
+	if patch.Variables != nil {
+		out.Variables = patch.Variables
+	}
+	if patch.SamplePayload != nil {
+		out.SamplePayload = patch.SamplePayload
+	}
+	if patch.OutputFormat != "" {
+		out.OutputFormat = patch.OutputFormat
+	}
+	if patch.Layout != nil {
+		out.Layout = patch.Layout
+		if *patch.Layout == "" {
+			out.Layout = nil
+		}
+	}
+	if patch.Translations != nil {
+		out.Translations = patch.Translations
+	}
+	if patch.DefaultLocale != nil {
+		out.DefaultLocale = patch.DefaultLocale
+		if *patch.DefaultLocale == "" {
+			out.DefaultLocale = nil
+		}
+	}
+	if patch.PDFAConformance != nil {
+		out.PDFAConformance = patch.PDFAConformance
+		if *patch.PDFAConformance == "" {
+			out.PDFAConformance = nil
+		}
+	}
+	if patch.EInvoice != nil {
+		out.EInvoice = patch.EInvoice
+		if patch.EInvoice.Syntax == "" && patch.EInvoice.Content == "" {
+			out.EInvoice = nil
+		}
+	}
+	return out
+}
+
+func contentChecksum(content string) *string {
+	sum := sha256.Sum256([]byte(content))
+	chk := hex.EncodeToString(sum[:])
+	return &chk
+}
+
 func mapRepoErr(err error) error {
 	if err == nil {
 		return nil
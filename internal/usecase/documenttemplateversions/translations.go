@@ -0,0 +1,69 @@
+package documenttemplateversions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/shared/i18n"
+	"go-document-generator/internal/shared/templating"
+)
+
+// validateTranslations memastikan tag locale valid dan default_locale punya bundle.
+func validateTranslations(v verEntity.TemplateVersion) error {
+	locales := map[string]bool{}
+	for l, msgs := range v.Translations {
+		if !i18n.ValidLocale(l) {
+			return fmt.Errorf("translations: invalid locale %q", l)
+		}
+		if locales[i18n.Normalize(l)] {
+			return fmt.Errorf("translations: duplicate locale %q", l)
+		}
+		locales[i18n.Normalize(l)] = true
+		for k := range msgs {
+			if strings.TrimSpace(k) == "" {
+				return fmt.Errorf("translations: empty key in locale %q", l)
+			}
+		}
+	}
+	if v.DefaultLocale != nil {
+		if !i18n.ValidLocale(*v.DefaultLocale) {
+			return fmt.Errorf("default_locale: invalid locale %q", *v.DefaultLocale)
+		}
+		if len(v.Translations) > 0 && !locales[i18n.Normalize(*v.DefaultLocale)] {
+			return fmt.Errorf("default_locale %q has no translation bundle", *v.DefaultLocale)
+		}
+	}
+	return nil
+}
+
+// lintTranslations melaporkan key {{t "key"}} yang tidak punya pesan di suatu locale (setelah fallback).
+func lintTranslations(v verEntity.TemplateVersion, src templating.Source) []Diagnostic {
+	keys := src.TranslationKeys()
+	if len(keys) == 0 || len(v.Translations) == 0 {
+		return nil
+	}
+	locales := make([]string, 0, len(v.Translations))
+	for l := range v.Translations {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	var out []Diagnostic
+	for _, l := range locales {
+		for _, k := range i18n.Missing(v.Translations, l, deref(v.DefaultLocale), keys) {
+			out = append(out, Diagnostic{
+				Severity: LintWarning, Code: "missing_translation", Path: l + "." + k,
+				Message: fmt.Sprintf("translation key %s has no message for locale %s", k, l),
+			})
+		}
+	}
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
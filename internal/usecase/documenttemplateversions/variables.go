@@ -0,0 +1,57 @@
+package documenttemplateversions
+
+import (
+	verEntity "go-document-generator/internal/entity/documenttemplateversions"
+	"go-document-generator/internal/shared/templating"
+)
+
+// variableSourceDerived menandai entri Variables hasil derive, bukan deklarasi penulis template.
+const variableSourceDerived = "derived"
+
+// deriveVariables melengkapi Variables dari AST content dan membuat schema skeleton bila
+// schema tidak diisi (atau masih hasil generate sebelumnya). Deklarasi eksplisit penulis
+// template dipertahankan; entri derive hanya ditambahkan untuk path yang belum dideklarasikan.
+func deriveVariables(v *verEntity.TemplateVersion) {
+	refs, err := templating.Refs(v.Content)
+	if err != nil {
+		return
+	}
+	schema := v.Schema
+	if isGeneratedSchema(schema) {
+		schema = nil
+	}
+	vars := templating.Derive(refs, schema, v.SamplePayload)
+
+	declared := declaredVariables(v.Variables)
+	merged := make([]any, 0, len(v.Variables)+len(vars))
+	for _, item := range v.Variables {
+		if m, ok := item.(map[string]any); ok && m["source"] == variableSourceDerived {
+			continue
+		}
+		merged = append(merged, item)
+	}
+	for _, d := range vars {
+		if declared[d.Path] {
+			continue
+		}
+		usages := make([]any, 0, len(d.Usages))
+		for _, u := range d.Usages {
+			usages = append(usages, map[string]any{"usage": string(u.Usage), "line": u.Line, "col": u.Col})
+		}
+		entry := map[string]any{"path": d.Path, "source": variableSourceDerived, "usages": usages}
+		if d.Type != "" {
+			entry["type"] = d.Type
+		}
+		merged = append(merged, entry)
+	}
+	v.Variables = merged
+
+	if len(schema) == 0 {
+		v.Schema = templating.SchemaSkeleton(vars)
+		v.Schema["$comment"] = verEntity.GeneratedSchemaComment
+	}
+}
+
+func isGeneratedSchema(schema map[string]any) bool {
+	return schema["$comment"] == verEntity.GeneratedSchemaComment
+}